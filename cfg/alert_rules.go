@@ -0,0 +1,422 @@
+package cfg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/olebedev/config"
+)
+
+// AlertRule is an alert defined in a module's "alerts" list. While its condition holds,
+// the module's border and title take the rule's color and, when it starts holding, the
+// rule's notification is sent and its command and webhook are run:
+//
+//	kafka:
+//	  alerts:
+//	    - when: lag > 10000
+//	      notify: "Consumers are falling behind"
+//	    - name: hot
+//	      when: temp_c > 35 and humidity >= 60
+//	      color: orange
+//	      command: "say 'it is hot in here'"
+//
+// The fields in a condition are the values the module publishes to the data bus, such
+// as "temperature", or values other modules publish, such as "weather.temperature"
+type AlertRule struct {
+	Color     string
+	Command   string
+	Condition *AlertCondition
+	Name      string
+	Notify    string
+	Webhook   string
+}
+
+// AlertCondition is a condition over a module's values, such as `lag > 10000` or
+// `state == "failed" or (errors > 5 and not muted)`. Comparisons are >, >=, <, <=, ==,
+// and != between fields, numbers, quoted strings, true, and false, and are combined with
+// and, or, and not. Values are compared as numbers when both are numbers, and as text
+// otherwise. A comparison with a field that has no value is false
+type AlertCondition struct {
+	Expression string
+
+	root alertNode
+}
+
+// alertNode is a node of a parsed condition
+type alertNode interface {
+	eval(lookup func(field string) (interface{}, bool)) bool
+}
+
+type alertAnd struct{ left, right alertNode }
+type alertOr struct{ left, right alertNode }
+type alertNot struct{ node alertNode }
+
+type alertComparison struct {
+	left  alertOperand
+	op    string
+	right alertOperand
+}
+
+// alertTruthy is a lone operand, which holds if it's true or a non-zero number
+type alertTruthy struct {
+	operand alertOperand
+}
+
+type alertOperand struct {
+	field   string
+	literal interface{}
+}
+
+// alertParser turns the tokens of a condition into nodes
+type alertParser struct {
+	pos    int
+	tokens []string
+}
+
+var alertOperators = map[string]bool{">": true, ">=": true, "<": true, "<=": true, "==": true, "!=": true}
+
+// NewAlertRulesFromYAML creates and returns the alert rules in the module's "alerts"
+// list. Rules whose conditions can't be parsed are reported as validation errors. Rules
+// are colored with the module's crit color unless they say otherwise
+func NewAlertRulesFromYAML(moduleConfig *config.Config, critColor string) ([]*AlertRule, []Validatable) {
+	rules := []*AlertRule{}
+	validations := []Validatable{}
+
+	for _, value := range moduleConfig.UList("alerts") {
+		ruleConfig := &config.Config{Root: value}
+
+		when := ruleConfig.UString("when", "")
+		condition, err := ParseAlertCondition(when)
+		if err != nil {
+			validations = append(validations, newSettingValidation("alerts", when, err))
+			continue
+		}
+
+		rules = append(rules, &AlertRule{
+			Color:     ruleConfig.UString("color", critColor),
+			Command:   ruleConfig.UString("command", ""),
+			Condition: condition,
+			Name:      ruleConfig.UString("name", when),
+			Notify:    ruleConfig.UString("notify", ""),
+			Webhook:   ruleConfig.UString("webhook", ""),
+		})
+	}
+
+	return rules, validations
+}
+
+// ParseAlertCondition parses a condition, such as "lag > 10000"
+func ParseAlertCondition(expression string) (*AlertCondition, error) {
+	tokens, err := alertTokens(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("an alert needs a condition in 'when'")
+	}
+
+	parser := alertParser{tokens: tokens}
+
+	root, err := parser.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if parser.pos < len(tokens) {
+		return nil, fmt.Errorf("unexpected '%s' in '%s'", tokens[parser.pos], expression)
+	}
+
+	return &AlertCondition{Expression: expression, root: root}, nil
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Holds returns TRUE if the condition holds for the values the lookup function returns
+func (condition *AlertCondition) Holds(lookup func(field string) (interface{}, bool)) bool {
+	return condition.root.eval(lookup)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (node alertAnd) eval(lookup func(field string) (interface{}, bool)) bool {
+	return node.left.eval(lookup) && node.right.eval(lookup)
+}
+
+func (node alertOr) eval(lookup func(field string) (interface{}, bool)) bool {
+	return node.left.eval(lookup) || node.right.eval(lookup)
+}
+
+func (node alertNot) eval(lookup func(field string) (interface{}, bool)) bool {
+	return !node.node.eval(lookup)
+}
+
+func (node alertTruthy) eval(lookup func(field string) (interface{}, bool)) bool {
+	value, ok := node.operand.value(lookup)
+	if !ok {
+		return false
+	}
+
+	if number, isNumber := alertNumber(value); isNumber {
+		return number != 0
+	}
+
+	return fmt.Sprintf("%v", value) == "true"
+}
+
+func (node alertComparison) eval(lookup func(field string) (interface{}, bool)) bool {
+	left, ok := node.left.value(lookup)
+	if !ok {
+		return false
+	}
+
+	right, ok := node.right.value(lookup)
+	if !ok {
+		return false
+	}
+
+	leftNumber, leftIsNumber := alertNumber(left)
+	rightNumber, rightIsNumber := alertNumber(right)
+
+	if leftIsNumber && rightIsNumber {
+		switch node.op {
+		case ">":
+			return leftNumber > rightNumber
+		case ">=":
+			return leftNumber >= rightNumber
+		case "<":
+			return leftNumber < rightNumber
+		case "<=":
+			return leftNumber <= rightNumber
+		case "==":
+			return leftNumber == rightNumber
+		default:
+			return leftNumber != rightNumber
+		}
+	}
+
+	leftText, rightText := fmt.Sprintf("%v", left), fmt.Sprintf("%v", right)
+
+	switch node.op {
+	case ">":
+		return leftText > rightText
+	case ">=":
+		return leftText >= rightText
+	case "<":
+		return leftText < rightText
+	case "<=":
+		return leftText <= rightText
+	case "==":
+		return leftText == rightText
+	default:
+		return leftText != rightText
+	}
+}
+
+// value returns the operand's literal, or the value of its field
+func (operand alertOperand) value(lookup func(field string) (interface{}, bool)) (interface{}, bool) {
+	if operand.field == "" {
+		return operand.literal, true
+	}
+
+	return lookup(operand.field)
+}
+
+func (parser *alertParser) next() string {
+	if parser.pos >= len(parser.tokens) {
+		return ""
+	}
+
+	return parser.tokens[parser.pos]
+}
+
+func (parser *alertParser) or() (alertNode, error) {
+	left, err := parser.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.next() == "or" || parser.next() == "||" {
+		parser.pos++
+
+		right, err := parser.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = alertOr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *alertParser) and() (alertNode, error) {
+	left, err := parser.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.next() == "and" || parser.next() == "&&" {
+		parser.pos++
+
+		right, err := parser.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = alertAnd{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *alertParser) unary() (alertNode, error) {
+	switch parser.next() {
+	case "not", "!":
+		parser.pos++
+
+		node, err := parser.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return alertNot{node: node}, nil
+	case "(":
+		parser.pos++
+
+		node, err := parser.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if parser.next() != ")" {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		parser.pos++
+
+		return node, nil
+	}
+
+	left, err := parser.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	op := parser.next()
+	if !alertOperators[op] {
+		return alertTruthy{operand: left}, nil
+	}
+	parser.pos++
+
+	right, err := parser.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	return alertComparison{left: left, op: op, right: right}, nil
+}
+
+func (parser *alertParser) operand() (alertOperand, error) {
+	token := parser.next()
+	if token == "" {
+		return alertOperand{}, fmt.Errorf("the condition ends too soon")
+	}
+	parser.pos++
+
+	switch {
+	case token == "true" || token == "false":
+		return alertOperand{literal: token == "true"}, nil
+	case strings.HasPrefix(token, `"`) || strings.HasPrefix(token, "'"):
+		return alertOperand{literal: token[1 : len(token)-1]}, nil
+	}
+
+	if number, err := strconv.ParseFloat(token, 64); err == nil {
+		return alertOperand{literal: number}, nil
+	}
+
+	if !unicode.IsLetter(rune(token[0])) && token[0] != '_' {
+		return alertOperand{}, fmt.Errorf("unexpected '%s'", token)
+	}
+
+	return alertOperand{field: token}, nil
+}
+
+// alertNumber returns the value as a float64, if it is a number or a string that is one
+func alertNumber(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case uint:
+		return float64(number), true
+	case uint64:
+		return float64(number), true
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		return parsed, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// alertTokens splits a condition into fields, numbers, quoted strings, operators, and
+// parentheses
+func alertTokens(expression string) ([]string, error) {
+	tokens := []string{}
+	runes := []rune(expression)
+
+	for idx := 0; idx < len(runes); {
+		char := runes[idx]
+
+		switch {
+		case unicode.IsSpace(char):
+			idx++
+		case char == '(' || char == ')':
+			tokens = append(tokens, string(char))
+			idx++
+		case char == '"' || char == '\'':
+			end := idx + 1
+			for end < len(runes) && runes[end] != char {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string in '%s'", expression)
+			}
+
+			tokens = append(tokens, string(runes[idx:end+1]))
+			idx = end + 1
+		case strings.ContainsRune("<>=!&|", char):
+			end := idx + 1
+			for end < len(runes) && strings.ContainsRune("<>=!&|", runes[end]) {
+				end++
+			}
+
+			op := string(runes[idx:end])
+			if !alertOperators[op] && op != "&&" && op != "||" && op != "!" {
+				return nil, fmt.Errorf("unknown operator '%s' in '%s'", op, expression)
+			}
+
+			tokens = append(tokens, op)
+			idx = end
+		default:
+			end := idx
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()<>=!&|"'`, runes[end]) {
+				end++
+			}
+
+			tokens = append(tokens, string(runes[idx:end]))
+			idx = end
+		}
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,101 @@
+package cfg
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// XdgCacheDir defines the path to the minimal XDG-compatible cache directory
+const XdgCacheDir = "~/.cache/"
+
+// ModuleCacheMaxBytes is how large a module's cache directory can grow before its
+// oldest files are deleted. It is set from the "wtf.cache.moduleMaxSize" setting, in
+// megabytes
+var ModuleCacheMaxBytes int64 = 50 * 1024 * 1024
+
+/* -------------------- Exported Functions -------------------- */
+
+// WtfCacheDir returns the absolute path to the cache directory, creating it if it does
+// not already exist. Honours $XDG_CACHE_HOME if it is set
+func WtfCacheDir() (string, error) {
+	cacheDir := os.Getenv("XDG_CACHE_HOME")
+
+	if cacheDir == "" {
+		var err error
+
+		cacheDir, err = expandHomeDir(XdgCacheDir)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	wtfCacheDir := filepath.Join(cacheDir, "wtf")
+
+	err := os.MkdirAll(wtfCacheDir, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	return wtfCacheDir, nil
+}
+
+// CacheDirFor returns the absolute path to the named module's own cache directory,
+// creating it if it does not already exist. If the directory has grown past
+// ModuleCacheMaxBytes, its least recently changed files are deleted to bring it back
+// under. Modules keep downloaded images, history, and other files they can rebuild here
+func CacheDirFor(moduleName string) (string, error) {
+	wtfCacheDir, err := WtfCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	moduleCacheDir := filepath.Join(wtfCacheDir, "modules", moduleName)
+
+	err = os.MkdirAll(moduleCacheDir, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	// A cache that can't be pruned is still usable, just larger than it should be
+	PruneCacheDir(moduleCacheDir, ModuleCacheMaxBytes)
+
+	return moduleCacheDir, nil
+}
+
+// PruneCacheDir deletes the least recently changed files in the directory until the
+// files in it take up no more than maxBytes
+func PruneCacheDir(dir string, maxBytes int64) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	total := int64(0)
+	for _, file := range files {
+		if !file.IsDir() {
+			total += file.Size()
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime().Before(files[j].ModTime()) })
+
+	for _, file := range files {
+		if total <= maxBytes {
+			break
+		}
+
+		if file.IsDir() {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(dir, file.Name())); err != nil {
+			return err
+		}
+
+		total -= file.Size()
+	}
+
+	return nil
+}
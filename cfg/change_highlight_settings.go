@@ -0,0 +1,43 @@
+package cfg
+
+import (
+	"github.com/olebedev/config"
+)
+
+const (
+	changeHighlightsPath = "changeHighlights"
+
+	defaultChangeHighlightColor  = "darkslategray"
+	defaultChangeHighlightCycles = 1
+)
+
+// ChangeHighlightSettings control the highlighting of the rows that are new or changed
+// since a module's previous refresh, which shows what moved in busy lists such as build
+// or pull request queues
+type ChangeHighlightSettings struct {
+	Color   string
+	Cycles  int
+	Enabled bool
+}
+
+// NewChangeHighlightSettingsFromYAML creates and returns an instance of
+// ChangeHighlightSettings. Each setting defaults to its counterpart under
+// "wtf.changeHighlights":
+//
+//	wtf:
+//	  changeHighlights:
+//	    color: darkslategray
+//	    cycles: 1
+//	    enabled: true
+//
+// Changed rows are given the background color for the given number of refreshes. Rows
+// aren't highlighted unless it's enabled
+func NewChangeHighlightSettingsFromYAML(moduleConfig *config.Config, globalConfig *config.Config) ChangeHighlightSettings {
+	globalPath := "wtf." + changeHighlightsPath
+
+	return ChangeHighlightSettings{
+		Color:   moduleConfig.UString(changeHighlightsPath+".color", globalConfig.UString(globalPath+".color", defaultChangeHighlightColor)),
+		Cycles:  moduleConfig.UInt(changeHighlightsPath+".cycles", globalConfig.UInt(globalPath+".cycles", defaultChangeHighlightCycles)),
+		Enabled: moduleConfig.UBool(changeHighlightsPath+".enabled", globalConfig.UBool(globalPath+".enabled", false)),
+	}
+}
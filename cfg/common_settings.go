@@ -2,6 +2,7 @@ package cfg
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/olebedev/config"
@@ -13,11 +14,14 @@ type Colors struct {
 	BorderFocused   string
 	BorderNormal    string
 	Checked         string
+	Crit            string
 	Foreground      string
 	HighlightFore   string
 	HighlightBack   string
+	OK              string
 	Text            string
 	Title           string
+	Warn            string
 
 	Rows struct {
 		Even string
@@ -30,6 +34,32 @@ type Module struct {
 	Type string
 }
 
+// localModuleTypes are the module types that get their data from the local machine
+// rather than over the network
+var localModuleTypes = map[string]bool{
+	"anki":          true,
+	"bargraph":      true,
+	"carousel":      true,
+	"clocks":        true,
+	"cmdrunner":     true,
+	"git":           true,
+	"group":         true,
+	"habits":        true,
+	"logger":        true,
+	"meetings":      true,
+	"mercurial":     true,
+	"notifications": true,
+	"power":         true,
+	"resourceusage": true,
+	"scratchpad":    true,
+	"security":      true,
+	"spotify":       true,
+	"status":        true,
+	"summary":       true,
+	"textfile":      true,
+	"todo":          true,
+}
+
 type Sigils struct {
 	Checkbox struct {
 		Checked   string
@@ -47,31 +77,46 @@ type Common struct {
 	PositionSettings `help:"Defines where in the grid this module’s widget will be displayed."`
 	Sigils
 
-	Bordered        bool   `help:"Whether or not the module should be displayed with a border." values:"true, false" optional:"true" default:"true"`
-	Enabled         bool   `help:"Whether or not this module is executed and if its data displayed onscreen." values:"true, false" optional:"true" default:"false"`
-	RefreshInterval int    `help:"How often, in seconds, this module will update its data." values:"A positive integer, 0..n." optional:"true"`
-	Title           string `help:"The title string to show when displaying this module" optional:"true"`
-	Config          *config.Config
-
-	focusChar int `help:"Define one of the number keys as a short cut key to access the widget." optional:"true"`
+	Alerts           []*AlertRule            `help:"Conditions over the module's values, such as 'lag > 10000', that color the module's border and title while they hold (when, color, name) and send a notification (notify) or run a command or webhook (command, webhook) when they start holding." optional:"true"`
+	Bordered         bool                    `help:"Whether or not the module should be displayed with a border." values:"true, false" optional:"true" default:"true"`
+	ChangeHighlights ChangeHighlightSettings `help:"Whether to highlight the rows that are new or changed since the previous refresh (enabled), for how many refreshes (cycles), and in which background color (color)." optional:"true"`
+	Confirmations    bool                    `help:"Whether or not to ask before doing something that can't be undone, such as deleting an item. Defaults to wtf.confirmations." values:"true, false" optional:"true" default:"true"`
+	DateTime         DateTimeSettings        `help:"The Go layouts dates (dateFormat) and times (timeFormat) are written in, and the day weeks start on (weekStart). Each defaults to its counterpart under wtf." optional:"true"`
+	Enabled          bool                    `help:"Whether or not this module is executed and if its data displayed onscreen." values:"true, false" optional:"true" default:"false"`
+	Notifications    NotificationSettings    `help:"Whether to publish notifications (enabled), show them on the desktop (desktop), and the minimum seconds between repeats of the same one (throttle)." optional:"true"`
+	OfflineCache     bool                    `help:"Whether or not to show the data from the last successful refresh, marked as stale, when a refresh fails." values:"true, false" optional:"true" default:"true"`
+	Page             string                  `help:"The name of the page this module’s widget is displayed on. Defaults to the first page." optional:"true"`
+	PauseDuringDND   bool                    `help:"Whether or not to stop refreshing this module while Do Not Disturb is on." values:"true, false" optional:"true" default:"false"`
+	RefreshIndicator bool                    `help:"Whether or not to show the refresh status in the title bar." values:"true, false" optional:"true" default:"true"`
+	RefreshInterval  int                     `help:"How often, in seconds, this module will update its data." values:"A positive integer, 0..n, or a cron expression." optional:"true"`
+	RefreshSchedule  *CronSchedule
+	Sensitive        bool   `help:"Whether or not this module shows something private, and so is hidden in ambient mode." values:"true, false" optional:"true" default:"false"`
+	Title            string `help:"The title string to show when displaying this module" optional:"true"`
+	Config           *config.Config
+
+	focusChar   int `help:"Define one of the number keys as a short cut key to access the widget." optional:"true"`
+	validations []Validatable
 }
 
 func NewCommonSettingsFromModule(name, defaultTitle string, moduleConfig *config.Config, globalSettings *config.Config) *Common {
-	colorsConfig, _ := globalSettings.Get("wtf.colors")
+	theme := NewModuleThemeFromConfig(moduleConfig, globalSettings)
 	sigilsPath := "wtf.sigils"
 
 	common := Common{
 		Colors: Colors{
-			Background:      moduleConfig.UString("background", globalSettings.UString("background", "black")),
-			BorderFocusable: colorsConfig.UString("border.focusable", "red"),
-			BorderFocused:   colorsConfig.UString("border.focused", "orange"),
-			BorderNormal:    colorsConfig.UString("border.normal", "gray"),
-			Checked:         colorsConfig.UString("checked", "white"),
-			Foreground:      moduleConfig.UString("foreground", colorsConfig.UString("foreground", "white")),
-			HighlightFore:   colorsConfig.UString("highlight.fore", "black"),
-			HighlightBack:   colorsConfig.UString("highlight.back", "green"),
-			Text:            moduleConfig.UString("colors.text", colorsConfig.UString("text", "white")),
-			Title:           moduleConfig.UString("colors.title", colorsConfig.UString("title", "white")),
+			Background:      moduleConfig.UString("background", globalSettings.UString("background", theme.Background)),
+			BorderFocusable: theme.BorderFocusable,
+			BorderFocused:   theme.BorderFocused,
+			BorderNormal:    theme.BorderNormal,
+			Checked:         theme.Checked,
+			Crit:            theme.Crit,
+			Foreground:      moduleConfig.UString("foreground", theme.Foreground),
+			HighlightFore:   theme.HighlightFore,
+			HighlightBack:   theme.HighlightBack,
+			OK:              theme.OK,
+			Text:            theme.Text,
+			Title:           theme.Title,
+			Warn:            theme.Warn,
 		},
 
 		Module: Module{
@@ -79,19 +124,37 @@ func NewCommonSettingsFromModule(name, defaultTitle string, moduleConfig *config
 			Type: moduleConfig.UString("type", name),
 		},
 
-		PositionSettings: NewPositionSettingsFromYAML(name, moduleConfig),
-
-		Bordered:        moduleConfig.UBool("border", true),
-		Enabled:         moduleConfig.UBool("enabled", false),
-		RefreshInterval: moduleConfig.UInt("refreshInterval", 300),
-		Title:           moduleConfig.UString("title", defaultTitle),
-		Config:          moduleConfig,
+		PositionSettings: NewPositionSettingsFromYAML(name, moduleConfig, globalSettings),
+
+		Bordered:         moduleConfig.UBool("border", true),
+		ChangeHighlights: NewChangeHighlightSettingsFromYAML(moduleConfig, globalSettings),
+		Confirmations:    moduleConfig.UBool("confirmations", globalSettings.UBool("wtf.confirmations", true)),
+		Enabled:          moduleConfig.UBool("enabled", false),
+		OfflineCache:     moduleConfig.UBool("offlineCache", globalSettings.UBool("wtf.offlineCache", true)),
+		Notifications:    NewNotificationSettingsFromYAML(moduleConfig, globalSettings),
+		Page:             moduleConfig.UString("page", ""),
+		PauseDuringDND:   moduleConfig.UBool("pauseDuringDND", false),
+		RefreshIndicator: moduleConfig.UBool("refreshIndicator", globalSettings.UBool("wtf.refreshIndicator", true)),
+		RefreshInterval:  moduleConfig.UInt("refreshInterval", 300),
+		Sensitive:        moduleConfig.UBool("sensitive", false),
+		Title:            moduleConfig.UString("title", defaultTitle),
+		Config:           moduleConfig,
 
 		focusChar: moduleConfig.UInt("focusChar", -1),
 	}
 
-	common.Colors.Rows.Even = moduleConfig.UString("colors.rows.even", moduleConfig.UString("rows.even", "white"))
-	common.Colors.Rows.Odd = moduleConfig.UString("colors.rows.even", moduleConfig.UString("rows.odd", "lightblue"))
+	common.RefreshSchedule, common.validations = refreshScheduleFromConfig(moduleConfig)
+
+	alerts, alertValidations := NewAlertRulesFromYAML(moduleConfig, common.Colors.Crit)
+	common.Alerts = alerts
+	common.validations = append(common.validations, alertValidations...)
+
+	dateTime, dateTimeValidations := NewDateTimeSettingsFromYAML(moduleConfig, globalSettings)
+	common.DateTime = dateTime
+	common.validations = append(common.validations, dateTimeValidations...)
+
+	common.Colors.Rows.Even = moduleConfig.UString("rows.even", theme.RowsEven)
+	common.Colors.Rows.Odd = moduleConfig.UString("rows.odd", theme.RowsOdd)
 
 	common.Sigils.Checkbox.Checked = globalSettings.UString(sigilsPath+".checkbox.checked", "x")
 	common.Sigils.Checkbox.Unchecked = globalSettings.UString(sigilsPath+".checkbox.unchecked", " ")
@@ -147,6 +210,18 @@ func (common *Common) SigilStr(len, pos int, width int) string {
 	return sigils
 }
 
+// UsesNetwork returns TRUE if the module fetches its data over the network. Modules can
+// override the default for their type with the "network" setting
+func (common *Common) UsesNetwork() bool {
+	usesNetwork := !localModuleTypes[common.Module.Type]
+
+	if common.Config == nil {
+		return usesNetwork
+	}
+
+	return common.Config.UBool("network", usesNetwork)
+}
+
 // Validations aggregates all the validations from all the sub-sections in Common into a
 // single array of validations
 func (common *Common) Validations() []Validatable {
@@ -156,5 +231,29 @@ func (common *Common) Validations() []Validatable {
 		validatables = append(validatables, validation)
 	}
 
+	validatables = append(validatables, common.validations...)
+
 	return validatables
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// refreshScheduleFromConfig returns the cron schedule defined by the module's
+// refreshInterval, or nil if refreshInterval is a number of seconds
+func refreshScheduleFromConfig(moduleConfig *config.Config) (*CronSchedule, []Validatable) {
+	expression, err := moduleConfig.String("refreshInterval")
+	if err != nil {
+		return nil, []Validatable{}
+	}
+
+	if _, err := strconv.Atoi(expression); err == nil {
+		return nil, []Validatable{}
+	}
+
+	schedule, err := NewCronSchedule(expression)
+	if err != nil {
+		return nil, []Validatable{newSettingValidation("refreshInterval", expression, err)}
+	}
+
+	return schedule, []Validatable{}
+}
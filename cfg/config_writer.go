@@ -0,0 +1,560 @@
+package cfg
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// ConfigWriter makes changes to a config file's text in place, so that the user's
+// comments, blank lines, and key order survive runtime changes such as layout edits or
+// disabling a module. The text is parsed into yaml.v3's nodes to find what to change,
+// and each change is made to the text at the positions the nodes were read from, rather
+// than by encoding the nodes again, which would re-indent the whole file and drop its
+// blank lines.
+//
+// Keys are found through mappings written in block or flow style, over any number of
+// lines, and through the items of lists, which are picked by their index, as in
+// "wtf.pages.0.name". The anchors and tags on the values it replaces are kept, so that
+// the aliases to them still resolve. A key set beneath an alias, which would change
+// every place the alias is used, is set on a merge of the aliased mapping instead:
+//
+//	position:
+//	  <<: *pos
+//	  top: 2
+type ConfigWriter struct {
+	filePath string
+	text     string
+}
+
+// NewConfigWriter reads the config file at the given path for changing
+func NewConfigWriter(filePath string) (*ConfigWriter, error) {
+	absPath, err := expandHomeDir(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := ioutil.ReadFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConfigWriterFromText(absPath, string(text)), nil
+}
+
+// NewConfigWriterFromText creates a ConfigWriter that changes the given text and
+// saves it to the given path
+func NewConfigWriterFromText(filePath, text string) *ConfigWriter {
+	return &ConfigWriter{
+		filePath: filePath,
+		text:     strings.TrimSuffix(text, "\n") + "\n",
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Save writes the changed text back to the config file. It is written in place, rather
+// than replaced, so that the running app sees the change and reloads its config
+func (writer *ConfigWriter) Save() error {
+	return ioutil.WriteFile(writer.filePath, []byte(writer.String()), 0644)
+}
+
+// Set sets the value at the dot-separated path, such as "wtf.mods.todo.enabled",
+// adding the keys along the path that are missing
+func (writer *ConfigWriter) Set(path string, value interface{}) error {
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal([]byte(writer.text), &doc); err != nil {
+		return err
+	}
+
+	keys := strings.Split(path, ".")
+
+	if len(doc.Content) == 0 {
+		child, err := nested(keys[1:], value)
+		if err != nil {
+			return err
+		}
+
+		entry, err := renderEntry(0, keys[0], child)
+		if err != nil {
+			return err
+		}
+
+		writer.text = strings.TrimLeft(writer.text, "\n") + entry + "\n"
+		return nil
+	}
+
+	src := newYAMLSource(writer.text)
+	at := location{node: doc.Content[0]}
+
+	for idx, key := range keys {
+		switch {
+		case at.node.Kind == yaml.AliasNode && at.node.Alias != nil && at.node.Alias.Kind == yaml.MappingNode:
+			merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			merged.Content = append(merged.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: "<<"},
+				&yaml.Node{Kind: yaml.AliasNode, Value: at.node.Value},
+			)
+
+			child, err := nested(keys[idx+1:], value)
+			if err != nil {
+				return err
+			}
+			merged.Content = append(merged.Content, scalarNode(key), child)
+
+			return writer.replace(src, at, merged)
+		case at.node.Kind == yaml.ScalarNode && at.node.Tag == "!!null" && at.key != nil:
+			child, err := nested(keys[idx:], value)
+			if err != nil {
+				return err
+			}
+
+			return writer.replace(src, at, child)
+		}
+
+		next := location{flow: at.flow || at.node.Style&yaml.FlowStyle != 0}
+
+		switch at.node.Kind {
+		case yaml.MappingNode:
+			for pair := 0; pair+1 < len(at.node.Content); pair += 2 {
+				if at.node.Content[pair].Value == key && at.node.Content[pair].Tag != "!!merge" {
+					next.key, next.node = at.node.Content[pair], at.node.Content[pair+1]
+					break
+				}
+			}
+
+			if next.node == nil {
+				child, err := nested(keys[idx+1:], value)
+				if err != nil {
+					return err
+				}
+
+				return writer.add(src, at, key, child)
+			}
+		case yaml.SequenceNode:
+			item, err := strconv.Atoi(key)
+			if err != nil || item < 0 || item >= len(at.node.Content) {
+				return fmt.Errorf("%s: %s has no item %s", path, strings.Join(keys[:idx], "."), key)
+			}
+
+			next.node, next.parent = at.node.Content[item], at.node
+		default:
+			return fmt.Errorf("%s: %s is not a mapping", path, strings.Join(keys[:idx], "."))
+		}
+
+		at = next
+
+		if idx == len(keys)-1 {
+			node := &yaml.Node{}
+			if err := node.Encode(value); err != nil {
+				return err
+			}
+
+			return writer.replace(src, at, node)
+		}
+	}
+
+	return nil
+}
+
+// String returns the changed text
+func (writer *ConfigWriter) String() string {
+	return writer.text
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// location is a node that a path leads to: the value of a key, or an item in a list
+type location struct {
+	flow   bool
+	key    *yaml.Node
+	node   *yaml.Node
+	parent *yaml.Node
+}
+
+// add adds the key, set to the value, to the end of the mapping at the location
+func (writer *ConfigWriter) add(src *yamlSource, at location, key string, value *yaml.Node) error {
+	mapping := at.node
+
+	if at.flow || mapping.Style&yaml.FlowStyle != 0 {
+		flow, err := renderFlow(value)
+		if err != nil {
+			return err
+		}
+
+		entry := fmt.Sprintf("%s: %s", renderKey(key), flow)
+
+		if len(mapping.Content) == 0 {
+			open := src.valueStart(mapping) + 1
+			writer.splice(open, open, entry)
+			return nil
+		}
+
+		last := src.end(mapping.Content[len(mapping.Content)-1], mapping.Content[len(mapping.Content)-2].Column-1)
+		writer.splice(last, last, ", "+entry)
+		return nil
+	}
+
+	indent := mapping.Content[0].Column - 1
+
+	entry, err := renderEntry(indent, key, value)
+	if err != nil {
+		return err
+	}
+
+	last := src.lineEnd(src.end(mapping.Content[len(mapping.Content)-1], indent))
+	writer.splice(last, last, "\n"+entry)
+
+	return nil
+}
+
+// replace replaces the value at the location, keeping its anchor and tag, with the
+// value, written in flow style if it's inside a flow collection and in block style if not
+func (writer *ConfigWriter) replace(src *yamlSource, at location, value *yaml.Node) error {
+	ownerIndent := 0
+	if at.key != nil {
+		ownerIndent = at.key.Column - 1
+	} else {
+		ownerIndent = src.indentAt(at.node.Line)
+	}
+
+	start := src.valueStart(at.node)
+	if at.key != nil && isBlockCollection(at.node) && at.node.Anchor == "" && at.node.Style&yaml.TaggedStyle == 0 {
+		start = src.afterKey(at.key)
+	}
+	end := src.end(at.node, ownerIndent)
+
+	if at.flow {
+		flow, err := renderFlow(value)
+		if err != nil {
+			return err
+		}
+
+		writer.splice(start, end, flow)
+		return nil
+	}
+
+	for start > 0 && (writer.text[start-1] == ' ' || writer.text[start-1] == '\t') {
+		start--
+	}
+
+	if at.key == nil {
+		lines, err := render(value)
+		if err != nil {
+			return err
+		}
+
+		writer.splice(start, end, " "+strings.Join(indentLines(lines, ownerIndent+2, 1), "\n"))
+		return nil
+	}
+
+	entry, err := renderEntry(ownerIndent, "key", value)
+	if err != nil {
+		return err
+	}
+
+	writer.splice(start, end, strings.TrimPrefix(entry, strings.Repeat(" ", ownerIndent)+"key:"))
+
+	return nil
+}
+
+// splice replaces the text between the two offsets
+func (writer *ConfigWriter) splice(start, end int, text string) {
+	writer.text = writer.text[:start] + text + writer.text[end:]
+}
+
+func indentLines(lines []string, indent, from int) []string {
+	for idx := from; idx < len(lines); idx++ {
+		if lines[idx] != "" {
+			lines[idx] = strings.Repeat(" ", indent) + lines[idx]
+		}
+	}
+
+	return lines
+}
+
+func isBlockCollection(node *yaml.Node) bool {
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && node.Style&yaml.FlowStyle == 0
+}
+
+// nested returns the value, set beneath each of the keys in turn
+func nested(keys []string, value interface{}) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+
+	for idx := len(keys) - 1; idx >= 0; idx-- {
+		node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{scalarNode(keys[idx]), node}}
+	}
+
+	return node, nil
+}
+
+// render returns the lines of the value written out in block style
+func render(value *yaml.Node) ([]string, error) {
+	buf := bytes.Buffer{}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"), nil
+}
+
+// renderEntry returns the lines that set the key to the value, indented as given
+func renderEntry(indent int, key string, value *yaml.Node) (string, error) {
+	lines, err := render(&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{scalarNode(key), value}})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(indentLines(lines, indent, 0), "\n"), nil
+}
+
+// renderFlow returns the value written out in flow style, on one line
+func renderFlow(value *yaml.Node) (string, error) {
+	setFlowStyle(value)
+
+	lines, err := render(value)
+	if err != nil {
+		return "", err
+	}
+
+	for idx := range lines {
+		lines[idx] = strings.TrimSpace(lines[idx])
+	}
+
+	return strings.Join(lines, " "), nil
+}
+
+func renderKey(key string) string {
+	lines, err := render(scalarNode(key))
+	if err != nil || len(lines) != 1 {
+		return strconv.Quote(key)
+	}
+
+	return lines[0]
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func setFlowStyle(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		node.Style = yaml.FlowStyle
+	case yaml.ScalarNode:
+		if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 || strings.Contains(node.Value, "\n") {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+	}
+
+	for _, child := range node.Content {
+		setFlowStyle(child)
+	}
+}
+
+// yamlSource finds where in the text the nodes parsed from it start and end
+type yamlSource struct {
+	lineStarts []int
+	text       string
+}
+
+func newYAMLSource(text string) *yamlSource {
+	src := &yamlSource{lineStarts: []int{0}, text: text}
+
+	for idx, char := range text {
+		if char == '\n' {
+			src.lineStarts = append(src.lineStarts, idx+1)
+		}
+	}
+
+	return src
+}
+
+// afterKey returns the offset just after the colon that follows the key
+func (src *yamlSource) afterKey(key *yaml.Node) int {
+	offset := src.end(key, key.Column-1)
+
+	colon := strings.IndexByte(src.text[offset:], ':')
+	if colon < 0 {
+		return offset
+	}
+
+	return offset + colon + 1
+}
+
+// end returns the offset just after the node's text. ownerIndent is the indentation of
+// the key or list item the node belongs to, which a block scalar's lines are indented past
+func (src *yamlSource) end(node *yaml.Node, ownerIndent int) int {
+	start := src.valueStart(node)
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		return start + 1 + len(node.Value)
+	case yaml.MappingNode, yaml.SequenceNode:
+		if node.Style&yaml.FlowStyle != 0 {
+			return src.flowEnd(start)
+		}
+
+		if len(node.Content) == 0 {
+			return start
+		}
+
+		last := node.Content[len(node.Content)-1]
+		if node.Kind == yaml.MappingNode {
+			return src.end(last, node.Content[len(node.Content)-2].Column-1)
+		}
+
+		return src.end(last, src.indentAt(last.Line))
+	}
+
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		end := start + strings.IndexAny(src.text[start:]+" ", " \t\n")
+
+		for line := node.Line; line < len(src.lineStarts); line++ {
+			text := src.line(line + 1)
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			if src.indentAt(line+1) <= ownerIndent {
+				break
+			}
+			end = src.lineStarts[line] + len(text)
+		}
+
+		return end
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		for idx := start + 1; idx < len(src.text); idx++ {
+			switch src.text[idx] {
+			case '\\':
+				idx++
+			case '"':
+				return idx + 1
+			}
+		}
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		for idx := start + 1; idx < len(src.text); idx++ {
+			if src.text[idx] == '\'' {
+				if idx+1 < len(src.text) && src.text[idx+1] == '\'' {
+					idx++
+					continue
+				}
+				return idx + 1
+			}
+		}
+	case node.Tag == "!!null" && node.Value == "":
+		return start
+	default:
+		return start + len(node.Value)
+	}
+
+	return len(src.text)
+}
+
+// flowEnd returns the offset just after the bracket that closes the flow collection
+// opened at the offset, which may be several lines further on
+func (src *yamlSource) flowEnd(start int) int {
+	depth := 0
+	quote := byte(0)
+
+	for idx := start; idx < len(src.text); idx++ {
+		char := src.text[idx]
+
+		switch {
+		case quote != 0:
+			if char == '\\' && quote == '"' {
+				idx++
+			} else if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '#' && idx > 0 && (src.text[idx-1] == ' ' || src.text[idx-1] == '\t'):
+			idx = src.lineEnd(idx)
+		case char == '{' || char == '[':
+			depth++
+		case char == '}' || char == ']':
+			depth--
+			if depth == 0 {
+				return idx + 1
+			}
+		}
+	}
+
+	return len(src.text)
+}
+
+func (src *yamlSource) indentAt(line int) int {
+	text := src.line(line)
+	return len(text) - len(strings.TrimLeft(text, " "))
+}
+
+// line returns the text of the line, counted from 1, without its newline
+func (src *yamlSource) line(line int) string {
+	start := src.lineStarts[line-1]
+
+	end := strings.IndexByte(src.text[start:], '\n')
+	if end < 0 {
+		return src.text[start:]
+	}
+
+	return src.text[start : start+end]
+}
+
+// lineEnd returns the offset of the newline that ends the line the offset is on
+func (src *yamlSource) lineEnd(offset int) int {
+	end := strings.IndexByte(src.text[offset:], '\n')
+	if end < 0 {
+		return len(src.text)
+	}
+
+	return offset + end
+}
+
+// offset returns the offset of the line and column, both counted from 1, that yaml.v3
+// gives a node's position as. The column is counted in characters
+func (src *yamlSource) offset(line, column int) int {
+	offset := src.lineStarts[line-1]
+
+	for ; column > 1 && offset < len(src.text); column-- {
+		_, size := utf8.DecodeRuneInString(src.text[offset:])
+		offset += size
+	}
+
+	return offset
+}
+
+// valueStart returns the offset of the node's value, after its anchor and tag
+func (src *yamlSource) valueStart(node *yaml.Node) int {
+	offset := src.offset(node.Line, node.Column)
+
+	if node.Kind == yaml.AliasNode {
+		return offset
+	}
+
+	for offset < len(src.text) && (src.text[offset] == '&' || src.text[offset] == '!') {
+		for offset < len(src.text) && !strings.ContainsRune(" \t\n", rune(src.text[offset])) {
+			offset++
+		}
+		for offset < len(src.text) && (src.text[offset] == ' ' || src.text[offset] == '\t') {
+			offset++
+		}
+	}
+
+	return offset
+}
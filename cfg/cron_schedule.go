@@ -0,0 +1,210 @@
+package cfg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a refresh schedule defined by a five-field cron expression:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field is "*", a value, a range ("9-18"), a step ("*/5", "0-30/10"), or a
+// comma-separated list of those. Months and days of the week may also be given by
+// their three-letter names ("jan", "mon"). As in cron, if both the day of the month
+// and the day of the week are restricted, a time matching either of them matches
+type CronSchedule struct {
+	Expression string
+
+	days     uint64
+	hours    uint64
+	minutes  uint64
+	months   uint64
+	weekdays uint64
+
+	anyDay     bool
+	anyWeekday bool
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+// cronSearchLimit is how far ahead Next() looks for a matching time before giving up on
+// expressions that can never match, such as "0 0 31 2 *"
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{
+		name: "month", min: 1, max: 12,
+		names: map[string]int{
+			"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+			"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+		},
+	},
+	{
+		name: "day of week", min: 0, max: 7,
+		names: map[string]int{
+			"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+		},
+	},
+}
+
+// NewCronSchedule parses a cron expression and returns the CronSchedule it describes
+func NewCronSchedule(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression '%s' must have %d fields", expression, len(cronFields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for idx, field := range fields {
+		fieldBits, err := cronFields[idx].parse(field)
+		if err != nil {
+			return nil, fmt.Errorf("cron expression '%s': %v", expression, err)
+		}
+
+		bits[idx] = fieldBits
+	}
+
+	// Both 0 and 7 mean Sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	schedule := CronSchedule{
+		Expression: expression,
+
+		minutes:  bits[0],
+		hours:    bits[1],
+		days:     bits[2],
+		months:   bits[3],
+		weekdays: bits[4],
+
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+
+	return &schedule, nil
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Next returns the first time after the given time that matches the schedule, or the
+// zero time if the schedule never matches
+func (schedule *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		switch {
+		case !hasBit(schedule.months, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !schedule.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !hasBit(schedule.hours, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !hasBit(schedule.minutes, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (schedule *CronSchedule) matchesDay(t time.Time) bool {
+	day := hasBit(schedule.days, t.Day())
+	weekday := hasBit(schedule.weekdays, int(t.Weekday()))
+
+	switch {
+	case schedule.anyDay:
+		return weekday
+	case schedule.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// parse returns the set of values matched by one field of a cron expression, as a
+// bitmask in which bit N is set if the value N matches
+func (field cronField) parse(expression string) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(expression, ",") {
+		rangeStr, step := part, 1
+
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			rangeStr = part[:idx]
+
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s '%s'", field.name, part)
+			}
+		}
+
+		start, end := field.min, field.max
+
+		if rangeStr != "*" {
+			bounds := strings.SplitN(rangeStr, "-", 2)
+
+			var err error
+			start, err = field.value(bounds[0])
+			if err != nil {
+				return 0, err
+			}
+
+			end = start
+			if len(bounds) == 2 {
+				end, err = field.value(bounds[1])
+				if err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				end = field.max
+			}
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("invalid range in %s '%s'", field.name, part)
+		}
+
+		for val := start; val <= end; val += step {
+			bits |= 1 << uint(val)
+		}
+	}
+
+	return bits, nil
+}
+
+// value parses a single number or name in a field of a cron expression
+func (field cronField) value(str string) (int, error) {
+	if val, ok := field.names[strings.ToLower(str)]; ok {
+		return val, nil
+	}
+
+	val, err := strconv.Atoi(str)
+	if err != nil || val < field.min || val > field.max {
+		return 0, fmt.Errorf("invalid %s '%s'", field.name, str)
+	}
+
+	return val, nil
+}
+
+// hasBit returns TRUE if bit N of the bitmask is set
+func hasBit(bits uint64, n int) bool {
+	return bits&(1<<uint(n)) != 0
+}
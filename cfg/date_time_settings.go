@@ -0,0 +1,102 @@
+package cfg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+// DateTimeSettings are how a module writes dates and times, and the day its weeks start
+// on. The formats are Go time layouts; when neither the module nor "wtf" sets one, the
+// module uses its own default
+type DateTimeSettings struct {
+	DateFormat string
+	TimeFormat string
+	WeekStart  time.Weekday
+}
+
+// NewDateTimeSettingsFromYAML creates and returns an instance of DateTimeSettings. Each
+// setting defaults to its counterpart under "wtf", so that every calendar, clock, and
+// agenda module can be changed at once:
+//
+//	wtf:
+//	  dateFormat: "Mon, 2 Jan"
+//	  timeFormat: "3:04 PM"
+//	  weekStart: monday
+//
+// Weeks start on Sunday unless weekStart says otherwise
+func NewDateTimeSettingsFromYAML(moduleConfig *config.Config, globalConfig *config.Config) (DateTimeSettings, []Validatable) {
+	settings := DateTimeSettings{
+		DateFormat: moduleConfig.UString("dateFormat", globalConfig.UString("wtf.dateFormat", "")),
+		TimeFormat: moduleConfig.UString("timeFormat", globalConfig.UString("wtf.timeFormat", "")),
+		WeekStart:  time.Sunday,
+	}
+
+	weekStart := moduleConfig.UString("weekStart", globalConfig.UString("wtf.weekStart", ""))
+	if weekStart == "" {
+		return settings, []Validatable{}
+	}
+
+	weekday, err := ParseWeekday(weekStart)
+	if err != nil {
+		return settings, []Validatable{newSettingValidation("weekStart", weekStart, err)}
+	}
+
+	settings.WeekStart = weekday
+
+	return settings, []Validatable{}
+}
+
+// ParseWeekday reads a day of the week, such as "monday" or "Mon"
+func ParseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || (len(name) >= 3 && strings.HasPrefix(full, name)) {
+			return day, nil
+		}
+	}
+
+	return time.Sunday, fmt.Errorf("'%s' is not a day of the week", name)
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// DateLayout returns the date format, or the fallback if none is set
+func (settings DateTimeSettings) DateLayout(fallback string) string {
+	if settings.DateFormat == "" {
+		return fallback
+	}
+
+	return settings.DateFormat
+}
+
+// StartOfWeek returns midnight on the first day of the week the time falls in
+func (settings DateTimeSettings) StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) - int(settings.WeekStart) + 7) % 7
+	day := t.AddDate(0, 0, -offset)
+
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+}
+
+// TimeLayout returns the time format, or the fallback if none is set
+func (settings DateTimeSettings) TimeLayout(fallback string) string {
+	if settings.TimeFormat == "" {
+		return fallback
+	}
+
+	return settings.TimeFormat
+}
+
+// Weekdays returns the days of the week in order, starting with the first
+func (settings DateTimeSettings) Weekdays() []time.Weekday {
+	days := make([]time.Weekday, 7)
+	for i := range days {
+		days[i] = time.Weekday((int(settings.WeekStart) + i) % 7)
+	}
+
+	return days
+}
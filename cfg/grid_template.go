@@ -0,0 +1,160 @@
+package cfg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+const (
+	// gridTemplateEmptyCell marks a cell in a grid template that no widget occupies
+	gridTemplateEmptyCell = "."
+
+	// gridTemplateRowSeparator separates the rows of a grid template
+	gridTemplateRowSeparator = "|"
+)
+
+// GridArea is the rectangular block of grid cells occupied by a named area
+type GridArea struct {
+	Height int
+	Left   int
+	Top    int
+	Width  int
+}
+
+// GridTemplate is a CSS-grid-style layout that places widgets by name rather than by
+// row and column number. A template such as
+//
+//	"clock weather | github github"
+//
+// defines a grid of two rows and two columns, with the github widget spanning both
+// columns of the second row
+type GridTemplate struct {
+	Areas   map[string]GridArea
+	Columns int
+	Rows    int
+}
+
+// NewGridTemplate parses a template string into a GridTemplate. Rows are separated
+// by '|', cells within a row by whitespace, and '.' marks an empty cell. Every row must
+// have the same number of cells, and every named area must form a rectangle
+func NewGridTemplate(template string) (*GridTemplate, error) {
+	cells := [][]string{}
+
+	for _, row := range strings.Split(template, gridTemplateRowSeparator) {
+		fields := strings.Fields(row)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(cells) > 0 && len(fields) != len(cells[0]) {
+			return nil, fmt.Errorf("grid template row %d has %d cells, expected %d", len(cells)+1, len(fields), len(cells[0]))
+		}
+
+		cells = append(cells, fields)
+	}
+
+	if len(cells) == 0 {
+		return nil, fmt.Errorf("grid template is empty")
+	}
+
+	tmpl := &GridTemplate{
+		Areas:   map[string]GridArea{},
+		Columns: len(cells[0]),
+		Rows:    len(cells),
+	}
+
+	counts := map[string]int{}
+
+	for top, row := range cells {
+		for left, name := range row {
+			if name == gridTemplateEmptyCell {
+				continue
+			}
+
+			counts[name]++
+
+			area, ok := tmpl.Areas[name]
+			if !ok {
+				tmpl.Areas[name] = GridArea{Top: top, Left: left, Width: 1, Height: 1}
+				continue
+			}
+
+			if left < area.Left {
+				area.Width += area.Left - left
+				area.Left = left
+			}
+			area.Width = maxInt(area.Width, left-area.Left+1)
+			area.Height = maxInt(area.Height, top-area.Top+1)
+
+			tmpl.Areas[name] = area
+		}
+	}
+
+	for name, area := range tmpl.Areas {
+		if counts[name] != area.Width*area.Height {
+			return nil, fmt.Errorf("grid template area '%s' is not a rectangle", name)
+		}
+	}
+
+	return tmpl, nil
+}
+
+// GridTemplateAt parses the grid template defined at the given config path. Returns nil
+// if no template is defined there
+func GridTemplateAt(cfg *config.Config, path string) (*GridTemplate, error) {
+	template := gridTemplateString(cfg, path)
+	if template == "" {
+		return nil, nil
+	}
+
+	return NewGridTemplate(template)
+}
+
+// GridTemplateFromConfig returns the grid template that applies to the named page (or
+// the first page, if no name is given), falling back to the top-level
+// `wtf.grid.template`. The template may be written as a single string or as a list with
+// one string per row. Returns nil if no template is defined
+func GridTemplateFromConfig(globalConfig *config.Config, pageName string) (*GridTemplate, error) {
+	template := gridTemplateString(globalConfig, "wtf.grid.template")
+
+	for idx := range globalConfig.UList("wtf.pages") {
+		path := fmt.Sprintf("wtf.pages.%d", idx)
+
+		if globalConfig.UString(path+".name") == pageName || (pageName == "" && idx == 0) {
+			if pageTemplate := gridTemplateString(globalConfig, path+".grid.template"); pageTemplate != "" {
+				template = pageTemplate
+			}
+		}
+	}
+
+	if template == "" {
+		return nil, nil
+	}
+
+	return NewGridTemplate(template)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func gridTemplateString(cfg *config.Config, path string) string {
+	if str := cfg.UString(path); str != "" {
+		return str
+	}
+
+	rows := []string{}
+	for _, row := range cfg.UList(path) {
+		rows = append(rows, fmt.Sprintf("%v", row))
+	}
+
+	return strings.Join(rows, gridTemplateRowSeparator)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
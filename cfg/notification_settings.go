@@ -0,0 +1,39 @@
+package cfg
+
+import (
+	"github.com/olebedev/config"
+)
+
+const (
+	notificationsPath = "notifications"
+
+	defaultNotificationThrottle = 300
+)
+
+// NotificationSettings control the notifications a module publishes when something it
+// watches crosses a threshold, such as a build failing or the battery running low
+type NotificationSettings struct {
+	Desktop  bool
+	Enabled  bool
+	Throttle int
+}
+
+// NewNotificationSettingsFromYAML creates and returns an instance of NotificationSettings.
+// Each setting defaults to its counterpart under "wtf.notifications":
+//
+//	wtf:
+//	  notifications:
+//	    desktop: true
+//	    enabled: true
+//	    throttle: 300
+//
+// Notifications are off unless they are enabled
+func NewNotificationSettingsFromYAML(moduleConfig *config.Config, globalConfig *config.Config) NotificationSettings {
+	globalPath := "wtf." + notificationsPath
+
+	return NotificationSettings{
+		Desktop:  moduleConfig.UBool(notificationsPath+".desktop", globalConfig.UBool(globalPath+".desktop", true)),
+		Enabled:  moduleConfig.UBool(notificationsPath+".enabled", globalConfig.UBool(globalPath+".enabled", false)),
+		Throttle: moduleConfig.UInt(notificationsPath+".throttle", globalConfig.UInt(globalPath+".throttle", defaultNotificationThrottle)),
+	}
+}
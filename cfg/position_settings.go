@@ -16,15 +16,38 @@ type PositionSettings struct {
 	Left   int
 	Top    int
 	Width  int
+
+	// Breakpoints holds the alternative positions to use when the named grid
+	// breakpoint is active, keyed by breakpoint name
+	Breakpoints map[string]*PositionSettings
 }
 
-// NewPositionSettingsFromYAML creates and returns a new instance of cfg.Position
-func NewPositionSettingsFromYAML(moduleName string, moduleConfig *config.Config) PositionSettings {
+// NewPositionSettingsFromYAML creates and returns a new instance of cfg.Position. A module
+// that does not define a numeric position is placed by the grid template area matching
+// its `area` setting, which defaults to the module's name
+func NewPositionSettingsFromYAML(moduleName string, moduleConfig *config.Config, globalConfig *config.Config) PositionSettings {
 	var currVal int
 	var err error
 
 	validations := NewValidations()
 
+	template, err := GridTemplateFromConfig(globalConfig, moduleConfig.UString("page", ""))
+	if err != nil {
+		validations.append("template", newPositionValidation("template", 0, err))
+	}
+
+	_, posErr := moduleConfig.Int(positionPath + ".top")
+	hasPosition := posErr == nil
+
+	if area, ok := templateAreaFor(template, moduleConfig.UString("area", moduleName)); ok && !hasPosition {
+		validations.append("top", newPositionValidation("top", area.Top, nil))
+		validations.append("left", newPositionValidation("left", area.Left, nil))
+		validations.append("width", newPositionValidation("width", area.Width, nil))
+		validations.append("height", newPositionValidation("height", area.Height, nil))
+
+		return newPositionSettings(moduleConfig, validations)
+	}
+
 	// Parse the positional data from the config data
 	currVal, err = moduleConfig.Int(positionPath + ".top")
 	validations.append("top", newPositionValidation("top", currVal, err))
@@ -38,6 +61,12 @@ func NewPositionSettingsFromYAML(moduleName string, moduleConfig *config.Config)
 	currVal, err = moduleConfig.Int(positionPath + ".height")
 	validations.append("height", newPositionValidation("height", currVal, err))
 
+	return newPositionSettings(moduleConfig, validations)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func newPositionSettings(moduleConfig *config.Config, validations *Validations) PositionSettings {
 	pos := PositionSettings{
 		Validations: validations,
 
@@ -45,7 +74,30 @@ func NewPositionSettingsFromYAML(moduleName string, moduleConfig *config.Config)
 		Left:   validations.valueFor("left"),
 		Width:  validations.valueFor("width"),
 		Height: validations.valueFor("height"),
+
+		Breakpoints: map[string]*PositionSettings{},
+	}
+
+	breakpoints := moduleConfig.UMap(positionPath + ".breakpoints")
+	for name := range breakpoints {
+		path := positionPath + ".breakpoints." + name
+
+		pos.Breakpoints[name] = &PositionSettings{
+			Top:    moduleConfig.UInt(path+".top", pos.Top),
+			Left:   moduleConfig.UInt(path+".left", pos.Left),
+			Width:  moduleConfig.UInt(path+".width", pos.Width),
+			Height: moduleConfig.UInt(path+".height", pos.Height),
+		}
 	}
 
 	return pos
 }
+
+func templateAreaFor(template *GridTemplate, name string) (GridArea, bool) {
+	if template == nil {
+		return GridArea{}, false
+	}
+
+	area, ok := template.Areas[name]
+	return area, ok
+}
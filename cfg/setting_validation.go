@@ -0,0 +1,41 @@
+package cfg
+
+import (
+	"fmt"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// settingValidation is the validation of a single, non-numeric configuration setting
+type settingValidation struct {
+	err   error
+	name  string
+	value string
+}
+
+func (setVal *settingValidation) Error() error {
+	return setVal.err
+}
+
+func (setVal *settingValidation) HasError() bool {
+	return setVal.err != nil
+}
+
+func (setVal *settingValidation) IntValue() int {
+	return 0
+}
+
+// String returns the Stringer representation of the settingValidation
+func (setVal *settingValidation) String() string {
+	return fmt.Sprintf("Invalid value for %s:\t%s", aurora.Yellow(setVal.name), setVal.value)
+}
+
+func newSettingValidation(name, value string, err error) *settingValidation {
+	setVal := &settingValidation{
+		err:   err,
+		name:  name,
+		value: value,
+	}
+
+	return setVal
+}
@@ -0,0 +1,212 @@
+package cfg
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+// DefaultThemeName is the name of the theme used when no theme is configured
+const DefaultThemeName = "default"
+
+// Theme is a named palette of colors that is applied consistently to every widget's
+// borders, titles, text, and rows, and to the semantic colors modules use to show
+// whether something is ok, needs attention, or is critical
+type Theme struct {
+	Background      string
+	BorderEditing   string
+	BorderFocusable string
+	BorderFocused   string
+	BorderNormal    string
+	Checked         string
+	Crit            string
+	Foreground      string
+	HighlightBack   string
+	HighlightFore   string
+	OK              string
+	RowsEven        string
+	RowsOdd         string
+	Text            string
+	Title           string
+	Warn            string
+}
+
+var themes = map[string]Theme{
+	DefaultThemeName: {
+		Background:      "black",
+		BorderEditing:   "yellow",
+		BorderFocusable: "red",
+		BorderFocused:   "orange",
+		BorderNormal:    "gray",
+		Checked:         "white",
+		Crit:            "red",
+		Foreground:      "white",
+		HighlightBack:   "green",
+		HighlightFore:   "black",
+		OK:              "green",
+		RowsEven:        "white",
+		RowsOdd:         "lightblue",
+		Text:            "white",
+		Title:           "white",
+		Warn:            "yellow",
+	},
+	"dracula": {
+		Background:      "#282a36",
+		BorderEditing:   "#f1fa8c",
+		BorderFocusable: "#6272a4",
+		BorderFocused:   "#ff79c6",
+		BorderNormal:    "#44475a",
+		Checked:         "#50fa7b",
+		Crit:            "#ff5555",
+		Foreground:      "#f8f8f2",
+		HighlightBack:   "#bd93f9",
+		HighlightFore:   "#282a36",
+		OK:              "#50fa7b",
+		RowsEven:        "#f8f8f2",
+		RowsOdd:         "#8be9fd",
+		Text:            "#f8f8f2",
+		Title:           "#bd93f9",
+		Warn:            "#f1fa8c",
+	},
+	"gruvbox": {
+		Background:      "#282828",
+		BorderEditing:   "#fabd2f",
+		BorderFocusable: "#458588",
+		BorderFocused:   "#fe8019",
+		BorderNormal:    "#504945",
+		Checked:         "#b8bb26",
+		Crit:            "#fb4934",
+		Foreground:      "#ebdbb2",
+		HighlightBack:   "#83a598",
+		HighlightFore:   "#282828",
+		OK:              "#b8bb26",
+		RowsEven:        "#ebdbb2",
+		RowsOdd:         "#a89984",
+		Text:            "#ebdbb2",
+		Title:           "#fabd2f",
+		Warn:            "#fabd2f",
+	},
+	"nord": {
+		Background:      "#2e3440",
+		BorderEditing:   "#ebcb8b",
+		BorderFocusable: "#5e81ac",
+		BorderFocused:   "#88c0d0",
+		BorderNormal:    "#4c566a",
+		Checked:         "#a3be8c",
+		Crit:            "#bf616a",
+		Foreground:      "#d8dee9",
+		HighlightBack:   "#88c0d0",
+		HighlightFore:   "#2e3440",
+		OK:              "#a3be8c",
+		RowsEven:        "#e5e9f0",
+		RowsOdd:         "#81a1c1",
+		Text:            "#e5e9f0",
+		Title:           "#88c0d0",
+		Warn:            "#ebcb8b",
+	},
+	"solarized": {
+		Background:      "#002b36",
+		BorderEditing:   "#b58900",
+		BorderFocusable: "#268bd2",
+		BorderFocused:   "#cb4b16",
+		BorderNormal:    "#073642",
+		Checked:         "#859900",
+		Crit:            "#dc322f",
+		Foreground:      "#839496",
+		HighlightBack:   "#268bd2",
+		HighlightFore:   "#002b36",
+		OK:              "#859900",
+		RowsEven:        "#93a1a1",
+		RowsOdd:         "#2aa198",
+		Text:            "#93a1a1",
+		Title:           "#b58900",
+		Warn:            "#b58900",
+	},
+}
+
+// NewThemeFromConfig returns the theme selected by the `wtf.theme` setting, with any
+// colors defined under `wtf.colors` applied on top of it. The theme may be one of the
+// built-in themes or the path to a theme file. Theme files use the same keys as
+// `wtf.colors`, and are also looked for in the `themes` directory of the config dir
+func NewThemeFromConfig(globalConfig *config.Config) Theme {
+	theme := themeNamed(globalConfig.UString("wtf.theme", DefaultThemeName))
+
+	colorsConfig, err := globalConfig.Get("wtf.colors")
+	if err != nil {
+		return theme
+	}
+
+	return themeFromColorsConfig(theme, colorsConfig)
+}
+
+// NewModuleThemeFromConfig resolves the colors for a single module. Each level of the
+// cascade overrides the one before it:
+//
+//  1. the theme selected by `wtf.theme`
+//  2. the global `wtf.colors` block
+//  3. the module's own `colors` block
+func NewModuleThemeFromConfig(moduleConfig *config.Config, globalConfig *config.Config) Theme {
+	theme := NewThemeFromConfig(globalConfig)
+
+	colorsConfig, err := moduleConfig.Get("colors")
+	if err != nil {
+		return theme
+	}
+
+	return themeFromColorsConfig(theme, colorsConfig)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// themeFromColorsConfig returns a copy of the base theme with the colors defined in
+// the given config replacing the theme's own
+func themeFromColorsConfig(base Theme, colorsConfig *config.Config) Theme {
+	return Theme{
+		Background:      colorsConfig.UString("background", base.Background),
+		BorderEditing:   colorsConfig.UString("border.editing", base.BorderEditing),
+		BorderFocusable: colorsConfig.UString("border.focusable", base.BorderFocusable),
+		BorderFocused:   colorsConfig.UString("border.focused", base.BorderFocused),
+		BorderNormal:    colorsConfig.UString("border.normal", base.BorderNormal),
+		Checked:         colorsConfig.UString("checked", base.Checked),
+		Crit:            colorsConfig.UString("crit", base.Crit),
+		Foreground:      colorsConfig.UString("foreground", base.Foreground),
+		HighlightBack:   colorsConfig.UString("highlight.back", base.HighlightBack),
+		HighlightFore:   colorsConfig.UString("highlight.fore", base.HighlightFore),
+		OK:              colorsConfig.UString("ok", base.OK),
+		RowsEven:        colorsConfig.UString("rows.even", base.RowsEven),
+		RowsOdd:         colorsConfig.UString("rows.odd", base.RowsOdd),
+		Text:            colorsConfig.UString("text", base.Text),
+		Title:           colorsConfig.UString("title", base.Title),
+		Warn:            colorsConfig.UString("warn", base.Warn),
+	}
+}
+
+// themeNamed returns the built-in theme with the given name or, failing that, the theme
+// loaded from the named theme file. Falls back to the default theme
+func themeNamed(name string) Theme {
+	if theme, ok := themes[strings.ToLower(name)]; ok {
+		return theme
+	}
+
+	paths := []string{name}
+	if configDir, err := WtfConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(configDir, "themes", name+".yml"))
+	}
+
+	for _, path := range paths {
+		absPath, err := expandHomeDir(path)
+		if err != nil {
+			continue
+		}
+
+		themeConfig, err := config.ParseYamlFile(absPath)
+		if err != nil {
+			continue
+		}
+
+		return themeFromColorsConfig(themes[DefaultThemeName], themeConfig)
+	}
+
+	return themes[DefaultThemeName]
+}
@@ -0,0 +1,68 @@
+package cfg_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+)
+
+func TestAlertCondition(t *testing.T) {
+	values := map[string]interface{}{
+		"lag":    12000,
+		"state":  "failed",
+		"temp_c": "36.5",
+		"muted":  false,
+	}
+	lookup := func(field string) (interface{}, bool) {
+		value, ok := values[field]
+		return value, ok
+	}
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{"lag > 10000", true},
+		{"lag<=10000", false},
+		{"temp_c > 35", true},
+		{`state == "failed" and not muted`, true},
+		{"state != 'failed' or (lag >= 12000 && !muted)", true},
+		{"missing > 0", false},
+		{"muted", false},
+	}
+
+	for _, test := range tests {
+		condition, err := ParseAlertCondition(test.expression)
+		Nil(t, err, test.expression)
+		Equal(t, test.expected, condition.Holds(lookup), test.expression)
+	}
+
+	for _, expression := range []string{"", "lag >", "lag => 5", "(lag > 5", `state == "failed`} {
+		_, err := ParseAlertCondition(expression)
+		NotNil(t, err, expression)
+	}
+}
+
+func TestNewAlertRulesFromYAML(t *testing.T) {
+	moduleConfig, _ := config.ParseYaml(`
+alerts:
+  - when: lag > 10000
+    notify: Consumers are falling behind
+  - name: hot
+    when: temp_c > 35
+    color: orange
+  - when: lag >
+`)
+
+	rules, validations := NewAlertRulesFromYAML(moduleConfig, "red")
+
+	Equal(t, 2, len(rules))
+	Equal(t, "lag > 10000", rules[0].Name)
+	Equal(t, "red", rules[0].Color)
+	Equal(t, "Consumers are falling behind", rules[0].Notify)
+	Equal(t, "hot", rules[1].Name)
+	Equal(t, "orange", rules[1].Color)
+	Equal(t, 1, len(validations))
+}
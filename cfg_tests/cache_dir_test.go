@@ -0,0 +1,54 @@
+package cfg_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+)
+
+func TestCacheDirFor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-cache")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	os.Setenv("XDG_CACHE_HOME", dir)
+	defer os.Unsetenv("XDG_CACHE_HOME")
+
+	moduleCacheDir, err := CacheDirFor("spotifyweb")
+	Nil(t, err)
+	Equal(t, filepath.Join(dir, "wtf", "modules", "spotifyweb"), moduleCacheDir)
+
+	info, err := os.Stat(moduleCacheDir)
+	Nil(t, err)
+	True(t, info.IsDir())
+}
+
+func TestPruneCacheDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-cache")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	for idx, name := range []string{"oldest", "older", "newest"} {
+		path := filepath.Join(dir, name)
+		Nil(t, ioutil.WriteFile(path, make([]byte, 100), 0600))
+
+		changed := now.Add(time.Duration(idx-3) * time.Hour)
+		Nil(t, os.Chtimes(path, changed, changed))
+	}
+
+	Nil(t, PruneCacheDir(dir, 250))
+
+	files, _ := ioutil.ReadDir(dir)
+	names := []string{}
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+
+	Equal(t, []string{"newest", "older"}, names)
+}
@@ -0,0 +1,217 @@
+package cfg_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+)
+
+const writerConfig = `# My dashboard
+wtf:
+  colors:
+    border:
+      focused: orange # the one I'm looking at
+  mods:
+    # Work things
+    github:
+      enabled: true
+      position: { top: 0, left: 0, height: 2, width: 1 } # tall
+      repositories:
+        - wtfutil/wtf
+
+    todo:
+      enabled: true
+      position:
+        top: 1
+        left: 1
+        height: 1
+        width: 1
+`
+
+func TestConfigWriterSet(t *testing.T) {
+	writer := NewConfigWriterFromText("config.yml", writerConfig)
+
+	Nil(t, writer.Set("wtf.mods.github.enabled", false))
+	Nil(t, writer.Set("wtf.mods.github.position.top", 3))
+	Nil(t, writer.Set("wtf.mods.todo.position.left", 2))
+	Nil(t, writer.Set("wtf.mods.todo.title", "Things to do"))
+	Nil(t, writer.Set("wtf.colors.border.focused", "red"))
+	Nil(t, writer.Set("wtf.refreshInterval", 5))
+
+	Equal(t, `# My dashboard
+wtf:
+  colors:
+    border:
+      focused: red # the one I'm looking at
+  mods:
+    # Work things
+    github:
+      enabled: false
+      position: { top: 3, left: 0, height: 2, width: 1 } # tall
+      repositories:
+        - wtfutil/wtf
+
+    todo:
+      enabled: true
+      position:
+        top: 1
+        left: 2
+        height: 1
+        width: 1
+      title: Things to do
+  refreshInterval: 5
+`, writer.String())
+
+	NotNil(t, writer.Set("wtf.mods.todo.enabled.deeper", 1))
+}
+
+func TestConfigWriterBlockScalars(t *testing.T) {
+	writer := NewConfigWriterFromText("config.yml", `wtf:
+  mods:
+    textfile:
+      text: |
+        enabled: true
+        title: Not a key
+
+      title: Notes
+      footer: >-
+        Folded
+        text
+`)
+
+	Nil(t, writer.Set("wtf.mods.textfile.enabled", false))
+	Nil(t, writer.Set("wtf.mods.textfile.footer", "Short"))
+	Nil(t, writer.Set("wtf.mods.textfile.text", "first\nsecond"))
+
+	Equal(t, `wtf:
+  mods:
+    textfile:
+      text: |-
+        first
+        second
+
+      title: Notes
+      footer: Short
+      enabled: false
+`, writer.String())
+
+	NotNil(t, writer.Set("wtf.mods.textfile.text.deeper", 1))
+}
+
+func TestConfigWriterAnchors(t *testing.T) {
+	writer := NewConfigWriterFromText("config.yml", `defaults: &defaults
+  enabled: &on true
+  refreshInterval: 30
+wtf:
+  mods:
+    todo: &todo
+      <<: *defaults
+      position: &pos { top: 0, left: 0, height: 1, width: 1 }
+    clocks:
+      enabled: *on
+      position: *pos
+`)
+
+	Nil(t, writer.Set("defaults.enabled", false))
+	Nil(t, writer.Set("wtf.mods.todo.refreshInterval", 60))
+	Nil(t, writer.Set("wtf.mods.todo.position.top", 2))
+	Nil(t, writer.Set("wtf.mods.clocks.enabled", true))
+
+	Equal(t, `defaults: &defaults
+  enabled: &on false
+  refreshInterval: 30
+wtf:
+  mods:
+    todo: &todo
+      <<: *defaults
+      position: &pos { top: 2, left: 0, height: 1, width: 1 }
+      refreshInterval: 60
+    clocks:
+      enabled: true
+      position: *pos
+`, writer.String())
+
+	// A key beneath an alias is set on a merge of the aliased mapping, leaving the
+	// anchored mapping and its other aliases as they were
+	Nil(t, writer.Set("wtf.mods.clocks.position.top", 1))
+
+	Equal(t, `defaults: &defaults
+  enabled: &on false
+  refreshInterval: 30
+wtf:
+  mods:
+    todo: &todo
+      <<: *defaults
+      position: &pos { top: 2, left: 0, height: 1, width: 1 }
+      refreshInterval: 60
+    clocks:
+      enabled: true
+      position:
+        <<: *pos
+        top: 1
+`, writer.String())
+}
+
+func TestConfigWriterSequences(t *testing.T) {
+	writer := NewConfigWriterFromText("config.yml", `wtf:
+  pages:
+    - name: Work # the default
+      grid:
+        template: "a b"
+    - name: Home
+  mods:
+    todo:
+`)
+
+	Nil(t, writer.Set("wtf.pages.0.name", "Office"))
+	Nil(t, writer.Set("wtf.pages.0.grid.template", "a a"))
+	Nil(t, writer.Set("wtf.pages.1.hidden", true))
+	Nil(t, writer.Set("wtf.mods.todo.position.top", 1))
+
+	Equal(t, `wtf:
+  pages:
+    - name: Office # the default
+      grid:
+        template: a a
+    - name: Home
+      hidden: true
+  mods:
+    todo:
+      position:
+        top: 1
+`, writer.String())
+
+	NotNil(t, writer.Set("wtf.pages.2.name", "Play"))
+	NotNil(t, writer.Set("wtf.pages.first.name", "Play"))
+}
+
+func TestConfigWriterFlowMappings(t *testing.T) {
+	writer := NewConfigWriterFromText("config.yml", `wtf:
+  mods:
+    todo:
+      position: {
+        top: 0, # first row
+        left: 'one''s',
+        breakpoints: { narrow: { top: 1 } }
+      }
+      enabled: true
+`)
+
+	Nil(t, writer.Set("wtf.mods.todo.position.left", 1))
+	Nil(t, writer.Set("wtf.mods.todo.position.breakpoints.narrow.top", 4))
+	Nil(t, writer.Set("wtf.mods.todo.position.breakpoints.wide.top", 0))
+	Nil(t, writer.Set("wtf.mods.todo.position.width", 2))
+	Nil(t, writer.Set("wtf.mods.todo.enabled", false))
+
+	Equal(t, `wtf:
+  mods:
+    todo:
+      position: {
+        top: 0, # first row
+        left: 1,
+        breakpoints: { narrow: { top: 4 }, wide: {top: 0} }, width: 2
+      }
+      enabled: false
+`, writer.String())
+}
@@ -0,0 +1,41 @@
+package cfg_tests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	at := func(day, hour, min int) time.Time {
+		// June 1, 2019 was a Saturday
+		return time.Date(2019, 6, day, hour, min, 0, 0, time.UTC)
+	}
+
+	marketHours, err := NewCronSchedule("*/5 9-18 * * 1-5")
+	Nil(t, err)
+
+	Equal(t, at(3, 9, 0), marketHours.Next(at(1, 12, 0)))
+	Equal(t, at(3, 9, 5), marketHours.Next(at(3, 9, 0)))
+	Equal(t, at(3, 10, 0), marketHours.Next(at(3, 9, 57)))
+	Equal(t, at(4, 9, 0), marketHours.Next(at(3, 18, 55)))
+
+	named, err := NewCronSchedule("30 8 * jun sat,sun")
+	Nil(t, err)
+
+	Equal(t, at(2, 8, 30), named.Next(at(1, 8, 30)))
+
+	never, err := NewCronSchedule("0 0 31 2 *")
+	Nil(t, err)
+
+	Equal(t, true, never.Next(at(1, 0, 0)).IsZero())
+}
+
+func TestNewCronScheduleErrors(t *testing.T) {
+	for _, expression := range []string{"* * * *", "60 * * * *", "* 5-2 * * *", "*/0 * * * *", "* * * foo *"} {
+		_, err := NewCronSchedule(expression)
+		NotNil(t, err, expression)
+	}
+}
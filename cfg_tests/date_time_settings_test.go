@@ -0,0 +1,45 @@
+package cfg_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+)
+
+func TestNewDateTimeSettingsFromYAML(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n  dateFormat: \"2 Jan\"\n  weekStart: monday\n")
+	moduleConfig, _ := config.ParseYaml("timeFormat: \"3:04 PM\"\n")
+
+	settings, validations := NewDateTimeSettingsFromYAML(moduleConfig, globalConfig)
+
+	Equal(t, 0, len(validations))
+	Equal(t, "2 Jan", settings.DateLayout("Jan 2"))
+	Equal(t, "3:04 PM", settings.TimeLayout("15:04"))
+	Equal(t, time.Monday, settings.WeekStart)
+
+	badConfig, _ := config.ParseYaml("weekStart: someday\n")
+	settings, validations = NewDateTimeSettingsFromYAML(badConfig, globalConfig)
+
+	Equal(t, 1, len(validations))
+	Equal(t, time.Sunday, settings.WeekStart)
+	Equal(t, "15:04", settings.TimeLayout("15:04"))
+}
+
+func TestStartOfWeek(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2019, time.August, 7, 15, 30, 0, 0, time.UTC)
+
+	sunday := DateTimeSettings{WeekStart: time.Sunday}
+	Equal(t, time.Date(2019, time.August, 4, 0, 0, 0, 0, time.UTC), sunday.StartOfWeek(now))
+
+	monday := DateTimeSettings{WeekStart: time.Monday}
+	Equal(t, time.Date(2019, time.August, 5, 0, 0, 0, 0, time.UTC), monday.StartOfWeek(now))
+	Equal(t, time.Monday, monday.Weekdays()[0])
+	Equal(t, time.Sunday, monday.Weekdays()[6])
+
+	thursday := DateTimeSettings{WeekStart: time.Thursday}
+	Equal(t, time.Date(2019, time.August, 1, 0, 0, 0, 0, time.UTC), thursday.StartOfWeek(now))
+}
@@ -0,0 +1,39 @@
+package cfg_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+)
+
+func TestNewGridTemplate(t *testing.T) {
+	tmpl, err := NewGridTemplate("clock weather | github github | . todo")
+
+	Nil(t, err)
+	Equal(t, 2, tmpl.Columns)
+	Equal(t, 3, tmpl.Rows)
+	Equal(t, GridArea{Top: 0, Left: 0, Width: 1, Height: 1}, tmpl.Areas["clock"])
+	Equal(t, GridArea{Top: 0, Left: 1, Width: 1, Height: 1}, tmpl.Areas["weather"])
+	Equal(t, GridArea{Top: 1, Left: 0, Width: 2, Height: 1}, tmpl.Areas["github"])
+	Equal(t, GridArea{Top: 2, Left: 1, Width: 1, Height: 1}, tmpl.Areas["todo"])
+
+	tmpl, err = NewGridTemplate("clock github | weather github")
+
+	Nil(t, err)
+	Equal(t, GridArea{Top: 0, Left: 1, Width: 1, Height: 2}, tmpl.Areas["github"])
+}
+
+func TestNewGridTemplateErrors(t *testing.T) {
+	_, err := NewGridTemplate("")
+	NotNil(t, err)
+
+	_, err = NewGridTemplate("clock weather | github")
+	NotNil(t, err)
+
+	_, err = NewGridTemplate("clock github | github .")
+	NotNil(t, err)
+
+	_, err = NewGridTemplate("github clock github")
+	NotNil(t, err)
+}
@@ -0,0 +1,50 @@
+package cfg_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/cfg"
+)
+
+var themeConfig = `
+wtf:
+  theme: nord
+  colors:
+    title: yellow
+  mods:
+    clock:
+      colors:
+        border:
+          focused: pink
+        crit: orange
+`
+
+func TestNewThemeFromConfig(t *testing.T) {
+	globalConfig, _ := config.ParseYaml(themeConfig)
+	theme := NewThemeFromConfig(globalConfig)
+
+	Equal(t, "#2e3440", theme.Background)
+	Equal(t, "#88c0d0", theme.BorderFocused)
+	Equal(t, "yellow", theme.Title)
+}
+
+func TestNewModuleThemeFromConfig(t *testing.T) {
+	globalConfig, _ := config.ParseYaml(themeConfig)
+	moduleConfig, _ := globalConfig.Get("wtf.mods.clock")
+	theme := NewModuleThemeFromConfig(moduleConfig, globalConfig)
+
+	Equal(t, "#2e3440", theme.Background)
+	Equal(t, "pink", theme.BorderFocused)
+	Equal(t, "orange", theme.Crit)
+	Equal(t, "yellow", theme.Title)
+}
+
+func TestNewThemeFromConfigUnknownTheme(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n  theme: nonexistent\n")
+	theme := NewThemeFromConfig(globalConfig)
+
+	Equal(t, "black", theme.Background)
+	Equal(t, "orange", theme.BorderFocused)
+}
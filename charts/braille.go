@@ -0,0 +1,59 @@
+package charts
+
+// brailleBase is the braille character with none of its dots raised
+const brailleBase = 0x2800
+
+// brailleDots are the bits of a braille character's dots, by column and then by row
+// from the top
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// BrailleLine returns a line chart of the values, as height lines of width characters.
+// Each braille character has two columns of four dots, so the chart shows the last
+// width*2 values at four times the resolution of its height, scaled between the
+// smallest and largest of them
+func BrailleLine(values []float64, width, height int) []string {
+	lines := []string{}
+	if width <= 0 || height <= 0 {
+		return lines
+	}
+
+	grid := make([][]rune, height)
+	for row := range grid {
+		grid[row] = make([]rune, width)
+		for col := range grid[row] {
+			grid[row][col] = brailleBase
+		}
+	}
+
+	dotRows := height * 4
+	prev := -1
+
+	for x, level := range scale(lastN(values, width*2), dotRows) {
+		// Fill in the dots between this point and the one before it so that the line
+		// has no gaps where it rises or falls steeply
+		from, to := level, level
+		if prev >= 0 {
+			if prev < from {
+				from = prev + 1
+			} else if prev > to {
+				to = prev - 1
+			}
+		}
+
+		for y := from; y <= to; y++ {
+			dotRow := dotRows - 1 - y
+			grid[dotRow/4][x/2] |= brailleDots[x%2][dotRow%4]
+		}
+
+		prev = level
+	}
+
+	for _, row := range grid {
+		lines = append(lines, string(row))
+	}
+
+	return lines
+}
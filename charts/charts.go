@@ -0,0 +1,108 @@
+// Package charts draws small charts out of text, for widgets to show trends and
+// proportions instead of plain numbers
+package charts
+
+import (
+	"math"
+	"strings"
+)
+
+// barEighths are the characters that fill the last cell of a bar, in eighths
+var barEighths = []rune(" ▏▎▍▌▋▊▉")
+
+// sparkTicks are the characters a sparkline is drawn with, from lowest to highest
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Bar returns a horizontal bar width characters wide, filled to the given percentage to
+// the nearest eighth of a character and padded with spaces
+func Bar(percent float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	percent = math.Max(0, math.Min(100, percent))
+	eighths := int(math.Round(percent / 100 * float64(width*8)))
+
+	str := strings.Repeat("█", eighths/8)
+	cells := eighths / 8
+
+	if eighths%8 > 0 {
+		str += string(barEighths[eighths%8])
+		cells++
+	}
+
+	return str + strings.Repeat(" ", width-cells)
+}
+
+// Sparkline returns a one-line chart of the values, one character per value, scaled
+// between the smallest and largest of them. Only the last width values are drawn
+func Sparkline(values []float64, width int) string {
+	values = lastN(values, width)
+
+	ticks := make([]rune, len(values))
+	for idx, level := range scale(values, len(sparkTicks)) {
+		ticks[idx] = sparkTicks[level]
+	}
+
+	return string(ticks)
+}
+
+// Trend returns an arrow showing whether the last value is up, down, or level compared
+// with the first, or an empty string if there are fewer than two values
+func Trend(values []float64) string {
+	if len(values) < 2 {
+		return ""
+	}
+
+	first, last := values[0], values[len(values)-1]
+
+	switch {
+	case last > first:
+		return "↑"
+	case last < first:
+		return "↓"
+	default:
+		return "→"
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// lastN returns the last n values, or all of them if there are fewer than n
+func lastN(values []float64, n int) []float64 {
+	if n < 0 {
+		n = 0
+	}
+
+	if len(values) > n {
+		return values[len(values)-n:]
+	}
+
+	return values
+}
+
+// scale maps each value onto one of the given number of levels, with the smallest
+// value on level 0 and the largest on the top level. When all the values are the same
+// they are all put on level 0
+func scale(values []float64, levels int) []int {
+	scaled := make([]int, len(values))
+	if len(values) == 0 || levels < 1 {
+		return scaled
+	}
+
+	min, max := values[0], values[0]
+	for _, val := range values {
+		min = math.Min(min, val)
+		max = math.Max(max, val)
+	}
+
+	if max == min {
+		return scaled
+	}
+
+	for idx, val := range values {
+		scaled[idx] = int(math.Round((val - min) / (max - min) * float64(levels-1)))
+	}
+
+	return scaled
+}
@@ -0,0 +1,41 @@
+package charts
+
+import "sync"
+
+// History holds the most recent values of a series that is fetched one value at a
+// time, such as a price, for drawing as a chart
+type History struct {
+	mu     sync.Mutex
+	size   int
+	values []float64
+}
+
+// NewHistory creates and returns a history that keeps the last size values
+func NewHistory(size int) *History {
+	return &History{
+		size:   size,
+		values: []float64{},
+	}
+}
+
+// Add appends the value, dropping the oldest value if the history is full
+func (history *History) Add(value float64) {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	history.values = append(history.values, value)
+	if len(history.values) > history.size {
+		history.values = history.values[len(history.values)-history.size:]
+	}
+}
+
+// Values returns a copy of the values, oldest first
+func (history *History) Values() []float64 {
+	history.mu.Lock()
+	defer history.mu.Unlock()
+
+	values := make([]float64, len(history.values))
+	copy(values, history.values)
+
+	return values
+}
@@ -0,0 +1,44 @@
+package charts_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/charts"
+)
+
+func TestBar(t *testing.T) {
+	Equal(t, "          ", Bar(0, 10))
+	Equal(t, "█████     ", Bar(50, 10))
+	Equal(t, "██▌ ", Bar(62.5, 4))
+	Equal(t, "████", Bar(150, 4))
+	Equal(t, "", Bar(50, 0))
+}
+
+func TestBrailleLine(t *testing.T) {
+	Equal(t, []string{"⠀⡜", "⡰⠁"}, BrailleLine([]float64{1, 3, 5, 7}, 2, 2))
+	Equal(t, []string{"⣀⣀"}, BrailleLine([]float64{5, 5, 5, 5, 5, 5}, 2, 1))
+}
+
+func TestHistory(t *testing.T) {
+	history := NewHistory(3)
+	for _, val := range []float64{1, 2, 3, 4} {
+		history.Add(val)
+	}
+
+	Equal(t, []float64{2, 3, 4}, history.Values())
+}
+
+func TestSparkline(t *testing.T) {
+	Equal(t, "▁▅█", Sparkline([]float64{0, 5, 10}, 10))
+	Equal(t, "▁█", Sparkline([]float64{0, 5, 10}, 2))
+	Equal(t, "▁▁", Sparkline([]float64{3, 3}, 10))
+	Equal(t, "", Sparkline([]float64{}, 10))
+}
+
+func TestTrend(t *testing.T) {
+	Equal(t, "↑", Trend([]float64{1, 0, 2}))
+	Equal(t, "↓", Trend([]float64{2, 3, 1}))
+	Equal(t, "→", Trend([]float64{2, 2}))
+	Equal(t, "", Trend([]float64{2}))
+}
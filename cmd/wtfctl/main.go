@@ -0,0 +1,133 @@
+// wtfctl controls a running wtf dashboard through its API server. It needs the server
+// to be turned on in wtf's config, and is pointed at it with -address or $WTF_ADDRESS:
+//
+//	wtfctl refresh github
+//	wtfctl page 2
+//	wtfctl notify "deploy done"
+//	wtfctl widgets weather
+//
+// Any command the command palette knows can be sent. "widgets" prints the JSON state of
+// every widget, or of the named one
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultAddress = "127.0.0.1:7777"
+
+func main() {
+	address := flag.String("address", envOr("WTF_ADDRESS", defaultAddress), "The address of wtf's API server, a host and port or unix:/path/to/socket")
+	token := flag.String("token", os.Getenv("WTF_TOKEN"), "The token wtf's API server requires, if any")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: wtfctl [options] command [args...]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	body, err := send(*address, *token, flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wtfctl: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(body) > 0 {
+		fmt.Println(string(body))
+	}
+}
+
+// envOr returns the value of the environment variable, or the fallback if it isn't set
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+// newClient returns an HTTP client that talks to the server at the address, and the URL
+// of the server's root
+func newClient(address string) (*http.Client, string) {
+	if !strings.HasPrefix(address, "unix:") {
+		return &http.Client{Timeout: 10 * time.Second}, "http://" + address
+	}
+
+	socket := strings.TrimPrefix(address, "unix:")
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		},
+	}
+
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}, "http://wtf"
+}
+
+// send sends the command to the server and returns the body of a response that
+// carries something to show
+func send(address, token string, args []string) ([]byte, error) {
+	client, root := newClient(address)
+
+	var req *http.Request
+	var err error
+
+	if args[0] == "widgets" {
+		req, err = http.NewRequest("GET", root+"/api/widgets/"+strings.Join(args[1:], ""), nil)
+	} else {
+		payload, _ := json.Marshal(map[string][]string{"args": args})
+		req, err = http.NewRequest("POST", root+"/api/commands", bytes.NewReader(payload))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		failure := map[string]string{}
+		if json.Unmarshal(body, &failure) == nil && failure["error"] != "" {
+			return nil, fmt.Errorf("%s", failure["error"])
+		}
+
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+
+	if args[0] != "widgets" {
+		return nil, nil
+	}
+
+	var pretty bytes.Buffer
+	if json.Indent(&pretty, body, "", "  ") != nil {
+		return body, nil
+	}
+
+	return pretty.Bytes(), nil
+}
@@ -14,9 +14,15 @@ import (
 // Flags is the container for command line flag data
 type Flags struct {
 	Config  string `short:"c" long:"config" optional:"yes" description:"Path to config file"`
+	Format  string `short:"f" long:"format" default:"text" description:"The format 'wtf snapshot' writes the dashboard in: text, ansi, or html"`
+	Height  int    `long:"height" default:"50" description:"The height, in lines, of the dashboard 'wtf snapshot' draws"`
 	Module  string `short:"m" long:"module" optional:"yes" description:"Display info about a specific module, i.e.: 'wtf -m=todo'"`
+	Output  string `short:"o" long:"output" description:"The file 'wtf snapshot' writes to, instead of standard output"`
 	Profile bool   `short:"p" long:"profile" optional:"yes" description:"Profile application memory usage"`
 	Version bool   `short:"v" long:"version" description:"Show version info"`
+	Width   int    `long:"width" default:"160" description:"The width, in columns, of the dashboard 'wtf snapshot' draws"`
+
+	args []string
 }
 
 // NewFlags creates an instance of Flags
@@ -44,6 +50,39 @@ func (flags *Flags) RenderIf(version string, config *config.Config) {
 		fmt.Println(version)
 		os.Exit(0)
 	}
+
+	if flags.HasCommand("list-modules") {
+		help.ListModules(config)
+		os.Exit(0)
+	}
+
+	if flags.HasCommand("describe-module") {
+		if len(flags.CommandArgs()) != 1 {
+			fmt.Println("Usage: wtf describe-module NAME, i.e. 'wtf describe-module github'")
+			os.Exit(1)
+		}
+
+		if err := help.DescribeModule(flags.CommandArgs()[0], config); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+}
+
+// HasCommand returns TRUE if the named command, such as 'snapshot' or 'export', was
+// passed in
+func (flags *Flags) HasCommand(name string) bool {
+	return len(flags.args) > 0 && flags.args[0] == name
+}
+
+// CommandArgs returns the arguments passed in after the command's name
+func (flags *Flags) CommandArgs() []string {
+	if len(flags.args) < 2 {
+		return []string{}
+	}
+
+	return flags.args[1:]
 }
 
 // HasCustomConfig returns TRUE if a config path was passed in, FALSE if one was not
@@ -64,11 +103,15 @@ func (flags *Flags) HasVersion() bool {
 // Parse parses the incoming flags
 func (flags *Flags) Parse() {
 	parser := goFlags.NewParser(flags, goFlags.Default)
-	if _, err := parser.Parse(); err != nil {
+	parser.Usage = "[OPTIONS] [snapshot | export | list-modules | describe-module NAME | new-module NAME]"
+
+	args, err := parser.Parse()
+	if err != nil {
 		if flagsErr, ok := err.(*goFlags.Error); ok && flagsErr.Type == goFlags.ErrHelp {
 			os.Exit(0)
 		}
 	}
+	flags.args = args
 
 	// If no config file is explicitly passed in as a param,
 	// set the flag to the default config file
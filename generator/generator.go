@@ -0,0 +1,160 @@
+// Package generator writes the skeleton of a new module into the wtf source tree, for
+// 'wtf new-module'
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// makerPath is where, in the source tree, modules are registered
+const makerPath = "maker/widget_maker.go"
+
+var (
+	moduleImportRegExp = regexp.MustCompile(`^\t_ "github.com/wtfutil/wtf/modules/([^"]+)"$`)
+	moduleNameRegExp   = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+)
+
+// NewModule writes a new module package, with its settings, widget, keyboard controls,
+// registration, and a test, into the source tree at root, and imports it in the widget
+// maker. It returns the paths of the files it wrote
+func NewModule(root, name string) ([]string, error) {
+	if !moduleNameRegExp.MatchString(name) {
+		return nil, fmt.Errorf("'%s' is not a valid module name: use lower-case letters and digits", name)
+	}
+
+	moduleDir := filepath.Join(root, "modules", name)
+	if _, err := os.Stat(moduleDir); err == nil {
+		return nil, fmt.Errorf("%s already exists", moduleDir)
+	}
+
+	makerFile := filepath.Join(root, makerPath)
+	maker, err := ioutil.ReadFile(makerFile)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not the wtf source tree: %v", root, err)
+	}
+
+	registered, err := Register(string(maker), name)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := map[string]string{
+		filepath.Join(moduleDir, "keyboard.go"):                         keyboardTemplate,
+		filepath.Join(moduleDir, "module.go"):                           moduleTemplate,
+		filepath.Join(moduleDir, "settings.go"):                         settingsTemplate,
+		filepath.Join(moduleDir, "widget.go"):                           widgetTemplate,
+		filepath.Join(root, "modules", name+"_tests", "widget_test.go"): testTemplate,
+	}
+
+	paths := []string{}
+	for path := range templates {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	written := []string{}
+
+	for _, path := range paths {
+		if err := writeTemplate(path, templates[path], name); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+
+	if err := ioutil.WriteFile(makerFile, []byte(registered), 0644); err != nil {
+		return written, err
+	}
+
+	return append(written, makerFile), nil
+}
+
+// Register adds the module's import, which registers its type, to the widget maker's
+// source, in alphabetical order, and returns the new source
+func Register(source, name string) (string, error) {
+	lines := strings.Split(source, "\n")
+
+	importAt := -1
+
+	for idx, line := range lines {
+		match := moduleImportRegExp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		if match[1] == name {
+			return "", fmt.Errorf("%s is already registered", name)
+		}
+
+		// Goes after the last module that comes before it or, if none do, before the first
+		if match[1] < name {
+			importAt = idx + 1
+		} else if importAt < 0 {
+			importAt = idx
+		}
+	}
+
+	if importAt < 0 {
+		return "", errors.New("could not find where modules are registered")
+	}
+
+	lines = insert(lines, importAt, fmt.Sprintf("\t_ \"github.com/wtfutil/wtf/modules/%s\"", name))
+
+	formatted, err := format.Source([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return "", err
+	}
+
+	return string(formatted), nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func insert(lines []string, at int, added ...string) []string {
+	result := append([]string{}, lines[:at]...)
+	result = append(result, added...)
+
+	return append(result, lines[at:]...)
+}
+
+// writeTemplate executes the template with the module's name and writes the result,
+// formatted, to the path
+func writeTemplate(path, text, name string) error {
+	tpl, err := template.New(filepath.Base(path)).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		Name  string
+		Title string
+	}{
+		Name:  name,
+		Title: strings.Title(name),
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, source, 0644)
+}
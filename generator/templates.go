@@ -0,0 +1,133 @@
+package generator
+
+// The templates for the files of a new module. Each is executed with the module's name
+// and its name in title case
+const (
+	keyboardTemplate = `package {{.Name}}
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+}
+`
+
+	moduleTemplate = `package {{.Name}}
+
+import (
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.ModuleType{
+		Make: func(app *tview.Application, pages *tview.Pages, name string, moduleConfig, globalConfig *config.Config) wtf.Wtfable {
+			return NewWidget(app, pages, NewSettingsFromYAML(name, moduleConfig, globalConfig))
+		},
+		Name:     "{{.Name}}",
+		Settings: Settings{},
+	})
+}
+`
+
+	settingsTemplate = `package {{.Name}}
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "{{.Title}}"
+
+type Settings struct {
+	common *cfg.Common
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+	}
+
+	return &settings
+}
+`
+
+	testTemplate = `package {{.Name}}_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/modules/{{.Name}}"
+)
+
+func TestNewWidget(t *testing.T) {
+	moduleConfig, _ := config.ParseYaml("enabled: true")
+	globalConfig, _ := config.ParseYaml("wtf: {}")
+
+	settings := NewSettingsFromYAML("{{.Name}}", moduleConfig, globalConfig)
+	widget := NewWidget(tview.NewApplication(), tview.NewPages(), settings)
+
+	Equal(t, "{{.Title}}", widget.CommonSettings().Title)
+	Equal(t, "{{.Name}}", widget.CommonSettings().Module.Type)
+}
+`
+
+	widgetTemplate = `package {{.Name}}
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.TextWidget
+
+	settings *Settings
+}
+
+// NewWidget creates and returns an instance of Widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
+		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+	widget.View.SetScrollable(true)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// HelpText returns the help text for the widget's keys
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the widget's data and redraws it
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	// The last call should always be to the display function
+	widget.display()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) display() {
+	widget.Redraw(widget.CommonSettings().Title, "Some text", false)
+}
+`
+)
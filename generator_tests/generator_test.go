@@ -0,0 +1,31 @@
+package generator_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/generator"
+)
+
+const makerSource = `package maker
+
+import (
+	"github.com/wtfutil/wtf/wtf"
+
+	// Each module registers its type when it is loaded
+	_ "github.com/wtfutil/wtf/modules/clocks"
+	_ "github.com/wtfutil/wtf/modules/zendesk"
+)
+`
+
+func TestRegister(t *testing.T) {
+	registered, err := Register(makerSource, "deploys")
+	Nil(t, err)
+
+	Contains(t, registered, `	_ "github.com/wtfutil/wtf/modules/clocks"
+	_ "github.com/wtfutil/wtf/modules/deploys"
+	_ "github.com/wtfutil/wtf/modules/zendesk"`)
+
+	_, err = Register(makerSource, "clocks")
+	NotNil(t, err)
+}
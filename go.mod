@@ -19,15 +19,22 @@ require (
 	github.com/go-ole/go-ole v1.2.4 // indirect
 	github.com/godbus/dbus v5.0.1+incompatible // indirect
 	github.com/golang/glog v0.0.0-20170312005925-543a34c32e4d // indirect
+	github.com/golang/protobuf v1.3.1
 	github.com/google/go-github/v26 v26.0.9
 	github.com/hashicorp/go-cleanhttp v0.5.1 // indirect
+	github.com/hashicorp/go-hclog v0.0.0-20181001195459-61d530d6c27f // indirect
+	github.com/hashicorp/go-plugin v1.0.1-0.20190610192547-a1bc61569a26
+	github.com/hashicorp/yamux v0.0.0-20181012175058-2f1d1f20f75d // indirect
 	github.com/hekmon/cunits v2.0.1+incompatible // indirect
 	github.com/hekmon/transmissionrpc v0.0.0-20190525133028-1d589625bacd
 	github.com/jessevdk/go-flags v1.4.0
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/logrusorgru/aurora v0.0.0-20190428105938-cea283e61946
 	github.com/mattn/go-isatty v0.0.8 // indirect
+	github.com/mattn/go-runewidth v0.0.4
+	github.com/mitchellh/go-testing-interface v1.0.4 // indirect
 	github.com/mmcdole/gofeed v1.0.0-beta2.0.20190420154928-0e68beaf6fdf
+	github.com/oklog/run v1.0.0 // indirect
 	github.com/olebedev/config v0.0.0-20190528211619-364964f3a8e4
 	github.com/onsi/ginkgo v1.8.0 // indirect
 	github.com/onsi/gomega v1.5.0 // indirect
@@ -40,9 +47,12 @@ require (
 	github.com/stretchr/testify v1.3.0
 	github.com/xanzy/go-gitlab v0.18.0
 	github.com/yfronto/newrelic v0.0.0-20180622232530-7c9c2852e8f9
+	github.com/yuin/gopher-lua v0.0.0-20190514113301-1cd887cd7036
 	github.com/zmb3/spotify v0.0.0-20190520155326-158b1863f5b5
 	github.com/zorkian/go-datadog-api v2.21.0+incompatible
+	go.etcd.io/bbolt v1.3.5
 	go.opencensus.io v0.22.0 // indirect
+	go.starlark.net v0.0.0-20190702223751-32f345186213
 	golang.org/x/crypto v0.0.0-20190701094942-4def268fd1a4 // indirect
 	golang.org/x/net v0.0.0-20190628185345-da137c7871d7 // indirect
 	golang.org/x/oauth2 v0.0.0-20190614102709-0f29369cfe45
@@ -52,8 +62,9 @@ require (
 	google.golang.org/api v0.7.0
 	google.golang.org/appengine v1.6.1 // indirect
 	google.golang.org/genproto v0.0.0-20190627203621-eb59cef1c072 // indirect
-	google.golang.org/grpc v1.21.1 // indirect
+	google.golang.org/grpc v1.21.1
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 	gopkg.in/jarcoal/httpmock.v1 v1.0.0-20190314184232-a8ac0a50d0b5 // indirect
 	gopkg.in/yaml.v2 v2.2.2
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/flags"
+	"github.com/wtfutil/wtf/maker"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// snapshotTimeout is how long 'wtf snapshot' and 'wtf export' wait for the modules to refresh before
+// drawing the dashboard with whatever they have by then
+const snapshotTimeout = 30 * time.Second
+
+// headless is the dashboard running on a screen that is never shown
+type headless struct {
+	app     *tview.Application
+	display *wtf.Display
+	screen  tcell.SimulationScreen
+	widgets []wtf.Wtfable
+}
+
+// runExport runs the modules without the dashboard, writing the exporter's summary of
+// their data every interval until the process is stopped
+func runExport(config *config.Config, flags *flags.Flags) error {
+	exporter := wtf.NewExporter(config)
+	if exporter == nil {
+		return errors.New("there is no wtf.exporter.template to export")
+	}
+
+	dashboard, err := startHeadless(config, flags.Width, flags.Height)
+	if err != nil {
+		return err
+	}
+	defer disableAllWidgets(dashboard.widgets)
+
+	waitForRefreshes(snapshotTimeout)
+	exporter.Start()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	<-interrupt
+
+	exporter.Stop()
+	dashboard.app.Stop()
+
+	return nil
+}
+
+// runSnapshot refreshes every module once, draws the first page of the dashboard onto a
+// screen that is never shown, and writes what was drawn to a file or to standard output
+func runSnapshot(config *config.Config, flags *flags.Flags) error {
+	dashboard, err := startHeadless(config, flags.Width, flags.Height)
+	if err != nil {
+		return err
+	}
+	defer disableAllWidgets(dashboard.widgets)
+
+	app, screen := dashboard.app, dashboard.screen
+
+	waitForRefreshes(snapshotTimeout)
+
+	// Draw once more, behind every update the modules have queued, and wait for it
+	drawn := make(chan struct{}, 1)
+	app.QueueUpdateDraw(func() {
+		app.SetAfterDrawFunc(func(tcell.Screen) {
+			select {
+			case drawn <- struct{}{}:
+			default:
+			}
+		})
+	})
+	<-drawn
+
+	cells, width, height := screen.GetContents()
+	app.Stop()
+
+	text, err := wtf.RenderSnapshot(cells, width, height, flags.Format)
+	if err != nil {
+		return err
+	}
+
+	if flags.Output == "" {
+		fmt.Print(text)
+		return nil
+	}
+
+	return ioutil.WriteFile(flags.Output, []byte(text), 0644)
+}
+
+// startHeadless makes the widgets and runs the dashboard on a screen of the given size
+// that is never shown, and has every widget refreshed straight away
+func startHeadless(config *config.Config, width, height int) (*headless, error) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		return nil, err
+	}
+	screen.SetSize(width, height)
+
+	app := tview.NewApplication()
+	app.SetScreen(screen)
+
+	pages := tview.NewPages()
+
+	widgets := maker.MakeWidgets(app, pages, config)
+
+	display := wtf.NewDisplay(widgets, config)
+	pages.AddPage("grid", display.Pages, true, true)
+	app.SetRoot(pages, true)
+
+	go func() {
+		_ = app.Run()
+	}()
+
+	display.Scheduler().Refresh(widgets)
+
+	return &headless{app: app, display: display, screen: screen, widgets: widgets}, nil
+}
+
+// waitForRefreshes waits until the refreshes the scheduler has been asked for have all
+// finished, or until the timeout runs out
+func waitForRefreshes(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		if _, total := wtf.RefreshStatuses.Progress(); total == 0 {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// runHeadless runs 'wtf snapshot' or 'wtf export' and exits
+func runHeadless(config *config.Config, flags *flags.Flags) {
+	run := runSnapshot
+	if flags.HasCommand("export") {
+		run = runExport
+	}
+
+	if err := run(config, flags); err != nil {
+		fmt.Fprintf(os.Stderr, "\n\033[0;31mERROR:\033[0m %v\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
@@ -2,10 +2,13 @@ package help
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/olebedev/config"
+	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/maker"
 	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 // Display displays the output of the --help argument
@@ -17,14 +20,89 @@ func Display(moduleName string, config *config.Config) {
 	}
 }
 
+// DescribeModule displays what a type of module can do, its keys, and its settings, for
+// 'wtf describe-module'
+func DescribeModule(moduleType string, globalConfig *config.Config) error {
+	registered, ok := wtf.LookupModule(moduleType)
+	if !ok {
+		return fmt.Errorf("there is no '%s' module. 'wtf list-modules' lists them all", moduleType)
+	}
+
+	widget := makeExample(moduleType, globalConfig)
+	defer widget.Disable()
+
+	capabilities := strings.Join(registered.Capabilities(widget).Names(), ", ")
+	if capabilities == "" {
+		capabilities = "none"
+	}
+
+	fmt.Printf("%s\n\nCapabilities: %s\n\n", registered.Name, capabilities)
+	fmt.Println(utils.StripColorTags(widget.HelpText()))
+	fmt.Printf("Configuration Attributes%s\n", configText(registered, widget))
+
+	return nil
+}
+
+// ListModules displays every type of module and what it can do, for 'wtf list-modules'
+func ListModules(globalConfig *config.Config) {
+	table := wtf.NewTable(
+		wtf.TableColumn{Title: "MODULE"},
+		wtf.TableColumn{Title: "CAPABILITIES"},
+	)
+
+	for _, registered := range wtf.ModuleTypes() {
+		widget := makeExample(registered.Name, globalConfig)
+		table.AddRow(registered.Name, strings.Join(registered.Capabilities(widget).Names(), ", "))
+		widget.Disable()
+	}
+
+	table.Separator = "  "
+
+	for _, line := range table.Lines(0) {
+		fmt.Println(strings.TrimRight(utils.StripColorTags(line), " "))
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// configText returns the help for the module's settings, from its registered settings
+// struct if it has one and otherwise from the widget
+func configText(registered wtf.ModuleType, widget wtf.Wtfable) string {
+	if registered.Settings == nil {
+		return widget.ConfigText()
+	}
+
+	return utils.HelpFromInterface(registered.Settings)
+}
+
+// makeExample makes a widget of the module type, turned on but otherwise with the
+// default settings, to find out what it can do
+func makeExample(moduleType string, globalConfig *config.Config) wtf.Wtfable {
+	moduleConfig := &config.Config{Root: map[string]interface{}{"enabled": true}}
+
+	return maker.MakeWidget(tview.NewApplication(), tview.NewPages(), moduleType, moduleType, moduleConfig, globalConfig)
+}
+
 func helpFor(moduleName string, config *config.Config) string {
 	modConfig, _ := config.Get("wtf.mods." + moduleName)
-	widget := maker.MakeWidget(nil, nil, moduleName, moduleName, modConfig, config)
+
+	widgetType := moduleName
+	if modConfig != nil {
+		widgetType = modConfig.UString("type", moduleName)
+	}
+
+	widget := maker.MakeWidget(nil, nil, moduleName, widgetType, modConfig, config)
 
 	result := ""
 	result += utils.StripColorTags(widget.HelpText())
 	result += "\n"
 	result += "Configuration Attributes"
-	result += widget.ConfigText()
+
+	if registered, ok := wtf.LookupModule(widgetType); ok {
+		result += configText(registered, widget)
+	} else {
+		result += widget.ConfigText()
+	}
+
 	return result
 }
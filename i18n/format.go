@@ -0,0 +1,187 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// nameTokens are the parts of a time layout that spell out a month or weekday, longest
+// first so that "January" isn't taken for "Jan"
+var nameTokens = []string{"January", "Monday", "Jan", "Mon"}
+
+// relativeUnits are the units relative times are given in, largest first
+var relativeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// FormatDate formats the time with a Go time layout, in the current language
+func FormatDate(t time.Time, layout string) string {
+	return Current().FormatDate(t, layout)
+}
+
+// FormatNumber formats the number with the given number of decimals, in the current
+// language
+func FormatNumber(value float64, decimals int) string {
+	return Current().FormatNumber(value, decimals)
+}
+
+// RelativeTime describes when the time is relative to now, such as "3 hours ago" or
+// "in 2 days", in the current language
+func RelativeTime(t, now time.Time) string {
+	return Current().RelativeTime(t, now)
+}
+
+// ShortRelativeTime describes when the time is relative to now compactly, such as
+// "2d ago" or "in 12m", in the current language
+func ShortRelativeTime(t, now time.Time) string {
+	return Current().ShortRelativeTime(t, now)
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// FormatDate formats the time with a Go time layout, spelling out the months and
+// weekdays in the locale's language
+func (locale *Locale) FormatDate(t time.Time, layout string) string {
+	var out strings.Builder
+
+	for layout != "" {
+		token, at := nextNameToken(layout)
+		if token == "" {
+			out.WriteString(t.Format(layout))
+			break
+		}
+
+		out.WriteString(t.Format(layout[:at]))
+		out.WriteString(locale.name(t, token))
+
+		layout = layout[at+len(token):]
+	}
+
+	return out.String()
+}
+
+// FormatNumber formats the number with the given number of decimals, grouping its
+// thousands, with the locale's separators
+func (locale *Locale) FormatNumber(value float64, decimals int) string {
+	str := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+
+	parts := strings.SplitN(str, ".", 2)
+	whole := parts[0]
+
+	groups := []string{}
+	for len(whole) > 3 {
+		groups = append([]string{whole[len(whole)-3:]}, groups...)
+		whole = whole[:len(whole)-3]
+	}
+	groups = append([]string{whole}, groups...)
+
+	str = strings.Join(groups, locale.ThousandsSeparator)
+	if len(parts) > 1 {
+		str += locale.DecimalSeparator + parts[1]
+	}
+
+	if value < 0 && strings.Trim(str, "0"+locale.DecimalSeparator+locale.ThousandsSeparator) != "" {
+		str = "-" + str
+	}
+
+	return str
+}
+
+// RelativeTime describes when the time is relative to now, in the largest unit that
+// fits, such as "3 hours ago" or "in 2 days"
+func (locale *Locale) RelativeTime(t, now time.Time) string {
+	diff := now.Sub(t)
+	template := locale.Ago
+	if diff < 0 {
+		diff = -diff
+		template = locale.In
+	}
+
+	if diff < 45*time.Second {
+		return locale.JustNow
+	}
+
+	for _, unit := range relativeUnits {
+		if diff < unit.size {
+			continue
+		}
+
+		count := int(diff / unit.size)
+		forms := locale.Units[unit.name]
+
+		word := forms[1]
+		if count == 1 {
+			word = forms[0]
+		}
+
+		return fmt.Sprintf(template, fmt.Sprintf("%d %s", count, word))
+	}
+
+	return locale.JustNow
+}
+
+// ShortRelativeTime describes when the time is relative to now compactly, in the
+// largest unit that fits, such as "2d ago" or "in 12m"
+func (locale *Locale) ShortRelativeTime(t, now time.Time) string {
+	diff := now.Sub(t)
+	template := locale.Ago
+	if diff < 0 {
+		diff = -diff
+		template = locale.In
+	}
+
+	if diff < time.Minute {
+		return locale.ShortNow
+	}
+
+	for _, unit := range relativeUnits {
+		if diff >= unit.size {
+			return fmt.Sprintf(template, fmt.Sprintf("%d%s", int(diff/unit.size), locale.ShortUnits[unit.name]))
+		}
+	}
+
+	return locale.ShortNow
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// name spells out the month or weekday the token stands for
+func (locale *Locale) name(t time.Time, token string) string {
+	switch token {
+	case "January":
+		return locale.Months[t.Month()-1]
+	case "Jan":
+		return locale.ShortMonths[t.Month()-1]
+	case "Monday":
+		return locale.Weekdays[t.Weekday()]
+	default:
+		return locale.ShortWeekdays[t.Weekday()]
+	}
+}
+
+// nextNameToken returns the first month or weekday token in the layout and where it is
+func nextNameToken(layout string) (string, int) {
+	first := ""
+	at := -1
+
+	for _, token := range nameTokens {
+		idx := strings.Index(layout, token)
+		if idx >= 0 && (at < 0 || idx < at) {
+			first = token
+			at = idx
+		}
+	}
+
+	return first, at
+}
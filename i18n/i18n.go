@@ -0,0 +1,172 @@
+// Package i18n renders dates, relative times, numbers, and wtf's own UI strings in the
+// user's language:
+//
+//	wtf:
+//	  language: de
+//	  translations:
+//	    "Press r to retry": "Zum Wiederholen r drücken"
+//
+// Without a language, the one in the LANG environment variable is used if a locale is
+// bundled for it, and English otherwise. The translations replace or add to the
+// bundled locale's strings
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/olebedev/config"
+)
+
+// DefaultLanguage is the language used when no locale is bundled for the one asked for
+const DefaultLanguage = "en"
+
+// Locale is how one language writes dates, relative times, and numbers, along with its
+// translations of the UI strings
+type Locale struct {
+	Code string
+	Name string
+
+	Months        [12]string
+	ShortMonths   [12]string
+	Weekdays      [7]string
+	ShortWeekdays [7]string
+
+	DecimalSeparator   string
+	ThousandsSeparator string
+
+	// Ago and In wrap a relative time, such as "%s ago" and "in %s"
+	Ago     string
+	In      string
+	JustNow string
+	// Units are the singular and plural of each unit of relative time: second, minute,
+	// hour, day, month, and year
+	Units map[string][2]string
+	// ShortNow and ShortUnits are used for compact relative times, such as "2d ago"
+	ShortNow   string
+	ShortUnits map[string]string
+
+	// Strings translate the UI strings, which are keyed by their English text
+	Strings map[string]string
+}
+
+var (
+	current = english
+	locales = map[string]*Locale{}
+
+	mu sync.Mutex
+)
+
+// Configure sets the language and the translations from the config
+func Configure(globalConfig *config.Config) {
+	language := globalConfig.UString("wtf.language", "")
+	if language == "" {
+		language = os.Getenv("LANG")
+	}
+
+	locale := Lookup(language)
+
+	overrides := globalConfig.UMap("wtf.translations", map[string]interface{}{})
+	if len(overrides) > 0 {
+		locale = locale.withStrings(overrides)
+	}
+
+	mu.Lock()
+	current = locale
+	mu.Unlock()
+}
+
+// Current returns the locale in use
+func Current() *Locale {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return current
+}
+
+// Languages returns the codes of the bundled locales
+func Languages() []string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	codes := []string{}
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+// Lookup returns the locale for the language, such as "de", "pt-BR", or "fr_FR.UTF-8".
+// A region the locale isn't bundled for falls back to its language, and a language it
+// isn't bundled for to English
+func Lookup(language string) *Locale {
+	code := strings.ToLower(strings.SplitN(language, ".", 2)[0])
+	code = strings.Replace(code, "_", "-", -1)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if locale, ok := locales[code]; ok {
+		return locale
+	}
+
+	if locale, ok := locales[strings.SplitN(code, "-", 2)[0]]; ok {
+		return locale
+	}
+
+	return locales[DefaultLanguage]
+}
+
+// Register bundles the locale, replacing any bundled for the same code
+func Register(locale *Locale) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	locales[strings.ToLower(locale.Code)] = locale
+}
+
+// T returns the UI string in the current language, or as it is if it has no
+// translation
+func T(str string) string {
+	return Current().T(str)
+}
+
+// Tf translates the format string and formats it with the arguments
+func Tf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// T returns the UI string in the locale's language, or as it is if it has no
+// translation
+func (locale *Locale) T(str string) string {
+	if translated, ok := locale.Strings[str]; ok && translated != "" {
+		return translated
+	}
+
+	return str
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// withStrings returns a copy of the locale with the strings added to its own
+func (locale *Locale) withStrings(overrides map[string]interface{}) *Locale {
+	copied := *locale
+	copied.Strings = make(map[string]string, len(locale.Strings)+len(overrides))
+
+	for key, val := range locale.Strings {
+		copied.Strings[key] = val
+	}
+
+	for key, val := range overrides {
+		copied.Strings[key] = fmt.Sprintf("%v", val)
+	}
+
+	return &copied
+}
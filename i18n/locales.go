@@ -0,0 +1,221 @@
+package i18n
+
+// The bundled locales. English needs no strings, as the UI strings are keyed by their
+// English text
+
+var english = &Locale{
+	Code: "en",
+	Name: "English",
+
+	Months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+
+	DecimalSeparator:   ".",
+	ThousandsSeparator: ",",
+
+	Ago:     "%s ago",
+	In:      "in %s",
+	JustNow: "just now",
+	Units: map[string][2]string{
+		"second": {"second", "seconds"},
+		"minute": {"minute", "minutes"},
+		"hour":   {"hour", "hours"},
+		"day":    {"day", "days"},
+		"month":  {"month", "months"},
+		"year":   {"year", "years"},
+	},
+	ShortNow: "now",
+	ShortUnits: map[string]string{
+		"second": "s",
+		"minute": "m",
+		"hour":   "h",
+		"day":    "d",
+		"month":  "mo",
+		"year":   "y",
+	},
+
+	Strings: map[string]string{},
+}
+
+var german = &Locale{
+	Code: "de",
+	Name: "Deutsch",
+
+	Months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	ShortMonths:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	ShortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+
+	DecimalSeparator:   ",",
+	ThousandsSeparator: ".",
+
+	Ago:     "vor %s",
+	In:      "in %s",
+	JustNow: "gerade eben",
+	Units: map[string][2]string{
+		"second": {"Sekunde", "Sekunden"},
+		"minute": {"Minute", "Minuten"},
+		"hour":   {"Stunde", "Stunden"},
+		"day":    {"Tag", "Tagen"},
+		"month":  {"Monat", "Monaten"},
+		"year":   {"Jahr", "Jahren"},
+	},
+	ShortNow: "jetzt",
+	ShortUnits: map[string]string{
+		"second": "s",
+		"minute": "min",
+		"hour":   "h",
+		"day":    "T",
+		"month":  "Mon",
+		"year":   "J",
+	},
+
+	Strings: map[string]string{
+		"Error":                        "Fehler",
+		"Status":                       "Status",
+		"Endpoint":                     "Endpunkt",
+		"Hint":                         "Hinweis",
+		"Press r to retry":             "Zum Wiederholen r drücken",
+		"stale since %s":               "veraltet seit %s",
+		"circuit open, retrying at %s": "pausiert, neuer Versuch um %s",
+		"Global keyboard commands":     "Globale Tastenbefehle",
+		"Show/hide this help":          "Diese Hilfe ein-/ausblenden",
+		"Open the command palette":     "Befehlspalette öffnen",
+		"Refresh all widgets":          "Alle Widgets aktualisieren",
+		"Refresh the focused widget":   "Das fokussierte Widget aktualisieren",
+		"Focus the next widget":        "Nächstes Widget fokussieren",
+		"Focus the previous widget":    "Vorheriges Widget fokussieren",
+		"Show the next page":           "Nächste Seite anzeigen",
+		"Show the previous page":       "Vorherige Seite anzeigen",
+		"Quit":                         "Beenden",
+		"today":                        "heute",
+		"Enter for a new line, Ctrl-D to save, Esc to cancel": "Enter für eine neue Zeile, Strg-D zum Speichern, Esc zum Abbrechen",
+		"Enter to save, Esc to cancel":                        "Enter zum Speichern, Esc zum Abbrechen",
+		"y to confirm, n or Esc to cancel":                    "y zum Bestätigen, n oder Esc zum Abbrechen",
+	},
+}
+
+var french = &Locale{
+	Code: "fr",
+	Name: "Français",
+
+	Months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	ShortMonths:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+	Weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	ShortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+
+	DecimalSeparator:   ",",
+	ThousandsSeparator: "\u00a0",
+
+	Ago:     "il y a %s",
+	In:      "dans %s",
+	JustNow: "à l'instant",
+	Units: map[string][2]string{
+		"second": {"seconde", "secondes"},
+		"minute": {"minute", "minutes"},
+		"hour":   {"heure", "heures"},
+		"day":    {"jour", "jours"},
+		"month":  {"mois", "mois"},
+		"year":   {"an", "ans"},
+	},
+	ShortNow: "maintenant",
+	ShortUnits: map[string]string{
+		"second": "s",
+		"minute": "min",
+		"hour":   "h",
+		"day":    "j",
+		"month":  "mois",
+		"year":   "a",
+	},
+
+	Strings: map[string]string{
+		"Error":                        "Erreur",
+		"Status":                       "Statut",
+		"Endpoint":                     "Adresse",
+		"Hint":                         "Conseil",
+		"Press r to retry":             "Appuyez sur r pour réessayer",
+		"stale since %s":               "périmé depuis %s",
+		"circuit open, retrying at %s": "en pause, nouvel essai à %s",
+		"Global keyboard commands":     "Raccourcis clavier globaux",
+		"Show/hide this help":          "Afficher/masquer cette aide",
+		"Open the command palette":     "Ouvrir la palette de commandes",
+		"Refresh all widgets":          "Actualiser tous les widgets",
+		"Refresh the focused widget":   "Actualiser le widget actif",
+		"Focus the next widget":        "Activer le widget suivant",
+		"Focus the previous widget":    "Activer le widget précédent",
+		"Show the next page":           "Afficher la page suivante",
+		"Show the previous page":       "Afficher la page précédente",
+		"Quit":                         "Quitter",
+		"today":                        "aujourd'hui",
+		"Enter for a new line, Ctrl-D to save, Esc to cancel": "Entrée pour une nouvelle ligne, Ctrl-D pour enregistrer, Échap pour annuler",
+		"Enter to save, Esc to cancel":                        "Entrée pour enregistrer, Échap pour annuler",
+		"y to confirm, n or Esc to cancel":                    "y pour confirmer, n ou Échap pour annuler",
+	},
+}
+
+var spanish = &Locale{
+	Code: "es",
+	Name: "Español",
+
+	Months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	ShortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+	Weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	ShortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+
+	DecimalSeparator:   ",",
+	ThousandsSeparator: ".",
+
+	Ago:     "hace %s",
+	In:      "en %s",
+	JustNow: "ahora mismo",
+	Units: map[string][2]string{
+		"second": {"segundo", "segundos"},
+		"minute": {"minuto", "minutos"},
+		"hour":   {"hora", "horas"},
+		"day":    {"día", "días"},
+		"month":  {"mes", "meses"},
+		"year":   {"año", "años"},
+	},
+	ShortNow: "ahora",
+	ShortUnits: map[string]string{
+		"second": "s",
+		"minute": "min",
+		"hour":   "h",
+		"day":    "d",
+		"month":  "m",
+		"year":   "a",
+	},
+
+	Strings: map[string]string{
+		"Error":                        "Error",
+		"Status":                       "Estado",
+		"Endpoint":                     "Dirección",
+		"Hint":                         "Sugerencia",
+		"Press r to retry":             "Pulsa r para reintentar",
+		"stale since %s":               "desactualizado desde %s",
+		"circuit open, retrying at %s": "en pausa, reintentando a las %s",
+		"Global keyboard commands":     "Atajos de teclado globales",
+		"Show/hide this help":          "Mostrar/ocultar esta ayuda",
+		"Open the command palette":     "Abrir la paleta de comandos",
+		"Refresh all widgets":          "Actualizar todos los widgets",
+		"Refresh the focused widget":   "Actualizar el widget activo",
+		"Focus the next widget":        "Activar el siguiente widget",
+		"Focus the previous widget":    "Activar el widget anterior",
+		"Show the next page":           "Mostrar la página siguiente",
+		"Show the previous page":       "Mostrar la página anterior",
+		"Quit":                         "Salir",
+		"today":                        "hoy",
+		"Enter for a new line, Ctrl-D to save, Esc to cancel": "Intro para una nueva línea, Ctrl-D para guardar, Esc para cancelar",
+		"Enter to save, Esc to cancel":                        "Intro para guardar, Esc para cancelar",
+		"y to confirm, n or Esc to cancel":                    "y para confirmar, n o Esc para cancelar",
+	},
+}
+
+func init() {
+	Register(english)
+	Register(german)
+	Register(french)
+	Register(spanish)
+}
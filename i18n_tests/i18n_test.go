@@ -0,0 +1,47 @@
+package i18n_tests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/i18n"
+)
+
+func TestLookup(t *testing.T) {
+	Equal(t, "de", Lookup("de").Code)
+	Equal(t, "fr", Lookup("fr_FR.UTF-8").Code)
+	Equal(t, "es", Lookup("es-MX").Code)
+	Equal(t, "en", Lookup("tlh").Code)
+	Equal(t, "en", Lookup("").Code)
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2019, time.March, 4, 9, 30, 0, 0, time.UTC)
+
+	Equal(t, "Monday, March 4, 2019", Lookup("en").FormatDate(date, "Monday, January 2, 2006"))
+	Equal(t, "Montag, 4. März 2019 09:30", Lookup("de").FormatDate(date, "Monday, 2. January 2006 15:04"))
+	Equal(t, "lun. 4 mars", Lookup("fr").FormatDate(date, "Mon 2 Jan"))
+}
+
+func TestFormatNumber(t *testing.T) {
+	Equal(t, "1,234,567.89", Lookup("en").FormatNumber(1234567.891, 2))
+	Equal(t, "-1.234,5", Lookup("de").FormatNumber(-1234.5, 1))
+	Equal(t, "999", Lookup("es").FormatNumber(999, 0))
+	Equal(t, "0", Lookup("en").FormatNumber(-0.2, 0))
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2019, time.March, 4, 9, 30, 0, 0, time.UTC)
+
+	Equal(t, "just now", Lookup("en").RelativeTime(now.Add(-10*time.Second), now))
+	Equal(t, "3 hours ago", Lookup("en").RelativeTime(now.Add(-3*time.Hour), now))
+	Equal(t, "in 1 day", Lookup("en").RelativeTime(now.Add(25*time.Hour), now))
+	Equal(t, "vor 2 Tagen", Lookup("de").RelativeTime(now.Add(-50*time.Hour), now))
+	Equal(t, "dans 5 minutes", Lookup("fr").RelativeTime(now.Add(5*time.Minute), now))
+}
+
+func TestT(t *testing.T) {
+	Equal(t, "Fehler", Lookup("de").T("Error"))
+	Equal(t, "Not translated", Lookup("de").T("Not translated"))
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Line is one line of the log file
+type Line struct {
+	Level   Level
+	Message string
+	Module  string
+	Time    time.Time
+}
+
+// FormatLine returns the message as it is written in the log file, such as:
+//
+//	2019/08/01 09:30:00 WARN github: rate limit nearly reached
+//
+// A message over several lines is written as several log lines
+func FormatLine(at time.Time, level Level, module, msg string) string {
+	prefix := fmt.Sprintf("%s %s %s: ", at.Format(timeFormat), level, module)
+
+	text := ""
+	for _, line := range strings.Split(strings.TrimRight(msg, "\n"), "\n") {
+		text += prefix + line + "\n"
+	}
+
+	return text
+}
+
+// ParseLine reads a line written by FormatLine. It returns false for lines that were
+// not, such as those written by older versions of wtf
+func ParseLine(text string) (Line, bool) {
+	chunks := strings.SplitN(text, " ", 5)
+	if len(chunks) < 4 || !strings.HasSuffix(chunks[3], ":") {
+		return Line{}, false
+	}
+
+	at, err := time.ParseInLocation(timeFormat, chunks[0]+" "+chunks[1], time.Local)
+	if err != nil {
+		return Line{}, false
+	}
+
+	level, err := ParseLevel(chunks[2])
+	if err != nil {
+		return Line{}, false
+	}
+
+	line := Line{
+		Level:  level,
+		Module: strings.TrimSuffix(chunks[3], ":"),
+		Time:   at,
+	}
+
+	if len(chunks) == 5 {
+		line.Message = chunks[4]
+	}
+
+	return line, true
+}
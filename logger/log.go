@@ -1,39 +1,207 @@
+// Package logger writes wtf's log file. Each module logs through its own Logger, so
+// that a misbehaving module can be turned up to debug without flooding the log with
+// everything else:
+//
+//	wtf:
+//	  log:
+//	    file: "~/.config/wtf/log/wtf.log"
+//	    level: warn
+//	    modules:
+//	      github: debug
 package logger
 
 import (
-	"log"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/utils"
 )
 
-/* -------------------- Exported Functions -------------------- */
+// Level is how serious a log message is. Messages less serious than the level set for
+// their module are not written
+type Level int
 
-func Log(msg string) {
-	if LogFileMissing() {
-		return
+// The levels, from least to most serious
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// timeFormat is how the time is written at the start of each line
+const timeFormat = "2006/01/02 15:04:05"
+
+var levelNames = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+// Logger writes log messages on behalf of one module
+type Logger struct {
+	module string
+}
+
+var (
+	filePath     = defaultLogFilePath()
+	level        = LevelInfo
+	moduleLevels = map[string]Level{}
+
+	mu sync.Mutex
+)
+
+// For returns the logger for the named module. Its messages are tagged with the name,
+// and its level can be set under "wtf.log.modules"
+func For(module string) *Logger {
+	return &Logger{module: module}
+}
+
+// Configure sets the log file and the levels from the "wtf.log" config. Levels that
+// are not valid are ignored
+func Configure(globalConfig *config.Config) {
+	path := defaultLogFilePath()
+	if custom := globalConfig.UString("wtf.log.file", ""); custom != "" {
+		if expanded, err := utils.ExpandHomeDir(custom); err == nil {
+			path = expanded
+		}
 	}
 
-	f, err := os.OpenFile(LogFilePath(), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	newLevel, err := ParseLevel(globalConfig.UString("wtf.log.level", "info"))
 	if err != nil {
-		log.Fatalf("error opening file: %v", err)
+		newLevel = LevelInfo
+	}
+
+	newModuleLevels := map[string]Level{}
+	for module, name := range globalConfig.UMap("wtf.log.modules", map[string]interface{}{}) {
+		if moduleLevel, err := ParseLevel(fmt.Sprintf("%v", name)); err == nil {
+			newModuleLevels[module] = moduleLevel
+		}
 	}
-	defer f.Close()
 
-	log.SetOutput(f)
-	log.Println(msg)
+	mu.Lock()
+	defer mu.Unlock()
+
+	filePath = path
+	level = newLevel
+	moduleLevels = newModuleLevels
+}
+
+// ParseLevel returns the level with the given name, such as "debug" or "warn"
+func ParseLevel(name string) (Level, error) {
+	for idx, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return Level(idx), nil
+		}
+	}
+
+	if strings.EqualFold(name, "warning") {
+		return LevelWarn, nil
+	}
+
+	return LevelInfo, fmt.Errorf("'%s' is not a log level: use debug, info, warn, or error", name)
+}
+
+// Log writes the message to the log file at the info level. It is kept for code that
+// does not belong to a module; modules should log through For
+func Log(msg string) {
+	For("wtf").write(LevelInfo, msg)
 }
 
+// LogFileMissing returns true if there is nowhere to write the log
 func LogFileMissing() bool {
 	return LogFilePath() == ""
 }
 
+// LogFilePath returns the path to the log file
 func LogFilePath() string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return filePath
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// String returns the level's name as it is written in the log
+func (level Level) String() string {
+	if level < LevelDebug || level > LevelError {
+		return "UNKNOWN"
+	}
+
+	return levelNames[level]
+}
+
+// Debugf logs a message that is only useful when tracking down a problem
+func (logger *Logger) Debugf(format string, args ...interface{}) {
+	logger.write(LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Enabled returns true if messages at the level are written for the logger's module.
+// It lets callers skip building messages that would not be written
+func (logger *Logger) Enabled(msgLevel Level) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return msgLevel >= logger.level()
+}
+
+// Errorf logs a failure, such as data that could not be fetched or parsed
+func (logger *Logger) Errorf(format string, args ...interface{}) {
+	logger.write(LevelError, fmt.Sprintf(format, args...))
+}
+
+// Infof logs a message about the normal running of the module
+func (logger *Logger) Infof(format string, args ...interface{}) {
+	logger.write(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs something unexpected that the module carried on past
+func (logger *Logger) Warnf(format string, args ...interface{}) {
+	logger.write(LevelWarn, fmt.Sprintf(format, args...))
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// level returns the level set for the logger's module or, if there is none, the
+// level for everything. The caller must hold mu
+func (logger *Logger) level() Level {
+	if moduleLevel, ok := moduleLevels[logger.module]; ok {
+		return moduleLevel
+	}
+
+	return level
+}
+
+// write appends the message to the log file, one line per line of the message, if its
+// level is high enough. Errors are dropped, as there is nowhere left to report them
+func (logger *Logger) write(msgLevel Level, msg string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if filePath == "" || msgLevel < logger.level() {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return
+	}
+
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	file.WriteString(FormatLine(time.Now(), msgLevel, logger.module, msg))
+}
+
+func defaultLogFilePath() string {
 	dir, err := utils.Home()
 	if err != nil {
 		return ""
 	}
 
-	return filepath.Join(dir, ".config", "wtf", "log.txt")
+	return filepath.Join(dir, ".config", "wtf", "log", "wtf.log")
 }
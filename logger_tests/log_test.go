@@ -0,0 +1,82 @@
+package logger_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/logger"
+)
+
+func TestConfigure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-logger")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	logFile := filepath.Join(dir, "log", "wtf.log")
+
+	cfg, _ := config.ParseYaml(`
+wtf:
+  log:
+    file: ` + logFile + `
+    level: warn
+    modules:
+      github: debug
+      todo: nonsense
+`)
+	Configure(cfg)
+	defer Configure(&config.Config{Root: map[string]interface{}{}})
+
+	Equal(t, logFile, LogFilePath())
+	True(t, For("github").Enabled(LevelDebug))
+	False(t, For("todo").Enabled(LevelInfo))
+	True(t, For("todo").Enabled(LevelWarn))
+
+	For("github").Debugf("fetched %d repos", 3)
+	For("todo").Infof("not written")
+	For("todo").Errorf("could not save")
+
+	text, err := ioutil.ReadFile(logFile)
+	Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(text)), "\n")
+	Equal(t, 2, len(lines))
+	True(t, strings.HasSuffix(lines[0], " DEBUG github: fetched 3 repos"))
+	True(t, strings.HasSuffix(lines[1], " ERROR todo: could not save"))
+}
+
+func TestFormatLine(t *testing.T) {
+	at := time.Date(2019, 8, 1, 9, 30, 0, 0, time.Local)
+
+	Equal(t, "2019/08/01 09:30:00 WARN github: slow\n", FormatLine(at, LevelWarn, "github", "slow"))
+	Equal(
+		t,
+		"2019/08/01 09:30:00 INFO git: one\n2019/08/01 09:30:00 INFO git: two\n",
+		FormatLine(at, LevelInfo, "git", "one\ntwo\n"),
+	)
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("Warning")
+	Nil(t, err)
+	Equal(t, LevelWarn, level)
+
+	_, err = ParseLevel("loud")
+	NotNil(t, err)
+}
+
+func TestParseLine(t *testing.T) {
+	at := time.Date(2019, 8, 1, 9, 30, 0, 0, time.Local)
+
+	line, ok := ParseLine(strings.TrimSuffix(FormatLine(at, LevelError, "jira", "parsing the response: EOF"), "\n"))
+	True(t, ok)
+	Equal(t, Line{Level: LevelError, Message: "parsing the response: EOF", Module: "jira", Time: at}, line)
+
+	_, ok = ParseLine("2019/08/01 09:30:00 an old log line")
+	False(t, ok)
+}
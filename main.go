@@ -1,14 +1,12 @@
 package main
 
-// Generators
-// To generate the skeleton for a new TextWidget use 'WTF_WIDGET_NAME=MySuperAwesomeWidget go generate -run=text
-//go:generate -command text go run generator/textwidget.go
-//go:generate text
-
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gdamore/tcell"
@@ -18,12 +16,27 @@ import (
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/flags"
+	"github.com/wtfutil/wtf/i18n"
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/maker"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
+var ambientMode *wtf.AmbientMode
+var apiServer *wtf.APIServer
+var commandPalette *wtf.CommandPalette
+var display *wtf.Display
+var drawnScreen tcell.Screen
+var exporter *wtf.Exporter
 var focusTracker wtf.FocusTracker
+var globalKeys *wtf.KeyMap
+var helpOverlay *wtf.HelpOverlay
+var layoutEditor *wtf.LayoutEditor
+var screenshotter *wtf.Screenshotter
+var sharer *wtf.Sharer
+var statusBar *wtf.StatusBar
+var vimNavigation *wtf.VimNavigation
 var runningWidgets []wtf.Wtfable
 
 var (
@@ -34,26 +47,130 @@ var (
 
 /* -------------------- Functions -------------------- */
 
+// configure applies the app-wide settings in the config. It runs at startup and again
+// whenever the config file changes, so that edits to these settings take effect on reload
+func configure(config *config.Config) {
+	wtf.SetColorDepth(wtf.DetectColorDepth(config.UString("wtf.colorDepth", "auto")))
+	wtf.OpenFileUtil = config.UString("wtf.openFileUtil", "open")
+	utils.SetOpenerCommand(config.UString("wtf.openerCommand", ""))
+
+	wtf.ConfigureEventHooks(config)
+	wtf.ConfigureClipboard(config)
+	wtf.ConfigureImages(config)
+	logger.Configure(config)
+	i18n.Configure(config)
+	wtf.ConfigureRetention(config)
+	wtf.ConfigureDoNotDisturb(config)
+	wtf.ConfigureSync(config)
+	configureCache(config)
+}
+
+// configureCache sets how large each module's cache directory can grow
+func configureCache(config *config.Config) {
+	cfg.ModuleCacheMaxBytes = int64(config.UInt("wtf.cache.moduleMaxSize", 50)) * 1024 * 1024
+}
+
+// configureHTTP sets up the HTTP client the modules share at startup, exiting if the
+// config's HTTP settings are invalid. On reload, invalid settings are reported instead
+func configureHTTP(config *config.Config) {
+	err := wtf.ConfigureHTTP(config)
+	if err != nil {
+		fmt.Printf("\n\033[0;31mERROR:\033[0m %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// dashboardRoot returns the primitive that fills the screen: the display's pages, with
+// the status bar beneath them if it's turned on
+func dashboardRoot() tview.Primitive {
+	if statusBar == nil {
+		return display.Pages
+	}
+
+	flex := tview.NewFlex()
+	flex.SetDirection(tview.FlexRow)
+	flex.AddItem(display.Pages, 0, 1, false)
+	flex.AddItem(statusBar.View, 1, 0, false)
+
+	return flex
+}
+
 func disableAllWidgets(widgets []wtf.Wtfable) {
 	for _, widget := range widgets {
 		widget.Disable()
 	}
 }
 
+// focusedWidget returns the widget that has the focus, if any
+func focusedWidget() wtf.Wtfable {
+	return focusTracker.FocusedWidget()
+}
+
 func keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
-	// These keys are global keys used by the app. Widgets should not implement these keys
-	switch event.Key() {
-	case tcell.KeyCtrlR:
-		refreshAllWidgets(runningWidgets)
+	// While the layout is being edited, the editor gets every key press
+	if layoutEditor.Active {
+		if globalKeys.Matches("editLayout", event) {
+			layoutEditor.Stop()
+			return nil
+		}
+
+		return layoutEditor.InputCapture(event)
+	}
+
+	// A key press that wakes the blanked screen does nothing else
+	if ambientMode.Touch() {
+		return nil
+	}
+
+	// In ambient mode only the keys that leave it and quit do anything
+	if ambientMode.Active() {
+		if globalKeys.Matches("ambient", event) || globalKeys.Matches("quit", event) {
+			globalKeys.Handle(event)
+		}
+
+		return nil
+	}
+
+	// While a text prompt is open, it gets every key press
+	if wtf.PromptOpen() {
+		return event
+	}
+
+	if helpOverlay.Visible() {
+		if globalKeys.Matches("help", event) || event.Key() == tcell.KeyEsc {
+			helpOverlay.Hide()
+			return nil
+		}
+
+		return event
+	}
+
+	if commandPalette.Visible() {
+		if event.Key() == tcell.KeyEsc {
+			commandPalette.Hide()
+			return nil
+		}
+
+		return event
+	}
+
+	// While a search is being typed, the focused widget gets every key press
+	if wtf.SearchCapture(focusTracker.FocusedWidget(), event) {
 		return nil
-	case tcell.KeyTab:
-		focusTracker.Next()
+	}
+
+	if vimNavigation.InputCapture(event, &focusTracker) {
 		return nil
-	case tcell.KeyBacktab:
-		focusTracker.Prev()
+	}
+
+	// These keys are global keys used by the app. Widgets should not implement these keys
+	if globalKeys.Handle(event) {
 		return nil
-	case tcell.KeyEsc:
-		focusTracker.None()
+	}
+
+	// Alt-1 through Alt-9 jump directly to that page
+	if event.Modifiers()&tcell.ModAlt != 0 && event.Rune() >= '1' && event.Rune() <= '9' {
+		switchPage(func() { display.ShowPage(int(event.Rune() - '1')) })
 		return nil
 	}
 
@@ -74,10 +191,405 @@ func keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
+// mouseIntercept handles mouse button presses and wheel motion. Clicking on a widget
+// focuses it and passes the click on to it; the wheel scrolls the widget under the pointer
+func mouseIntercept(event *tcell.EventMouse) {
+	if ambientMode.Touch() || layoutEditor.Active {
+		return
+	}
+
+	x, y := event.Position()
+
+	widget := display.WidgetAt(x, y)
+	if widget == nil {
+		return
+	}
+
+	switch {
+	case event.Buttons()&tcell.WheelUp != 0:
+		wtf.MouseScroll(widget, true)
+	case event.Buttons()&tcell.WheelDown != 0:
+		wtf.MouseScroll(widget, false)
+	case event.Buttons()&tcell.Button1 != 0:
+		focusTracker.FocusOnWidget(widget)
+		wtf.MouseClick(widget, x, y)
+	}
+}
+
+// makeGlobalKeys registers the app-wide keyboard actions, using any keys the config
+// has remapped them to
+func makeGlobalKeys(app *tview.Application, config *config.Config) *wtf.KeyMap {
+	keys := wtf.NewKeyMap(config)
+
+	keys.Add("help", "?", "Show/hide this help", showHelp)
+	keys.Add("ambient", "Ctrl-A", "Turn ambient mode on/off", toggleAmbient)
+	keys.Add("command", ":", "Open the command palette", func() { commandPalette.Show() })
+	keys.Add("refresh", "Ctrl-R", "Refresh all widgets", func() { refreshAllWidgets(runningWidgets) })
+	keys.Add("refreshFocused", "Ctrl-F", "Refresh the focused widget", refreshFocusedWidget)
+	keys.AddOptional("retry", "r", "Retry the failed widgets", retryFailedWidgets)
+	keys.Add("pause", "Ctrl-S", "Pause/resume refreshing the focused widget", togglePause)
+	keys.Add("dnd", "Ctrl-G", "Turn Do Not Disturb on/off", toggleDND)
+	keys.Add("nextWidget", "Tab", "Focus the next widget", func() { focusTracker.Next() })
+	keys.Add("prevWidget", "Backtab", "Focus the previous widget", func() { focusTracker.Prev() })
+	keys.Add("unfocus", "Esc", "Remove the focus from the widget", func() { focusTracker.None() })
+	keys.Add("nextPage", "Ctrl-N", "Show the next page", func() { switchPage(display.NextPage) })
+	keys.Add("prevPage", "Ctrl-P", "Show the previous page", func() { switchPage(display.PrevPage) })
+	keys.Add("screenshot", "Ctrl-X", "Save what the focused widget shows to a file", func() {
+		screenshotWidget(app, focusTracker.FocusedWidget(), "")
+	})
+	keys.Add("share", "Ctrl-Y", "Share the focused widget's content and copy the link", func() {
+		shareWidget(app, focusTracker.FocusedWidget())
+	})
+	keys.Add("zoom", "Ctrl-Z", "Zoom/unzoom the focused widget", func() { display.ToggleZoom(focusTracker.FocusedWidget()) })
+	keys.Add("editLayout", "Ctrl-E", "Start/stop editing the layout", func() {
+		display.Unzoom()
+		layoutEditor.Toggle(focusTracker.FocusedWidget())
+	})
+
+	keys.Add("quit", "Ctrl-C", "Quit", app.Stop)
+
+	keys.AddNote("PgUp/PgDn", "Scroll the focused widget")
+	keys.AddNote("Alt-1..9", "Show that page")
+	keys.AddNote("1..9", "Focus that widget")
+
+	if config.UBool("wtf.navigation.vim", false) {
+		keys.AddNote("Ctrl-W h/j/k/l", "Focus the widget to the left, below, above, or right")
+		keys.AddNote("gg/G", "Select the first/last row of the focused widget")
+	}
+
+	return keys
+}
+
+// makeCommandPalette registers the commands that can be run from the command palette
+func makeCommandPalette(app *tview.Application, pages *tview.Pages, configFilePath string) *wtf.CommandPalette {
+	palette := wtf.NewCommandPalette(app, pages)
+
+	palette.Add("refresh", "Refresh all widgets, or the named widgets", func(args []string) error {
+		if len(args) == 0 {
+			refreshAllWidgets(runningWidgets)
+			return nil
+		}
+
+		widgets := []wtf.Wtfable{}
+		for _, widget := range runningWidgets {
+			for _, name := range args {
+				if widget.Enabled() && (widget.Name() == name || widget.CommonSettings().Module.Type == name) {
+					widgets = append(widgets, widget)
+				}
+			}
+		}
+
+		if len(widgets) == 0 {
+			return fmt.Errorf("no widget named %s", strings.Join(args, ", "))
+		}
+
+		refreshAllWidgets(widgets)
+		return nil
+	})
+
+	palette.Add("disable", "Turn off the named widgets in the config file", func(args []string) error {
+		if len(args) == 0 {
+			return errors.New("name the widgets to turn off")
+		}
+
+		writer, err := cfg.NewConfigWriter(configFilePath)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range args {
+			if findWidget(name) == nil {
+				return fmt.Errorf("no widget named %s", name)
+			}
+
+			if err := writer.Set("wtf.mods."+name+".enabled", false); err != nil {
+				return err
+			}
+		}
+
+		// Saving the config file reloads it, which removes the widgets
+		return writer.Save()
+	})
+
+	palette.Add("ambient", "Turn ambient mode on/off", func(args []string) error {
+		toggleAmbient()
+		return nil
+	})
+
+	palette.Add("dnd", "Turn Do Not Disturb on/off", func(args []string) error {
+		toggleDND()
+		return nil
+	})
+
+	palette.Add("goto", "Focus the widget with the given number", func(args []string) error {
+		if len(args) != 1 || !focusTracker.FocusOn(args[0]) {
+			return fmt.Errorf("no widget numbered %s", strings.Join(args, " "))
+		}
+
+		return nil
+	})
+
+	palette.Add("page", "Show the page with the given number or name", func(args []string) error {
+		name := strings.Join(args, " ")
+
+		idx := display.PageIndex(name)
+		if num, err := strconv.Atoi(name); err == nil {
+			idx = num - 1
+		}
+
+		if idx < 0 || idx >= display.PageCount() {
+			return fmt.Errorf("no page %s", name)
+		}
+
+		switchPage(func() { display.ShowPage(idx) })
+		return nil
+	})
+
+	palette.Add("zoom", "Zoom/unzoom the focused widget", func(args []string) error {
+		display.ToggleZoom(focusTracker.FocusedWidget())
+		return nil
+	})
+
+	palette.Add("screenshot", "Save what the focused widget shows to a file, as text, ansi, html, or png", func(args []string) error {
+		widget := focusTracker.FocusedWidget()
+		if widget == nil {
+			return errors.New("focus the widget to take a screenshot of")
+		}
+
+		format := ""
+		if len(args) > 0 {
+			format = args[0]
+		}
+
+		switch format {
+		case "", wtf.SnapshotText, wtf.SnapshotANSI, wtf.SnapshotHTML, wtf.ScreenshotPNG:
+		default:
+			return fmt.Errorf("unknown screenshot format %s, expected text, ansi, html, or png", format)
+		}
+
+		screenshotWidget(app, widget, format)
+		return nil
+	})
+
+	palette.Add("share", "Share the focused widget's content, or the named widget's, and copy the link", func(args []string) error {
+		widget := focusTracker.FocusedWidget()
+		if len(args) > 0 {
+			widget = findWidget(args[0])
+		}
+
+		if widget == nil {
+			return fmt.Errorf("no widget named %s", strings.Join(args, " "))
+		}
+
+		shareWidget(app, widget)
+		return nil
+	})
+
+	palette.Add("help", "Show the help", func(args []string) error {
+		showHelp()
+		return nil
+	})
+
+	palette.Add("notify", "Show a notification with the given message", func(args []string) error {
+		notification := wtf.Notification{
+			Message: strings.Join(args, " "),
+			Module:  "wtf",
+			Title:   "wtf",
+		}
+
+		wtf.Notifications.Publish(notification, cfg.NotificationSettings{Desktop: true, Enabled: true})
+		return nil
+	})
+
+	palette.Add("quit", "Quit", func(args []string) error {
+		app.Stop()
+		return nil
+	}, "q")
+
+	return palette
+}
+
+// findWidget returns the running widget with the given name, or nil if there isn't one
+func findWidget(name string) wtf.Wtfable {
+	for _, widget := range runningWidgets {
+		if widget.Name() == name {
+			return widget
+		}
+	}
+
+	return nil
+}
+
 func refreshAllWidgets(widgets []wtf.Wtfable) {
+	display.Scheduler().Refresh(widgets)
+}
+
+// refreshFocusedWidget refreshes the focused widget, if any
+func refreshFocusedWidget() {
+	if focused := focusTracker.FocusedWidget(); focused != nil {
+		refreshAllWidgets([]wtf.Wtfable{focused})
+	}
+}
+
+// retryFailedWidgets refreshes the focused widget if its last refresh failed or, if no
+// widget is focused, every widget whose last refresh failed. Returns false if there was
+// nothing to retry, so that the retry key can go on to the focused widget
+func retryFailedWidgets() bool {
+	widgets := runningWidgets
+	if focused := focusTracker.FocusedWidget(); focused != nil {
+		widgets = []wtf.Wtfable{focused}
+	}
+
+	failed := []wtf.Wtfable{}
 	for _, widget := range widgets {
-		go widget.Refresh()
+		if widget.Enabled() && wtf.RefreshStatuses.Status(widget.Name()).Err != nil {
+			failed = append(failed, widget)
+		}
+	}
+
+	if len(failed) == 0 {
+		return false
+	}
+
+	display.Scheduler().Refresh(failed)
+	return true
+}
+
+// togglePause pauses or resumes the automatic refreshes of the focused widget
+func togglePause() {
+	if focused := focusTracker.FocusedWidget(); focused != nil {
+		display.Scheduler().TogglePause(focused)
+	}
+}
+
+// toggleAmbient turns ambient mode on or off. The focus is dropped, as the focused widget
+// may not be onscreen in ambient mode
+func toggleAmbient() {
+	focusTracker.None()
+	ambientMode.Toggle()
+}
+
+// toggleDND turns Do Not Disturb on or off and refreshes the widgets, so that their
+// alert colors are muted or brought back and the widgets it pauses stop or start again
+func toggleDND() {
+	state := "off"
+	if wtf.DND.Toggle(time.Now()) {
+		state = "on"
+	}
+
+	refreshAllWidgets(runningWidgets)
+	helpOverlay.Show(fmt.Sprintf("Do Not Disturb is %s", state))
+}
+
+// switchPage changes the onscreen page and drops the focus, as the previously-focused
+// widget is no longer visible
+func switchPage(switchFunc func()) {
+	if display.PageCount() < 2 {
+		return
 	}
+
+	focusTracker.Reset()
+	switchFunc()
+}
+
+// showLayoutError shows an error from saving the layout. Edit mode is left first, as it
+// would otherwise keep the keys that close the overlay
+func showLayoutError(err error) {
+	layoutEditor.Stop()
+	helpOverlay.Show(fmt.Sprintf("\n [red::b]Saving the layout failed[-::-]\n\n %s", tview.Escape(err.Error())))
+}
+
+// showReloadError shows a problem with the reloaded config, which the dashboard carries
+// on without. The terminal belongs to the dashboard by then, so it can't be printed
+func showReloadError(app *tview.Application, err error) {
+	app.QueueUpdateDraw(func() {
+		helpOverlay.Show(fmt.Sprintf("\n [red::b]Problem in the reloaded config[-::-]\n\n %s", tview.Escape(err.Error())))
+	})
+}
+
+// showHelp opens the help overlay listing the global keys, the commands, and the keys
+// of the focused widget
+func showHelp() {
+	text := globalKeys.HelpText() + "\n" + commandPalette.HelpText()
+
+	if focused := focusTracker.FocusedWidget(); focused != nil {
+		text += "\n" + focused.HelpText()
+	}
+
+	helpOverlay.Show(text)
+}
+
+// restoreSession brings back the page, focus, and scroll positions the dashboard had
+// when the app last exited
+func restoreSession() {
+	focused := wtf.LoadSession().Restore(display, runningWidgets)
+	if focused != nil {
+		focusTracker.FocusOnWidget(focused)
+	}
+}
+
+// saveSession records the page, focus, and scroll positions of the dashboard, for the
+// next launch to restore
+func saveSession() {
+	session := wtf.CaptureSession(display, focusTracker.FocusedWidget(), runningWidgets)
+	if err := session.Save(); err != nil {
+		logger.For("wtf").Warnf("saving the session: %v", err)
+	}
+}
+
+// screenshotWidget saves what the widget shows onscreen to a file in the format, or in
+// the configured one if it's empty, and shows where it went. The cells are read on the
+// app's goroutine, and the file is written in the background
+func screenshotWidget(app *tview.Application, widget wtf.Wtfable, format string) {
+	if widget == nil || drawnScreen == nil {
+		return
+	}
+
+	shot := screenshotter
+
+	app.QueueUpdate(func() {
+		x, y, width, height := widget.TextView().GetRect()
+		cells := wtf.ScreenCells(drawnScreen, x, y, width, height)
+
+		go func() {
+			path, err := shot.Save(widget.Name(), cells, width, height, format, time.Now())
+
+			message := fmt.Sprintf("\n [green::b]Saved %s[-::-]\n\n %s", widget.Name(), tview.Escape(path))
+			if err != nil {
+				message = fmt.Sprintf("\n [red::b]Saving %s failed[-::-]\n\n %s", widget.Name(), tview.Escape(err.Error()))
+			}
+
+			app.QueueUpdateDraw(func() { helpOverlay.Show(message) })
+		}()
+	})
+}
+
+// shareWidget uploads the text the widget is showing, copies the link to the clipboard,
+// and shows it. The upload happens in the background
+func shareWidget(app *tview.Application, widget wtf.Wtfable) {
+	if widget == nil {
+		return
+	}
+
+	name := widget.Name()
+	text := widget.TextView().GetText(true)
+	share := sharer
+
+	go func() {
+		link, err := share.Share(name, text)
+
+		var message string
+		switch {
+		case err != nil:
+			message = fmt.Sprintf("\n [red::b]Sharing %s failed[-::-]\n\n %s", name, tview.Escape(err.Error()))
+		case wtf.CopyToClipboard(link) != nil:
+			message = fmt.Sprintf("\n [green::b]Shared %s[-::-]\n\n %s", name, link)
+		default:
+			message = fmt.Sprintf("\n [green::b]Shared %s[-::-]\n\n %s\n\n [gray]The link is on the clipboard[-]", name, link)
+		}
+
+		app.QueueUpdateDraw(func() { helpOverlay.Show(message) })
+	}()
 }
 
 func setTerm(config *config.Config) {
@@ -89,7 +601,128 @@ func setTerm(config *config.Config) {
 	}
 }
 
-func watchForConfigChanges(app *tview.Application, configFilePath string, isCustomConfig bool, grid *tview.Grid, pages *tview.Pages) {
+// startAPIServer starts serving the widgets' data over HTTP if the server is turned on,
+// stopping the server that served the previous widgets. If the new server can't listen
+// on its address, the previous one is started again to serve the new widgets and the
+// error is returned. Commands sent to the server are run by the command palette
+func startAPIServer(app *tview.Application, widgets []wtf.Wtfable, config *config.Config) error {
+	previous := apiServer
+	if previous != nil {
+		previous.Stop()
+	}
+
+	server := wtf.NewAPIServer(app, widgets, config)
+	if server == nil {
+		apiServer = nil
+		return nil
+	}
+
+	server.SetCommands(func(args []string) error { return commandPalette.Exec(args) })
+
+	if err := server.Start(); err != nil {
+		if previous == nil {
+			return fmt.Errorf("could not start the API server: %v", err)
+		}
+
+		previous.SetWidgets(widgets)
+		if restartErr := previous.Start(); restartErr != nil {
+			apiServer = nil
+			return fmt.Errorf("could not start the API server: %v", err)
+		}
+
+		return fmt.Errorf("could not start the API server, so the previous one was kept: %v", err)
+	}
+
+	apiServer = server
+	return nil
+}
+
+// startExporter starts writing the exporter's summary to its file if it has one,
+// stopping the exporter that ran with the previous config. While the dashboard is
+// onscreen the summary can't be written to standard output
+func startExporter(config *config.Config) {
+	if exporter != nil {
+		exporter.Stop()
+	}
+
+	exporter = wtf.NewExporter(config)
+	if exporter == nil || exporter.ToStdout() {
+		exporter = nil
+		return
+	}
+
+	exporter.Start()
+}
+
+// reloadConfig rebuilds the dashboard from the changed config file. The new widgets are
+// made on the watcher's goroutine, then swapped in on the app's, which the key and mouse
+// handlers that use them run on. It returns once the swap is done, so that the next
+// change doesn't start from a half-replaced dashboard
+func reloadConfig(app *tview.Application, absPath string, pages *tview.Pages) {
+	config := cfg.LoadWtfConfigFile(absPath, false)
+	if err := wtf.ConfigureHTTP(config); err != nil {
+		showReloadError(app, fmt.Errorf("the HTTP settings were left as they were: %v", err))
+	}
+	configure(config)
+
+	widgets := maker.MakeWidgets(app, pages, config)
+	wtf.ValidateWidgets(widgets)
+
+	done := make(chan struct{})
+
+	app.QueueUpdateDraw(func() {
+		defer close(done)
+
+		// Disable all widgets to stop scheduler goroutines and remove widgets from memory
+		disableAllWidgets(runningWidgets)
+		if statusBar != nil {
+			statusBar.Stop()
+		}
+		wtf.Images.Clear()
+
+		// A screen blanked by the previous ambient mode would stay blank over the new pages
+		if ambientMode != nil {
+			ambientMode.Touch()
+		}
+
+		runningWidgets = widgets
+
+		focusTracker = wtf.NewFocusTracker(app, widgets, config)
+
+		display = wtf.NewDisplay(widgets, config)
+		statusBar = wtf.NewStatusBar(app, widgets, config, focusedWidget)
+		pages.AddPage("grid", dashboardRoot(), true, true)
+		ambientMode = wtf.NewAmbientMode(app, pages, display, widgets, config)
+		if config.UBool("wtf.ambient.enabled", false) {
+			ambientMode.Toggle()
+		}
+
+		if err := startAPIServer(app, widgets, config); err != nil {
+			showReloadError(app, err)
+		}
+		startExporter(config)
+		screenshotter = wtf.NewScreenshotter(config)
+		sharer = wtf.NewSharer(config)
+
+		layoutEditor = wtf.NewLayoutEditor(display, config, absPath)
+		layoutEditor.SetErrorFunc(showLayoutError)
+
+		// Keys that conflict leave the previous keys in place, so the dashboard
+		// can still be driven while the config is fixed
+		keys := makeGlobalKeys(app, config)
+		if err := wtf.CheckKeys(keys, widgets); err != nil {
+			showReloadError(app, fmt.Errorf("the keys were left as they were: %v", err))
+		} else {
+			globalKeys = keys
+		}
+
+		vimNavigation = wtf.NewVimNavigation(config)
+	})
+
+	<-done
+}
+
+func watchForConfigChanges(app *tview.Application, configFilePath string, isCustomConfig bool, pages *tview.Pages) {
 	watch := watcher.New()
 	absPath, _ := utils.ExpandHomeDir(configFilePath)
 
@@ -100,20 +733,7 @@ func watchForConfigChanges(app *tview.Application, configFilePath string, isCust
 		for {
 			select {
 			case <-watch.Event:
-				// Disable all widgets to stop scheduler goroutines and remove widgets from memory
-				disableAllWidgets(runningWidgets)
-
-				config := cfg.LoadWtfConfigFile(absPath, false)
-
-				widgets := maker.MakeWidgets(app, pages, config)
-				runningWidgets = widgets
-
-				wtf.ValidateWidgets(widgets)
-
-				focusTracker = wtf.NewFocusTracker(app, widgets, config)
-
-				display := wtf.NewDisplay(widgets, config)
-				pages.AddPage("grid", display.Grid, true, true)
+				reloadConfig(app, absPath, pages)
 			case err := <-watch.Error:
 				log.Fatalln(err)
 			case <-watch.Closed:
@@ -144,6 +764,11 @@ func main() {
 	// Parse and handle flags
 	flags := flags.NewFlags()
 	flags.Parse()
+
+	if flags.HasCommand("new-module") {
+		newModule(flags)
+	}
+
 	config := cfg.LoadWtfConfigFile(flags.ConfigFilePath(), flags.HasCustomConfig())
 	flags.RenderIf(version, config)
 
@@ -152,8 +777,12 @@ func main() {
 	}
 
 	setTerm(config)
+	configureHTTP(config)
+	configure(config)
 
-	wtf.OpenFileUtil = config.UString("wtf.openFileUtil", "open")
+	if flags.HasCommand("snapshot") || flags.HasCommand("export") {
+		runHeadless(config, flags)
+	}
 
 	app := tview.NewApplication()
 	pages := tview.NewPages()
@@ -165,15 +794,70 @@ func main() {
 
 	focusTracker = wtf.NewFocusTracker(app, widgets, config)
 
-	display := wtf.NewDisplay(widgets, config)
-	pages.AddPage("grid", display.Grid, true, true)
+	display = wtf.NewDisplay(widgets, config)
+	statusBar = wtf.NewStatusBar(app, widgets, config, focusedWidget)
+	pages.AddPage("grid", dashboardRoot(), true, true)
+	ambientMode = wtf.NewAmbientMode(app, pages, display, widgets, config)
+
+	if err := startAPIServer(app, widgets, config); err != nil {
+		fmt.Printf("\n\033[0;31mERROR:\033[0m %v\n", err)
+		os.Exit(1)
+	}
+	startExporter(config)
+	screenshotter = wtf.NewScreenshotter(config)
+	sharer = wtf.NewSharer(config)
+
+	layoutEditor = wtf.NewLayoutEditor(display, config, flags.ConfigFilePath())
+	layoutEditor.SetErrorFunc(showLayoutError)
+
+	globalKeys = makeGlobalKeys(app, config)
+	wtf.ValidateKeys(globalKeys, widgets)
+
+	helpOverlay = wtf.NewHelpOverlay(app, pages)
+	commandPalette = makeCommandPalette(app, pages, flags.ConfigFilePath())
+	vimNavigation = wtf.NewVimNavigation(config)
 
 	app.SetInputCapture(keyboardIntercept)
 
-	go watchForConfigChanges(app, flags.Config, flags.HasCustomConfig(), display.Grid, pages)
+	if config.UBool("wtf.mouse", true) {
+		screen, err := wtf.NewMouseScreen(app, mouseIntercept)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		app.SetScreen(screen)
+	}
+
+	// Re-flow the widgets whenever the terminal is resized across a grid breakpoint
+	app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		width, _ := screen.Size()
+		display.Resize(width)
+		return false
+	})
+
+	// Images drawn with the terminal's graphics protocol go over the widgets once they are
+	// drawn. The screen is kept for screenshots to be read from
+	app.SetAfterDrawFunc(func(drawn tcell.Screen) {
+		drawnScreen = drawn
+		wtf.Images.Draw(drawn)
+	})
+
+	if config.UBool("wtf.session.restore", true) {
+		restoreSession()
+	}
+
+	// Wall-mounted dashboards can start off in ambient mode
+	if config.UBool("wtf.ambient.enabled", false) {
+		ambientMode.Toggle()
+	}
+
+	go watchForConfigChanges(app, flags.Config, flags.HasCustomConfig(), pages)
 
 	if err := app.SetRoot(pages, true).Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	saveSession()
 }
@@ -1,60 +1,96 @@
 package maker
 
 import (
+	"sort"
+
 	"github.com/olebedev/config"
 	"github.com/rivo/tview"
-	"github.com/wtfutil/wtf/modules/bamboohr"
-	"github.com/wtfutil/wtf/modules/bargraph"
-	"github.com/wtfutil/wtf/modules/circleci"
-	"github.com/wtfutil/wtf/modules/clocks"
-	"github.com/wtfutil/wtf/modules/cmdrunner"
-	"github.com/wtfutil/wtf/modules/cryptoexchanges/bittrex"
-	"github.com/wtfutil/wtf/modules/cryptoexchanges/blockfolio"
-	"github.com/wtfutil/wtf/modules/cryptoexchanges/cryptolive"
-	"github.com/wtfutil/wtf/modules/datadog"
-	"github.com/wtfutil/wtf/modules/feedreader"
-	"github.com/wtfutil/wtf/modules/gcal"
-	"github.com/wtfutil/wtf/modules/gerrit"
-	"github.com/wtfutil/wtf/modules/git"
-	"github.com/wtfutil/wtf/modules/github"
-	"github.com/wtfutil/wtf/modules/gitlab"
-	"github.com/wtfutil/wtf/modules/gitter"
-	"github.com/wtfutil/wtf/modules/googleanalytics"
-	"github.com/wtfutil/wtf/modules/gspreadsheets"
-	"github.com/wtfutil/wtf/modules/hackernews"
-	"github.com/wtfutil/wtf/modules/hibp"
-	"github.com/wtfutil/wtf/modules/ipaddresses/ipapi"
-	"github.com/wtfutil/wtf/modules/ipaddresses/ipinfo"
-	"github.com/wtfutil/wtf/modules/jenkins"
-	"github.com/wtfutil/wtf/modules/jira"
-	"github.com/wtfutil/wtf/modules/logger"
-	"github.com/wtfutil/wtf/modules/mercurial"
-	"github.com/wtfutil/wtf/modules/nbascore"
-	"github.com/wtfutil/wtf/modules/newrelic"
-	"github.com/wtfutil/wtf/modules/opsgenie"
-	"github.com/wtfutil/wtf/modules/pagerduty"
-	"github.com/wtfutil/wtf/modules/power"
-	"github.com/wtfutil/wtf/modules/resourceusage"
-	"github.com/wtfutil/wtf/modules/rollbar"
-	"github.com/wtfutil/wtf/modules/security"
-	"github.com/wtfutil/wtf/modules/spotify"
-	"github.com/wtfutil/wtf/modules/spotifyweb"
-	"github.com/wtfutil/wtf/modules/status"
-	"github.com/wtfutil/wtf/modules/textfile"
-	"github.com/wtfutil/wtf/modules/todo"
-	"github.com/wtfutil/wtf/modules/todoist"
-	"github.com/wtfutil/wtf/modules/transmission"
-	"github.com/wtfutil/wtf/modules/travisci"
-	"github.com/wtfutil/wtf/modules/trello"
-	"github.com/wtfutil/wtf/modules/twitter"
+	"github.com/wtfutil/wtf/modules/rpcplugin"
 	"github.com/wtfutil/wtf/modules/unknown"
-	"github.com/wtfutil/wtf/modules/victorops"
-	"github.com/wtfutil/wtf/modules/weatherservices/prettyweather"
-	"github.com/wtfutil/wtf/modules/weatherservices/weather"
-	"github.com/wtfutil/wtf/modules/zendesk"
+	"github.com/wtfutil/wtf/pluginrpc"
 	"github.com/wtfutil/wtf/wtf"
+
+	// Each module registers its type when it is loaded
+	_ "github.com/wtfutil/wtf/modules/anki"
+	_ "github.com/wtfutil/wtf/modules/bamboohr"
+	_ "github.com/wtfutil/wtf/modules/bargraph"
+	_ "github.com/wtfutil/wtf/modules/blog"
+	_ "github.com/wtfutil/wtf/modules/buildkite"
+	_ "github.com/wtfutil/wtf/modules/carousel"
+	_ "github.com/wtfutil/wtf/modules/circleci"
+	_ "github.com/wtfutil/wtf/modules/clocks"
+	_ "github.com/wtfutil/wtf/modules/cmdrunner"
+	_ "github.com/wtfutil/wtf/modules/coverage"
+	_ "github.com/wtfutil/wtf/modules/cryptoexchanges/bittrex"
+	_ "github.com/wtfutil/wtf/modules/cryptoexchanges/blockfolio"
+	_ "github.com/wtfutil/wtf/modules/cryptoexchanges/cryptolive"
+	_ "github.com/wtfutil/wtf/modules/datadog"
+	_ "github.com/wtfutil/wtf/modules/drone"
+	_ "github.com/wtfutil/wtf/modules/elasticsearch"
+	_ "github.com/wtfutil/wtf/modules/featureflags"
+	_ "github.com/wtfutil/wtf/modules/feedreader"
+	_ "github.com/wtfutil/wtf/modules/gcal"
+	_ "github.com/wtfutil/wtf/modules/gerrit"
+	_ "github.com/wtfutil/wtf/modules/git"
+	_ "github.com/wtfutil/wtf/modules/github"
+	_ "github.com/wtfutil/wtf/modules/gitlab"
+	_ "github.com/wtfutil/wtf/modules/gitter"
+	_ "github.com/wtfutil/wtf/modules/googleanalytics"
+	_ "github.com/wtfutil/wtf/modules/group"
+	_ "github.com/wtfutil/wtf/modules/gspreadsheets"
+	_ "github.com/wtfutil/wtf/modules/habits"
+	_ "github.com/wtfutil/wtf/modules/hackernews"
+	_ "github.com/wtfutil/wtf/modules/helpdesk"
+	_ "github.com/wtfutil/wtf/modules/hibp"
+	_ "github.com/wtfutil/wtf/modules/holidays"
+	_ "github.com/wtfutil/wtf/modules/ipaddresses/ipapi"
+	_ "github.com/wtfutil/wtf/modules/ipaddresses/ipinfo"
+	_ "github.com/wtfutil/wtf/modules/jenkins"
+	_ "github.com/wtfutil/wtf/modules/jira"
+	_ "github.com/wtfutil/wtf/modules/logger"
+	_ "github.com/wtfutil/wtf/modules/meetings"
+	_ "github.com/wtfutil/wtf/modules/mercurial"
+	_ "github.com/wtfutil/wtf/modules/msgraph"
+	_ "github.com/wtfutil/wtf/modules/nbascore"
+	_ "github.com/wtfutil/wtf/modules/newrelic"
+	_ "github.com/wtfutil/wtf/modules/notifications"
+	_ "github.com/wtfutil/wtf/modules/opsgenie"
+	_ "github.com/wtfutil/wtf/modules/pagerduty"
+	_ "github.com/wtfutil/wtf/modules/phabricator"
+	_ "github.com/wtfutil/wtf/modules/plugin"
+	_ "github.com/wtfutil/wtf/modules/power"
+	_ "github.com/wtfutil/wtf/modules/rabbitmq"
+	_ "github.com/wtfutil/wtf/modules/releases"
+	_ "github.com/wtfutil/wtf/modules/resourceusage"
+	_ "github.com/wtfutil/wtf/modules/rollbar"
+	_ "github.com/wtfutil/wtf/modules/s3"
+	_ "github.com/wtfutil/wtf/modules/scratchpad"
+	_ "github.com/wtfutil/wtf/modules/security"
+	_ "github.com/wtfutil/wtf/modules/sports"
+	_ "github.com/wtfutil/wtf/modules/spotify"
+	_ "github.com/wtfutil/wtf/modules/spotifyweb"
+	_ "github.com/wtfutil/wtf/modules/status"
+	_ "github.com/wtfutil/wtf/modules/summary"
+	_ "github.com/wtfutil/wtf/modules/textfile"
+	_ "github.com/wtfutil/wtf/modules/timetracking"
+	_ "github.com/wtfutil/wtf/modules/todo"
+	_ "github.com/wtfutil/wtf/modules/todoist"
+	_ "github.com/wtfutil/wtf/modules/transmission"
+	_ "github.com/wtfutil/wtf/modules/travisci"
+	_ "github.com/wtfutil/wtf/modules/trello"
+	_ "github.com/wtfutil/wtf/modules/twitter"
+	_ "github.com/wtfutil/wtf/modules/victorops"
+	_ "github.com/wtfutil/wtf/modules/weatherservices/prettyweather"
+	_ "github.com/wtfutil/wtf/modules/weatherservices/weather"
+	_ "github.com/wtfutil/wtf/modules/webstats"
+	_ "github.com/wtfutil/wtf/modules/whatsnew"
+	_ "github.com/wtfutil/wtf/modules/youtube"
+	_ "github.com/wtfutil/wtf/modules/zendesk"
 )
 
+// MakeWidget creates a widget of the given type, from the module types registered by
+// the module packages and, failing that, from the plugins directory. Unknown types get
+// a widget that says so
 func MakeWidget(
 	app *tview.Application,
 	pages *tview.Pages,
@@ -63,168 +99,48 @@ func MakeWidget(
 	moduleConfig *config.Config,
 	globalConfig *config.Config,
 ) wtf.Wtfable {
-	var widget wtf.Wtfable
-
-	// Always in alphabetical order
-	switch widgetType {
-	case "bamboohr":
-		settings := bamboohr.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = bamboohr.NewWidget(app, settings)
-	case "bargraph":
-		settings := bargraph.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = bargraph.NewWidget(app, settings)
-	case "bittrex":
-		settings := bittrex.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = bittrex.NewWidget(app, settings)
-	case "blockfolio":
-		settings := blockfolio.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = blockfolio.NewWidget(app, settings)
-	case "circleci":
-		settings := circleci.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = circleci.NewWidget(app, settings)
-	case "clocks":
-		settings := clocks.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = clocks.NewWidget(app, settings)
-	case "cmdrunner":
-		settings := cmdrunner.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = cmdrunner.NewWidget(app, settings)
-	case "cryptolive":
-		settings := cryptolive.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = cryptolive.NewWidget(app, settings)
-	case "datadog":
-		settings := datadog.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = datadog.NewWidget(app, pages, settings)
-	case "feedreader":
-		settings := feedreader.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = feedreader.NewWidget(app, pages, settings)
-	case "gcal":
-		settings := gcal.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = gcal.NewWidget(app, settings)
-	case "gerrit":
-		settings := gerrit.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = gerrit.NewWidget(app, pages, settings)
-	case "git":
-		settings := git.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = git.NewWidget(app, pages, settings)
-	case "github":
-		settings := github.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = github.NewWidget(app, pages, settings)
-	case "gitlab":
-		settings := gitlab.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = gitlab.NewWidget(app, pages, settings)
-	case "gitter":
-		settings := gitter.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = gitter.NewWidget(app, pages, settings)
-	case "googleanalytics":
-		settings := googleanalytics.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = googleanalytics.NewWidget(app, settings)
-	case "gspreadsheets":
-		settings := gspreadsheets.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = gspreadsheets.NewWidget(app, settings)
-	case "hackernews":
-		settings := hackernews.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = hackernews.NewWidget(app, pages, settings)
-	case "hibp":
-		settings := hibp.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = hibp.NewWidget(app, settings)
-	case "ipapi":
-		settings := ipapi.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = ipapi.NewWidget(app, settings)
-	case "ipinfo":
-		settings := ipinfo.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = ipinfo.NewWidget(app, settings)
-	case "jenkins":
-		settings := jenkins.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = jenkins.NewWidget(app, pages, settings)
-	case "jira":
-		settings := jira.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = jira.NewWidget(app, pages, settings)
-	case "logger":
-		settings := logger.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = logger.NewWidget(app, settings)
-	case "mercurial":
-		settings := mercurial.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = mercurial.NewWidget(app, pages, settings)
-	case "nbascore":
-		settings := nbascore.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = nbascore.NewWidget(app, pages, settings)
-	case "newrelic":
-		settings := newrelic.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = newrelic.NewWidget(app, settings)
-	case "opsgenie":
-		settings := opsgenie.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = opsgenie.NewWidget(app, settings)
-	case "pagerduty":
-		settings := pagerduty.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = pagerduty.NewWidget(app, settings)
-	case "power":
-		settings := power.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = power.NewWidget(app, settings)
-	case "prettyweather":
-		settings := prettyweather.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = prettyweather.NewWidget(app, settings)
-	case "resourceusage":
-		settings := resourceusage.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = resourceusage.NewWidget(app, settings)
-	case "rollbar":
-		settings := rollbar.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = rollbar.NewWidget(app, pages, settings)
-	case "security":
-		settings := security.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = security.NewWidget(app, settings)
-	case "spotify":
-		settings := spotify.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = spotify.NewWidget(app, pages, settings)
-	case "spotifyweb":
-		settings := spotifyweb.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = spotifyweb.NewWidget(app, pages, settings)
-	case "status":
-		settings := status.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = status.NewWidget(app, settings)
-	case "textfile":
-		settings := textfile.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = textfile.NewWidget(app, pages, settings)
-	case "todo":
-		settings := todo.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = todo.NewWidget(app, pages, settings)
-	case "todoist":
-		settings := todoist.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = todoist.NewWidget(app, pages, settings)
-	case "transmission":
-		settings := transmission.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = transmission.NewWidget(app, pages, settings)
-	case "travisci":
-		settings := travisci.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = travisci.NewWidget(app, pages, settings)
-	case "trello":
-		settings := trello.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = trello.NewWidget(app, settings)
-	case "twitter":
-		settings := twitter.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = twitter.NewWidget(app, pages, settings)
-	case "victorops":
-		settings := victorops.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = victorops.NewWidget(app, settings)
-	case "weather":
-		settings := weather.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = weather.NewWidget(app, pages, settings)
-	case "zendesk":
-		settings := zendesk.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = zendesk.NewWidget(app, pages, settings)
-	default:
-		settings := unknown.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
-		widget = unknown.NewWidget(app, settings)
+	if moduleType, ok := wtf.LookupModule(widgetType); ok {
+		if moduleType.MakeContainer != nil {
+			children := makeChildren(app, pages, moduleConfig, globalConfig)
+			return moduleType.MakeContainer(app, pages, widgetName, moduleConfig, globalConfig, children)
+		}
+
+		return moduleType.Make(app, pages, widgetName, moduleConfig, globalConfig)
 	}
 
-	return widget
+	if path := pluginrpc.Path(widgetType, globalConfig); path != "" {
+		settings := rpcplugin.NewSettingsFromYAML(widgetName, path, moduleConfig, globalConfig)
+		return rpcplugin.NewWidget(app, pages, settings)
+	}
+
+	settings := unknown.NewSettingsFromYAML(widgetName, moduleConfig, globalConfig)
+	return unknown.NewWidget(app, settings)
 }
 
+// MakeWidgets creates a widget for each enabled module in the config. A module's type
+// defaults to its name, so any number of modules with different names can share a type,
+// each with its own settings, title, and position. Modules shown inside a container,
+// such as a carousel or a group, are made by their container rather than placed on
+// the grid
 func MakeWidgets(app *tview.Application, pages *tview.Pages, config *config.Config) []wtf.Wtfable {
 	widgets := []wtf.Wtfable{}
 
 	mods, _ := config.Map("wtf.mods")
 
+	// Make the widgets in the same order every time
+	names := make([]string, 0, len(mods))
 	for mod := range mods {
+		names = append(names, mod)
+	}
+	sort.Strings(names)
+
+	contained := containedNames(config, names)
+
+	for _, mod := range names {
+		if contained[mod] {
+			continue
+		}
+
 		modConfig, _ := config.Get("wtf.mods." + mod)
 		widgetType := modConfig.UString("type", mod)
 
@@ -236,3 +152,52 @@ func MakeWidgets(app *tview.Application, pages *tview.Pages, config *config.Conf
 
 	return widgets
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// containedNames returns the names of the modules that are shown inside a container
+func containedNames(config *config.Config, names []string) map[string]bool {
+	contained := map[string]bool{}
+
+	for _, mod := range names {
+		modConfig, err := config.Get("wtf.mods." + mod)
+		if err != nil || !isContainer(modConfig.UString("type", mod)) {
+			continue
+		}
+
+		for _, child := range wtf.ToStrs(modConfig.UList("widgets")) {
+			contained[child] = true
+		}
+	}
+
+	return contained
+}
+
+// isContainer returns TRUE if the module type shows other modules inside itself
+func isContainer(widgetType string) bool {
+	moduleType, ok := wtf.LookupModule(widgetType)
+	return ok && moduleType.MakeContainer != nil
+}
+
+// makeChildren creates the enabled modules listed in a container's widgets setting, in
+// the order they are listed. Containers cannot be nested, so a container listed inside
+// another is left out
+func makeChildren(app *tview.Application, pages *tview.Pages, moduleConfig *config.Config, globalConfig *config.Config) []wtf.Wtfable {
+	children := []wtf.Wtfable{}
+
+	for _, name := range wtf.ToStrs(moduleConfig.UList("widgets")) {
+		childConfig, err := globalConfig.Get("wtf.mods." + name)
+		if err != nil {
+			continue
+		}
+
+		childType := childConfig.UString("type", name)
+		if isContainer(childType) || !childConfig.UBool("enabled", false) {
+			continue
+		}
+
+		children = append(children, MakeWidget(app, pages, name, childType, childConfig, globalConfig))
+	}
+
+	return children
+}
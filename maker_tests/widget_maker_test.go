@@ -0,0 +1,79 @@
+package maker_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/maker"
+	"github.com/wtfutil/wtf/modules/carousel"
+)
+
+func TestMakeWidgetsWithSharedType(t *testing.T) {
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  mods:
+    work:
+      type: clocks
+      enabled: true
+      title: Work
+      position: { top: 0, left: 0, height: 1, width: 1 }
+    home:
+      type: clocks
+      enabled: true
+      title: Home
+      position: { top: 1, left: 0, height: 1, width: 1 }
+    away:
+      type: clocks
+      enabled: false
+`)
+	Nil(t, err)
+
+	widgets := MakeWidgets(tview.NewApplication(), tview.NewPages(), globalConfig)
+
+	Equal(t, 2, len(widgets))
+
+	Equal(t, "home", widgets[0].Name())
+	Equal(t, "Home", widgets[0].CommonSettings().Title)
+	Equal(t, 1, widgets[0].CommonSettings().Top)
+
+	Equal(t, "work", widgets[1].Name())
+	Equal(t, "Work", widgets[1].CommonSettings().Title)
+	Equal(t, 0, widgets[1].CommonSettings().Top)
+
+	Equal(t, "clocks", widgets[1].CommonSettings().Module.Type)
+}
+
+func TestMakeWidgetsWithCarousel(t *testing.T) {
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  mods:
+    rotating:
+      type: carousel
+      enabled: true
+      interval: 0
+      widgets: [work, home, away]
+      position: { top: 0, left: 0, height: 1, width: 1 }
+    work:
+      type: clocks
+      enabled: true
+    home:
+      type: clocks
+      enabled: true
+    away:
+      type: clocks
+      enabled: false
+`)
+	Nil(t, err)
+
+	widgets := MakeWidgets(tview.NewApplication(), tview.NewPages(), globalConfig)
+
+	Equal(t, 1, len(widgets))
+
+	children := widgets[0].(*carousel.Widget).Children()
+
+	Equal(t, 2, len(children))
+	Equal(t, "work", children[0].Name())
+	Equal(t, "home", children[1].Name())
+}
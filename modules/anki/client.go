@@ -0,0 +1,113 @@
+package anki
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// ankiConnectVersion is the version of the AnkiConnect API the requests are written for
+const ankiConnectVersion = 6
+
+// Deck is a deck and how many of its cards are due today: new cards, cards being
+// learned, and cards due for review
+type Deck struct {
+	Learning int    `json:"learn_count"`
+	Name     string `json:"name"`
+	New      int    `json:"new_count"`
+	Review   int    `json:"review_count"`
+}
+
+// Due returns how many of the deck's cards are left to study today
+func (deck Deck) Due() int {
+	return deck.New + deck.Learning + deck.Review
+}
+
+// fetchDecks returns the decks, or the configured ones, with their due counts, in the
+// order Anki lists them
+func (widget *Widget) fetchDecks() ([]Deck, error) {
+	names := widget.settings.decks
+	if len(names) == 0 {
+		if err := widget.invoke("deckNames", nil, &names); err != nil {
+			return nil, err
+		}
+		sort.Strings(names)
+	}
+
+	stats := map[string]Deck{}
+	if err := widget.invoke("getDeckStats", map[string]interface{}{"decks": names}, &stats); err != nil {
+		return nil, err
+	}
+
+	byName := map[string]Deck{}
+	for _, deck := range stats {
+		byName[deck.Name] = deck
+	}
+
+	decks := []Deck{}
+	for _, name := range names {
+		if deck, ok := byName[name]; ok {
+			decks = append(decks, deck)
+		}
+	}
+
+	return decks, nil
+}
+
+// invoke calls the AnkiConnect action with the params and decodes its result
+func (widget *Widget) invoke(action string, params interface{}, result interface{}) error {
+	request := map[string]interface{}{
+		"action":  action,
+		"version": ankiConnectVersion,
+	}
+	if params != nil {
+		request["params"] = params
+	}
+	if widget.settings.apiKey != "" {
+		request["key"] = widget.settings.apiKey
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", widget.settings.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	response := struct {
+		Error  *string         `json:"error"`
+		Result json.RawMessage `json:"result"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return errors.New(*response.Error)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
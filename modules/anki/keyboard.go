@@ -0,0 +1,16 @@
+package anki
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next deck")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous deck")
+	widget.SetKeyboardChar("o", widget.reviewDeck, "Review deck in Anki")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next deck")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous deck")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.reviewDeck, "Review deck in Anki")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,34 @@
+package anki
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Anki"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey string   `help:"The API key AnkiConnect is set up to require, if any." optional:"true"`
+	critAt string   `help:"The time of day, in 24-hour format, from which decks with cards still due are shown in the critical color." optional:"true" default:"20:00"`
+	decks  []string `help:"The decks to show. Without them, every deck is shown." optional:"true"`
+	url    string   `help:"The address AnkiConnect listens on." optional:"true" default:"http://localhost:8765"`
+	warnAt string   `help:"The time of day, in 24-hour format, from which decks with cards still due are shown in the warning color." optional:"true" default:"14:00"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey: ymlConfig.UString("apiKey", ""),
+		critAt: ymlConfig.UString("critAt", "20:00"),
+		decks:  wtf.ToStrs(ymlConfig.UList("decks")),
+		url:    ymlConfig.UString("url", "http://localhost:8765"),
+		warnAt: ymlConfig.UString("warnAt", "14:00"),
+	}
+
+	return &settings
+}
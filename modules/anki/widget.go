@@ -0,0 +1,187 @@
+package anki
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows how many cards are due today in each Anki deck, from AnkiConnect. Decks
+// with cards left are shown in warning colors as the day goes on
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	decks    []Deck
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the due counts of the decks
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	decks, err := widget.fetchDecks()
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.decks = decks
+	widget.SetItemCount(len(decks))
+
+	widget.PublishData("due", widget.totalDue())
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	title := fmt.Sprintf("%s (%d due)", widget.CommonSettings().Title, widget.totalDue())
+
+	widget.Redraw(title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the decks every minute, so that their colors escalate as
+// the day goes on
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.decks) == 0 {
+		return " [gray]No decks[white]"
+	}
+
+	str := ""
+	color := widget.dueColor(time.Now())
+
+	for idx, deck := range widget.decks {
+		// Subdecks are named after their parents, such as "Japanese::Kanji"
+		depth := strings.Count(deck.Name, "::")
+		name := deck.Name
+		if depth > 0 {
+			name = deck.Name[strings.LastIndex(deck.Name, "::")+2:]
+		}
+
+		due := "[gray]done[white]"
+		if deck.Due() > 0 {
+			due = fmt.Sprintf(
+				"[%s]%d due[white] [gray]%d new, %d learning, %d review[white]",
+				color,
+				deck.Due(),
+				deck.New,
+				deck.Learning,
+				deck.Review,
+			)
+		}
+
+		row := fmt.Sprintf(
+			"[%s] %s%s %s",
+			widget.RowColor(idx),
+			strings.Repeat("  ", depth),
+			tview.Escape(name),
+			due,
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(name)+2*depth)
+	}
+
+	return str
+}
+
+// dueColor returns the color of the due counts at the given time of day: the text color
+// until warnAt, then the warning color until critAt, then the critical color
+func (widget *Widget) dueColor(now time.Time) string {
+	colors := widget.CommonSettings().Colors
+
+	switch {
+	case afterTimeOfDay(now, widget.settings.critAt):
+		return colors.Crit
+	case afterTimeOfDay(now, widget.settings.warnAt):
+		return colors.Warn
+	default:
+		return "white"
+	}
+}
+
+// reviewDeck opens the selected deck's review screen in Anki
+func (widget *Widget) reviewDeck() {
+	sel := widget.GetSelected()
+	if sel < 0 || sel >= len(widget.decks) {
+		return
+	}
+
+	if err := widget.invoke("guiDeckReview", map[string]string{"name": widget.decks[sel].Name}, nil); err != nil {
+		logger.For(widget.Name()).Errorf("opening %s for review: %v", widget.decks[sel].Name, err)
+	}
+}
+
+// rowFor describes the deck at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	deck := widget.decks[idx]
+
+	return wtf.Row{
+		ID:   deck.Name,
+		Text: fmt.Sprintf("%s (%d due)", deck.Name, deck.Due()),
+	}
+}
+
+// totalDue adds up the cards due in the top-level decks, whose counts include their
+// subdecks
+func (widget *Widget) totalDue() int {
+	total := 0
+
+	for _, deck := range widget.decks {
+		if !strings.Contains(deck.Name, "::") {
+			total += deck.Due()
+		}
+	}
+
+	return total
+}
+
+// afterTimeOfDay returns true if the time is at or past the time of day, which is in
+// 24-hour "15:04" format. Times of day that can't be read are never reached
+func afterTimeOfDay(now time.Time, timeOfDay string) bool {
+	parsed, err := time.Parse(wtf.TimeFormat, timeOfDay)
+	if err != nil {
+		return false
+	}
+
+	threshold := time.Date(now.Year(), now.Month(), now.Day(), parsed.Hour(), parsed.Minute(), 0, 0, now.Location())
+
+	return !now.Before(threshold)
+}
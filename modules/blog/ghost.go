@@ -0,0 +1,154 @@
+package blog
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ghostPosts struct {
+	Posts []struct {
+		Count struct {
+			Clicks  int `json:"clicks"`
+			Signups int `json:"signups"`
+		} `json:"count"`
+		Email *struct {
+			EmailCount  int `json:"email_count"`
+			OpenedCount int `json:"opened_count"`
+		} `json:"email"`
+		ID          string     `json:"id"`
+		PublishedAt *time.Time `json:"published_at"`
+		Status      string     `json:"status"`
+		Title       string     `json:"title"`
+		UpdatedAt   time.Time  `json:"updated_at"`
+		URL         string     `json:"url"`
+	} `json:"posts"`
+}
+
+// ghostPosts fetches the recently published posts, with their newsletter and member
+// counts, and the scheduled posts and drafts from the Ghost Admin API
+func (widget *Widget) ghostPosts() (posts, error) {
+	p := posts{}
+
+	params := url.Values{}
+	params.Set("filter", "status:published")
+	params.Set("include", "count.clicks,count.signups,email")
+	params.Set("limit", strconv.Itoa(widget.settings.posts))
+	params.Set("order", "published_at desc")
+
+	published, err := widget.fetchGhostPosts(params)
+	if err != nil {
+		return p, err
+	}
+	p.published = published
+
+	params = url.Values{}
+	params.Set("filter", "status:[draft,scheduled]")
+	params.Set("limit", "all")
+	params.Set("order", "updated_at desc")
+
+	pending, err := widget.fetchGhostPosts(params)
+	if err != nil {
+		return p, err
+	}
+
+	for _, post := range pending {
+		if post.Status == statusScheduled {
+			p.scheduled = append(p.scheduled, post)
+		} else {
+			p.drafts = append(p.drafts, post)
+		}
+	}
+
+	return p, nil
+}
+
+func (widget *Widget) fetchGhostPosts(params url.Values) ([]Post, error) {
+	token, err := ghostToken(widget.settings.apiKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", widget.settings.baseURL+"/ghost/api/admin/posts/?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept-Version", "v5.0")
+	req.Header.Set("Authorization", "Ghost "+token)
+
+	result := ghostPosts{}
+	if _, err := getJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	posts := []Post{}
+	for _, gp := range result.Posts {
+		post := Post{
+			Clicks:  gp.Count.Clicks,
+			EditURL: widget.settings.baseURL + "/ghost/#/editor/post/" + gp.ID,
+			ID:      gp.ID,
+			Signups: gp.Count.Signups,
+			Status:  gp.Status,
+			Title:   gp.Title,
+			Updated: gp.UpdatedAt,
+			URL:     gp.URL,
+		}
+
+		if gp.PublishedAt != nil {
+			post.Published = *gp.PublishedAt
+		}
+
+		if gp.Email != nil {
+			post.Opens = gp.Email.OpenedCount
+			post.Sent = gp.Email.EmailCount
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts, nil
+}
+
+// ghostToken signs a short-lived token for the Admin API with the admin API key, which is
+// the key's ID and its hex-encoded secret separated by a colon
+func ghostToken(apiKey string, now time.Time) (string, error) {
+	parts := strings.SplitN(apiKey, ":", 2)
+	if len(parts) != 2 {
+		return "", errors.New("the Ghost Admin API key should be an ID and a secret separated by a colon")
+	}
+
+	secret, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "kid": parts[0], "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": "/admin/",
+		"exp": now.Add(5 * time.Minute).Unix(),
+		"iat": now.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,17 @@
+package blog
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next post")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous post")
+	widget.SetKeyboardChar("o", widget.openPost, "Open post in browser")
+	widget.SetKeyboardChar("e", widget.editPost, "Open post in the editor")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next post")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous post")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openPost, "Open post in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
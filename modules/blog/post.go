@@ -0,0 +1,57 @@
+package blog
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	statusDraft     = "draft"
+	statusPublished = "published"
+	statusScheduled = "scheduled"
+)
+
+// Post is a blog post, with how it has done since it was published. Ghost counts the
+// opens of the newsletter it was sent in, its link clicks, and the members who signed
+// up from it, while WordPress counts its comments
+type Post struct {
+	Comments  int
+	Clicks    int
+	EditURL   string
+	ID        string
+	Opens     int
+	Published time.Time
+	Sent      int
+	Signups   int
+	Status    string
+	Title     string
+	Updated   time.Time
+	URL       string
+}
+
+// posts are the blog's recently published posts and those it has yet to publish
+type posts struct {
+	published []Post
+	scheduled []Post
+	drafts    []Post
+}
+
+// getJSON sends the request and decodes the response, whose headers it returns
+func getJSON(req *http.Request, result interface{}) (http.Header, error) {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, wtf.NewHTTPError(resp)
+	}
+
+	return resp.Header, json.NewDecoder(resp.Body).Decode(result)
+}
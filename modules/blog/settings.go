@@ -0,0 +1,42 @@
+package blog
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Blog"
+
+const (
+	ghost     = "ghost"
+	wordpress = "wordpress"
+)
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey   string `help:"Your Ghost Admin API key, or the WordPress application password of the username." values:"Falls back to WTF_GHOST_TOKEN or WTF_WORDPRESS_TOKEN."`
+	baseURL  string `help:"The address of your blog, such as https://blog.example.com."`
+	posts    int    `help:"How many of the most recently published posts to show." optional:"true" default:"5"`
+	service  string `help:"What the blog runs on." values:"ghost or wordpress" optional:"true" default:"ghost"`
+	username string `help:"The WordPress user whose application password is the apiKey." optional:"true"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+	service := ymlConfig.UString("service", ghost)
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:   ymlConfig.UString("apiKey", os.Getenv("WTF_"+strings.ToUpper(service)+"_TOKEN")),
+		baseURL:  strings.TrimSuffix(ymlConfig.UString("baseURL", ""), "/"),
+		posts:    ymlConfig.UInt("posts", 5),
+		service:  service,
+		username: ymlConfig.UString("username", ""),
+	}
+
+	return &settings
+}
@@ -0,0 +1,208 @@
+package blog
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows how a Ghost or WordPress blog's recent posts have done, and the posts
+// that are scheduled or still drafts
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	blogPosts posts
+	posts     []Post
+	settings  *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	if parsed, err := url.Parse(settings.baseURL); err == nil {
+		widget.SetQuotaHost(parsed.Hostname())
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the recently published posts, the scheduled posts, and the drafts
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	var p posts
+	var err error
+
+	switch widget.settings.service {
+	case ghost:
+		p, err = widget.ghostPosts()
+	case wordpress:
+		p, err = widget.wordPressPosts()
+	default:
+		err = fmt.Errorf("unknown service %q, which should be ghost or wordpress", widget.settings.service)
+	}
+
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.blogPosts = p
+	widget.posts = append(append(append([]Post{}, p.published...), p.scheduled...), p.drafts...)
+	widget.SetItemCount(len(widget.posts))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	title := fmt.Sprintf("%s (%d drafts)", widget.CommonSettings().Title, len(widget.blogPosts.drafts))
+
+	widget.Redraw(title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the posts so that their publish and edit times stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	p := widget.blogPosts
+	offset := 0
+
+	str := " [red]Recent[white]\n"
+	str += widget.postRows(p.published, offset)
+	offset += len(p.published)
+
+	if len(p.scheduled) > 0 {
+		str += "\n [red]Scheduled[white]\n"
+		str += widget.postRows(p.scheduled, offset)
+		offset += len(p.scheduled)
+	}
+
+	str += "\n [red]Drafts[white]\n"
+	str += widget.postRows(p.drafts, offset)
+
+	return str
+}
+
+func (widget *Widget) editPost() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.posts) {
+		utils.OpenURL(widget.posts[sel].EditURL)
+	}
+}
+
+// openPost opens a published post on the blog, and any other post in the editor
+func (widget *Widget) openPost() {
+	sel := widget.GetSelected()
+	if sel < 0 || sel >= len(widget.posts) {
+		return
+	}
+
+	post := widget.posts[sel]
+	if post.Status == statusPublished && post.URL != "" {
+		utils.OpenURL(post.URL)
+	} else {
+		utils.OpenURL(post.EditURL)
+	}
+}
+
+// postRows writes a row for each post, starting at the offset in the widget's posts
+func (widget *Widget) postRows(posts []Post, offset int) string {
+	if len(posts) == 0 {
+		return " [gray]none[white]\n"
+	}
+
+	str := ""
+
+	for i, post := range posts {
+		idx := offset + i
+
+		var detail string
+		switch post.Status {
+		case statusPublished:
+			detail = strings.Join(append([]string{wtf.RelativeTime(post.Published)}, performance(post)...), ", ")
+		case statusScheduled:
+			detail = wtf.RelativeTime(post.Published)
+		default:
+			detail = "edited " + wtf.RelativeTime(post.Updated)
+		}
+
+		row := fmt.Sprintf(
+			"[%s] %s [gray]%s[white]",
+			widget.RowColor(idx),
+			tview.Escape(post.Title),
+			detail,
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(post.Title))
+	}
+
+	return str
+}
+
+// rowFor describes the post at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	post := widget.posts[idx]
+
+	link := post.URL
+	if post.Status != statusPublished || link == "" {
+		link = post.EditURL
+	}
+
+	return wtf.Row{
+		ID:   post.ID,
+		Text: post.Title,
+		URL:  link,
+	}
+}
+
+// performance describes how a published post has done, leaving out what the blog doesn't
+// count
+func performance(post Post) []string {
+	parts := []string{}
+
+	if post.Sent > 0 {
+		parts = append(parts, fmt.Sprintf("%d%% opened", post.Opens*100/post.Sent))
+	}
+	if post.Clicks > 0 {
+		parts = append(parts, fmt.Sprintf("%d clicks", post.Clicks))
+	}
+	if post.Signups > 0 {
+		parts = append(parts, fmt.Sprintf("%d signups", post.Signups))
+	}
+	if post.Comments > 0 {
+		parts = append(parts, fmt.Sprintf("%d comments", post.Comments))
+	}
+
+	return parts
+}
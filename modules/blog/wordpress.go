@@ -0,0 +1,148 @@
+package blog
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/wtfutil/wtf/logger"
+)
+
+// wordPressTimeFormat is how the WP REST API writes its GMT dates, which have no zone
+const wordPressTimeFormat = "2006-01-02T15:04:05"
+
+type wordPressPost struct {
+	DateGMT     string `json:"date_gmt"`
+	ID          int64  `json:"id"`
+	Link        string `json:"link"`
+	ModifiedGMT string `json:"modified_gmt"`
+	Status      string `json:"status"`
+	Title       struct {
+		Rendered string `json:"rendered"`
+	} `json:"title"`
+}
+
+// wordPressPosts fetches the recently published posts, with their comment counts, and the
+// scheduled posts and drafts from the WP REST API
+func (widget *Widget) wordPressPosts() (posts, error) {
+	p := posts{}
+
+	params := url.Values{}
+	params.Set("status", "publish")
+	params.Set("per_page", strconv.Itoa(widget.settings.posts))
+	params.Set("orderby", "date")
+
+	published, err := widget.fetchWordPressPosts(params)
+	if err != nil {
+		return p, err
+	}
+
+	for idx, post := range published {
+		comments, err := widget.wordPressCommentCount(post.ID)
+		if err != nil {
+			logger.For(widget.Name()).Warnf("counting the comments on %q: %v", post.Title, err)
+			continue
+		}
+		published[idx].Comments = comments
+	}
+	p.published = published
+
+	params = url.Values{}
+	params.Set("status", "draft,future")
+	params.Set("per_page", "100")
+	params.Set("orderby", "modified")
+	params.Set("context", "edit")
+
+	pending, err := widget.fetchWordPressPosts(params)
+	if err != nil {
+		return p, err
+	}
+
+	for _, post := range pending {
+		if post.Status == statusScheduled {
+			p.scheduled = append(p.scheduled, post)
+		} else {
+			p.drafts = append(p.drafts, post)
+		}
+	}
+
+	return p, nil
+}
+
+func (widget *Widget) fetchWordPressPosts(params url.Values) ([]Post, error) {
+	req, err := widget.wordPressRequest("/wp/v2/posts", params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []wordPressPost{}
+	if _, err := getJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	posts := []Post{}
+	for _, wp := range result {
+		post := Post{
+			EditURL: fmt.Sprintf("%s/wp-admin/post.php?post=%d&action=edit", widget.settings.baseURL, wp.ID),
+			ID:      strconv.FormatInt(wp.ID, 10),
+			Status:  wordPressStatus(wp.Status),
+			Title:   html.UnescapeString(wp.Title.Rendered),
+			URL:     wp.Link,
+		}
+
+		post.Published, _ = time.Parse(wordPressTimeFormat, wp.DateGMT)
+		post.Updated, _ = time.Parse(wordPressTimeFormat, wp.ModifiedGMT)
+
+		posts = append(posts, post)
+	}
+
+	return posts, nil
+}
+
+// wordPressCommentCount returns how many approved comments the post has, which the API
+// gives as the total of a page of them
+func (widget *Widget) wordPressCommentCount(postID string) (int, error) {
+	params := url.Values{}
+	params.Set("post", postID)
+	params.Set("per_page", "1")
+
+	req, err := widget.wordPressRequest("/wp/v2/comments", params)
+	if err != nil {
+		return 0, err
+	}
+
+	header, err := getJSON(req, &[]interface{}{})
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(header.Get("X-WP-Total"))
+}
+
+func (widget *Widget) wordPressRequest(route string, params url.Values) (*http.Request, error) {
+	req, err := http.NewRequest("GET", widget.settings.baseURL+"/wp-json"+route+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if widget.settings.username != "" {
+		req.SetBasicAuth(widget.settings.username, widget.settings.apiKey)
+	}
+
+	return req, nil
+}
+
+// wordPressStatus names a WordPress post status the way Ghost does
+func wordPressStatus(status string) string {
+	switch status {
+	case "publish":
+		return statusPublished
+	case "future":
+		return statusScheduled
+	default:
+		return statusDraft
+	}
+}
@@ -0,0 +1,101 @@
+package buildkite
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const apiURL = "https://api.buildkite.com/v2"
+
+// Build is a run of a pipeline
+type Build struct {
+	Branch     string     `json:"branch"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Message    string     `json:"message"`
+	Number     int        `json:"number"`
+	StartedAt  *time.Time `json:"started_at"`
+	State      string     `json:"state"`
+	WebURL     string     `json:"web_url"`
+
+	pipeline string
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Duration returns how long the build ran for, or has been running for. Builds that
+// haven't started have no duration
+func (build *Build) Duration(now time.Time) time.Duration {
+	if build.StartedAt == nil {
+		return 0
+	}
+
+	if build.FinishedAt == nil {
+		return now.Sub(*build.StartedAt)
+	}
+
+	return build.FinishedAt.Sub(*build.StartedAt)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// buildsFor returns the pipeline's most recent builds, newest first
+func (widget *Widget) buildsFor(pipeline string) ([]Build, error) {
+	params := url.Values{}
+	params.Set("per_page", strconv.Itoa(widget.settings.builds))
+	if widget.settings.branch != "" {
+		params.Set("branch", widget.settings.branch)
+	}
+
+	path := fmt.Sprintf("/organizations/%s/pipelines/%s/builds?%s", widget.settings.organization, pipeline, params.Encode())
+
+	builds := []Build{}
+	if err := widget.apiRequest("GET", path, &builds); err != nil {
+		return nil, err
+	}
+
+	for idx := range builds {
+		builds[idx].pipeline = pipeline
+	}
+
+	return builds, nil
+}
+
+// rebuild starts a new build of the same commit as the build
+func (widget *Widget) rebuild(build Build) error {
+	path := fmt.Sprintf("/organizations/%s/pipelines/%s/builds/%d/rebuild", widget.settings.organization, build.pipeline, build.Number)
+
+	return widget.apiRequest("PUT", path, nil)
+}
+
+// apiRequest makes a request to the Buildkite API and decodes the JSON response into
+// result, if it's given
+func (widget *Widget) apiRequest(method, path string, result interface{}) error {
+	req, err := http.NewRequest(method, apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+widget.settings.apiKey)
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,18 @@
+package buildkite
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next build")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous build")
+	widget.SetKeyboardChar("o", widget.openBuild, "Open build in browser")
+	widget.SetKeyboardChar("R", widget.rebuildSelected, "Retry the selected build")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next build")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous build")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openBuild, "Open build in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,36 @@
+package buildkite
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Buildkite"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey       string   `help:"Your Buildkite API access token, with the read_builds scope and, to retry builds, write_builds." values:"Falls back to WTF_BUILDKITE_TOKEN."`
+	branch       string   `help:"Only show the builds of this branch." optional:"true"`
+	builds       int      `help:"How many of each pipeline's most recent builds to show." optional:"true" default:"3"`
+	organization string   `help:"The slug of your Buildkite organization."`
+	pipelines    []string `help:"The slugs of the pipelines to show the builds of."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:       ymlConfig.UString("apiKey", os.Getenv("WTF_BUILDKITE_TOKEN")),
+		branch:       ymlConfig.UString("branch", ""),
+		builds:       ymlConfig.UInt("builds", 3),
+		organization: ymlConfig.UString("organization", ""),
+		pipelines:    wtf.ToStrs(ymlConfig.UList("pipelines")),
+	}
+
+	return &settings
+}
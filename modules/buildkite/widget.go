@@ -0,0 +1,199 @@
+package buildkite
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget lists the most recent builds of each of the configured pipelines
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	builds   []Build
+	err      error
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetQuotaHost("api.buildkite.com")
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the recent builds of every pipeline
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	builds := []Build{}
+	for _, pipeline := range widget.settings.pipelines {
+		pipelineBuilds, err := widget.buildsFor(pipeline)
+		if err != nil {
+			widget.RedrawError(widget.CommonSettings().Title, err)
+			return
+		}
+
+		builds = append(builds, pipelineBuilds...)
+	}
+
+	widget.builds = builds
+	widget.SetItemCount(len(builds))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the builds so that the durations of running builds stay
+// current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.settings.pipelines) == 0 {
+		return " [gray]No pipelines configured[white]"
+	}
+
+	now := time.Now()
+	str := ""
+	pipeline := ""
+
+	for idx, build := range widget.builds {
+		if build.pipeline != pipeline {
+			if pipeline != "" {
+				str += "\n"
+			}
+			pipeline = build.pipeline
+			str += fmt.Sprintf(" [red]%s[white]\n", pipeline)
+		}
+
+		row := fmt.Sprintf(
+			"[%s] [%s]%s #%d[%s] %s %s [gray]%s[white]",
+			widget.RowColor(idx),
+			stateColor(build.State),
+			stateIcon(build.State),
+			build.Number,
+			widget.RowColor(idx),
+			tview.Escape(build.Branch),
+			tview.Escape(strings.Split(build.Message, "\n")[0]),
+			formatDuration(build.Duration(now)),
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(build.Branch))
+	}
+
+	return str
+}
+
+func (widget *Widget) openBuild() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.builds) {
+		utils.OpenURL(widget.builds[sel].WebURL)
+	}
+}
+
+// rebuildSelected asks whether to retry the selected build and, if so, starts a new
+// build of the same commit
+func (widget *Widget) rebuildSelected() {
+	sel := widget.GetSelected()
+	if sel < 0 || sel >= len(widget.builds) {
+		return
+	}
+
+	build := widget.builds[sel]
+	question := fmt.Sprintf("Retry %s #%d?", build.pipeline, build.Number)
+
+	widget.Confirm(question, func() {
+		if err := widget.rebuild(build); err != nil {
+			widget.RedrawError(widget.CommonSettings().Title, err)
+			return
+		}
+
+		widget.Refresh()
+	})
+}
+
+// rowFor describes the build at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	build := widget.builds[idx]
+
+	return wtf.Row{
+		ID:   fmt.Sprintf("%s#%d", build.pipeline, build.Number),
+		Text: strings.Split(build.Message, "\n")[0],
+		URL:  build.WebURL,
+	}
+}
+
+// formatDuration writes the duration to the second, such as "4m12s". Builds that
+// haven't started yet have no duration
+func formatDuration(duration time.Duration) string {
+	if duration <= 0 {
+		return ""
+	}
+
+	return duration.Round(time.Second).String()
+}
+
+func stateColor(state string) string {
+	switch state {
+	case "passed":
+		return "green"
+	case "failed", "failing":
+		return "red"
+	case "running", "scheduled", "blocked":
+		return "yellow"
+	case "canceled", "canceling", "skipped", "not_run":
+		return "gray"
+	default:
+		return "white"
+	}
+}
+
+func stateIcon(state string) string {
+	switch state {
+	case "passed":
+		return "✔"
+	case "failed", "failing":
+		return "✘"
+	case "running", "scheduled":
+		return "●"
+	case "blocked":
+		return "⏸"
+	default:
+		return "○"
+	}
+}
@@ -0,0 +1,6 @@
+package carousel
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("[", widget.Prev, "Show the previous widget")
+	widget.SetKeyboardChar("]", widget.Next, "Show the next widget")
+}
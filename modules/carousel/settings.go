@@ -0,0 +1,27 @@
+package carousel
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Carousel"
+
+type Settings struct {
+	common *cfg.Common
+
+	interval int      `help:"How often, in seconds, the carousel moves on to the next widget. The carousel does not move while it has focus." values:"Any positive whole number, or 0 to only move on a key press." optional:"true"`
+	widgets  []string `help:"The names of the modules to cycle through, in the order they are shown. Each is configured in the mods section like any other module, and needs no position of its own."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		interval: ymlConfig.UInt("interval", 10),
+		widgets:  wtf.ToStrs(ymlConfig.UList("widgets")),
+	}
+
+	return &settings
+}
@@ -0,0 +1,196 @@
+package carousel
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Widget shows one of its child widgets at a time in a single grid slot, moving on to
+// the next one on a timer or a key press
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.TextWidget
+
+	app      *tview.Application
+	children []wtf.Wtfable
+	current  int
+	mu       sync.Mutex
+	settings *Settings
+}
+
+// NewWidget creates and returns an instance of Widget that cycles through the given
+// child widgets
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings, children []wtf.Wtfable) *Widget {
+	widget := Widget{
+		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
+		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
+
+		app:      app,
+		children: children,
+		settings: settings,
+	}
+
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	// The child being shown draws its own border and title in place of the carousel's
+	widget.View.SetBorder(false)
+	widget.View.SetDrawFunc(widget.draw)
+
+	if settings.interval > 0 && len(children) > 1 {
+		go widget.rotate(time.Duration(settings.interval) * time.Second)
+	}
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Children returns the widgets the carousel cycles through
+func (widget *Widget) Children() []wtf.Wtfable {
+	return widget.children
+}
+
+// Disable disables the carousel and every one of its children
+func (widget *Widget) Disable() {
+	widget.TextWidget.Disable()
+
+	for _, child := range widget.children {
+		child.Disable()
+	}
+}
+
+// HelpText returns the carousel's own keys followed by those of the child being shown
+func (widget *Widget) HelpText() string {
+	text := widget.KeyboardWidget.HelpText()
+
+	if child := widget.Current(); child != nil {
+		text += "\n" + child.HelpText()
+	}
+
+	return text
+}
+
+// InputCapture handles the carousel's own keys, and passes every other key press on to
+// the child being shown
+func (widget *Widget) InputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if widget.KeyboardWidget.InputCapture(event) == nil {
+		return nil
+	}
+
+	child := widget.Current()
+	if child == nil {
+		return event
+	}
+
+	if handler := child.TextView().InputHandler(); handler != nil {
+		handler(event, func(tview.Primitive) {})
+	}
+
+	return nil
+}
+
+// Current returns the child widget being shown, or nil if the carousel has no children
+func (widget *Widget) Current() wtf.Wtfable {
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	if len(widget.children) == 0 {
+		return nil
+	}
+
+	return widget.children[widget.current]
+}
+
+// Next shows the next child widget, wrapping around to the first after the last
+func (widget *Widget) Next() {
+	widget.step(1)
+}
+
+// Prev shows the previous child widget, wrapping around to the last before the first
+func (widget *Widget) Prev() {
+	widget.step(-1)
+}
+
+// Refresh refreshes every one of the carousel's children, so that each is up to date
+// when its turn comes around
+func (widget *Widget) Refresh() {
+	for _, child := range widget.children {
+		if child.Enabled() {
+			child.Refresh()
+		}
+	}
+}
+
+// SearchCapture passes the key press on to the child being shown if it is searching
+// its rows
+func (widget *Widget) SearchCapture(event *tcell.EventKey) bool {
+	child := widget.Current()
+	if child == nil {
+		return false
+	}
+
+	return wtf.SearchCapture(child, event)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// draw draws the child being shown in the carousel's place, with its focus mirroring
+// the carousel's, and marks which of the children it is in the bottom border
+func (widget *Widget) draw(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+	child := widget.Current()
+	if child == nil {
+		return x, y, width, height
+	}
+
+	view := child.TextView()
+	view.SetRect(x, y, width, height)
+
+	wtf.FocusChild(child, widget.View.HasFocus())
+	view.Draw(screen)
+
+	widget.mu.Lock()
+	marker := fmt.Sprintf(" %d/%d ", widget.current+1, len(widget.children))
+	widget.mu.Unlock()
+
+	tview.Print(screen, marker, x+1, y+height-1, width-2, tview.AlignRight, wtf.ColorFor(widget.CommonSettings().Colors.Title))
+
+	// Nothing of the carousel's own is drawn on top of the child
+	return x, y, 0, 0
+}
+
+// rotate moves on to the next child widget every interval until the carousel is
+// disabled. It holds still while the carousel has focus, so that the child being used
+// does not slide away
+func (widget *Widget) rotate(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if widget.Disabled() {
+			return
+		}
+
+		if !widget.View.HasFocus() {
+			widget.Next()
+		}
+	}
+}
+
+func (widget *Widget) step(delta int) {
+	widget.mu.Lock()
+	count := len(widget.children)
+	if count > 0 {
+		widget.current = (widget.current + delta + count) % count
+	}
+	widget.mu.Unlock()
+
+	widget.app.QueueUpdateDraw(func() {})
+}
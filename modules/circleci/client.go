@@ -3,11 +3,13 @@ package circleci
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 type Client struct {
@@ -54,14 +56,14 @@ func (client *Client) circleRequest(path string) (*http.Response, error) {
 		return nil, err
 	}
 
-	httpClient := &http.Client{}
+	httpClient := wtf.HTTPClient()
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, fmt.Errorf(resp.Status)
+		return nil, wtf.NewHTTPError(resp)
 	}
 
 	return resp, nil
@@ -70,7 +72,8 @@ func (client *Client) circleRequest(path string) (*http.Response, error) {
 func parseJson(obj interface{}, text io.Reader) {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		logger.For("circleci").Errorf("reading the response: %v", err)
+		return
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -79,7 +82,8 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			logger.For("circleci").Errorf("parsing the response: %v", err)
+			return
 		}
 	}
 }
@@ -0,0 +1,17 @@
+package circleci
+
+import (
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.ModuleType{
+		Make: func(app *tview.Application, pages *tview.Pages, name string, moduleConfig, globalConfig *config.Config) wtf.Wtfable {
+			return NewWidget(app, NewSettingsFromYAML(name, moduleConfig, globalConfig))
+		},
+		Name:     "circleci",
+		Settings: Settings{},
+	})
+}
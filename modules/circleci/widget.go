@@ -11,7 +11,8 @@ type Widget struct {
 	wtf.TextWidget
 	*Client
 
-	settings *Settings
+	failedBuilds map[string]bool
+	settings     *Settings
 }
 
 func NewWidget(app *tview.Application, settings *Settings) *Widget {
@@ -35,16 +36,14 @@ func (widget *Widget) Refresh() {
 	builds, err := widget.Client.BuildsFor()
 
 	title := fmt.Sprintf("%s - Builds", widget.CommonSettings().Title)
-	var content string
-	wrap := false
 	if err != nil {
-		wrap = true
-		content = err.Error()
-	} else {
-		content = widget.contentFrom(builds)
+		widget.RedrawError(title, err)
+		return
 	}
 
-	widget.Redraw(title, content, wrap)
+	widget.notifyFailures(builds)
+
+	widget.Redraw(title, widget.contentFrom(builds), false)
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -69,6 +68,32 @@ func (widget *Widget) contentFrom(builds []*Build) string {
 	return str
 }
 
+// notifyFailures sends a notification for each build that has failed since the last
+// refresh, and publishes how many builds are failing. Builds that had already failed when
+// the widget first loaded are not notified
+func (widget *Widget) notifyFailures(builds []*Build) {
+	failedBuilds := make(map[string]bool)
+
+	for _, build := range builds {
+		if build.Status != "failed" {
+			continue
+		}
+
+		key := fmt.Sprintf("%s-%d", build.Reponame, build.BuildNum)
+		failedBuilds[key] = true
+
+		if widget.failedBuilds != nil && !widget.failedBuilds[key] {
+			widget.Notify(
+				fmt.Sprintf("%s build failed", build.Reponame),
+				fmt.Sprintf("%s #%d on %s by %s", build.Reponame, build.BuildNum, build.Branch, build.AuthorName),
+			)
+		}
+	}
+
+	widget.failedBuilds = failedBuilds
+	widget.PublishData("failed", len(failedBuilds))
+}
+
 func buildColor(build *Build) string {
 	switch build.Status {
 	case "failed":
@@ -2,6 +2,8 @@ package clocks
 
 import (
 	"time"
+
+	"github.com/wtfutil/wtf/i18n"
 )
 
 type Clock struct {
@@ -19,7 +21,7 @@ func NewClock(label string, timeLoc *time.Location) Clock {
 }
 
 func (clock *Clock) Date(dateFormat string) string {
-	return clock.LocalTime().Format(dateFormat)
+	return i18n.FormatDate(clock.LocalTime(), dateFormat)
 }
 
 func (clock *Clock) LocalTime() time.Time {
@@ -31,5 +33,5 @@ func (clock *Clock) ToLocal(t time.Time) time.Time {
 }
 
 func (clock *Clock) Time(timeFormat string) string {
-	return clock.LocalTime().Format(timeFormat)
+	return i18n.FormatDate(clock.LocalTime(), timeFormat)
 }
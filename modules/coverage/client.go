@@ -0,0 +1,148 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const codecovAPIURL = "https://api.codecov.io/api/v2"
+const coverallsURL = "https://coveralls.io"
+
+// codecovHosts are how Codecov's web pages abbreviate the providers
+var codecovHosts = map[string]string{
+	"bitbucket": "bb",
+	"github":    "gh",
+	"gitlab":    "gl",
+}
+
+// Project is the latest coverage of a repository, and how it changed from the build
+// before
+type Project struct {
+	Coverage float64
+	Delta    float64
+	HasDelta bool
+	Name     string
+	URL      string
+}
+
+type codecovCommits struct {
+	Results []struct {
+		Totals *struct {
+			Coverage float64 `json:"coverage"`
+		} `json:"totals"`
+	} `json:"results"`
+}
+
+type coverallsRepo struct {
+	CoverageChange *float64 `json:"coverage_change"`
+	CoveredPercent float64  `json:"covered_percent"`
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// codecovProject compares the coverage of the project's two most recent commits that
+// have a coverage report. Commits whose reports haven't been processed have no totals
+func (widget *Widget) codecovProject(name string) (Project, error) {
+	project := Project{
+		Name: name,
+		URL:  fmt.Sprintf("https://app.codecov.io/%s/%s", codecovHosts[widget.settings.provider], name),
+	}
+
+	owner, repo := splitName(name)
+
+	params := url.Values{}
+	params.Set("page_size", "10")
+	if widget.settings.branch != "" {
+		params.Set("branch", widget.settings.branch)
+	}
+
+	path := fmt.Sprintf("%s/%s/%s/repos/%s/commits/?%s", codecovAPIURL, widget.settings.provider, owner, repo, params.Encode())
+
+	commits := codecovCommits{}
+	if err := widget.getJSON(path, widget.settings.apiKey, &commits); err != nil {
+		return project, err
+	}
+
+	coverages := []float64{}
+	for _, commit := range commits.Results {
+		if commit.Totals != nil {
+			coverages = append(coverages, commit.Totals.Coverage)
+		}
+	}
+
+	if len(coverages) == 0 {
+		return project, fmt.Errorf("%s has no coverage reports", name)
+	}
+
+	project.Coverage = coverages[0]
+	if len(coverages) > 1 {
+		project.Delta = coverages[0] - coverages[1]
+		project.HasDelta = true
+	}
+
+	return project, nil
+}
+
+// coverallsProject returns the project's coverage as of its latest build on Coveralls
+func (widget *Widget) coverallsProject(name string) (Project, error) {
+	project := Project{
+		Name: name,
+		URL:  fmt.Sprintf("%s/%s/%s", coverallsURL, widget.settings.provider, name),
+	}
+
+	path := project.URL + ".json"
+	if widget.settings.branch != "" {
+		path += "?" + url.Values{"branch": []string{widget.settings.branch}}.Encode()
+	}
+
+	repo := coverallsRepo{}
+	if err := widget.getJSON(path, "", &repo); err != nil {
+		return project, err
+	}
+
+	project.Coverage = repo.CoveredPercent
+	if repo.CoverageChange != nil {
+		project.Delta = *repo.CoverageChange
+		project.HasDelta = true
+	}
+
+	return project, nil
+}
+
+func (widget *Widget) getJSON(path, token string, result interface{}) error {
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// splitName splits "owner/repo" into its owner and repository
+func splitName(name string) (string, string) {
+	idx := strings.LastIndex(name, "/")
+	if idx < 0 {
+		return "", name
+	}
+
+	return name[:idx], name[idx+1:]
+}
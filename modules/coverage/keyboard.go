@@ -0,0 +1,17 @@
+package coverage
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next project")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous project")
+	widget.SetKeyboardChar("o", widget.openProject, "Open project in browser")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next project")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous project")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openProject, "Open project in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,36 @@
+package coverage
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Coverage"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey   string   `help:"Your Codecov API token, which private repositories need." optional:"true" values:"Falls back to WTF_CODECOV_TOKEN."`
+	branch   string   `help:"Show the coverage of this branch rather than of each repository's default branch." optional:"true"`
+	projects []string `help:"The repositories to show the coverage of, such as wtfutil/wtf."`
+	provider string   `help:"Where the repositories are hosted." values:"github, gitlab, or bitbucket" optional:"true" default:"github"`
+	service  string   `help:"Where the coverage reports are uploaded to." values:"codecov or coveralls" optional:"true" default:"codecov"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:   ymlConfig.UString("apiKey", os.Getenv("WTF_CODECOV_TOKEN")),
+		branch:   ymlConfig.UString("branch", ""),
+		projects: wtf.ToStrs(ymlConfig.UList("projects")),
+		provider: ymlConfig.UString("provider", "github"),
+		service:  ymlConfig.UString("service", "codecov"),
+	}
+
+	return &settings
+}
@@ -0,0 +1,160 @@
+package coverage
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows the latest code coverage of each project, from Codecov or Coveralls,
+// and whether it went up or down
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	projects []Project
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	if settings.service == "coveralls" {
+		widget.SetQuotaHost("coveralls.io")
+	} else {
+		widget.SetQuotaHost("api.codecov.io")
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the coverage of every project. Projects whose coverage can't be
+// fetched are left out
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	projects := []Project{}
+	var lastErr error
+
+	for _, name := range widget.settings.projects {
+		project, err := widget.fetchProject(name)
+		if err != nil {
+			logger.For(widget.Name()).Warnf("fetching the coverage of %s: %v", name, err)
+			lastErr = err
+			continue
+		}
+
+		projects = append(projects, project)
+	}
+
+	if len(projects) == 0 && lastErr != nil {
+		widget.RedrawError(widget.CommonSettings().Title, lastErr)
+		return
+	}
+
+	widget.projects = projects
+	widget.SetItemCount(len(projects))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.projects) == 0 {
+		return " [gray]No projects[white]"
+	}
+
+	nameWidth := 0
+	for _, project := range widget.projects {
+		if len(project.Name) > nameWidth {
+			nameWidth = len(project.Name)
+		}
+	}
+
+	str := ""
+	for idx, project := range widget.projects {
+		row := fmt.Sprintf(
+			"[%s] %-*s %6.2f%% %s",
+			widget.RowColor(idx),
+			nameWidth,
+			project.Name,
+			project.Coverage,
+			trend(project),
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, nameWidth)
+	}
+
+	return str
+}
+
+func (widget *Widget) fetchProject(name string) (Project, error) {
+	if widget.settings.service == "coveralls" {
+		return widget.coverallsProject(name)
+	}
+
+	return widget.codecovProject(name)
+}
+
+func (widget *Widget) openProject() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.projects) {
+		utils.OpenURL(widget.projects[sel].URL)
+	}
+}
+
+// rowFor describes the project at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	project := widget.projects[idx]
+
+	return wtf.Row{
+		ID:   project.Name,
+		Text: fmt.Sprintf("%.2f%%", project.Coverage),
+		URL:  project.URL,
+	}
+}
+
+// trend shows how the coverage changed: a green arrow up when it rose, a red arrow down
+// when it fell, and nothing when there's no earlier build to compare with
+func trend(project Project) string {
+	switch {
+	case !project.HasDelta:
+		return ""
+	case project.Delta >= 0.005:
+		return fmt.Sprintf("[green]▲ +%.2f[white]", project.Delta)
+	case project.Delta <= -0.005:
+		return fmt.Sprintf("[red]▼ %.2f[white]", project.Delta)
+	default:
+		return "[gray]= 0.00[white]"
+	}
+}
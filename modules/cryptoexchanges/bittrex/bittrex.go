@@ -1,5 +1,10 @@
 package bittrex
 
+import "github.com/wtfutil/wtf/charts"
+
+// trendLength is the number of recent prices drawn in a market's trend sparkline
+const trendLength = 30
+
 type summaryList struct {
 	items []*bCurrency
 }
@@ -13,7 +18,9 @@ type bCurrency struct {
 
 // Market Currency
 type mCurrency struct {
-	name string
+	name    string
+	history *charts.History
+	series  string
 	summaryInfo
 }
 
@@ -24,6 +31,7 @@ type summaryInfo struct {
 	Last           string
 	OpenSellOrders string
 	OpenBuyOrders  string
+	Trend          string
 }
 
 type summaryResponse struct {
@@ -7,8 +7,8 @@ import (
 )
 
 func (widget *Widget) display() {
-	if ok == false {
-		widget.Redraw(widget.CommonSettings().Title, errorText, true)
+	if !widget.ok {
+		widget.Redraw(widget.CommonSettings().Title, widget.errorText, true)
 		return
 	}
 
@@ -38,6 +38,7 @@ func (widget *Widget) summaryText(list *summaryList) string {
 					formatableText("High", "High") +
 					formatableText("Low", "Low") +
 					formatableText("Last", "Last") +
+					formatableText("Trend", "Trend") +
 					formatableText("Volume", "Volume") +
 					"\n" +
 					formatableText("Open Buy", "OpenBuyOrders") +
@@ -52,6 +53,7 @@ func (widget *Widget) summaryText(list *summaryList) string {
 				"High":           marketCurrency.High,
 				"Low":            marketCurrency.Low,
 				"Last":           marketCurrency.Last,
+				"Trend":          marketCurrency.Trend,
 				"Volume":         marketCurrency.Volume,
 				"OpenBuyOrders":  marketCurrency.OpenBuyOrders,
 				"OpenSellOrders": marketCurrency.OpenSellOrders,
@@ -3,24 +3,22 @@ package bittrex
 import (
 	"encoding/json"
 	"fmt"
-	"time"
-
 	"net/http"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/charts"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-var ok = true
-var errorText = ""
-
 const baseURL = "https://bittrex.com/api/v1.1/public/getmarketsummary"
 
 // Widget define wtf widget to register widget later
 type Widget struct {
 	wtf.TextWidget
 
-	settings *Settings
+	errorText string
+	ok        bool
+	settings  *Settings
 	summaryList
 }
 
@@ -29,13 +27,11 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 	widget := Widget{
 		TextWidget: wtf.NewTextWidget(app, settings.common, false),
 
+		ok:          true,
 		settings:    settings,
 		summaryList: summaryList{},
 	}
 
-	ok = true
-	errorText = ""
-
 	widget.setSummaryList()
 
 	return &widget
@@ -52,15 +48,26 @@ func (widget *Widget) makeSummaryMarketList(currencySymbol string, market []inte
 	mCurrencyList := []*mCurrency{}
 
 	for _, marketSymbol := range market {
-		mCurrencyList = append(mCurrencyList, makeMarketCurrency(marketSymbol.(string)))
+		mCurrencyList = append(mCurrencyList, makeMarketCurrency(currencySymbol, marketSymbol.(string)))
 	}
 
 	return mCurrencyList
 }
 
-func makeMarketCurrency(name string) *mCurrency {
+// makeMarketCurrency creates a market, with the prices recorded for it in earlier runs
+// already in its history
+func makeMarketCurrency(currencySymbol, name string) *mCurrency {
+	series := fmt.Sprintf("bittrex.%s-%s", currencySymbol, name)
+
+	history := charts.NewHistory(trendLength)
+	for _, price := range wtf.Retention.Values(series) {
+		history.Add(price)
+	}
+
 	return &mCurrency{
-		name: name,
+		name:    name,
+		history: history,
+		series:  series,
 		summaryInfo: summaryInfo{
 			High:           "",
 			Low:            "",
@@ -68,6 +75,7 @@ func makeMarketCurrency(name string) *mCurrency {
 			Last:           "",
 			OpenBuyOrders:  "",
 			OpenSellOrders: "",
+			Trend:          charts.Sparkline(history.Values(), trendLength),
 		},
 	}
 }
@@ -89,31 +97,27 @@ func (widget *Widget) updateSummary() {
 		recover()
 	}()
 
-	client := &http.Client{
-		Timeout: time.Duration(5 * time.Second),
-	}
-
 	for _, baseCurrency := range widget.summaryList.items {
 		for _, mCurrency := range baseCurrency.markets {
 			request := makeRequest(baseCurrency.name, mCurrency.name)
-			response, err := client.Do(request)
+			response, err := wtf.HTTPClient().Do(request)
 
 			if err != nil {
-				ok = false
-				errorText = "Please Check Your Internet Connection!"
+				widget.ok = false
+				widget.errorText = "Please Check Your Internet Connection!"
 				break
 			} else {
-				ok = true
-				errorText = ""
+				widget.ok = true
+				widget.errorText = ""
 			}
 
 			if response.StatusCode != http.StatusOK {
-				errorText = response.Status
-				ok = false
+				widget.errorText = response.Status
+				widget.ok = false
 				break
 			} else {
-				ok = true
-				errorText = ""
+				widget.ok = true
+				widget.errorText = ""
 			}
 
 			defer response.Body.Close()
@@ -122,12 +126,12 @@ func (widget *Widget) updateSummary() {
 			decoder.Decode(&jsonResponse)
 
 			if !jsonResponse.Success {
-				ok = false
-				errorText = fmt.Sprintf("%s-%s: %s", baseCurrency.name, mCurrency.name, jsonResponse.Message)
+				widget.ok = false
+				widget.errorText = fmt.Sprintf("%s-%s: %s", baseCurrency.name, mCurrency.name, jsonResponse.Message)
 				break
 			}
-			ok = true
-			errorText = ""
+			widget.ok = true
+			widget.errorText = ""
 
 			mCurrency.Last = fmt.Sprintf("%f", jsonResponse.Result[0].Last)
 			mCurrency.High = fmt.Sprintf("%f", jsonResponse.Result[0].High)
@@ -135,6 +139,10 @@ func (widget *Widget) updateSummary() {
 			mCurrency.Volume = fmt.Sprintf("%f", jsonResponse.Result[0].Volume)
 			mCurrency.OpenBuyOrders = fmt.Sprintf("%d", jsonResponse.Result[0].OpenBuyOrders)
 			mCurrency.OpenSellOrders = fmt.Sprintf("%d", jsonResponse.Result[0].OpenSellOrders)
+
+			mCurrency.history.Add(jsonResponse.Result[0].Last)
+			wtf.Retention.Record(mCurrency.series, jsonResponse.Result[0].Last)
+			mCurrency.Trend = charts.Sparkline(mCurrency.history.Values(), trendLength)
 		}
 	}
 
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 
 	"github.com/rivo/tview"
@@ -34,7 +33,7 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 func (widget *Widget) Refresh() {
 	positions, err := Fetch(widget.device_token)
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 
@@ -105,7 +104,7 @@ type AllPositionsResponse struct {
 }
 
 func MakeApiRequest(token string, method string) ([]byte, error) {
-	client := &http.Client{}
+	client := wtf.HTTPClient()
 	url := "https://api-v0.blockfolio.com/rest/" + method + "/" + token + "?use_alias=true&fiat_currency=USD"
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -130,8 +129,7 @@ func GetAllPositions(token string) (*AllPositionsResponse, error) {
 
 	err := json.Unmarshal(jsn, &parsed)
 	if err != nil {
-		log.Fatalf("Failed to parse json %v", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to parse json: %v", err)
 	}
 	return &parsed, err
 }
@@ -5,15 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
-	"time"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
 var baseURL = "https://min-api.cryptocompare.com/data/price"
-var ok = true
 
 // Widget define wtf widget to register widget later
 type Widget struct {
 	*list
+	ok       bool
 	settings *Settings
 
 	Result string
@@ -24,6 +25,7 @@ type Widget struct {
 // NewWidget Make new instance of widget
 func NewWidget(settings *Settings) *Widget {
 	widget := Widget{
+		ok:       true,
 		settings: settings,
 	}
 
@@ -47,7 +49,7 @@ func (widget *Widget) setList() {
 func (widget *Widget) Refresh(wg *sync.WaitGroup) {
 	if len(widget.list.items) != 0 {
 		widget.updateCurrencies()
-		if !ok {
+		if !widget.ok {
 			widget.Result = fmt.Sprint("Please check your internet connection!")
 		} else {
 			widget.display()
@@ -103,24 +105,18 @@ func (widget *Widget) updateCurrencies() {
 	}()
 	for _, fromCurrency := range widget.list.items {
 
-		var (
-			client       http.Client
-			jsonResponse cResponse
-		)
-
-		client = http.Client{
-			Timeout: time.Duration(5 * time.Second),
-		}
+		var jsonResponse cResponse
 
 		request := makeRequest(fromCurrency)
-		response, err := client.Do(request)
+		response, err := wtf.HTTPClient().Do(request)
 
 		if err != nil {
-			ok = false
-		} else {
-			ok = true
+			widget.ok = false
+			continue
 		}
 
+		widget.ok = true
+
 		defer response.Body.Close()
 
 		_ = json.NewDecoder(response.Body).Decode(&jsonResponse)
@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 	"sync"
-	"time"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
 var baseURL = "https://min-api.cryptocompare.com/data/top/exchanges"
@@ -71,22 +71,22 @@ func (widget *Widget) updateData() {
 		recover()
 	}()
 
-	client := &http.Client{
-		Timeout: time.Duration(5 * time.Second),
-	}
-
 	for _, fromCurrency := range widget.list.items {
 		for _, toCurrency := range fromCurrency.to {
 
 			request := makeRequest(fromCurrency.name, toCurrency.name, fromCurrency.limit)
-			response, _ := client.Do(request)
+			response, err := wtf.HTTPClient().Do(request)
+			if err != nil {
+				continue
+			}
 
 			var jsonResponse responseInterface
 
-			err := json.NewDecoder(response.Body).Decode(&jsonResponse)
+			err = json.NewDecoder(response.Body).Decode(&jsonResponse)
+			response.Body.Close()
 
 			if err != nil {
-				os.Exit(1)
+				continue
 			}
 
 			for idx, info := range jsonResponse.Data {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 	datadog "github.com/zorkian/go-datadog-api"
 )
@@ -12,6 +13,7 @@ type Widget struct {
 	wtf.KeyboardWidget
 	wtf.ScrollableWidget
 
+	alerting map[int]bool
 	monitors []datadog.Monitor
 	settings *Settings
 }
@@ -41,7 +43,7 @@ func (widget *Widget) Refresh() {
 	if monitorErr != nil {
 		widget.monitors = nil
 		widget.SetItemCount(0)
-		widget.Redraw(widget.CommonSettings().Title, monitorErr.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, monitorErr)
 		return
 	}
 	triggeredMonitors := []datadog.Monitor{}
@@ -52,6 +54,8 @@ func (widget *Widget) Refresh() {
 			triggeredMonitors = append(triggeredMonitors, monitor)
 		}
 	}
+	widget.notifyAlerting(triggeredMonitors)
+
 	widget.monitors = triggeredMonitors
 	widget.SetItemCount(len(widget.monitors))
 
@@ -95,11 +99,27 @@ func (widget *Widget) contentFrom(triggeredMonitors []datadog.Monitor) string {
 	return str
 }
 
+// notifyAlerting sends a notification for each monitor that has started alerting since
+// the last refresh
+func (widget *Widget) notifyAlerting(triggeredMonitors []datadog.Monitor) {
+	alerting := make(map[int]bool)
+
+	for _, monitor := range triggeredMonitors {
+		alerting[monitor.GetId()] = true
+
+		if widget.alerting != nil && !widget.alerting[monitor.GetId()] {
+			widget.Notify("Monitor alerting", monitor.GetName())
+		}
+	}
+
+	widget.alerting = alerting
+}
+
 func (widget *Widget) openItem() {
 
 	sel := widget.GetSelected()
 	if sel >= 0 && widget.monitors != nil && sel < len(widget.monitors) {
 		item := &widget.monitors[sel]
-		wtf.OpenFile(fmt.Sprintf("https://app.datadoghq.com/monitors/%d?q=*", *item.Id))
+		utils.OpenURL(fmt.Sprintf("https://app.datadoghq.com/monitors/%d?q=*", *item.Id))
 	}
 }
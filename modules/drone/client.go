@@ -0,0 +1,76 @@
+package drone
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Build is a run of a repository's pipelines
+type Build struct {
+	Author   string `json:"author_login"`
+	Finished int64  `json:"finished"`
+	Message  string `json:"message"`
+	Number   int    `json:"number"`
+	Started  int64  `json:"started"`
+	Status   string `json:"status"`
+	Target   string `json:"target"`
+
+	repo string
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// FinishedAt returns when the build finished, or the zero time if it hasn't
+func (build *Build) FinishedAt() time.Time {
+	if build.Finished == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(build.Finished, 0)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// buildURL returns the address of the build's page on the Drone server
+func (widget *Widget) buildURL(build Build) string {
+	return fmt.Sprintf("%s/%s/%d", widget.settings.baseURL, build.repo, build.Number)
+}
+
+// latestBuild returns the repository's most recent build, of the configured branch if
+// there is one
+func (widget *Widget) latestBuild(repo string) (Build, error) {
+	build := Build{}
+
+	path := fmt.Sprintf("%s/api/repos/%s/builds/latest", widget.settings.baseURL, repo)
+	if widget.settings.branch != "" {
+		path += "?" + url.Values{"branch": []string{widget.settings.branch}}.Encode()
+	}
+
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return build, err
+	}
+	req.Header.Set("Authorization", "Bearer "+widget.settings.apiKey)
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return build, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return build, wtf.NewHTTPError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&build); err != nil {
+		return build, err
+	}
+	build.repo = repo
+
+	return build, nil
+}
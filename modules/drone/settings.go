@@ -0,0 +1,35 @@
+package drone
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Drone"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey       string   `help:"Your Drone personal token, from your account page on the Drone server." values:"Falls back to WTF_DRONE_TOKEN."`
+	baseURL      string   `help:"The address of your Drone server, such as https://drone.example.com."`
+	branch       string   `help:"Show the latest build of this branch, rather than of any branch." optional:"true"`
+	repositories []string `help:"The repositories to show the latest build of, such as wtfutil/wtf."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:       ymlConfig.UString("apiKey", os.Getenv("WTF_DRONE_TOKEN")),
+		baseURL:      strings.TrimSuffix(ymlConfig.UString("baseURL", ""), "/"),
+		branch:       ymlConfig.UString("branch", ""),
+		repositories: wtf.ToStrs(ymlConfig.UList("repositories")),
+	}
+
+	return &settings
+}
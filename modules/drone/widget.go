@@ -0,0 +1,167 @@
+package drone
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows the latest build of each of the configured repositories on a Drone
+// server
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	builds   []Build
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	if parsed, err := url.Parse(settings.baseURL); err == nil {
+		widget.SetQuotaHost(parsed.Hostname())
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the latest build of every repository. Repositories whose build can't
+// be fetched, such as those that have never been built, are left out
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	builds := []Build{}
+	var lastErr error
+
+	for _, repo := range widget.settings.repositories {
+		build, err := widget.latestBuild(repo)
+		if err != nil {
+			logger.For(widget.Name()).Warnf("fetching the latest build of %s: %v", repo, err)
+			lastErr = err
+			continue
+		}
+
+		builds = append(builds, build)
+	}
+
+	if len(builds) == 0 && lastErr != nil {
+		widget.RedrawError(widget.CommonSettings().Title, lastErr)
+		return
+	}
+
+	widget.builds = builds
+	widget.SetItemCount(len(builds))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the builds so that their finish times stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.builds) == 0 {
+		return " [gray]No builds[white]"
+	}
+
+	str := ""
+	for idx, build := range widget.builds {
+		row := fmt.Sprintf(
+			"[%s] [%s]%s[%s] %s #%d (%s) %s [gray]%s[white]",
+			widget.RowColor(idx),
+			statusColor(build.Status),
+			statusIcon(build.Status),
+			widget.RowColor(idx),
+			build.repo,
+			build.Number,
+			tview.Escape(build.Target),
+			tview.Escape(strings.Split(build.Message, "\n")[0]),
+			wtf.RelativeTime(build.FinishedAt()),
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(build.repo))
+	}
+
+	return str
+}
+
+func (widget *Widget) openBuild() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.builds) {
+		utils.OpenURL(widget.buildURL(widget.builds[sel]))
+	}
+}
+
+// rowFor describes the build at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	build := widget.builds[idx]
+
+	return wtf.Row{
+		ID:   fmt.Sprintf("%s#%d", build.repo, build.Number),
+		Text: strings.Split(build.Message, "\n")[0],
+		URL:  widget.buildURL(build),
+	}
+}
+
+func statusColor(status string) string {
+	switch status {
+	case "success":
+		return "green"
+	case "failure", "error", "killed":
+		return "red"
+	case "pending", "running", "blocked":
+		return "yellow"
+	default:
+		return "gray"
+	}
+}
+
+func statusIcon(status string) string {
+	switch status {
+	case "success":
+		return "✔"
+	case "failure", "error", "killed":
+		return "✘"
+	case "pending", "running":
+		return "●"
+	case "blocked":
+		return "⏸"
+	default:
+		return "○"
+	}
+}
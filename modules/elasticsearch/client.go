@@ -0,0 +1,82 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type Client struct {
+	password                string
+	url                     string
+	username                string
+	verifyServerCertificate bool
+}
+
+func NewClient(settings *Settings) *Client {
+	client := Client{
+		password:                settings.password,
+		url:                     strings.TrimRight(settings.url, "/"),
+		username:                settings.username,
+		verifyServerCertificate: settings.verifyServerCertificate,
+	}
+
+	return &client
+}
+
+// Cluster fetches the cluster health and disk allocation data, and returns the nodes that
+// are at or above the given disk watermark
+func (client *Client) Cluster(watermark int) (*Cluster, error) {
+	cluster := &Cluster{}
+
+	err := client.getJSON("/_cluster/health", &cluster.Health)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := []Allocation{}
+	err = client.getJSON("/_cat/allocation?format=json", &allocations)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := []Shard{}
+	err = client.getJSON("/_cat/shards?format=json&h=index,node", &shards)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.HotNodes = hotNodesFrom(allocations, shards, watermark)
+
+	return cluster, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (client *Client) getJSON(path string, obj interface{}) error {
+	req, err := http.NewRequest("GET", client.url+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Accept", "application/json")
+	if client.username != "" {
+		req.SetBasicAuth(client.username, client.password)
+	}
+
+	httpClient := wtf.HTTPClientVerifying(client.verifyServerCertificate)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(obj)
+}
@@ -0,0 +1,76 @@
+package elasticsearch
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ClusterHealth is the response from the _cluster/health endpoint
+type ClusterHealth struct {
+	ClusterName      string `json:"cluster_name"`
+	NumberOfNodes    int    `json:"number_of_nodes"`
+	Status           string `json:"status"`
+	UnassignedShards int    `json:"unassigned_shards"`
+}
+
+// Allocation is a single node entry from the _cat/allocation endpoint
+type Allocation struct {
+	DiskPercent string `json:"disk.percent"`
+	Node        string `json:"node"`
+}
+
+// Shard is a single shard entry from the _cat/shards endpoint
+type Shard struct {
+	Index string `json:"index"`
+	Node  string `json:"node"`
+}
+
+// HotNode is a node whose disk usage has reached the configured watermark
+type HotNode struct {
+	DiskPercent int
+	Indices     []string
+	Name        string
+}
+
+// Cluster aggregates everything the widget displays about a cluster
+type Cluster struct {
+	Health   ClusterHealth
+	HotNodes []HotNode
+}
+
+// hotNodesFrom returns the nodes at or above the disk watermark, along with the names of
+// the indices that have shards on those nodes
+func hotNodesFrom(allocations []Allocation, shards []Shard, watermark int) []HotNode {
+	hotNodes := []HotNode{}
+
+	for _, allocation := range allocations {
+		percent, err := strconv.Atoi(allocation.DiskPercent)
+		if err != nil || percent < watermark {
+			continue
+		}
+
+		seen := map[string]bool{}
+		indices := []string{}
+
+		for _, shard := range shards {
+			if shard.Node == allocation.Node && !seen[shard.Index] {
+				seen[shard.Index] = true
+				indices = append(indices, shard.Index)
+			}
+		}
+
+		sort.Strings(indices)
+
+		hotNodes = append(hotNodes, HotNode{
+			DiskPercent: percent,
+			Indices:     indices,
+			Name:        allocation.Node,
+		})
+	}
+
+	sort.SliceStable(hotNodes, func(i, j int) bool {
+		return hotNodes[i].DiskPercent > hotNodes[j].DiskPercent
+	})
+
+	return hotNodes
+}
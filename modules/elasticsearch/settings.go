@@ -0,0 +1,35 @@
+package elasticsearch
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Elasticsearch"
+
+type Settings struct {
+	common *cfg.Common
+
+	diskWatermark           int    `help:"Nodes whose disk usage percentage is at or above this value are listed, along with the indices stored on them." values:"A percentage, 0..100." optional:"true"`
+	password                string `help:"The password for the cluster user." optional:"true"`
+	url                     string `help:"The URL of your Elasticsearch or OpenSearch cluster." values:"Example: http://localhost:9200" optional:"true"`
+	username                string `help:"The cluster user." optional:"true"`
+	verifyServerCertificate bool   `help:"Determines whether or not the server’s certificate chain and host name are verified." values:"true or false" optional:"true"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		diskWatermark:           ymlConfig.UInt("diskWatermark", 85),
+		password:                ymlConfig.UString("password", os.Getenv("WTF_ELASTICSEARCH_PASSWORD")),
+		url:                     ymlConfig.UString("url", "http://localhost:9200"),
+		username:                ymlConfig.UString("username"),
+		verifyServerCertificate: ymlConfig.UBool("verifyServerCertificate", true),
+	}
+
+	return &settings
+}
@@ -0,0 +1,93 @@
+package elasticsearch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type Widget struct {
+	wtf.TextWidget
+
+	client   *Client
+	settings *Settings
+}
+
+func NewWidget(app *tview.Application, settings *Settings) *Widget {
+	widget := Widget{
+		TextWidget: wtf.NewTextWidget(app, settings.common, false),
+
+		client:   NewClient(settings),
+		settings: settings,
+	}
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	cluster, err := widget.client.Cluster(widget.settings.diskWatermark)
+
+	title := widget.CommonSettings().Title
+	if err != nil {
+		widget.RedrawError(title, err)
+		return
+	}
+
+	title = fmt.Sprintf("%s - [green]%s[white]", title, cluster.Health.ClusterName)
+	widget.Redraw(title, widget.contentFrom(cluster), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom(cluster *Cluster) string {
+	health := cluster.Health
+
+	str := fmt.Sprintf(" Status: [%s]%s[white]\n", widget.statusColor(health.Status), health.Status)
+	str += fmt.Sprintf(" Nodes: %d  Unassigned shards: ", health.NumberOfNodes)
+
+	if health.UnassignedShards > 0 {
+		str += fmt.Sprintf("[red]%d[white]\n", health.UnassignedShards)
+	} else {
+		str += fmt.Sprintf("%d\n", health.UnassignedShards)
+	}
+
+	str += fmt.Sprintf("\n [red]Disk Usage ≥ %d%%[white]\n", widget.settings.diskWatermark)
+
+	if len(cluster.HotNodes) == 0 {
+		return str + " [grey]none[white]\n"
+	}
+
+	for _, node := range cluster.HotNodes {
+		str += fmt.Sprintf(" [yellow]%3d%%[white] %s\n", node.DiskPercent, node.Name)
+
+		if len(node.Indices) > 0 {
+			str += fmt.Sprintf("      [grey]%s[white]\n", strings.Join(node.Indices, ", "))
+		}
+	}
+
+	return str
+}
+
+// statusColor maps the cluster's health status onto the theme's status colors
+func (widget *Widget) statusColor(status string) string {
+	colors := widget.settings.common.Colors
+
+	switch status {
+	case "green":
+		return colors.OK
+	case "yellow":
+		return colors.Warn
+	case "red":
+		return colors.Crit
+	default:
+		return colors.Text
+	}
+}
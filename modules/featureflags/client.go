@@ -0,0 +1,184 @@
+package featureflags
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// noRollout is the rollout of flags that are served to everyone or no one
+const noRollout = -1
+
+// Flag is a feature flag and its state in each of the configured environments
+type Flag struct {
+	Key    string
+	Name   string
+	States map[string]State
+	URL    string
+}
+
+// State is whether a flag is on in an environment and, if it's rolled out to only some
+// users, to what percentage of them
+type State struct {
+	On      bool
+	Rollout int
+}
+
+// String describes the state as "on", "off", or the rollout percentage, such as "25%"
+func (state State) String() string {
+	switch {
+	case !state.On:
+		return "off"
+	case state.Rollout != noRollout:
+		return fmt.Sprintf("%d%%", state.Rollout)
+	default:
+		return "on"
+	}
+}
+
+/* -------------------- LaunchDarkly -------------------- */
+
+type ldFlags struct {
+	Items []struct {
+		Environments map[string]struct {
+			Fallthrough struct {
+				Rollout *struct {
+					Variations []struct {
+						Variation int `json:"variation"`
+						Weight    int `json:"weight"`
+					} `json:"variations"`
+				} `json:"rollout"`
+			} `json:"fallthrough"`
+			On bool `json:"on"`
+		} `json:"environments"`
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"items"`
+}
+
+// launchDarklyFlags fetches the project's flags with their targeting in each environment.
+// The rollout is the share of the fallthrough given to the first variation, which is
+// true for boolean flags. Weights are in thousandths of a percent
+func (widget *Widget) launchDarklyFlags() ([]Flag, error) {
+	params := url.Values{}
+	for _, env := range widget.settings.environments {
+		params.Add("env", env)
+	}
+
+	path := fmt.Sprintf("%s/api/v2/flags/%s?%s", widget.settings.baseURL, widget.settings.project, params.Encode())
+
+	result := ldFlags{}
+	if err := widget.getJSON(path, &result); err != nil {
+		return nil, err
+	}
+
+	flags := []Flag{}
+	for _, item := range result.Items {
+		flag := Flag{
+			Key:    item.Key,
+			Name:   item.Name,
+			States: map[string]State{},
+			URL:    fmt.Sprintf("%s/%s/%s/features/%s", widget.settings.baseURL, widget.settings.project, widget.settings.environments[0], item.Key),
+		}
+
+		for name, env := range item.Environments {
+			state := State{On: env.On, Rollout: noRollout}
+
+			if rollout := env.Fallthrough.Rollout; rollout != nil {
+				state.Rollout = 0
+				for _, variation := range rollout.Variations {
+					if variation.Variation == 0 {
+						state.Rollout = variation.Weight / 1000
+					}
+				}
+			}
+
+			flag.States[name] = state
+		}
+
+		flags = append(flags, flag)
+	}
+
+	return flags, nil
+}
+
+/* -------------------- Unleash -------------------- */
+
+type unleashFeatures struct {
+	Features []struct {
+		Environments []struct {
+			Enabled    bool   `json:"enabled"`
+			Name       string `json:"name"`
+			Strategies []struct {
+				Name       string                 `json:"name"`
+				Parameters map[string]interface{} `json:"parameters"`
+			} `json:"strategies"`
+		} `json:"environments"`
+		Name string `json:"name"`
+	} `json:"features"`
+}
+
+// unleashFlags fetches the project's feature toggles with whether they're enabled in
+// each environment. A toggle whose only strategy is a gradual rollout is rolled out to
+// that strategy's percentage
+func (widget *Widget) unleashFlags() ([]Flag, error) {
+	path := fmt.Sprintf("%s/api/admin/projects/%s/features", widget.settings.baseURL, widget.settings.project)
+
+	result := unleashFeatures{}
+	if err := widget.getJSON(path, &result); err != nil {
+		return nil, err
+	}
+
+	flags := []Flag{}
+	for _, feature := range result.Features {
+		flag := Flag{
+			Key:    feature.Name,
+			Name:   feature.Name,
+			States: map[string]State{},
+			URL:    fmt.Sprintf("%s/projects/%s/features/%s", widget.settings.baseURL, widget.settings.project, feature.Name),
+		}
+
+		for _, env := range feature.Environments {
+			state := State{On: env.Enabled, Rollout: noRollout}
+
+			if len(env.Strategies) == 1 && env.Strategies[0].Name == "flexibleRollout" {
+				if rollout, err := strconv.Atoi(fmt.Sprint(env.Strategies[0].Parameters["rollout"])); err == nil && rollout < 100 {
+					state.Rollout = rollout
+				}
+			}
+
+			flag.States[env.Name] = state
+		}
+
+		flags = append(flags, flag)
+	}
+
+	return flags, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) getJSON(path string, result interface{}) error {
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", widget.settings.apiKey)
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,17 @@
+package featureflags
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next flag")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous flag")
+	widget.SetKeyboardChar("o", widget.openFlag, "Open flag in browser")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next flag")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous flag")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openFlag, "Open flag in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
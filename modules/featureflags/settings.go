@@ -0,0 +1,53 @@
+package featureflags
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Feature Flags"
+
+const (
+	launchDarkly = "launchdarkly"
+	unleash      = "unleash"
+)
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey       string   `help:"Your LaunchDarkly API access token, or your Unleash admin API token." values:"Falls back to WTF_LAUNCHDARKLY_TOKEN or WTF_UNLEASH_TOKEN."`
+	baseURL      string   `help:"The address of your Unleash server, or of LaunchDarkly's API." optional:"true" default:"https://app.launchdarkly.com for LaunchDarkly"`
+	environments []string `help:"The environments to show each flag's state in." optional:"true" default:"production"`
+	flags        []string `help:"The keys of the flags to show. Without them every flag in the project is shown." optional:"true"`
+	project      string   `help:"The project the flags belong to." optional:"true" default:"default"`
+	service      string   `help:"Where the flags are managed." values:"launchdarkly or unleash" optional:"true" default:"launchdarkly"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+	service := ymlConfig.UString("service", launchDarkly)
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:       ymlConfig.UString("apiKey", os.Getenv("WTF_"+strings.ToUpper(service)+"_TOKEN")),
+		baseURL:      strings.TrimSuffix(ymlConfig.UString("baseURL", defaultBaseURL(service)), "/"),
+		environments: wtf.ToStrs(ymlConfig.UList("environments", []interface{}{"production"})),
+		flags:        wtf.ToStrs(ymlConfig.UList("flags")),
+		project:      ymlConfig.UString("project", "default"),
+		service:      service,
+	}
+
+	return &settings
+}
+
+func defaultBaseURL(service string) string {
+	if service == launchDarkly {
+		return "https://app.launchdarkly.com"
+	}
+
+	return ""
+}
@@ -0,0 +1,204 @@
+package featureflags
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget lists feature flags from LaunchDarkly or Unleash with their state in each
+// environment, and marks the flags whose state changed at the last refresh
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	changed  map[string]bool
+	flags    []Flag
+	previous map[string]map[string]State
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		changed:  map[string]bool{},
+		settings: settings,
+	}
+
+	if parsed, err := url.Parse(settings.baseURL); err == nil {
+		widget.SetQuotaHost(parsed.Hostname())
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the flags and compares their states with those of the last refresh
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	var flags []Flag
+	var err error
+
+	switch widget.settings.service {
+	case launchDarkly:
+		flags, err = widget.launchDarklyFlags()
+	case unleash:
+		flags, err = widget.unleashFlags()
+	default:
+		err = fmt.Errorf("unknown service %q, which should be launchdarkly or unleash", widget.settings.service)
+	}
+
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.flags = widget.configuredFlags(flags)
+	widget.trackChanges()
+	widget.SetItemCount(len(widget.flags))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	title := widget.CommonSettings().Title
+	if len(widget.changed) > 0 {
+		title = fmt.Sprintf("%s (%d changed)", title, len(widget.changed))
+	}
+
+	widget.Redraw(title, widget.contentFrom(), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// configuredFlags returns the flags listed in the settings, in the order they're listed,
+// or every flag if none are listed
+func (widget *Widget) configuredFlags(flags []Flag) []Flag {
+	if len(widget.settings.flags) == 0 {
+		return flags
+	}
+
+	byKey := map[string]Flag{}
+	for _, flag := range flags {
+		byKey[flag.Key] = flag
+	}
+
+	configured := []Flag{}
+	for _, key := range widget.settings.flags {
+		if flag, ok := byKey[key]; ok {
+			configured = append(configured, flag)
+		}
+	}
+
+	return configured
+}
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.flags) == 0 {
+		return " [gray]No flags[white]"
+	}
+
+	str := ""
+	for idx, flag := range widget.flags {
+		marker := " "
+		if widget.changed[flag.Key] {
+			marker = "[yellow]●"
+		}
+
+		row := fmt.Sprintf("[%s]%s[%s] %s", widget.RowColor(idx), marker, widget.RowColor(idx), tview.Escape(flag.Key))
+
+		for _, env := range widget.settings.environments {
+			state, ok := flag.States[env]
+			if !ok {
+				continue
+			}
+
+			row += fmt.Sprintf(" [gray]%s:[%s]%s", env, stateColor(state), state)
+		}
+
+		str += wtf.HighlightableHelper(widget.View, row+"[white]", idx, len(flag.Key))
+	}
+
+	return str
+}
+
+func (widget *Widget) openFlag() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.flags) {
+		utils.OpenURL(widget.flags[sel].URL)
+	}
+}
+
+// rowFor describes the flag at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	flag := widget.flags[idx]
+
+	return wtf.Row{
+		ID:   flag.Key,
+		Text: flag.Name,
+		URL:  flag.URL,
+	}
+}
+
+// trackChanges finds the flags whose state in any environment differs from the last
+// refresh. Nothing is marked on the first refresh, as there's nothing to compare with
+func (widget *Widget) trackChanges() {
+	current := map[string]map[string]State{}
+	changed := map[string]bool{}
+
+	for _, flag := range widget.flags {
+		current[flag.Key] = flag.States
+
+		if widget.previous == nil {
+			continue
+		}
+
+		before, ok := widget.previous[flag.Key]
+		if !ok {
+			changed[flag.Key] = true
+			continue
+		}
+
+		for _, env := range widget.settings.environments {
+			if before[env] != flag.States[env] {
+				changed[flag.Key] = true
+			}
+		}
+	}
+
+	widget.changed = changed
+	widget.previous = current
+}
+
+func stateColor(state State) string {
+	switch {
+	case !state.On:
+		return "red"
+	case state.Rollout != noRollout:
+		return "yellow"
+	default:
+		return "green"
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/mmcdole/gofeed"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -75,7 +76,7 @@ func (widget *Widget) Fetch(feedURLs []string) ([]*FeedItem, error) {
 func (widget *Widget) Refresh() {
 	feedItems, err := widget.Fetch(widget.settings.feeds)
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 	}
 
 	widget.stories = feedItems
@@ -94,6 +95,11 @@ func (widget *Widget) Render() {
 	widget.Redraw(title, widget.contentFrom(widget.stories), false)
 }
 
+// RenderRelativeTimes redraws the stories so that their publication times stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) fetchForFeed(feedURL string) ([]*FeedItem, error) {
@@ -143,6 +149,10 @@ func (widget *Widget) contentFrom(data []*FeedItem) string {
 			feedItem.item.Title,
 		)
 
+		if feedItem.item.PublishedParsed != nil {
+			row += fmt.Sprintf(" [gray]%s[white]", wtf.RelativeTime(*feedItem.item.PublishedParsed))
+		}
+
 		str += wtf.HighlightableHelper(widget.View, row, idx, len(feedItem.item.Title))
 	}
 
@@ -168,6 +178,6 @@ func (widget *Widget) openStory() {
 		story := widget.stories[sel]
 		story.viewed = true
 
-		wtf.OpenFile(story.item.Link)
+		utils.OpenURL(story.item.Link)
 	}
 }
@@ -3,6 +3,8 @@ package gcal
 import (
 	"time"
 
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/i18n"
 	"github.com/wtfutil/wtf/wtf"
 	"google.golang.org/api/calendar/v3"
 )
@@ -25,6 +27,31 @@ func (calEvent *CalEvent) AllDay() bool {
 	return len(calEvent.event.Start.Date) > 0
 }
 
+// CalendarEvent returns the event as it's published on the data bus, with its video
+// conference link if it has one
+func (calEvent *CalEvent) CalendarEvent() wtf.CalendarEvent {
+	event := wtf.CalendarEvent{
+		AllDay:      calEvent.AllDay(),
+		Description: calEvent.event.Description,
+		End:         calEvent.End(),
+		Location:    calEvent.event.Location,
+		Start:       calEvent.Start(),
+		Title:       calEvent.event.Summary,
+		URL:         calEvent.event.HangoutLink,
+	}
+
+	if calEvent.event.ConferenceData != nil {
+		for _, entryPoint := range calEvent.event.ConferenceData.EntryPoints {
+			if entryPoint.EntryPointType == "video" {
+				event.URL = entryPoint.Uri
+				break
+			}
+		}
+	}
+
+	return event
+}
+
 func (calEvent *CalEvent) ConflictsWith(otherEvents []*CalEvent) bool {
 	hasConflict := false
 
@@ -97,12 +124,14 @@ func (calEvent *CalEvent) Start() time.Time {
 	return start
 }
 
-func (calEvent *CalEvent) Timestamp() string {
+// Timestamp returns the date of an all-day event, or the time of any other, in the
+// formats of the date and time settings
+func (calEvent *CalEvent) Timestamp(dateTime cfg.DateTimeSettings) string {
 	if calEvent.AllDay() {
 		startTime, _ := time.ParseInLocation("2006-01-02", calEvent.event.Start.Date, time.Local)
-		return startTime.Format(wtf.FriendlyDateFormat)
+		return i18n.FormatDate(startTime, dateTime.DateLayout(wtf.FriendlyDateFormat))
 	}
 
 	startTime, _ := time.Parse(time.RFC3339, calEvent.event.Start.DateTime)
-	return startTime.Format(wtf.MinimumTimeFormat)
+	return i18n.FormatDate(startTime, dateTime.TimeLayout(wtf.MinimumTimeFormat))
 }
@@ -8,19 +8,13 @@
 package gcal
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
-	"net/http"
-	"os"
 	"sort"
 	"time"
 
-	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/oauth"
 	"github.com/wtfutil/wtf/utils"
-	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/calendar/v3"
 )
@@ -28,28 +22,18 @@ import (
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Fetch() ([]*CalEvent, error) {
-	ctx := context.Background()
+	client := widget.session.Client()
 
-	secretPath, _ := utils.ExpandHomeDir(widget.settings.secretFile)
-
-	b, err := ioutil.ReadFile(secretPath)
-	if err != nil {
-		return nil, err
-	}
-
-	config, err := google.ConfigFromJSON(b, calendar.CalendarReadonlyScope)
+	srv, err := calendar.New(client)
 	if err != nil {
 		return nil, err
 	}
-	client := getClient(ctx, config)
 
-	srv, err := calendar.New(client)
+	calendarIds, err := widget.getCalendarIdList(srv)
 	if err != nil {
 		return nil, err
 	}
 
-	calendarIds, err := widget.getCalendarIdList(srv)
-
 	// Get calendar events
 	var events calendar.Events
 
@@ -61,13 +45,10 @@ func (widget *Widget) Fetch() ([]*CalEvent, error) {
 	for _, calendarId := range calendarIds {
 		calendarEvents, err := srv.Events.List(calendarId).TimeZone(timezone).ShowDeleted(false).TimeMin(startTime).MaxResults(eventLimit).SingleEvents(true).OrderBy("startTime").Do()
 		if err != nil {
-			break
+			return nil, err
 		}
 		events.Items = append(events.Items, calendarEvents.Items...)
 	}
-	if err != nil {
-		return nil, err
-	}
 
 	// Sort events
 	timeDateChooser := func(event *calendar.Event) (time.Time, error) {
@@ -90,7 +71,7 @@ func (widget *Widget) Fetch() ([]*CalEvent, error) {
 		calEvents = append(calEvents, NewCalEvent(event))
 	}
 
-	return calEvents, err
+	return calEvents, nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -100,93 +81,33 @@ func fromMidnight() time.Time {
 	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 }
 
-// getClient uses a Context and Config to retrieve a Token
-// then generate a Client. It returns the generated Client.
-func getClient(ctx context.Context, config *oauth2.Config) *http.Client {
-	cacheFile, err := tokenCacheFile()
-	if err != nil {
-		log.Fatalf("Unable to get path to cached credential file. %v", err)
-	}
-	tok, err := tokenFromFile(cacheFile)
-	if err != nil {
-		tok = getTokenFromWeb(config)
-		saveToken(cacheFile, tok)
-	}
-	return config.Client(ctx, tok)
-}
-
-func isAuthenticated() bool {
-	cacheFile, err := tokenCacheFile()
-	if err != nil {
-		log.Fatalf("Unable to get path to cached credential file. %v", err)
-	}
-	_, err = tokenFromFile(cacheFile)
-	return err == nil
-}
-
-func (widget *Widget) authenticate() {
+// oauthConfig returns the config to sign in to Google with, using the client ID and
+// secret from the secret file that Google gives out for an OAuth client
+func (widget *Widget) oauthConfig() (*oauth.Config, error) {
 	secretPath, _ := utils.ExpandHomeDir(widget.settings.secretFile)
 
 	b, err := ioutil.ReadFile(secretPath)
 	if err != nil {
-		log.Fatalf("Unable to read secret file. %v", widget.settings.secretFile)
-	}
-
-	config, err := google.ConfigFromJSON(b, calendar.CalendarReadonlyScope)
-	tok := getTokenFromWeb(config)
-	cacheFile, err := tokenCacheFile()
-	saveToken(cacheFile, tok)
-}
-
-// getTokenFromWeb uses Config to request a Token.
-// It returns the retrieved Token.
-func getTokenFromWeb(config *oauth2.Config) *oauth2.Token {
-	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
-	fmt.Printf("Go to the following link in your browser then type the "+
-		"authorization code: \n%v (press 'return' before inserting the code)", authURL)
-
-	var code string
-	if _, err := fmt.Scan(&code); err != nil {
-		log.Fatalf("Unable to read authorization code %v", err)
+		return nil, fmt.Errorf("reading the secret file: %v", err)
 	}
 
-	tok, err := config.Exchange(oauth2.NoContext, code)
+	googleConfig, err := google.ConfigFromJSON(b, calendar.CalendarReadonlyScope)
 	if err != nil {
-		log.Fatalf("Unable to retrieve token from web %v", err)
+		return nil, fmt.Errorf("parsing the secret file: %v", err)
 	}
-	return tok
-}
-
-// tokenCacheFile generates credential file path/filename.
-// It returns the generated credential path/filename.
-func tokenCacheFile() (string, error) {
-	return cfg.CreateFile("gcal-auth.json")
-}
 
-// tokenFromFile retrieves a Token from a given file path.
-// It returns the retrieved Token and any read error encountered.
-func tokenFromFile(file string) (*oauth2.Token, error) {
-	f, err := os.Open(file)
-	if err != nil {
-		return nil, err
-	}
-	t := &oauth2.Token{}
-	err = json.NewDecoder(f).Decode(t)
-	defer f.Close()
-	return t, err
-}
+	provider, _ := oauth.LookupProvider("google")
 
-// saveToken uses a file path to create a file and store the
-// token in it.
-func saveToken(file string, token *oauth2.Token) {
-	fmt.Printf("Saving credential file to: %s\n", file)
-	f, err := os.OpenFile(file, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-	if err != nil {
-		log.Fatalf("Unable to cache oauth token: %v", err)
+	config := &oauth.Config{
+		CallbackPort: widget.settings.callbackPort,
+		ClientID:     googleConfig.ClientID,
+		ClientSecret: googleConfig.ClientSecret,
+		Flow:         widget.settings.flow,
+		Provider:     provider,
+		Scopes:       googleConfig.Scopes,
 	}
-	defer f.Close()
 
-	json.NewEncoder(f).Encode(token)
+	return config, nil
 }
 
 func (widget *Widget) getCalendarIdList(srv *calendar.Service) ([]string, error) {
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/wtfutil/wtf/i18n"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -45,7 +46,7 @@ func (widget *Widget) contentFrom(calEvents []*CalEvent) string {
 	}
 
 	for _, calEvent := range calEvents {
-		timestamp := fmt.Sprintf("[%s]%s", widget.descriptionColor(calEvent), calEvent.Timestamp())
+		timestamp := fmt.Sprintf("[%s]%s", widget.descriptionColor(calEvent), calEvent.Timestamp(widget.settings.common.DateTime))
 		if calEvent.AllDay() {
 			timestamp = ""
 		}
@@ -98,7 +99,7 @@ func (widget *Widget) dayDivider(event, prevEvent *CalEvent) string {
 
 		return fmt.Sprintf("[%s::b]",
 			widget.settings.colors.day) +
-			event.Start().Format(wtf.FullDateFormat) +
+			i18n.FormatDate(event.Start(), widget.settings.common.DateTime.DateLayout(wtf.FullDateFormat)) +
 			"\n"
 	}
 
@@ -131,7 +132,7 @@ func (widget *Widget) eventSummary(calEvent *CalEvent, conflict bool) string {
 	return summary
 }
 
-// timeUntil returns the number of hours or days until the event
+// timeUntil returns how long until the event, such as "in 12m"
 // If the event is in the past, returns nil
 func (widget *Widget) timeUntil(calEvent *CalEvent) string {
 	duration := time.Until(calEvent.Start()).Round(time.Minute)
@@ -140,29 +141,12 @@ func (widget *Widget) timeUntil(calEvent *CalEvent) string {
 		return ""
 	}
 
-	days := duration / (24 * time.Hour)
-	duration -= days * (24 * time.Hour)
-
-	hours := duration / time.Hour
-	duration -= hours * time.Hour
-
-	mins := duration / time.Minute
-
-	untilStr := ""
-
 	color := "[lightblue]"
-	if days > 0 {
-		untilStr = fmt.Sprintf("%dd", days)
-	} else if hours > 0 {
-		untilStr = fmt.Sprintf("%dh", hours)
-	} else {
-		untilStr = fmt.Sprintf("%dm", mins)
-		if mins < 30 {
-			color = "[red]"
-		}
+	if duration < 30*time.Minute {
+		color = "[red]"
 	}
 
-	return color + untilStr + "[white]"
+	return color + wtf.RelativeTime(calEvent.Start()) + "[white]"
 }
 
 func (widget *Widget) titleColor(calEvent *CalEvent) string {
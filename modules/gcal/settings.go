@@ -3,6 +3,7 @@ package gcal
 import (
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/oauth"
 )
 
 const defaultTitle = "Calendar"
@@ -20,13 +21,15 @@ type Settings struct {
 	colors
 	common *cfg.Common
 
+	callbackPort          string `help:"The port to listen for the sign-in redirect on, for the callback flow." optional:"true" default:"8080"`
 	conflictIcon          string `help:"The icon displayed beside calendar events that have conflicting times (they intersect or overlap in some way)." values:"Any displayable unicode character." optional:"true"`
 	currentIcon           string `help:"The icon displayed beside the current calendar event." values:"Any displayable unicode character." optional:"true"`
 	displayResponseStatus bool   `help:"Whether or not to display your response status to the calendar event." values:"true or false" optional:"true"`
 	email                 string `help:"The email address associated with your Google account. Necessary for determining 'responseStatus'." values:"A valid email address string."`
 	eventCount            int    `help:"The number of calendar events to display." values:"A positive integer, 0..n." optional:"true"`
+	flow                  string `help:"How to sign in: through a redirect to wtf, or on Google's device-code page, which needs a client of the 'TVs and Limited Input devices' type." values:"callback or device" optional:"true" default:"callback"`
 	multiCalendar         bool   `help:"Whether or not to display your primary calendar or all calendars you have access to." values:"true or false" optional:"true"`
-	secretFile            string `help:"Your Google client secret JSON file." values:"A string representing a file path to the JSON secret file."`
+	secretFile            string `help:"Your Google client secret JSON file, which the client ID and secret to sign in with are read from." values:"A string representing a file path to the JSON secret file."`
 	showDeclined          bool   `help:"Whether or not to display events you’ve declined to attend." values:"true or false" optional:"true"`
 	withLocation          bool   `help:"Whether or not to show the location of the appointment." values:"true or false"`
 	timezone              string `help:"The time zone used to display calendar event times." values:"A valid TZ database time zone string" optional:"true"`
@@ -37,11 +40,13 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
 
+		callbackPort:          ymlConfig.UString("callbackPort", "8080"),
 		conflictIcon:          ymlConfig.UString("conflictIcon", "🚨"),
 		currentIcon:           ymlConfig.UString("currentIcon", "🔸"),
 		displayResponseStatus: ymlConfig.UBool("displayResponseStatus", true),
 		email:                 ymlConfig.UString("email", ""),
 		eventCount:            ymlConfig.UInt("eventCount", 10),
+		flow:                  ymlConfig.UString("flow", oauth.FlowCallback),
 		multiCalendar:         ymlConfig.UBool("multiCalendar", false),
 		secretFile:            ymlConfig.UString("secretFile", ""),
 		showDeclined:          ymlConfig.UBool("showDeclined", false),
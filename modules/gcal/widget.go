@@ -1,26 +1,34 @@
 package gcal
 
 import (
+	"time"
+
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/oauth"
 	"github.com/wtfutil/wtf/wtf"
 )
 
 type Widget struct {
 	wtf.TextWidget
 
-	app       *tview.Application
 	calEvents []*CalEvent
+	session   *oauth.Session
 	settings  *Settings
 }
 
+// NewWidget creates a new instance of a widget. The user signs in to Google once, and the
+// sign-in is kept under the widget's name
 func NewWidget(app *tview.Application, settings *Settings) *Widget {
 	widget := Widget{
 		TextWidget: wtf.NewTextWidget(app, settings.common, true),
 
-		app:      app,
 		settings: settings,
 	}
 
+	widget.session = oauth.NewSession(widget.Name(), oauth.DefaultTokenStore(), widget.Refresh)
+	go widget.session.SignIn(widget.oauthConfig)
+
 	return &widget
 }
 
@@ -31,13 +39,21 @@ func (widget *Widget) Disable() {
 }
 
 func (widget *Widget) Refresh() {
-	if isAuthenticated() {
-		widget.fetchAndDisplayEvents()
+	if widget.Disabled() {
+		return
+	}
+
+	if widget.session.Client() == nil {
+		widget.Redraw(widget.CommonSettings().Title, widget.session.PromptText(), true)
 		return
 	}
 
-	widget.app.Suspend(widget.authenticate)
-	widget.Refresh()
+	widget.fetchAndDisplayEvents()
+}
+
+// RenderRelativeTimes redraws the events so that the time until each stays current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.display()
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -45,10 +61,43 @@ func (widget *Widget) Refresh() {
 func (widget *Widget) fetchAndDisplayEvents() {
 	calEvents, err := widget.Fetch()
 	if err != nil {
-		widget.calEvents = []*CalEvent{}
-	} else {
-		widget.calEvents = calEvents
+		logger.For(widget.Name()).Errorf("fetching the events: %v", err)
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
 	}
 
+	widget.calEvents = calEvents
+
+	widget.publishNextEvent()
+	widget.publishEvents()
 	widget.display()
 }
+
+// publishEvents puts the events on the data bus for other modules, such as meetings,
+// to use
+func (widget *Widget) publishEvents() {
+	events := []wtf.CalendarEvent{}
+
+	for _, calEvent := range widget.calEvents {
+		events = append(events, calEvent.CalendarEvent())
+	}
+
+	widget.PublishCalendarEvents(events)
+}
+
+// publishNextEvent puts the next timed event on the data bus as "nextEvent" and
+// "nextEventStart"
+func (widget *Widget) publishNextEvent() {
+	for _, calEvent := range widget.calEvents {
+		if calEvent.AllDay() || calEvent.Past() || calEvent.Now() {
+			continue
+		}
+
+		widget.PublishData("nextEvent", calEvent.event.Summary)
+		widget.PublishData("nextEventStart", calEvent.Start())
+		return
+	}
+
+	widget.PublishData("nextEvent", "")
+	widget.PublishData("nextEventStart", time.Time{})
+}
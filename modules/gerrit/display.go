@@ -2,6 +2,8 @@ package gerrit
 
 import (
 	"fmt"
+
+	glb "github.com/andygrunwald/go-gerrit"
 )
 
 func (widget *Widget) display() {
@@ -35,7 +37,7 @@ func (widget *Widget) displayMyIncomingReviews(project *GerritProject, username
 
 	str := ""
 	for idx, r := range project.IncomingReviews {
-		str += fmt.Sprintf(" [%s] [green]%d[white] [%s] %s\n", widget.rowColor(idx), r.Number, widget.rowColor(idx), r.Subject)
+		str += widget.displayChange(idx, r)
 	}
 
 	return str
@@ -48,12 +50,31 @@ func (widget *Widget) displayMyOutgoingReviews(project *GerritProject, username
 
 	str := ""
 	for idx, r := range project.OutgoingReviews {
-		str += fmt.Sprintf(" [%s] [green]%d[white] [%s] %s\n", widget.rowColor(idx+len(project.IncomingReviews)), r.Number, widget.rowColor(idx+len(project.IncomingReviews)), r.Subject)
+		str += widget.displayChange(idx+len(project.IncomingReviews), r)
 	}
 
 	return str
 }
 
+// displayChange writes the change with its label scores and, if it's ready to be
+// submitted, a check mark
+func (widget *Widget) displayChange(idx int, change glb.ChangeInfo) string {
+	ready := " "
+	if submitReady(change) {
+		ready = "[green]✔[white]"
+	}
+
+	return fmt.Sprintf(
+		" [%s] [green]%d[white] %s %s [%s] %s\n",
+		widget.rowColor(idx),
+		change.Number,
+		ready,
+		labelScores(change, widget.settings.labels),
+		widget.rowColor(idx),
+		change.Subject,
+	)
+}
+
 func (widget *Widget) displayStats(project *GerritProject) string {
 	str := fmt.Sprintf(
 		" Reviews: %d\n",
@@ -0,0 +1,87 @@
+package gerrit
+
+import (
+	"fmt"
+	"strings"
+
+	glb "github.com/andygrunwald/go-gerrit"
+)
+
+// labelAbbreviation shortens a label's name to its initials, such as "CR" for
+// "Code-Review" or "V" for "Verified"
+func labelAbbreviation(name string) string {
+	abbreviation := ""
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' || r == ' ' }) {
+		abbreviation += strings.ToUpper(word[:1])
+	}
+
+	return abbreviation
+}
+
+// labelScore returns the change's score on the label: the lowest vote if anyone voted
+// against it, or else the highest. Changes without votes score 0
+func labelScore(label glb.LabelInfo) int {
+	lowest, highest := 0, 0
+
+	for _, approval := range label.All {
+		if approval.Value < lowest {
+			lowest = approval.Value
+		}
+		if approval.Value > highest {
+			highest = approval.Value
+		}
+	}
+
+	if lowest < 0 {
+		return lowest
+	}
+
+	return highest
+}
+
+// labelScores writes the change's scores on the labels, such as "V+1 CR+2", coloured
+// green for votes for and red for votes against
+func labelScores(change glb.ChangeInfo, names []string) string {
+	scores := []string{}
+
+	for _, name := range names {
+		label, ok := change.Labels[name]
+		if !ok {
+			continue
+		}
+
+		score := labelScore(label)
+
+		color := "grey"
+		switch {
+		case score > 0:
+			color = "green"
+		case score < 0:
+			color = "red"
+		}
+
+		scores = append(scores, fmt.Sprintf("[%s]%s%+d[white]", color, labelAbbreviation(name), score))
+	}
+
+	return strings.Join(scores, " ")
+}
+
+// submitReady returns true if the change is ready to be submitted: every label it needs
+// has been approved, and none has been rejected or is blocking
+func submitReady(change glb.ChangeInfo) bool {
+	if len(change.Labels) == 0 {
+		return false
+	}
+
+	for _, label := range change.Labels {
+		if label.Blocking || label.Rejected.AccountID != 0 {
+			return false
+		}
+
+		if !label.Optional && label.Approved.AccountID == 0 {
+			return false
+		}
+	}
+
+	return true
+}
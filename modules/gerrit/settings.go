@@ -5,6 +5,7 @@ import (
 
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 type colors struct {
@@ -21,6 +22,7 @@ type Settings struct {
 	common *cfg.Common
 
 	domain                  string        `help:"Your Gerrit corporate domain."`
+	labels                  []string      `help:"The labels whose scores are shown next to each change, abbreviated to their initials." values:"Defaults to Verified and Code-Review." optional:"true"`
 	password                string        `help:"Your Gerrit HTTP Password."`
 	projects                []interface{} `help:"A list of Gerrit project names to fetch data for."`
 	username                string        `help:"Your Gerrit username."`
@@ -33,6 +35,7 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
 
 		domain:                  ymlConfig.UString("domain", ""),
+		labels:                  wtf.ToStrs(ymlConfig.UList("labels", []interface{}{"Verified", "Code-Review"})),
 		password:                ymlConfig.UString("password", os.Getenv("WTF_GERRIT_PASSWORD")),
 		projects:                ymlConfig.UList("projects"),
 		username:                ymlConfig.UString("username", ""),
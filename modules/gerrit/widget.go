@@ -1,13 +1,12 @@
 package gerrit
 
 import (
-	"crypto/tls"
 	"fmt"
-	"net/http"
 	"regexp"
 
 	glb "github.com/andygrunwald/go-gerrit"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -51,14 +50,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: !widget.settings.verifyServerCertificate,
-			},
-			Proxy: http.ProxyFromEnvironment,
-		},
-	}
+	httpClient := wtf.HTTPClientVerifying(widget.settings.verifyServerCertificate)
 
 	gerritUrl := widget.settings.domain
 	submatches := GerritURLPattern.FindAllStringSubmatch(widget.settings.domain, -1)
@@ -77,7 +69,7 @@ func (widget *Widget) Refresh() {
 	if err != nil {
 		widget.View.SetWrap(true)
 
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 	widget.gerrit = gerrit
@@ -145,7 +137,7 @@ func (widget *Widget) openReview() {
 		} else {
 			change = project.OutgoingReviews[sel-len(project.IncomingReviews)]
 		}
-		wtf.OpenFile(fmt.Sprintf("%s/%s/%d", widget.settings.domain, "#/c", change.Number))
+		utils.OpenURL(fmt.Sprintf("%s/%s/%d", widget.settings.domain, "#/c", change.Number))
 	}
 }
 
@@ -2,19 +2,14 @@ package git
 
 import (
 	"io/ioutil"
-	"log"
 	"sort"
 	"strings"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const offscreen = -1000
-const modalWidth = 80
-const modalHeight = 7
-
 type Widget struct {
 	wtf.KeyboardWidget
 	wtf.MultiSourceWidget
@@ -22,8 +17,6 @@ type Widget struct {
 
 	GitRepos []*GitRepo
 
-	app      *tview.Application
-	pages    *tview.Pages
 	settings *Settings
 }
 
@@ -33,8 +26,6 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 		MultiSourceWidget: wtf.NewMultiSourceWidget(settings.common, "repository", "repositories"),
 		TextWidget:        wtf.NewTextWidget(app, settings.common, true),
 
-		app:      app,
-		pages:    pages,
 		settings: settings,
 	}
 
@@ -50,21 +41,17 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 
 /* -------------------- Exported Functions -------------------- */
 
+// Checkout asks for the name of a branch and checks the current repository out to it
 func (widget *Widget) Checkout() {
-	form := widget.modalForm("Branch to checkout:", "")
+	prompt := wtf.TextPrompt{Label: "Branch to checkout:", History: "git_checkout"}
 
-	checkoutFctn := func() {
-		text := form.GetFormItem(0).(*tview.InputField).GetText()
+	widget.ShowPrompt(prompt, func(branch string) {
 		repoToCheckout := widget.GitRepos[widget.Idx]
-		repoToCheckout.checkout(text)
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
+		repoToCheckout.checkout(branch)
+
 		widget.display()
 		widget.Refresh()
-	}
-
-	widget.addButtons(form, checkoutFctn)
-	widget.modalFocus(form)
+	})
 }
 
 func (widget *Widget) Pull() {
@@ -87,61 +74,6 @@ func (widget *Widget) Refresh() {
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) addCheckoutButton(form *tview.Form, fctn func()) {
-	form.AddButton("Checkout", fctn)
-}
-
-func (widget *Widget) addButtons(form *tview.Form, checkoutFctn func()) {
-	widget.addCheckoutButton(form, checkoutFctn)
-	widget.addCancelButton(form)
-}
-
-func (widget *Widget) addCancelButton(form *tview.Form) {
-	cancelFn := func() {
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
-		widget.display()
-	}
-
-	form.AddButton("Cancel", cancelFn)
-	form.SetCancelFunc(cancelFn)
-}
-
-func (widget *Widget) modalFocus(form *tview.Form) {
-	widget.app.QueueUpdateDraw(func() {
-		frame := widget.modalFrame(form)
-		widget.pages.AddPage("modal", frame, false, true)
-		widget.app.SetFocus(frame)
-	})
-}
-
-func (widget *Widget) modalForm(lbl, text string) *tview.Form {
-	form := tview.NewForm().
-		SetButtonsAlign(tview.AlignCenter).
-		SetButtonTextColor(tview.Styles.PrimaryTextColor)
-
-	form.AddInputField(lbl, text, 60, nil, nil)
-
-	return form
-}
-
-func (widget *Widget) modalFrame(form *tview.Form) *tview.Frame {
-	frame := tview.NewFrame(form).SetBorders(0, 0, 0, 0, 0, 0)
-	frame.SetRect(offscreen, offscreen, modalWidth, modalHeight)
-	frame.SetBorder(true)
-	frame.SetBorders(1, 1, 0, 0, 1, 1)
-
-	drawFunc := func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
-		w, h := screen.Size()
-		frame.SetRect((w/2)-(width/2), (h/2)-(height/2), width, height)
-		return x, y, width, height
-	}
-
-	frame.SetDrawFunc(drawFunc)
-
-	return frame
-}
-
 func (widget *Widget) currentData() *GitRepo {
 	if len(widget.GitRepos) == 0 {
 		return nil
@@ -181,7 +113,8 @@ func (widget *Widget) findGitRepositories(repositories []*GitRepo, directory str
 
 	files, err := ioutil.ReadDir(directory)
 	if err != nil {
-		log.Fatal(err)
+		logger.For("git").Errorf("searching %s for repositories: %v", directory, err)
+		return repositories
 	}
 
 	var path string
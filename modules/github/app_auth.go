@@ -0,0 +1,144 @@
+package github
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jws"
+)
+
+// defaultAPIURL is where GitHub's API is, unless baseURL points at GitHub Enterprise
+const defaultAPIURL = "https://api.github.com/"
+
+// appTokenSource hands out installation access tokens for a GitHub App. Each token is
+// requested with a short-lived JWT signed by the app's private key, and lasts an hour
+type appTokenSource struct {
+	apiURL         string
+	appID          string
+	installationID string
+	privateKey     *rsa.PrivateKey
+}
+
+// installationToken is GitHub's answer to a request for an installation access token
+type installationToken struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Token     string    `json:"token"`
+}
+
+// newAppTokenSource reads the app's private key and returns a source of installation
+// access tokens, which are reused until they are about to expire
+func newAppTokenSource(apiURL, appID, installationID, privateKeyFile string) (oauth2.TokenSource, error) {
+	path, err := utils.ExpandHomeDir(privateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", privateKeyFile, err)
+	}
+
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+
+	source := &appTokenSource{
+		apiURL:         strings.TrimSuffix(apiURL, "/") + "/",
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     key,
+	}
+
+	return oauth2.ReuseTokenSource(nil, source), nil
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Token requests a new installation access token
+func (source *appTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := source.jwt(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%sapp/installations/%s/access_tokens", source.apiURL, source.installationID)
+
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.machine-man-preview+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, wtf.NewHTTPError(resp)
+	}
+
+	token := installationToken{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{AccessToken: token.Token, Expiry: token.ExpiresAt, TokenType: "token"}, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// jwt returns the token that proves a request comes from the app. GitHub accepts them
+// for up to ten minutes; it is backdated a minute to allow for clock drift
+func (source *appTokenSource) jwt(now time.Time) (string, error) {
+	claims := &jws.ClaimSet{
+		Exp: now.Add(9 * time.Minute).Unix(),
+		Iat: now.Add(-time.Minute).Unix(),
+		Iss: source.appID,
+	}
+
+	return jws.Encode(&jws.Header{Algorithm: "RS256", Typ: "JWT"}, claims, source.privateKey)
+}
+
+// parsePrivateKey reads an RSA private key in PEM form, as GitHub hands them out
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM-encoded private key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key is not an RSA key")
+	}
+
+	return key, nil
+}
@@ -4,24 +4,32 @@ import (
 	"fmt"
 
 	"github.com/google/go-github/v26/github"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func (widget *Widget) display() {
+	if widget.showReviewQueue {
+		widget.displayReviewQueue()
+		return
+	}
+
 	repo := widget.currentGithubRepo()
-	title := fmt.Sprintf("%s - %s", widget.CommonSettings().Title, widget.title(repo))
 	if repo == nil {
-		widget.TextWidget.Redraw(title, " GitHub repo data is unavailable ", false)
+		widget.TextWidget.Redraw(widget.CommonSettings().Title, " GitHub repo data is unavailable ", false)
 		return
 	}
 
+	title := fmt.Sprintf("%s - %s", widget.CommonSettings().Title, widget.title(repo))
+
 	_, _, width, _ := widget.View.GetRect()
 	str := widget.settings.common.SigilStr(len(widget.GithubRepos), widget.Idx, width) + "\n"
 	str += " [red]Stats[white]\n"
 	str += widget.displayStats(repo)
 	str += "\n [red]Open Review Requests[white]\n"
-	str += widget.displayMyReviewRequests(repo, widget.settings.username)
+	str += widget.displayMyReviewRequests(repo, repo.username)
 	str += "\n [red]My Pull Requests[white]\n"
-	str += widget.displayMyPullRequests(repo, widget.settings.username)
+	str += widget.displayMyPullRequests(repo, repo.username)
 	for _, customQuery := range widget.settings.customQueries {
 		str += fmt.Sprintf("\n [red]%s[white]\n", customQuery.title)
 		str += widget.displayCustomQuery(repo, customQuery.filter, customQuery.perPage)
@@ -39,7 +47,7 @@ func (widget *Widget) displayMyPullRequests(repo *GithubRepo, username string) s
 
 	str := ""
 	for _, pr := range prs {
-		str += fmt.Sprintf(" %s[green]%4d[white] %s\n", widget.mergeString(pr), *pr.Number, *pr.Title)
+		str += fmt.Sprintf(" %s[green]%4d[white] %s%s\n", widget.mergeString(pr), *pr.Number, *pr.Title, updatedString(pr))
 	}
 
 	return str
@@ -71,12 +79,45 @@ func (widget *Widget) displayMyReviewRequests(repo *GithubRepo, username string)
 
 	str := ""
 	for _, pr := range prs {
-		str += fmt.Sprintf(" [green]%4d[white] %s\n", *pr.Number, *pr.Title)
+		str += fmt.Sprintf(" [green]%4d[white] %s%s\n", *pr.Number, *pr.Title, updatedString(pr))
 	}
 
 	return str
 }
 
+// displayReviewQueue lists the pull requests waiting on the user's review, each with the
+// state of its checks, its mergeability, and how long it has waited
+func (widget *Widget) displayReviewQueue() {
+	title := fmt.Sprintf("%s - Review Queue (%d)", widget.CommonSettings().Title, len(widget.reviewQueue))
+
+	if len(widget.reviewQueue) == 0 {
+		widget.ScrollableWidget.Redraw(title, " [grey]No pull requests are waiting on your review[white]", false)
+		return
+	}
+
+	str := ""
+	for idx, item := range widget.reviewQueue {
+		merge, ok := mergeIcons[item.pr.GetMergeableState()]
+		if !ok {
+			merge = "[grey]?[white] "
+		}
+
+		row := fmt.Sprintf(
+			" %s %s[%s]%s %s [gray]%s[white]",
+			checksIcons[item.checks],
+			merge,
+			widget.RowColor(idx),
+			item.key(),
+			tview.Escape(item.pr.GetTitle()),
+			wtf.RelativeTime(item.pr.GetCreatedAt()),
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(item.key())+len(item.pr.GetTitle()))
+	}
+
+	widget.ScrollableWidget.Redraw(title, str, false)
+}
+
 func (widget *Widget) displayStats(repo *GithubRepo) string {
 	str := fmt.Sprintf(
 		" PRs: %d  Issues: %d  Stars: %d\n",
@@ -89,9 +130,21 @@ func (widget *Widget) displayStats(repo *GithubRepo) string {
 }
 
 func (widget *Widget) title(repo *GithubRepo) string {
+	if repo.origin != "" {
+		return fmt.Sprintf("[green]%s - %s - %s[white]", repo.origin, repo.Owner, repo.Name)
+	}
+
 	return fmt.Sprintf("[green]%s - %s[white]", repo.Owner, repo.Name)
 }
 
+// checksIcons show the state of a pull request's CI checks
+var checksIcons = map[string]string{
+	checksFailure: "[red]●[white]",
+	checksNone:    "[grey]-[white]",
+	checksPending: "[yellow]●[white]",
+	checksSuccess: "[green]●[white]",
+}
+
 var mergeIcons = map[string]string{
 	"dirty":    "[red]![white] ",
 	"clean":    "[green]✔[white] ",
@@ -99,6 +152,15 @@ var mergeIcons = map[string]string{
 	"blocked":  "[red]✖[white] ",
 }
 
+// updatedString returns how long ago the pull request was last updated, such as " 2d ago"
+func updatedString(pr *github.PullRequest) string {
+	if pr.UpdatedAt == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" [gray]%s[white]", wtf.RelativeTime(pr.GetUpdatedAt()))
+}
+
 func (widget *Widget) mergeString(pr *github.PullRequest) string {
 	if !widget.settings.enableStatus {
 		return ""
@@ -6,14 +6,16 @@ import (
 	"net/http"
 
 	ghb "github.com/google/go-github/v26/github"
-	"github.com/wtfutil/wtf/wtf"
+	"github.com/wtfutil/wtf/utils"
 	"golang.org/x/oauth2"
 )
 
 type GithubRepo struct {
-	apiKey    string
-	baseURL   string
-	uploadURL string
+	baseURL     string
+	origin      string
+	tokenSource oauth2.TokenSource
+	uploadURL   string
+	username    string
 
 	Name         string
 	Owner        string
@@ -21,21 +23,23 @@ type GithubRepo struct {
 	RemoteRepo   *ghb.Repository
 }
 
-func NewGithubRepo(name, owner, apiKey, baseURL, uploadURL string) *GithubRepo {
+// NewGithubRepo creates and returns a repository, whose API requests are authenticated
+// with tokens from the token source
+func NewGithubRepo(name, owner string, tokenSource oauth2.TokenSource, baseURL, uploadURL string) *GithubRepo {
 	repo := GithubRepo{
 		Name:  name,
 		Owner: owner,
 
-		apiKey:    apiKey,
-		baseURL:   baseURL,
-		uploadURL: uploadURL,
+		baseURL:     baseURL,
+		tokenSource: tokenSource,
+		uploadURL:   uploadURL,
 	}
 
 	return &repo
 }
 
 func (repo *GithubRepo) Open() {
-	wtf.OpenFile(*repo.RemoteRepo.HTMLURL)
+	utils.OpenURL(*repo.RemoteRepo.HTMLURL)
 }
 
 // Refresh reloads the github data via the Github API
@@ -68,6 +72,16 @@ func (repo *GithubRepo) StarCount() int {
 
 /* -------------------- Unexported Functions -------------------- */
 
+// label names the repository, such as "wtfutil/wtf", after the account it comes from
+// when the widget shows more than one, such as "work: platform/api"
+func (repo *GithubRepo) label() string {
+	if repo.origin == "" {
+		return fmt.Sprintf("%s/%s", repo.Owner, repo.Name)
+	}
+
+	return fmt.Sprintf("%s: %s/%s", repo.origin, repo.Owner, repo.Name)
+}
+
 func (repo *GithubRepo) isGitHubEnterprise() bool {
 	if len(repo.baseURL) > 0 {
 		if len(repo.uploadURL) == 0 {
@@ -79,11 +93,7 @@ func (repo *GithubRepo) isGitHubEnterprise() bool {
 }
 
 func (repo *GithubRepo) oauthClient() *http.Client {
-	tokenService := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: repo.apiKey},
-	)
-
-	return oauth2.NewClient(context.Background(), tokenService)
+	return oauth2.NewClient(context.Background(), repo.tokenSource)
 }
 
 func (repo *GithubRepo) githubClient() (*ghb.Client, error) {
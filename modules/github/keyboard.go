@@ -5,13 +5,21 @@ import (
 )
 
 func (widget *Widget) initializeKeyboardControls() {
-	widget.SetKeyboardChar("/", widget.ShowHelp, "Show/hide this help prompt")
+	widget.BindSearch(&widget.ScrollableWidget)
 	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
 	widget.SetKeyboardChar("l", widget.NextSource, "Select next source")
 	widget.SetKeyboardChar("h", widget.PrevSource, "Select previous source")
 	widget.SetKeyboardChar("o", widget.openRepo, "Open item in browser")
+	widget.SetKeyboardChar("v", widget.toggleReviewQueue, "Switch between the repositories and the review queue")
+	widget.SetKeyboardChar("j", widget.Next, "Select next pull request in the review queue")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous pull request in the review queue")
+	widget.SetKeyboardChar("a", widget.approveSelected, "Approve the selected pull request")
+	widget.SetKeyboardChar("c", widget.requestChanges, "Open the selected pull request's changes to request changes")
+	widget.SetKeyboardChar("y", widget.copyCheckoutCommand, "Copy the command that checks out the selected pull request")
 
 	widget.SetKeyboardKey(tcell.KeyRight, widget.NextSource, "Select next source")
 	widget.SetKeyboardKey(tcell.KeyLeft, widget.PrevSource, "Select previous source")
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next pull request in the review queue")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous pull request in the review queue")
 	widget.SetKeyboardKey(tcell.KeyEnter, widget.openRepo, "Open item in browser")
 }
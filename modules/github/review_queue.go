@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	ghb "github.com/google/go-github/v26/github"
+)
+
+const (
+	checksFailure = "failure"
+	checksNone    = ""
+	checksPending = "pending"
+	checksSuccess = "success"
+)
+
+// reviewItem is a pull request waiting on the user's review, with the state of its
+// CI checks and whether it can be merged
+type reviewItem struct {
+	checks string
+	pr     *ghb.PullRequest
+	repo   *GithubRepo
+}
+
+// checkoutCommand returns the command that checks the pull request out locally
+func (item *reviewItem) checkoutCommand() string {
+	return fmt.Sprintf("gh pr checkout %d --repo %s", item.pr.GetNumber(), item.repo.ghRepoArg())
+}
+
+// key identifies the pull request across repositories, such as "wtfutil/wtf#123", with
+// the account it comes from when there is more than one
+func (item *reviewItem) key() string {
+	return fmt.Sprintf("%s#%d", item.repo.label(), item.pr.GetNumber())
+}
+
+// approve submits a review approving the pull request
+func (repo *GithubRepo) approve(pr *ghb.PullRequest) error {
+	github, err := repo.githubClient()
+	if err != nil {
+		return err
+	}
+
+	review := &ghb.PullRequestReviewRequest{
+		CommitID: pr.GetHead().SHA,
+		Event:    ghb.String("APPROVE"),
+	}
+
+	_, _, err = github.PullRequests.CreateReview(context.Background(), repo.Owner, repo.Name, pr.GetNumber(), review)
+	return err
+}
+
+// checksState sums up the check runs on a commit: failure if any failed, pending if any
+// haven't finished, and success if all passed. Commits with no check runs have none
+func (repo *GithubRepo) checksState(github *ghb.Client, sha string) string {
+	if sha == "" {
+		return checksNone
+	}
+
+	results, _, err := github.Checks.ListCheckRunsForRef(context.Background(), repo.Owner, repo.Name, sha, nil)
+	if err != nil || len(results.CheckRuns) == 0 {
+		return checksNone
+	}
+
+	state := checksSuccess
+	for _, run := range results.CheckRuns {
+		if run.GetStatus() != "completed" {
+			state = checksPending
+			continue
+		}
+
+		switch run.GetConclusion() {
+		case "failure", "timed_out", "cancelled", "action_required":
+			return checksFailure
+		}
+	}
+
+	return state
+}
+
+// ghRepoArg is how the gh command names the repository, with the host in front for
+// GitHub Enterprise
+func (repo *GithubRepo) ghRepoArg() string {
+	if !repo.isGitHubEnterprise() {
+		return fmt.Sprintf("%s/%s", repo.Owner, repo.Name)
+	}
+
+	host := repo.baseURL
+	if parsed, err := url.Parse(repo.baseURL); err == nil {
+		host = parsed.Hostname()
+	}
+
+	return fmt.Sprintf("%s/%s/%s", host, repo.Owner, repo.Name)
+}
+
+// reviewQueue returns the pull requests the user has been asked to review, fetched one
+// by one for their mergeability, with the state of their head commit's checks
+func (repo *GithubRepo) reviewQueue(username string) []*reviewItem {
+	items := []*reviewItem{}
+
+	prs := repo.myReviewRequests(username)
+	if len(prs) == 0 {
+		return items
+	}
+
+	github, err := repo.githubClient()
+	if err != nil {
+		return items
+	}
+
+	for _, pr := range repo.individualPRs(prs) {
+		items = append(items, &reviewItem{
+			checks: repo.checksState(github, pr.GetHead().GetSHA()),
+			pr:     pr,
+			repo:   repo,
+		})
+	}
+
+	return items
+}
+
+// loadReviewQueue gathers the review queue from every repository, with the pull
+// requests that have waited longest first
+func (widget *Widget) loadReviewQueue() []*reviewItem {
+	queue := []*reviewItem{}
+
+	for _, repo := range widget.GithubRepos {
+		queue = append(queue, repo.reviewQueue(repo.username)...)
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].pr.GetCreatedAt().Before(queue[j].pr.GetCreatedAt())
+	})
+
+	return queue
+}
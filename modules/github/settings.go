@@ -14,13 +14,32 @@ const defaultTitle = "GitHub"
 type Settings struct {
 	common *cfg.Common
 
-	apiKey        string        `help:"Your GitHub API token."`
-	baseURL       string        `help:"Your GitHub Enterprise API URL." optional:"true"`
-	customQueries []customQuery `help:"Custom queries allow you to filter pull requests and issues however you like. Give the query a title and a filter. Filters can be copied directly from GitHub’s UI." optional:"true"`
-	enableStatus  bool          `help:"Display pull request mergeability status (‘dirty’, ‘clean’, ‘unstable’, ‘blocked’)." optional:"true"`
-	repositories  []string      `help:"A list of github repositories." values:"Example: wtfutil/wtf"`
-	uploadURL     string        `help:"Your GitHub Enterprise upload URL (often the same as API URL). optional:"true"`
-	username      string        `help:"Your GitHub username. Used to figure out which review requests you’ve been added to."`
+	accounts          []account     `help:"More GitHub or GitHub Enterprise accounts to show in the same widget, each with its own name, apiKey (or app), baseURL, uploadURL, username, and repositories. The settings above are used as the first account." optional:"true"`
+	apiKey            string        `help:"Your GitHub API token. Classic and fine-grained personal access tokens both work."`
+	appID             string        `help:"To authenticate as a GitHub App installation instead of with apiKey, the app’s ID, under app.id." optional:"true"`
+	appInstallationID string        `help:"The ID of the app’s installation on your account or organization, under app.installationID." optional:"true"`
+	appPrivateKeyFile string        `help:"The path to the app’s private key file, under app.privateKeyFile." optional:"true"`
+	baseURL           string        `help:"Your GitHub Enterprise API URL." optional:"true"`
+	customQueries     []customQuery `help:"Custom queries allow you to filter pull requests and issues however you like. Give the query a title and a filter. Filters can be copied directly from GitHub’s UI." optional:"true"`
+	enableStatus      bool          `help:"Display pull request mergeability status (‘dirty’, ‘clean’, ‘unstable’, ‘blocked’)." optional:"true"`
+	repositories      []string      `help:"A list of github repositories." values:"Example: wtfutil/wtf"`
+	reviewQueue       bool          `help:"Whether to open on the review queue, which lists the pull requests waiting on your review in every repository with their CI checks, mergeability, and age, rather than on the repositories. Press v to switch." optional:"true"`
+	uploadURL         string        `help:"Your GitHub Enterprise upload URL (often the same as API URL). optional:"true"`
+	username          string        `help:"Your GitHub username. Used to figure out which review requests you’ve been added to."`
+}
+
+// account is a GitHub or GitHub Enterprise login and the repositories watched with it.
+// Its name labels the rows that come from it
+type account struct {
+	apiKey            string
+	appID             string
+	appInstallationID string
+	appPrivateKeyFile string
+	baseURL           string
+	name              string
+	repositories      []string
+	uploadURL         string
+	username          string
 }
 
 type customQuery struct {
@@ -34,18 +53,72 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
 
-		apiKey:       ymlConfig.UString("apiKey", os.Getenv("WTF_GITHUB_TOKEN")),
-		baseURL:      ymlConfig.UString("baseURL", os.Getenv("WTF_GITHUB_BASE_URL")),
-		enableStatus: ymlConfig.UBool("enableStatus", false),
-		uploadURL:    ymlConfig.UString("uploadURL", os.Getenv("WTF_GITHUB_UPLOAD_URL")),
-		username:     ymlConfig.UString("username"),
+		apiKey:            ymlConfig.UString("apiKey", os.Getenv("WTF_GITHUB_TOKEN")),
+		appID:             ymlConfig.UString("app.id", os.Getenv("WTF_GITHUB_APP_ID")),
+		appInstallationID: ymlConfig.UString("app.installationID", os.Getenv("WTF_GITHUB_APP_INSTALLATION_ID")),
+		appPrivateKeyFile: ymlConfig.UString("app.privateKeyFile", os.Getenv("WTF_GITHUB_APP_PRIVATE_KEY_FILE")),
+		baseURL:           ymlConfig.UString("baseURL", os.Getenv("WTF_GITHUB_BASE_URL")),
+		enableStatus:      ymlConfig.UBool("enableStatus", false),
+		reviewQueue:       ymlConfig.UBool("reviewQueue", false),
+		uploadURL:         ymlConfig.UString("uploadURL", os.Getenv("WTF_GITHUB_UPLOAD_URL")),
+		username:          ymlConfig.UString("username"),
 	}
 	settings.repositories = parseRepositories(ymlConfig)
 	settings.customQueries = parseCustomQueries(ymlConfig)
+	settings.accounts = parseAccounts(ymlConfig, &settings)
 
 	return &settings
 }
 
+// parseAccounts returns the accounts to show: the one set up by the module's own
+// settings, if it has any repositories, followed by those under "accounts"
+// Example:
+//
+//	accounts:
+//	  - name: work
+//	    apiKey: "p-xxxxxxxxxxx"
+//	    baseURL: "https://github.example.com/api/v3/"
+//	    username: "senorprogrammer"
+//	    repositories:
+//	      - "platform/api"
+func parseAccounts(ymlConfig *config.Config, settings *Settings) []account {
+	accounts := []account{}
+
+	if len(settings.repositories) > 0 {
+		accounts = append(accounts, account{
+			apiKey:            settings.apiKey,
+			appID:             settings.appID,
+			appInstallationID: settings.appInstallationID,
+			appPrivateKeyFile: settings.appPrivateKeyFile,
+			baseURL:           settings.baseURL,
+			name:              apiHost(settings.baseURL),
+			repositories:      settings.repositories,
+			uploadURL:         settings.uploadURL,
+			username:          settings.username,
+		})
+	}
+
+	for _, value := range ymlConfig.UList("accounts") {
+		accountConfig := &config.Config{Root: value}
+
+		acct := account{
+			apiKey:            accountConfig.UString("apiKey"),
+			appID:             accountConfig.UString("app.id"),
+			appInstallationID: accountConfig.UString("app.installationID"),
+			appPrivateKeyFile: accountConfig.UString("app.privateKeyFile"),
+			baseURL:           accountConfig.UString("baseURL"),
+			repositories:      parseRepositories(accountConfig),
+			uploadURL:         accountConfig.UString("uploadURL"),
+			username:          accountConfig.UString("username", settings.username),
+		}
+		acct.name = accountConfig.UString("name", apiHost(acct.baseURL))
+
+		accounts = append(accounts, acct)
+	}
+
+	return accounts
+}
+
 func parseRepositories(ymlConfig *config.Config) []string {
 
 	result := []string{}
@@ -1,38 +1,54 @@
 package github
 
 import (
+	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
+	"golang.org/x/oauth2"
 )
 
 type Widget struct {
 	wtf.MultiSourceWidget
 	wtf.KeyboardWidget
-	wtf.TextWidget
+	wtf.ScrollableWidget
 
 	GithubRepos []*GithubRepo
 
-	settings *Settings
+	reviewQueue     []*reviewItem
+	reviewRequests  map[string]bool
+	settings        *Settings
+	showReviewQueue bool
 }
 
 func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
 		KeyboardWidget:    wtf.NewKeyboardWidget(app, pages, settings.common),
 		MultiSourceWidget: wtf.NewMultiSourceWidget(settings.common, "repository", "repositories"),
-		TextWidget:        wtf.NewTextWidget(app, settings.common, true),
+		ScrollableWidget:  wtf.NewScrollableWidget(app, settings.common, true),
 
-		settings: settings,
+		settings:        settings,
+		showReviewQueue: settings.reviewQueue,
 	}
 
-	widget.GithubRepos = widget.buildRepoCollection(widget.settings.repositories)
+	widget.GithubRepos = widget.buildRepoCollection(widget.settings.accounts)
+	if len(widget.settings.accounts) > 0 {
+		widget.SetQuotaHost(apiHost(widget.settings.accounts[0].baseURL))
+	}
 
 	widget.initializeKeyboardControls()
 	widget.View.SetInputCapture(widget.InputCapture)
 	widget.SetDisplayFunction(widget.display)
+	widget.SetRenderFunction(widget.display)
 
-	widget.Sources = widget.settings.repositories
+	widget.Sources = []string{}
+	for _, repo := range widget.GithubRepos {
+		widget.Sources = append(widget.Sources, repo.label())
+	}
 
 	widget.KeyboardWidget.SetView(widget.View)
 
@@ -46,6 +62,18 @@ func (widget *Widget) Refresh() {
 		repo.Refresh()
 	}
 
+	widget.notifyReviewRequests()
+
+	if widget.showReviewQueue {
+		widget.reviewQueue = widget.loadReviewQueue()
+	}
+	widget.SetItemCount(len(widget.reviewQueue))
+
+	widget.display()
+}
+
+// RenderRelativeTimes redraws the pull requests so that their update times stay current
+func (widget *Widget) RenderRelativeTimes() {
 	widget.display()
 }
 
@@ -55,26 +83,71 @@ func (widget *Widget) HelpText() string {
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (widget *Widget) buildRepoCollection(repoData []string) []*GithubRepo {
+// apiHost returns the host of the GitHub API the widget talks to
+func apiHost(baseURL string) string {
+	if baseURL == "" {
+		baseURL = defaultAPIURL
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Hostname()
+}
+
+// buildRepoCollection creates the repositories of every account. When there is more
+// than one account, each repository is labelled with the account it comes from
+func (widget *Widget) buildRepoCollection(accounts []account) []*GithubRepo {
 	githubRepos := []*GithubRepo{}
 
-	for _, repo := range repoData {
-		split := strings.Split(repo, "/")
-		owner, name := split[0], split[1]
-		repo := NewGithubRepo(
-			name,
-			owner,
-			widget.settings.apiKey,
-			widget.settings.baseURL,
-			widget.settings.uploadURL,
-		)
+	for _, acct := range accounts {
+		tokenSource := widget.tokenSource(acct)
 
-		githubRepos = append(githubRepos, repo)
+		for _, repo := range acct.repositories {
+			split := strings.Split(repo, "/")
+			if len(split) < 2 {
+				logger.For(widget.Name()).Warnf("'%s' is not in owner/name form", repo)
+				continue
+			}
+
+			owner, name := split[0], split[1]
+			repo := NewGithubRepo(
+				name,
+				owner,
+				tokenSource,
+				acct.baseURL,
+				acct.uploadURL,
+			)
+			repo.username = acct.username
+
+			if len(accounts) > 1 {
+				repo.origin = acct.name
+			}
+
+			githubRepos = append(githubRepos, repo)
+		}
 	}
 
 	return githubRepos
 }
 
+// tokenSource returns where the account's API tokens come from: the GitHub App's
+// installation, if one is set up, or else the personal access token
+func (widget *Widget) tokenSource(acct account) oauth2.TokenSource {
+	if acct.appID != "" {
+		source, err := newAppTokenSource(acct.baseURL, acct.appID, acct.appInstallationID, acct.appPrivateKeyFile)
+		if err == nil {
+			return source
+		}
+
+		logger.For("github").Errorf("authenticating as app %s: %v", acct.appID, err)
+	}
+
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: acct.apiKey})
+}
+
 func (widget *Widget) currentGithubRepo() *GithubRepo {
 	if len(widget.GithubRepos) == 0 {
 		return nil
@@ -87,10 +160,99 @@ func (widget *Widget) currentGithubRepo() *GithubRepo {
 	return widget.GithubRepos[widget.Idx]
 }
 
+// notifyReviewRequests sends a notification for each pull request the user has been
+// asked to review since the last refresh, and publishes how many reviews are waiting
+func (widget *Widget) notifyReviewRequests() {
+	reviewRequests := make(map[string]bool)
+
+	for _, repo := range widget.GithubRepos {
+		for _, pr := range repo.myReviewRequests(repo.username) {
+			key := fmt.Sprintf("%s#%d", repo.label(), pr.GetNumber())
+			reviewRequests[key] = true
+
+			if widget.reviewRequests != nil && !widget.reviewRequests[key] {
+				widget.Notify("Review requested", fmt.Sprintf("%s: %s", key, pr.GetTitle()))
+			}
+		}
+	}
+
+	widget.reviewRequests = reviewRequests
+	widget.PublishData("reviewRequests", len(reviewRequests))
+}
+
 func (widget *Widget) openRepo() {
+	if widget.showReviewQueue {
+		if item := widget.selectedReviewItem(); item != nil {
+			utils.OpenURL(item.pr.GetHTMLURL())
+		}
+		return
+	}
+
 	repo := widget.currentGithubRepo()
 
 	if repo != nil {
 		repo.Open()
 	}
 }
+
+/* -------------------- Review Queue -------------------- */
+
+// approveSelected approves the selected pull request in the review queue, once the
+// approval has been confirmed
+func (widget *Widget) approveSelected() {
+	item := widget.selectedReviewItem()
+	if item == nil {
+		return
+	}
+
+	widget.Confirm(fmt.Sprintf("Approve %s?", item.key()), func() {
+		if err := item.repo.approve(item.pr); err != nil {
+			widget.RedrawError(widget.CommonSettings().Title, fmt.Errorf("approving %s: %v", item.key(), err))
+			return
+		}
+
+		widget.Refresh()
+	})
+}
+
+// copyCheckoutCommand copies the command that checks out the selected pull request
+func (widget *Widget) copyCheckoutCommand() {
+	item := widget.selectedReviewItem()
+	if item == nil {
+		return
+	}
+
+	if err := wtf.CopyToClipboard(item.checkoutCommand()); err != nil {
+		logger.For(widget.Name()).Errorf("copying the checkout command: %v", err)
+	}
+}
+
+// requestChanges opens the selected pull request's changes in the browser, where the
+// review comments explaining the changes can be written
+func (widget *Widget) requestChanges() {
+	if item := widget.selectedReviewItem(); item != nil {
+		utils.OpenURL(item.pr.GetHTMLURL() + "/files")
+	}
+}
+
+func (widget *Widget) selectedReviewItem() *reviewItem {
+	if !widget.showReviewQueue {
+		return nil
+	}
+
+	selected := widget.GetSelected()
+	if selected < 0 || selected >= len(widget.reviewQueue) {
+		return nil
+	}
+
+	return widget.reviewQueue[selected]
+}
+
+// toggleReviewQueue switches between the repository view and the review queue
+func (widget *Widget) toggleReviewQueue() {
+	widget.showReviewQueue = !widget.showReviewQueue
+	widget.reviewQueue = []*reviewItem{}
+	widget.Unselect()
+
+	widget.Refresh()
+}
@@ -35,6 +35,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 	}
 
 	widget.GitlabProjects = widget.buildProjectCollection(settings.projects)
+	widget.SetQuotaHost(gitlab.BaseURL().Hostname())
 
 	widget.initializeKeyboardControls()
 	widget.View.SetInputCapture(widget.InputCapture)
@@ -9,6 +9,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func GetMessages(roomId string, numberOfMessages int, apiToken string) ([]Message, error) {
@@ -35,7 +37,7 @@ func GetRoom(roomUri, apiToken string) (*Room, error) {
 	parseJson(&rooms, resp.Body)
 
 	for _, room := range rooms.Results {
-		logger.Log(fmt.Sprintf("room: %s", room))
+		logger.For("gitter").Debugf("room: %s", room)
 		if room.URI == roomUri {
 			return &room, nil
 		}
@@ -55,14 +57,14 @@ func apiRequest(path, apiToken string) (*http.Response, error) {
 	bearer := fmt.Sprintf("Bearer %s", apiToken)
 	req.Header.Add("Authorization", bearer)
 
-	httpClient := &http.Client{}
+	httpClient := wtf.HTTPClient()
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, fmt.Errorf(resp.Status)
+		return nil, wtf.NewHTTPError(resp)
 	}
 
 	return resp, nil
@@ -71,7 +73,8 @@ func apiRequest(path, apiToken string) (*http.Response, error) {
 func parseJson(obj interface{}, text io.Reader) {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		logger.For("gitter").Errorf("reading the response: %v", err)
+		return
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -80,7 +83,8 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			logger.For("gitter").Errorf("parsing the response: %v", err)
+			return
 		}
 	}
 }
@@ -43,7 +43,7 @@ func (widget *Widget) Refresh() {
 
 	room, err := GetRoom(widget.settings.roomURI, widget.settings.apiToken)
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 
@@ -55,7 +55,7 @@ func (widget *Widget) Refresh() {
 	messages, err := GetMessages(room.ID, widget.settings.numberOfMessages, widget.settings.apiToken)
 
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 	widget.messages = messages
@@ -3,10 +3,10 @@ package googleanalytics
 import (
 	"net/http"
 	"io/ioutil"
-	"log"
 	"fmt"
 	"time"
 
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/utils"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -18,43 +18,44 @@ type websiteReport struct {
     Report *ga.GetReportsResponse
 }
 
-func (widget *Widget) Fetch() ([]websiteReport) {
+func (widget *Widget) Fetch() ([]websiteReport, error) {
 	secretPath, err := utils.ExpandHomeDir(widget.settings.secretFile)
 	if err != nil {
-		log.Fatalf("Unable to parse secretFile path")
+		logger.For("googleanalytics").Errorf("finding the secret file: %v", err)
+		return nil, fmt.Errorf("finding the secret file: %v", err)
 	}
 
 	service, err := makeReportService(secretPath)
 	if err != nil {
-		log.Fatalf("Unable to create Google Analytics Reporting Service")
+		logger.For("googleanalytics").Errorf("creating the reporting service: %v", err)
+		return nil, err
 	}
 
-	visitorsDataArray := getReports(service, widget.settings.viewIds, widget.settings.months)
-	return visitorsDataArray
+	return getReports(service, widget.settings.viewIds, widget.settings.months)
 }
 
 func makeReportService(secretPath string) (*ga.Service, error) {
 	clientSecret, err := ioutil.ReadFile(secretPath)
 	if err != nil {
-		log.Fatalf("Unable to read secretPath. %v", err)
+		return nil, fmt.Errorf("reading the secret file: %v", err)
 	}
 
 	jwtConfig, err := google.JWTConfigFromJSON(clientSecret, ga.AnalyticsReadonlyScope)
 	if err != nil {
-		log.Fatalf("Unable to get config from JSON. %v", err)
+		return nil, fmt.Errorf("parsing the secret file: %v", err)
 	}
 
 	var netClient *http.Client
 	netClient = jwtConfig.Client(oauth2.NoContext)
 	svc, err := ga.New(netClient)
 	if err != nil {
-		log.Fatalf("Failed to create Google Analytics Reporting Service")
+		return nil, fmt.Errorf("creating the reporting service: %v", err)
 	}
 
-	return svc, err
+	return svc, nil
 }
 
-func getReports(service *ga.Service, viewIds map[string]interface{}, displayedMonths int) ([]websiteReport) {
+func getReports(service *ga.Service, viewIds map[string]interface{}, displayedMonths int) ([]websiteReport, error) {
 	startDate := fmt.Sprintf("%s-01", time.Now().AddDate(0, -displayedMonths+1, 0).Format("2006-01"))
 	var websiteReports []websiteReport = nil
 
@@ -80,14 +81,16 @@ func getReports(service *ga.Service, viewIds map[string]interface{}, displayedMo
 		response, err := service.Reports.BatchGet(req).Do()
 
 		if err != nil {
-			log.Fatalf("GET request to analyticsreporting/v4 returned error with viewID: %s", viewId)
+			logger.For("googleanalytics").Errorf("fetching the report for view %s: %v", viewId, err)
+			return nil, fmt.Errorf("fetching the report for view %s: %v", viewId, err)
 		}
 		if response.HTTPStatusCode != 200 {
-			log.Fatalf("Did not get expected HTTP response code")
+			logger.For("googleanalytics").Errorf("fetching the report for view %s: HTTP %d", viewId, response.HTTPStatusCode)
+			return nil, fmt.Errorf("fetching the report for view %s: HTTP %d", viewId, response.HTTPStatusCode)
 		}
 
 		report := websiteReport{Name: website, Report: response,}
 		websiteReports = append(websiteReports, report)
 	}
-	return websiteReports
+	return websiteReports, nil
 }
@@ -22,7 +22,12 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 }
 
 func (widget *Widget) Refresh() {
-	websiteReports := widget.Fetch()
+	websiteReports, err := widget.Fetch()
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
 	contentTable := widget.createTable(websiteReports)
 
 	widget.Redraw(widget.CommonSettings().Title, contentTable, false)
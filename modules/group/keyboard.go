@@ -0,0 +1,6 @@
+package group
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("[", widget.Prev, "Select the previous widget in the group")
+	widget.SetKeyboardChar("]", widget.Next, "Select the next widget in the group")
+}
@@ -0,0 +1,17 @@
+package group
+
+import (
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func init() {
+	wtf.RegisterModule(wtf.ModuleType{
+		MakeContainer: func(app *tview.Application, pages *tview.Pages, name string, moduleConfig, globalConfig *config.Config, children []wtf.Wtfable) wtf.Wtfable {
+			return NewWidget(app, pages, NewSettingsFromYAML(name, moduleConfig, globalConfig), children)
+		},
+		Name:     "group",
+		Settings: Settings{},
+	})
+}
@@ -0,0 +1,29 @@
+package group
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Group"
+
+type Settings struct {
+	common *cfg.Common
+
+	columns []int    `help:"The widths of the group's columns, in the same form as the columns of the main grid." values:"A list of widths. 0 shares the remaining space equally." optional:"true"`
+	rows    []int    `help:"The heights of the group's rows, in the same form as the rows of the main grid." values:"A list of heights. 0 shares the remaining space equally." optional:"true"`
+	widgets []string `help:"The names of the modules shown in the group. Each is configured in the mods section like any other module, with a position within the group's own rows and columns."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		columns: wtf.ToInts(ymlConfig.UList("columns")),
+		rows:    wtf.ToInts(ymlConfig.UList("rows")),
+		widgets: wtf.ToStrs(ymlConfig.UList("widgets")),
+	}
+
+	return &settings
+}
@@ -0,0 +1,221 @@
+package group
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Widget lays out its child widgets on a grid of its own within a single cell of the
+// main grid, so that parts of a layout can be nested instead of adding rows and columns
+// to the whole display
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.TextWidget
+
+	app      *tview.Application
+	children []wtf.Wtfable
+	grid     *tview.Grid
+	selected int
+	settings *Settings
+}
+
+// NewWidget creates and returns an instance of Widget that lays out the given child
+// widgets
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings, children []wtf.Wtfable) *Widget {
+	widget := Widget{
+		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
+		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
+
+		app:      app,
+		children: children,
+		settings: settings,
+	}
+
+	widget.grid = widget.buildGrid()
+
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	// The children draw their own borders and titles in place of the group's
+	widget.View.SetBorder(false)
+	widget.View.SetDrawFunc(widget.draw)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Children returns the widgets in the group
+func (widget *Widget) Children() []wtf.Wtfable {
+	return widget.children
+}
+
+// Disable disables the group and every one of its children
+func (widget *Widget) Disable() {
+	widget.TextWidget.Disable()
+
+	for _, child := range widget.children {
+		child.Disable()
+	}
+}
+
+// HelpText returns the group's own keys followed by those of the selected child
+func (widget *Widget) HelpText() string {
+	text := widget.KeyboardWidget.HelpText()
+
+	if child := widget.Selected(); child != nil {
+		text += "\n" + child.HelpText()
+	}
+
+	return text
+}
+
+// InputCapture handles the group's own keys, and passes every other key press on to
+// the selected child
+func (widget *Widget) InputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if widget.KeyboardWidget.InputCapture(event) == nil {
+		return nil
+	}
+
+	child := widget.Selected()
+	if child == nil {
+		return event
+	}
+
+	if handler := child.TextView().InputHandler(); handler != nil {
+		handler(event, func(tview.Primitive) {})
+	}
+
+	return nil
+}
+
+// Next selects the next child in the group, wrapping around to the first after the last
+func (widget *Widget) Next() {
+	widget.step(1)
+}
+
+// Prev selects the previous child in the group, wrapping around to the last before the
+// first
+func (widget *Widget) Prev() {
+	widget.step(-1)
+}
+
+// Refresh refreshes every one of the group's children
+func (widget *Widget) Refresh() {
+	for _, child := range widget.children {
+		if child.Enabled() {
+			child.Refresh()
+		}
+	}
+}
+
+// SearchCapture passes the key press on to the selected child if it is searching its
+// rows
+func (widget *Widget) SearchCapture(event *tcell.EventKey) bool {
+	child := widget.Selected()
+	if child == nil {
+		return false
+	}
+
+	return wtf.SearchCapture(child, event)
+}
+
+// Selected returns the child that key presses go to while the group has focus, or nil
+// if the group has no children
+func (widget *Widget) Selected() wtf.Wtfable {
+	if len(widget.children) == 0 {
+		return nil
+	}
+
+	return widget.children[widget.selected]
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// buildGrid places the children on the group's grid at their positions. If the group
+// does not set its columns or rows, there are as many as the children need, sharing
+// the space equally
+func (widget *Widget) buildGrid() *tview.Grid {
+	columns := widget.settings.columns
+	rows := widget.settings.rows
+
+	if len(columns) == 0 || len(rows) == 0 {
+		maxColumn, maxRow := 1, 1
+
+		for _, child := range widget.children {
+			common := child.CommonSettings()
+
+			if common.Left+common.Width > maxColumn {
+				maxColumn = common.Left + common.Width
+			}
+			if common.Top+common.Height > maxRow {
+				maxRow = common.Top + common.Height
+			}
+		}
+
+		if len(columns) == 0 {
+			columns = make([]int, maxColumn)
+		}
+		if len(rows) == 0 {
+			rows = make([]int, maxRow)
+		}
+	}
+
+	grid := tview.NewGrid()
+	grid.SetColumns(columns...)
+	grid.SetRows(rows...)
+	grid.SetBorder(false)
+
+	for _, child := range widget.children {
+		common := child.CommonSettings()
+
+		grid.AddItem(
+			child.TextView(),
+			common.Top,
+			common.Left,
+			max(common.Height, 1),
+			max(common.Width, 1),
+			0,
+			0,
+			false,
+		)
+	}
+
+	return grid
+}
+
+// draw draws the group's grid of children in the group's place, showing the selected
+// child as focused while the group has focus
+func (widget *Widget) draw(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+	focused := widget.View.HasFocus()
+
+	for idx, child := range widget.children {
+		wtf.FocusChild(child, focused && idx == widget.selected)
+	}
+
+	widget.grid.SetRect(x, y, width, height)
+	widget.grid.Draw(screen)
+
+	// Nothing of the group's own is drawn on top of the children
+	return x, y, 0, 0
+}
+
+func (widget *Widget) step(delta int) {
+	count := len(widget.children)
+	if count == 0 {
+		return
+	}
+
+	widget.selected = (widget.selected + delta + count) % count
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -6,21 +6,12 @@
 package gspreadsheets
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
-	"net/http"
-	"net/url"
-	"os"
-	"os/user"
-	"path/filepath"
-	"strings"
 
+	"github.com/wtfutil/wtf/oauth"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
-	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	sheets "google.golang.org/api/sheets/v4"
 )
@@ -28,118 +19,55 @@ import (
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Fetch() ([]*sheets.ValueRange, error) {
-	ctx := context.Background()
-
-	secretPath, _ := utils.ExpandHomeDir(widget.settings.secretFile)
-
-	b, err := ioutil.ReadFile(secretPath)
-	if err != nil {
-		log.Fatalf("Unable to read secretPath. %v", err)
-		return nil, err
-	}
-
-	config, err := google.ConfigFromJSON(b, "https://www.googleapis.com/auth/spreadsheets.readonly")
-
-	if err != nil {
-		log.Fatalf("Unable to get config from JSON. %v", err)
-		return nil, err
-	}
-	client := getClient(ctx, config)
+	client := widget.session.Client()
 
 	srv, err := sheets.New(client)
 	if err != nil {
-		log.Fatalf("Unable to get create server. %v", err)
-		return nil, err
+		return nil, fmt.Errorf("creating the sheets service: %v", err)
 	}
 
 	cells := wtf.ToStrs(widget.settings.cellAddresses)
-	addresses := strings.Join(cells[:], ";")
 
 	responses := make([]*sheets.ValueRange, len(cells))
 
 	for i := 0; i < len(cells); i++ {
 		resp, err := srv.Spreadsheets.Values.Get(widget.settings.sheetID, cells[i]).Do()
 		if err != nil {
-			log.Fatalf("Error fetching cells %s", addresses)
-			return nil, err
+			return nil, fmt.Errorf("fetching cell %s: %v", cells[i], err)
 		}
 		responses[i] = resp
 	}
 
-	return responses, err
+	return responses, nil
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
-// getClient uses a Context and Config to retrieve a Token
-// then generate a Client. It returns the generated Client.
-func getClient(ctx context.Context, config *oauth2.Config) *http.Client {
-	cacheFile, err := tokenCacheFile()
-	if err != nil {
-		log.Fatalf("Unable to get path to cached credential file. %v", err)
-	}
-	tok, err := tokenFromFile(cacheFile)
-	if err != nil {
-		tok = getTokenFromWeb(config)
-		saveToken(cacheFile, tok)
-	}
-	return config.Client(ctx, tok)
-}
-
-// getTokenFromWeb uses Config to request a Token.
-// It returns the retrieved Token.
-func getTokenFromWeb(config *oauth2.Config) *oauth2.Token {
-	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
-	fmt.Printf("Go to the following link in your browser then type the "+
-		"authorization code: \n%v\n", authURL)
-
-	var code string
-	if _, err := fmt.Scan(&code); err != nil {
-		log.Fatalf("Unable to read authorization code %v", err)
-	}
+// oauthConfig returns the config to sign in to Google with, using the client ID and
+// secret from the secret file that Google gives out for an OAuth client
+func (widget *Widget) oauthConfig() (*oauth.Config, error) {
+	secretPath, _ := utils.ExpandHomeDir(widget.settings.secretFile)
 
-	tok, err := config.Exchange(oauth2.NoContext, code)
+	b, err := ioutil.ReadFile(secretPath)
 	if err != nil {
-		log.Fatalf("Unable to retrieve token from web %v", err)
+		return nil, fmt.Errorf("reading the secret file: %v", err)
 	}
-	return tok
-}
 
-// tokenCacheFile generates credential file path/filename.
-// It returns the generated credential path/filename.
-func tokenCacheFile() (string, error) {
-	usr, err := user.Current()
+	googleConfig, err := google.ConfigFromJSON(b, sheets.SpreadsheetsReadonlyScope)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("parsing the secret file: %v", err)
 	}
-	tokenCacheDir := filepath.Join(usr.HomeDir, ".credentials")
-	os.MkdirAll(tokenCacheDir, 0700)
-	return filepath.Join(tokenCacheDir,
-		url.QueryEscape("spreadsheets-go-quickstart.json")), err
-}
 
-// tokenFromFile retrieves a Token from a given file path.
-// It returns the retrieved Token and any read error encountered.
-func tokenFromFile(file string) (*oauth2.Token, error) {
-	f, err := os.Open(file)
-	if err != nil {
-		return nil, err
-	}
-	t := &oauth2.Token{}
-	err = json.NewDecoder(f).Decode(t)
-	defer f.Close()
-	return t, err
-}
+	provider, _ := oauth.LookupProvider("google")
 
-// saveToken uses a file path to create a file and store the
-// token in it.
-func saveToken(file string, token *oauth2.Token) {
-	fmt.Printf("Saving credential file to: %s\n", file)
-	f, err := os.OpenFile(file, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-	if err != nil {
-		log.Fatalf("Unable to cache oauth token: %v", err)
+	config := &oauth.Config{
+		CallbackPort: widget.settings.callbackPort,
+		ClientID:     googleConfig.ClientID,
+		ClientSecret: googleConfig.ClientSecret,
+		Flow:         widget.settings.flow,
+		Provider:     provider,
+		Scopes:       googleConfig.Scopes,
 	}
-	defer f.Close()
 
-	json.NewEncoder(f).Encode(token)
+	return config, nil
 }
@@ -3,6 +3,7 @@ package gspreadsheets
 import (
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/oauth"
 )
 
 const defaultTitle = "Google Spreadsheets"
@@ -15,8 +16,10 @@ type Settings struct {
 	colors
 	common *cfg.Common
 
+	callbackPort  string
 	cellAddresses []interface{}
 	cellNames     []interface{}
+	flow          string
 	secretFile    string
 	sheetID       string
 }
@@ -26,9 +29,11 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
 
-		cellNames:  ymlConfig.UList("cells.names"),
-		secretFile: ymlConfig.UString("secretFile"),
-		sheetID:    ymlConfig.UString("sheetId"),
+		callbackPort: ymlConfig.UString("callbackPort", "8080"),
+		cellNames:    ymlConfig.UList("cells.names"),
+		flow:         ymlConfig.UString("flow", oauth.FlowCallback),
+		secretFile:   ymlConfig.UString("secretFile"),
+		sheetID:      ymlConfig.UString("sheetId"),
 	}
 
 	settings.colors.values = ymlConfig.UString("colors.values", "green")
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/oauth"
 	"github.com/wtfutil/wtf/wtf"
 	sheets "google.golang.org/api/sheets/v4"
 )
@@ -11,9 +13,12 @@ import (
 type Widget struct {
 	wtf.TextWidget
 
+	session  *oauth.Session
 	settings *Settings
 }
 
+// NewWidget creates a new instance of a widget. The user signs in to Google once, and the
+// sign-in is kept under the widget's name
 func NewWidget(app *tview.Application, settings *Settings) *Widget {
 	widget := Widget{
 		TextWidget: wtf.NewTextWidget(app, settings.common, false),
@@ -21,13 +26,30 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 		settings: settings,
 	}
 
+	widget.session = oauth.NewSession(widget.Name(), oauth.DefaultTokenStore(), widget.Refresh)
+	go widget.session.SignIn(widget.oauthConfig)
+
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
-	cells, _ := widget.Fetch()
+	if widget.Disabled() {
+		return
+	}
+
+	if widget.session.Client() == nil {
+		widget.Redraw(widget.CommonSettings().Title, widget.session.PromptText(), true)
+		return
+	}
+
+	cells, err := widget.Fetch()
+	if err != nil {
+		logger.For(widget.Name()).Errorf("fetching the cells: %v", err)
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
 
 	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(cells), false)
 }
@@ -0,0 +1,96 @@
+package habits
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wtfutil/wtf/i18n"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+func (widget *Widget) contentFrom(habits []Habit, today time.Time) string {
+	if len(habits) == 0 {
+		return " No habits configured"
+	}
+
+	dateTime := widget.settings.common.DateTime
+
+	str := ""
+	for idx, habit := range habits {
+		done := widget.log[habit.Name]
+
+		check := widget.settings.common.Sigils.Checkbox.Unchecked
+		if habit.Done(done, today, dateTime) {
+			check = widget.settings.common.Sigils.Checkbox.Checked
+		}
+
+		unit := "d"
+		if habit.Frequency == frequencyWeekly {
+			unit = "w"
+		}
+
+		row := fmt.Sprintf(
+			"[%s]|%s| %s [%s]%d%s[white]",
+			widget.RowColor(idx),
+			check,
+			habit.Name,
+			widget.settings.colors.done,
+			habit.Streak(done, today, dateTime),
+			unit,
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(habit.Name))
+	}
+
+	selected := widget.GetSelected()
+	if selected < 0 {
+		selected = 0
+	}
+
+	str += "\n" + widget.heatmap(habits[selected], today)
+
+	return str
+}
+
+// heatmap draws the month as a calendar, with the days the habit was done filled in
+func (widget *Widget) heatmap(habit Habit, today time.Time) string {
+	done := widget.log[habit.Name]
+	dateTime := widget.settings.common.DateTime
+	locale := i18n.Current()
+
+	str := fmt.Sprintf(" [::b]%s[::-] %s\n ", habit.Name, i18n.FormatDate(today, "January"))
+	for _, weekday := range dateTime.Weekdays() {
+		str += fmt.Sprintf("%-3s", shortDay(locale.ShortWeekdays[weekday]))
+	}
+	str += "\n "
+
+	first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	for day := dateTime.StartOfWeek(first); day.Month() == today.Month() || day.Before(first); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == dateTime.WeekStart && day.After(first) {
+			str += "\n "
+		}
+
+		switch {
+		case day.Before(first):
+			str += "   "
+		case done[day.Format(dayFormat)]:
+			str += fmt.Sprintf("[%s]■[white]  ", widget.settings.colors.done)
+		case day.After(today):
+			str += "·  "
+		default:
+			str += fmt.Sprintf("[%s]□[white]  ", widget.settings.colors.missed)
+		}
+	}
+
+	return str + "\n"
+}
+
+// shortDay shortens a weekday's name to two letters, to fit a calendar column
+func shortDay(name string) string {
+	runes := []rune(name)
+	if len(runes) > 2 {
+		return string(runes[:2])
+	}
+
+	return name
+}
@@ -0,0 +1,81 @@
+package habits
+
+import (
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const (
+	frequencyDaily  = "daily"
+	frequencyWeekly = "weekly"
+)
+
+// dayFormat is how the days a habit was done are written in the habits file
+const dayFormat = "2006-01-02"
+
+// Habit is something to be done every day or every week
+type Habit struct {
+	Frequency string
+	Name      string
+}
+
+// days are the days a habit was done, keyed in dayFormat
+type days map[string]bool
+
+/* -------------------- Exported Functions -------------------- */
+
+// Done returns true if the habit has been done today or, for a weekly habit, this week
+func (habit *Habit) Done(done days, today time.Time, dateTime cfg.DateTimeSettings) bool {
+	if habit.Frequency == frequencyWeekly {
+		return done.inWeek(dateTime.StartOfWeek(today))
+	}
+
+	return done[today.Format(dayFormat)]
+}
+
+// Streak returns how many days or, for a weekly habit, weeks in a row the habit has
+// been done. A streak isn't broken until the day or week is over, so one that hasn't
+// been kept up today or this week yet counts up to yesterday or last week
+func (habit *Habit) Streak(done days, today time.Time, dateTime cfg.DateTimeSettings) int {
+	streak := 0
+
+	if habit.Frequency == frequencyWeekly {
+		week := dateTime.StartOfWeek(today)
+		if !done.inWeek(week) {
+			week = week.AddDate(0, 0, -7)
+		}
+
+		for done.inWeek(week) {
+			streak++
+			week = week.AddDate(0, 0, -7)
+		}
+
+		return streak
+	}
+
+	day := today
+	if !done[day.Format(dayFormat)] {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	for done[day.Format(dayFormat)] {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return streak
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// inWeek returns true if any of the seven days from the start of the week are done
+func (done days) inWeek(start time.Time) bool {
+	for i := 0; i < 7; i++ {
+		if done[start.AddDate(0, 0, i).Format(dayFormat)] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,17 @@
+package habits
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next habit")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous habit")
+	widget.SetKeyboardChar(" ", widget.toggleSelected, "Check off the habit for today, or undo it")
+	widget.SetKeyboardChar("x", widget.toggleSelected, "Check off the habit for today, or undo it")
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next habit")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous habit")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.toggleSelected, "Check off the habit for today, or undo it")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
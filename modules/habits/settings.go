@@ -0,0 +1,62 @@
+package habits
+
+import (
+	"fmt"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Habits"
+
+type colors struct {
+	done   string
+	missed string
+}
+
+type Settings struct {
+	colors
+	common *cfg.Common
+
+	filePath string  `help:"The file, in the config directory, that the days each habit was done are kept in." optional:"true" default:"habits.json"`
+	habits   []Habit `help:"The habits to track. Each is either a name, for a daily habit, or a name and a frequency." values:"A list of names, or of maps with name and frequency (daily or weekly)."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		filePath: ymlConfig.UString("filename", "habits.json"),
+		habits:   habitsFromConfig(ymlConfig),
+	}
+
+	settings.colors.done = ymlConfig.UString("colors.done", "green")
+	settings.colors.missed = ymlConfig.UString("colors.missed", "gray")
+
+	return &settings
+}
+
+// habitsFromConfig reads the habits list, in which each habit is either a name or a map
+// with a name and a frequency
+func habitsFromConfig(ymlConfig *config.Config) []Habit {
+	habits := []Habit{}
+
+	for _, item := range ymlConfig.UList("habits") {
+		habit := Habit{Frequency: frequencyDaily}
+
+		switch val := item.(type) {
+		case map[string]interface{}:
+			habit.Name = fmt.Sprintf("%v", val["name"])
+			if frequency, ok := val["frequency"].(string); ok && frequency == frequencyWeekly {
+				habit.Frequency = frequencyWeekly
+			}
+		default:
+			habit.Name = fmt.Sprintf("%v", val)
+		}
+
+		habits = append(habits, habit)
+	}
+
+	return habits
+}
@@ -0,0 +1,101 @@
+package habits
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// habitLog is the days each habit was done, keyed by the habit's name, as kept in the
+// habits file
+type habitLog map[string]days
+
+// loadLog reads the habits file. A missing file is an empty log
+func loadLog(path string) (habitLog, error) {
+	log := habitLog{}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return log, nil
+	}
+	if err != nil {
+		return log, err
+	}
+
+	saved := map[string][]string{}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return log, err
+	}
+
+	for name, dates := range saved {
+		log[name] = days{}
+		for _, date := range dates {
+			log[name][date] = true
+		}
+	}
+
+	return log, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// logPath returns where the habits file is: in the config directory, unless the file
+// path is absolute
+func logPath(filePath string) string {
+	if filepath.IsAbs(filePath) {
+		return filePath
+	}
+
+	configDir, err := cfg.WtfConfigDir()
+	if err != nil {
+		return filePath
+	}
+
+	return filepath.Join(configDir, filePath)
+}
+
+// save writes the log to the habits file, each habit's days in order
+func (log habitLog) save(path string) error {
+	saved := map[string][]string{}
+
+	for name, done := range log {
+		dates := []string{}
+		for date, ok := range done {
+			if ok {
+				dates = append(dates, date)
+			}
+		}
+		sort.Strings(dates)
+
+		saved[name] = dates
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// toggle marks the habit as done on the day, or as not done if it already was
+func (log habitLog) toggle(name, date string) {
+	if log[name] == nil {
+		log[name] = days{}
+	}
+
+	if log[name][date] {
+		delete(log[name], date)
+		return
+	}
+
+	log[name][date] = true
+}
@@ -0,0 +1,82 @@
+package habits
+
+import (
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget tracks habits, showing whether each has been done today, its streak, and a
+// heatmap of the month for the selected one
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	filePath string
+	log      habitLog
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		filePath: logPath(settings.filePath),
+		log:      habitLog{},
+		settings: settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh reloads the habits file, which may have been changed by another instance
+func (widget *Widget) Refresh() {
+	log, err := loadLog(widget.filePath)
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.log = log
+	widget.SetItemCount(len(widget.settings.habits))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(widget.settings.habits, wtf.Now()), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// toggleSelected checks off the selected habit for today, or undoes it if it was
+// already checked off today
+func (widget *Widget) toggleSelected() {
+	selected := widget.GetSelected()
+	if selected < 0 || selected >= len(widget.settings.habits) {
+		return
+	}
+
+	widget.log.toggle(widget.settings.habits[selected].Name, wtf.Now().Format(dayFormat))
+
+	if err := widget.log.save(widget.filePath); err != nil {
+		logger.For(widget.Name()).Errorf("saving %s: %v", widget.filePath, err)
+	}
+
+	widget.Render()
+}
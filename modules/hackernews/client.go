@@ -3,12 +3,14 @@ package hackernews
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func GetStories(storyType string) ([]int, error) {
@@ -49,14 +51,14 @@ var (
 func apiRequest(path string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", apiEndpoint+path+".json", nil)
 
-	httpClient := &http.Client{}
+	httpClient := wtf.HTTPClient()
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, fmt.Errorf(resp.Status)
+		return nil, wtf.NewHTTPError(resp)
 	}
 
 	return resp, nil
@@ -65,7 +67,8 @@ func apiRequest(path string) (*http.Response, error) {
 func parseJson(obj interface{}, text io.Reader) {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		logger.For("hackernews").Errorf("reading the response: %v", err)
+		return
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -74,7 +77,8 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			logger.For("hackernews").Errorf("parsing the response: %v", err)
+			return
 		}
 	}
 }
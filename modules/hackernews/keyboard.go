@@ -3,12 +3,13 @@ package hackernews
 import "github.com/gdamore/tcell"
 
 func (widget *Widget) initializeKeyboardControls() {
-	widget.SetKeyboardChar("/", widget.ShowHelp, "Show/hide this help widget")
+	widget.BindSearch(&widget.ScrollableWidget)
 	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
 	widget.SetKeyboardChar("j", widget.Next, "Select next item")
 	widget.SetKeyboardChar("k", widget.Prev, "Select previous item")
 	widget.SetKeyboardChar("o", widget.openStory, "Open story in browser")
 	widget.SetKeyboardChar("c", widget.openComments, "Open comments in browser")
+	widget.BindRowActions(&widget.ScrollableWidget)
 
 	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next item")
 	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous item")
@@ -3,9 +3,11 @@ package hackernews
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -26,6 +28,8 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 	}
 
 	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.AddRowAction("Open comments", widget.openCommentsFor)
 	widget.initializeKeyboardControls()
 	widget.View.SetInputCapture(widget.InputCapture)
 
@@ -47,7 +51,7 @@ func (widget *Widget) Refresh() {
 	}
 
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 	var stories []Story
@@ -100,7 +104,7 @@ func (widget *Widget) openStory() {
 	sel := widget.GetSelected()
 	if sel >= 0 && widget.stories != nil && sel < len(widget.stories) {
 		story := &widget.stories[sel]
-		wtf.OpenFile(story.URL)
+		utils.OpenURL(story.URL)
 	}
 }
 
@@ -108,6 +112,29 @@ func (widget *Widget) openComments() {
 	sel := widget.GetSelected()
 	if sel >= 0 && widget.stories != nil && sel < len(widget.stories) {
 		story := &widget.stories[sel]
-		wtf.OpenFile(fmt.Sprintf("https://news.ycombinator.com/item?id=%d", story.ID))
+		utils.OpenURL(commentsURL(strconv.Itoa(story.ID)))
 	}
 }
+
+func (widget *Widget) openCommentsFor(rows []wtf.Row) error {
+	for _, row := range rows {
+		utils.OpenURL(commentsURL(row.ID))
+	}
+
+	return nil
+}
+
+// rowFor describes the story at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	story := widget.stories[idx]
+
+	return wtf.Row{
+		ID:   strconv.Itoa(story.ID),
+		Text: story.Title,
+		URL:  story.URL,
+	}
+}
+
+func commentsURL(id string) string {
+	return "https://news.ycombinator.com/item?id=" + id
+}
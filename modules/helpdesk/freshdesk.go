@@ -0,0 +1,123 @@
+package helpdesk
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// freshdeskStatuses names Freshdesk's ticket statuses
+var freshdeskStatuses = map[int]string{2: "open", 3: "pending", 4: "resolved", 5: "closed"}
+
+// freshdeskPriorities names Freshdesk's ticket priorities
+var freshdeskPriorities = map[int]string{1: "low", 2: "normal", 3: "high", 4: "urgent"}
+
+type freshdeskResults struct {
+	Results []struct {
+		DueBy       *time.Time `json:"due_by"`
+		FrDueBy     *time.Time `json:"fr_due_by"`
+		FrEscalated bool       `json:"fr_escalated"`
+		ID          int64      `json:"id"`
+		Priority    int        `json:"priority"`
+		Status      int        `json:"status"`
+		Subject     string     `json:"subject"`
+	} `json:"results"`
+}
+
+// freshdeskQueue searches Freshdesk for the open and pending tickets assigned to the user
+// and those assigned to no one, in the group if there is one
+func (widget *Widget) freshdeskQueue() (queue, error) {
+	q := queue{}
+
+	if widget.agentID == 0 {
+		agent := struct {
+			ID int64 `json:"id"`
+		}{}
+
+		req, err := widget.freshdeskRequest("/api/v2/agents/me")
+		if err != nil {
+			return q, err
+		}
+
+		if err := widget.getJSON(req, &agent); err != nil {
+			return q, err
+		}
+
+		widget.agentID = agent.ID
+	}
+
+	yours, err := widget.freshdeskSearch(fmt.Sprintf("agent_id:%d AND (status:2 OR status:3)", widget.agentID))
+	if err != nil {
+		return q, err
+	}
+
+	unassignedQuery := "agent_id:null AND (status:2 OR status:3)"
+	if widget.settings.group != "" {
+		unassignedQuery = fmt.Sprintf("group_id:%s AND %s", widget.settings.group, unassignedQuery)
+	}
+
+	unassigned, err := widget.freshdeskSearch(unassignedQuery)
+	if err != nil {
+		return q, err
+	}
+
+	q.yours = yours
+	q.unassigned = unassigned
+
+	return q, nil
+}
+
+func (widget *Widget) freshdeskRequest(path string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s.freshdesk.com%s", widget.settings.subdomain, path), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Freshdesk takes the API key as the username, with any password
+	req.SetBasicAuth(widget.settings.apiKey, "X")
+
+	return req, nil
+}
+
+// freshdeskSearch returns the tickets that match the query. A ticket's SLA is breached
+// at its first response due time until that has been escalated, and at its resolution
+// due time after
+func (widget *Widget) freshdeskSearch(query string) ([]Ticket, error) {
+	params := url.Values{}
+	params.Set("query", fmt.Sprintf("%q", query))
+
+	req, err := widget.freshdeskRequest("/api/v2/search/tickets?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	results := freshdeskResults{}
+	if err := widget.getJSON(req, &results); err != nil {
+		return nil, err
+	}
+
+	tickets := []Ticket{}
+	for _, result := range results.Results {
+		ticket := Ticket{
+			ID:       result.ID,
+			Priority: freshdeskPriorities[result.Priority],
+			Status:   freshdeskStatuses[result.Status],
+			Subject:  result.Subject,
+			URL:      fmt.Sprintf("https://%s.freshdesk.com/a/tickets/%d", widget.settings.subdomain, result.ID),
+		}
+
+		switch {
+		case result.FrDueBy != nil && !result.FrEscalated && (result.DueBy == nil || result.FrDueBy.Before(*result.DueBy)):
+			ticket.BreachAt = *result.FrDueBy
+		case result.DueBy != nil:
+			ticket.BreachAt = *result.DueBy
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	sortBySLA(tickets)
+
+	return tickets, nil
+}
@@ -0,0 +1,142 @@
+package helpdesk
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const helpScoutAPIURL = "https://api.helpscout.net/v2"
+
+type helpScoutConversations struct {
+	Embedded struct {
+		Conversations []struct {
+			Assignee *struct {
+				ID int64 `json:"id"`
+			} `json:"assignee"`
+			CustomerWaitingSince struct {
+				Time *time.Time `json:"time"`
+			} `json:"customerWaitingSince"`
+			ID      int64  `json:"id"`
+			Number  int64  `json:"number"`
+			Status  string `json:"status"`
+			Subject string `json:"subject"`
+		} `json:"conversations"`
+	} `json:"_embedded"`
+}
+
+// helpScoutQueue lists the active Help Scout conversations, in the mailbox if there is
+// one, and sorts them into those assigned to the user and those assigned to no one
+func (widget *Widget) helpScoutQueue() (queue, error) {
+	q := queue{}
+
+	if widget.agentID == 0 {
+		me := struct {
+			ID int64 `json:"id"`
+		}{}
+
+		req, err := widget.helpScoutRequest("GET", "/users/me")
+		if err != nil {
+			return q, err
+		}
+
+		if err := widget.getJSON(req, &me); err != nil {
+			return q, err
+		}
+
+		widget.agentID = me.ID
+	}
+
+	params := url.Values{}
+	params.Set("status", "active")
+	params.Set("sortField", "waitingSince")
+	params.Set("sortOrder", "asc")
+	if widget.settings.group != "" {
+		params.Set("mailbox", widget.settings.group)
+	}
+
+	req, err := widget.helpScoutRequest("GET", "/conversations?"+params.Encode())
+	if err != nil {
+		return q, err
+	}
+
+	results := helpScoutConversations{}
+	if err := widget.getJSON(req, &results); err != nil {
+		return q, err
+	}
+
+	for _, conversation := range results.Embedded.Conversations {
+		ticket := Ticket{
+			ID:      conversation.Number,
+			Status:  conversation.Status,
+			Subject: conversation.Subject,
+			URL:     fmt.Sprintf("https://secure.helpscout.net/conversation/%d", conversation.ID),
+		}
+
+		if conversation.CustomerWaitingSince.Time != nil {
+			ticket.Waiting = *conversation.CustomerWaitingSince.Time
+		}
+
+		switch {
+		case conversation.Assignee == nil:
+			q.unassigned = append(q.unassigned, ticket)
+		case conversation.Assignee.ID == widget.agentID:
+			q.yours = append(q.yours, ticket)
+		}
+	}
+
+	sortBySLA(q.yours)
+	sortBySLA(q.unassigned)
+
+	return q, nil
+}
+
+// helpScoutRequest creates a request to the Help Scout API, signing the app in for a new
+// access token when the last one has expired
+func (widget *Widget) helpScoutRequest(method, path string) (*http.Request, error) {
+	if widget.token == "" || time.Now().After(widget.tokenExpiry) {
+		if err := widget.helpScoutSignIn(); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, helpScoutAPIURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+widget.token)
+
+	return req, nil
+}
+
+// helpScoutSignIn exchanges the app's ID and secret for an access token
+func (widget *Widget) helpScoutSignIn() error {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", widget.settings.appID)
+	form.Set("client_secret", widget.settings.apiKey)
+
+	req, err := http.NewRequest("POST", helpScoutAPIURL+"/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	token := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}{}
+
+	if err := widget.getJSON(req, &token); err != nil {
+		return err
+	}
+
+	widget.token = token.AccessToken
+	// Sign in again a minute early, rather than have a request fail as the token expires
+	widget.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package helpdesk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const intercomAPIURL = "https://api.intercom.io"
+
+type intercomConversations struct {
+	Conversations []struct {
+		ID       string `json:"id"`
+		Priority string `json:"priority"`
+		Source   struct {
+			Author struct {
+				Name string `json:"name"`
+			} `json:"author"`
+			Subject string `json:"subject"`
+		} `json:"source"`
+		State        string `json:"state"`
+		Title        string `json:"title"`
+		WaitingSince int64  `json:"waiting_since"`
+	} `json:"conversations"`
+}
+
+// intercomQueue searches Intercom for the open conversations assigned to the user and
+// those assigned to no one, in the team if there is one
+func (widget *Widget) intercomQueue() (queue, error) {
+	q := queue{}
+
+	if widget.agentID == 0 {
+		me := struct {
+			App struct {
+				IDCode string `json:"id_code"`
+			} `json:"app"`
+			ID string `json:"id"`
+		}{}
+
+		req, err := widget.intercomRequest("GET", "/me", nil)
+		if err != nil {
+			return q, err
+		}
+
+		if err := widget.getJSON(req, &me); err != nil {
+			return q, err
+		}
+
+		fmt.Sscan(me.ID, &widget.agentID)
+		widget.appCode = me.App.IDCode
+	}
+
+	yours, err := widget.intercomSearch(intercomFilter("admin_assignee_id", widget.agentID))
+	if err != nil {
+		return q, err
+	}
+
+	unassignedFilters := []map[string]interface{}{intercomFilter("admin_assignee_id", nil)}
+	if widget.settings.group != "" {
+		unassignedFilters = append(unassignedFilters, intercomFilter("team_assignee_id", widget.settings.group))
+	}
+
+	unassigned, err := widget.intercomSearch(unassignedFilters...)
+	if err != nil {
+		return q, err
+	}
+
+	q.yours = yours
+	q.unassigned = unassigned
+
+	return q, nil
+}
+
+func (widget *Widget) intercomRequest(method, path string, body interface{}) (*http.Request, error) {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, intercomAPIURL+path, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+widget.settings.apiKey)
+	req.Header.Set("Intercom-Version", "2.10")
+
+	return req, nil
+}
+
+// intercomSearch returns the open conversations that match every one of the filters.
+// Intercom's SLAs don't say when they'll be breached, so conversations are sorted by how
+// long the customer has been waiting
+func (widget *Widget) intercomSearch(filters ...map[string]interface{}) ([]Ticket, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"operator": "AND",
+			"value":    append([]map[string]interface{}{intercomFilter("open", true)}, filters...),
+		},
+	}
+
+	req, err := widget.intercomRequest("POST", "/conversations/search", query)
+	if err != nil {
+		return nil, err
+	}
+
+	results := intercomConversations{}
+	if err := widget.getJSON(req, &results); err != nil {
+		return nil, err
+	}
+
+	tickets := []Ticket{}
+	for _, conversation := range results.Conversations {
+		ticket := Ticket{
+			Priority: conversation.Priority,
+			Status:   conversation.State,
+			Subject:  intercomSubject(conversation.Title, conversation.Source.Subject, conversation.Source.Author.Name),
+			URL:      fmt.Sprintf("https://app.intercom.com/a/inbox/%s/inbox/conversation/%s", widget.appCode, conversation.ID),
+		}
+		fmt.Sscan(conversation.ID, &ticket.ID)
+
+		if conversation.WaitingSince > 0 {
+			ticket.Waiting = time.Unix(conversation.WaitingSince, 0)
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	sortBySLA(tickets)
+
+	return tickets, nil
+}
+
+func intercomFilter(field string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"field": field, "operator": "=", "value": value}
+}
+
+// intercomSubject returns the conversation's title or, as most conversations don't have
+// one, the subject of its first message or who it's from
+func intercomSubject(title, subject, author string) string {
+	switch {
+	case title != "":
+		return title
+	case subject != "":
+		return subject
+	case author != "":
+		return "Conversation with " + author
+	default:
+		return "Conversation"
+	}
+}
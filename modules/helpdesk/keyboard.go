@@ -0,0 +1,17 @@
+package helpdesk
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next ticket")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous ticket")
+	widget.SetKeyboardChar("o", widget.openTicket, "Open ticket in browser")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next ticket")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous ticket")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openTicket, "Open ticket in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,48 @@
+package helpdesk
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Helpdesk"
+
+const (
+	freshdesk = "freshdesk"
+	helpScout = "helpscout"
+	intercom  = "intercom"
+	zendesk   = "zendesk"
+)
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey    string `help:"Your Zendesk API token, Freshdesk API key, Intercom access token, or Help Scout app secret." values:"Falls back to WTF_ZENDESK_TOKEN, WTF_FRESHDESK_TOKEN, WTF_INTERCOM_TOKEN, or WTF_HELPSCOUT_TOKEN."`
+	appID     string `help:"The ID of your Help Scout app, whose secret is the apiKey." optional:"true"`
+	group     string `help:"Where to show the unassigned tickets of: the group's name in Zendesk, the group's ID in Freshdesk, the team's ID in Intercom, or the mailbox's ID in Help Scout. Without it, every unassigned ticket is shown." optional:"true"`
+	service   string `help:"The helpdesk the tickets or conversations are in." values:"zendesk, freshdesk, intercom, or helpscout" optional:"true" default:"zendesk"`
+	subdomain string `help:"Your Zendesk or Freshdesk subdomain, such as acme for acme.zendesk.com." optional:"true"`
+	username  string `help:"The email address you sign in to Zendesk with, which Zendesk API tokens belong to, as for the zendesk module." optional:"true"`
+	warnHours int    `help:"How many hours before a ticket's SLA is breached to show its countdown in yellow." optional:"true" default:"4"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+	service := ymlConfig.UString("service", zendesk)
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:    ymlConfig.UString("apiKey", os.Getenv("WTF_"+strings.ToUpper(service)+"_TOKEN")),
+		appID:     ymlConfig.UString("appID", ""),
+		group:     ymlConfig.UString("group", ""),
+		service:   service,
+		subdomain: ymlConfig.UString("subdomain", ""),
+		username:  ymlConfig.UString("username", ""),
+		warnHours: ymlConfig.UInt("warnHours", 4),
+	}
+
+	return &settings
+}
@@ -0,0 +1,80 @@
+package helpdesk
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Ticket is a support ticket or conversation that's open or pending, with when its SLA
+// will be breached if it has one, or else how long the customer has been waiting
+type Ticket struct {
+	BreachAt time.Time
+	ID       int64
+	Priority string
+	Status   string
+	Subject  string
+	URL      string
+	Waiting  time.Time
+}
+
+// queue is the tickets assigned to the user, and those that aren't assigned to anyone
+type queue struct {
+	unassigned []Ticket
+	yours      []Ticket
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// getJSON makes an authenticated request to the helpdesk's API and decodes the response
+func (widget *Widget) getJSON(req *http.Request, result interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	if req.Body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// sortBySLA puts the tickets whose SLAs are breached soonest first, and those without an
+// SLA last, longest waiting first
+func sortBySLA(tickets []Ticket) {
+	sort.SliceStable(tickets, func(i, j int) bool {
+		switch {
+		case tickets[i].BreachAt.IsZero() && tickets[j].BreachAt.IsZero():
+			return waitingBefore(tickets[i].Waiting, tickets[j].Waiting)
+		case tickets[i].BreachAt.IsZero():
+			return false
+		case tickets[j].BreachAt.IsZero():
+			return true
+		default:
+			return tickets[i].BreachAt.Before(tickets[j].BreachAt)
+		}
+	})
+}
+
+// waitingBefore returns true if the customer waiting since a has waited longer than the
+// one waiting since b. Customers who aren't waiting come last
+func waitingBefore(a, b time.Time) bool {
+	switch {
+	case a.IsZero():
+		return false
+	case b.IsZero():
+		return true
+	default:
+		return a.Before(b)
+	}
+}
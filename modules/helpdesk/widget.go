@@ -0,0 +1,207 @@
+package helpdesk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows the support tickets assigned to the user and those waiting for someone
+// to pick them up, from Zendesk, Freshdesk, Intercom, or Help Scout, soonest SLA breach
+// first
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	agentID     int64
+	appCode     string
+	queue       queue
+	settings    *Settings
+	tickets     []Ticket
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetQuotaHost(quotaHost(settings))
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the user's tickets and the unassigned ones
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	var q queue
+	var err error
+
+	switch widget.settings.service {
+	case zendesk:
+		q, err = widget.zendeskQueue()
+	case freshdesk:
+		q, err = widget.freshdeskQueue()
+	case intercom:
+		q, err = widget.intercomQueue()
+	case helpScout:
+		q, err = widget.helpScoutQueue()
+	default:
+		err = fmt.Errorf("unknown service %q, which should be zendesk, freshdesk, intercom, or helpscout", widget.settings.service)
+	}
+
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.queue = q
+	widget.tickets = append(append([]Ticket{}, q.yours...), q.unassigned...)
+	widget.SetItemCount(len(widget.tickets))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	title := fmt.Sprintf("%s (%d/%d)", widget.CommonSettings().Title, len(widget.queue.yours), len(widget.queue.unassigned))
+
+	widget.Redraw(title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the tickets so that their SLA countdowns stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	str := " [red]Yours[white]\n"
+	str += widget.ticketRows(widget.queue.yours, 0)
+
+	str += "\n [red]Unassigned[white]\n"
+	str += widget.ticketRows(widget.queue.unassigned, len(widget.queue.yours))
+
+	return str
+}
+
+func (widget *Widget) openTicket() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.tickets) {
+		utils.OpenURL(widget.tickets[sel].URL)
+	}
+}
+
+// rowFor describes the ticket at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	ticket := widget.tickets[idx]
+
+	return wtf.Row{
+		ID:   fmt.Sprintf("#%d", ticket.ID),
+		Text: ticket.Subject,
+		URL:  ticket.URL,
+	}
+}
+
+// slaCountdown describes how long is left until the ticket's SLA is breached: red once
+// it has been or within the hour, yellow within warnHours, and green otherwise. Tickets
+// without an SLA show how long the customer has been waiting
+func (widget *Widget) slaCountdown(ticket Ticket, now time.Time) string {
+	if ticket.BreachAt.IsZero() {
+		if ticket.Waiting.IsZero() {
+			return "[gray]no SLA"
+		}
+
+		return fmt.Sprintf("[gray]waiting %s", shortDuration(now.Sub(ticket.Waiting)))
+	}
+
+	left := ticket.BreachAt.Sub(now)
+
+	switch {
+	case left <= 0:
+		return fmt.Sprintf("[red]breached %s", wtf.RelativeTime(ticket.BreachAt))
+	case left <= time.Hour:
+		return fmt.Sprintf("[red]%s", wtf.RelativeTime(ticket.BreachAt))
+	case left <= time.Duration(widget.settings.warnHours)*time.Hour:
+		return fmt.Sprintf("[yellow]%s", wtf.RelativeTime(ticket.BreachAt))
+	default:
+		return fmt.Sprintf("[green]%s", wtf.RelativeTime(ticket.BreachAt))
+	}
+}
+
+// ticketRows writes the tickets as the list's rows, numbered from the offset
+func (widget *Widget) ticketRows(tickets []Ticket, offset int) string {
+	if len(tickets) == 0 {
+		return " [gray]none[white]\n"
+	}
+
+	now := time.Now()
+
+	str := ""
+	for i, ticket := range tickets {
+		idx := offset + i
+
+		row := fmt.Sprintf(
+			"[%s] #%d %s %s [gray]%s[white]",
+			widget.RowColor(idx),
+			ticket.ID,
+			tview.Escape(ticket.Subject),
+			widget.slaCountdown(ticket, now),
+			ticket.Priority,
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(ticket.Subject))
+	}
+
+	return str
+}
+
+// shortDuration writes the duration in its largest whole unit, such as "3d", "5h", or
+// "12m"
+func shortDuration(duration time.Duration) string {
+	switch {
+	case duration >= 24*time.Hour:
+		return fmt.Sprintf("%dd", duration/(24*time.Hour))
+	case duration >= time.Hour:
+		return fmt.Sprintf("%dh", duration/time.Hour)
+	default:
+		return fmt.Sprintf("%dm", duration/time.Minute)
+	}
+}
+
+// quotaHost returns the API host that the service's requests count against
+func quotaHost(settings *Settings) string {
+	switch settings.service {
+	case intercom:
+		return "api.intercom.io"
+	case helpScout:
+		return "api.helpscout.net"
+	default:
+		return fmt.Sprintf("%s.%s.com", settings.subdomain, settings.service)
+	}
+}
@@ -0,0 +1,102 @@
+package helpdesk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	zendeskapi "github.com/wtfutil/wtf/modules/zendesk"
+)
+
+type zendeskResults struct {
+	Results []struct {
+		ID       int64  `json:"id"`
+		Priority string `json:"priority"`
+		Slas     struct {
+			PolicyMetrics []struct {
+				BreachAt *time.Time `json:"breach_at"`
+				Stage    string     `json:"stage"`
+			} `json:"policy_metrics"`
+		} `json:"slas"`
+		Status  string `json:"status"`
+		Subject string `json:"subject"`
+	} `json:"results"`
+}
+
+// zendeskQueue searches Zendesk for the unsolved tickets assigned to the user and those
+// assigned to no one, in the group if there is one
+func (widget *Widget) zendeskQueue() (queue, error) {
+	q := queue{}
+
+	yours, err := widget.zendeskSearch("type:ticket status<solved assignee:me")
+	if err != nil {
+		return q, err
+	}
+
+	unassignedQuery := "type:ticket status<solved assignee:none"
+	if widget.settings.group != "" {
+		unassignedQuery += fmt.Sprintf(" group:%q", widget.settings.group)
+	}
+
+	unassigned, err := widget.zendeskSearch(unassignedQuery)
+	if err != nil {
+		return q, err
+	}
+
+	q.yours = yours
+	q.unassigned = unassigned
+
+	return q, nil
+}
+
+// zendeskSearch returns the tickets that match the query, with the SLA metric that will
+// be breached soonest
+func (widget *Widget) zendeskSearch(query string) ([]Ticket, error) {
+	client := zendeskapi.Client{
+		APIKey:    widget.settings.apiKey,
+		Subdomain: widget.settings.subdomain,
+		Username:  widget.settings.username,
+	}
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("include", "tickets(slas)")
+
+	data, err := client.Do("GET", "/search.json?"+params.Encode(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	results := zendeskResults{}
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+
+	tickets := []Ticket{}
+	for _, result := range results.Results {
+		ticket := Ticket{
+			ID:       result.ID,
+			Priority: result.Priority,
+			Status:   result.Status,
+			Subject:  result.Subject,
+			URL:      client.TicketURL(result.ID),
+		}
+
+		for _, metric := range result.Slas.PolicyMetrics {
+			if metric.BreachAt == nil || metric.Stage == "achieved" {
+				continue
+			}
+
+			if ticket.BreachAt.IsZero() || metric.BreachAt.Before(ticket.BreachAt) {
+				ticket.BreachAt = *metric.BreachAt
+			}
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	sortBySLA(tickets)
+
+	return tickets, nil
+}
@@ -6,13 +6,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"time"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
 const (
-	apiURL            = "https://haveibeenpwned.com/api/v3/breachedaccount/"
-	clientTimeoutSecs = 2
-	userAgent         = "WTFUtil"
+	apiURL    = "https://haveibeenpwned.com/api/v3/breachedaccount/"
+	userAgent = "WTFUtil"
 )
 
 type hibpError struct {
@@ -34,10 +34,6 @@ func (widget *Widget) fetchForAccount(account string, since string) (*Status, er
 		return nil, nil
 	}
 
-	hibpClient := http.Client{
-		Timeout: time.Second * clientTimeoutSecs,
-	}
-
 	asTruncated := true
 	if since != "" {
 		asTruncated = false
@@ -51,7 +47,7 @@ func (widget *Widget) fetchForAccount(account string, since string) (*Status, er
 	request.Header.Set("User-Agent", userAgent)
 	request.Header.Set("hibp-api-key", widget.settings.apiKey)
 
-	response, getErr := hibpClient.Do(request)
+	response, getErr := wtf.HTTPClient().Do(request)
 	if getErr != nil {
 		return nil, err
 	}
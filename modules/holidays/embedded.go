@@ -0,0 +1,141 @@
+package holidays
+
+import (
+	"strings"
+	"time"
+)
+
+// holidayRule is how the date of a holiday is worked out for any year
+type holidayRule struct {
+	name      string
+	localName string
+	date      func(year int) time.Time
+}
+
+// embeddedRules are the public holidays observed across each country that the module
+// knows without asking Nager.Date
+var embeddedRules = map[string][]holidayRule{
+	"DE": {
+		{"New Year's Day", "Neujahr", fixed(time.January, 1)},
+		{"Good Friday", "Karfreitag", easter(-2)},
+		{"Easter Monday", "Ostermontag", easter(1)},
+		{"Labour Day", "Tag der Arbeit", fixed(time.May, 1)},
+		{"Ascension Day", "Christi Himmelfahrt", easter(39)},
+		{"Whit Monday", "Pfingstmontag", easter(50)},
+		{"German Unity Day", "Tag der Deutschen Einheit", fixed(time.October, 3)},
+		{"Christmas Day", "Erster Weihnachtstag", fixed(time.December, 25)},
+		{"St. Stephen's Day", "Zweiter Weihnachtstag", fixed(time.December, 26)},
+	},
+	"FR": {
+		{"New Year's Day", "Jour de l'an", fixed(time.January, 1)},
+		{"Easter Monday", "Lundi de Pâques", easter(1)},
+		{"Labour Day", "Fête du Travail", fixed(time.May, 1)},
+		{"Victory in Europe Day", "Victoire 1945", fixed(time.May, 8)},
+		{"Ascension Day", "Ascension", easter(39)},
+		{"Whit Monday", "Lundi de Pentecôte", easter(50)},
+		{"Bastille Day", "Fête nationale", fixed(time.July, 14)},
+		{"Assumption Day", "Assomption", fixed(time.August, 15)},
+		{"All Saints' Day", "Toussaint", fixed(time.November, 1)},
+		{"Armistice Day", "Armistice 1918", fixed(time.November, 11)},
+		{"Christmas Day", "Noël", fixed(time.December, 25)},
+	},
+	"GB": {
+		{"New Year's Day", "", fixed(time.January, 1)},
+		{"Good Friday", "", easter(-2)},
+		{"Easter Monday", "", easter(1)},
+		{"Early May Bank Holiday", "", nthWeekday(time.May, time.Monday, 1)},
+		{"Spring Bank Holiday", "", nthWeekday(time.May, time.Monday, -1)},
+		{"Summer Bank Holiday", "", nthWeekday(time.August, time.Monday, -1)},
+		{"Christmas Day", "", fixed(time.December, 25)},
+		{"Boxing Day", "", fixed(time.December, 26)},
+	},
+	"US": {
+		{"New Year's Day", "", fixed(time.January, 1)},
+		{"Martin Luther King, Jr. Day", "", nthWeekday(time.January, time.Monday, 3)},
+		{"Presidents' Day", "", nthWeekday(time.February, time.Monday, 3)},
+		{"Memorial Day", "", nthWeekday(time.May, time.Monday, -1)},
+		{"Juneteenth", "", fixed(time.June, 19)},
+		{"Independence Day", "", fixed(time.July, 4)},
+		{"Labor Day", "", nthWeekday(time.September, time.Monday, 1)},
+		{"Columbus Day", "", nthWeekday(time.October, time.Monday, 2)},
+		{"Veterans Day", "", fixed(time.November, 11)},
+		{"Thanksgiving Day", "", nthWeekday(time.November, time.Thursday, 4)},
+		{"Christmas Day", "", fixed(time.December, 25)},
+	},
+}
+
+// embeddedHolidays returns the year's public holidays for the country from the
+// embedded rules, and false if there are no rules for it. Regions get their country's
+// holidays
+func embeddedHolidays(code string, year int) ([]Holiday, bool) {
+	rules, ok := embeddedRules[countryOf(code)]
+	if !ok {
+		return nil, false
+	}
+
+	holidays := []Holiday{}
+	for _, rule := range rules {
+		holidays = append(holidays, Holiday{
+			Country:   strings.ToUpper(code),
+			Date:      rule.date(year),
+			LocalName: rule.localName,
+			Name:      rule.name,
+		})
+	}
+
+	return holidays, true
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// easter returns the rule for a holiday the given number of days after Easter Sunday
+func easter(offset int) func(int) time.Time {
+	return func(year int) time.Time {
+		return easterSunday(year).AddDate(0, 0, offset)
+	}
+}
+
+// easterSunday works out Easter Sunday in the Gregorian calendar with the anonymous
+// Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+}
+
+// fixed returns the rule for a holiday on the same date every year
+func fixed(month time.Month, day int) func(int) time.Time {
+	return func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	}
+}
+
+// nthWeekday returns the rule for a holiday on the nth weekday of the month, or the
+// last one if n is -1
+func nthWeekday(month time.Month, weekday time.Weekday, n int) func(int) time.Time {
+	return func(year int) time.Time {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.Local)
+			return last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+		}
+
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+
+		return first.AddDate(0, 0, offset+7*(n-1))
+	}
+}
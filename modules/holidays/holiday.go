@@ -0,0 +1,37 @@
+package holidays
+
+import (
+	"strings"
+	"time"
+)
+
+// Holiday is a public holiday in one country or region
+type Holiday struct {
+	Country   string
+	Date      time.Time
+	LocalName string
+	Name      string
+}
+
+// countryOf returns the country part of a country or region code, such as "DE" for
+// "DE-BY"
+func countryOf(code string) string {
+	return strings.ToUpper(strings.SplitN(code, "-", 2)[0])
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// DisplayName returns the holiday's name, in the country's language if local is true
+func (holiday *Holiday) DisplayName(local bool) string {
+	if local && holiday.LocalName != "" {
+		return holiday.LocalName
+	}
+
+	return holiday.Name
+}
+
+// OnWorkday returns true if the holiday falls on a weekday, and so gives a day off
+func (holiday *Holiday) OnWorkday() bool {
+	weekday := holiday.Date.Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
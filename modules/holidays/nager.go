@@ -0,0 +1,80 @@
+package holidays
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const nagerURL = "https://date.nager.at/api/v3/PublicHolidays"
+
+// nagerHoliday is a holiday as Nager.Date describes it. Holidays that aren't observed
+// across the whole country list the regions that observe them
+type nagerHoliday struct {
+	Counties  []string `json:"counties"`
+	Date      string   `json:"date"`
+	Global    bool     `json:"global"`
+	LocalName string   `json:"localName"`
+	Name      string   `json:"name"`
+}
+
+// nagerHolidays fetches the year's public holidays for the country or region from
+// Nager.Date. For a region, the holidays observed across the whole country are included
+func nagerHolidays(code string, year int) ([]Holiday, error) {
+	url := fmt.Sprintf("%s/%d/%s", nagerURL, year, countryOf(code))
+
+	resp, err := wtf.HTTPClient().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, wtf.NewHTTPError(resp)
+	}
+
+	nagerList := []nagerHoliday{}
+	if err := json.NewDecoder(resp.Body).Decode(&nagerList); err != nil {
+		return nil, err
+	}
+
+	holidays := []Holiday{}
+	for _, nager := range nagerList {
+		if !nager.observedIn(code) {
+			continue
+		}
+
+		date, err := time.ParseInLocation("2006-01-02", nager.Date, time.Local)
+		if err != nil {
+			continue
+		}
+
+		holidays = append(holidays, Holiday{
+			Country:   strings.ToUpper(code),
+			Date:      date,
+			LocalName: nager.LocalName,
+			Name:      nager.Name,
+		})
+	}
+
+	return holidays, nil
+}
+
+// observedIn returns true if the holiday is observed in the country or region
+func (nager *nagerHoliday) observedIn(code string) bool {
+	if nager.Global {
+		return true
+	}
+
+	for _, county := range nager.Counties {
+		if strings.EqualFold(county, code) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,46 @@
+package holidays
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Holidays"
+
+const (
+	sourceEmbedded = "embedded"
+	sourceNager    = "nager"
+)
+
+type colors struct {
+	weekend string
+	workday string
+}
+
+type Settings struct {
+	colors
+	common *cfg.Common
+
+	countries  []string `help:"The countries, or regions of them, whose public holidays are shown." values:"ISO 3166-1 country codes such as US, or ISO 3166-2 region codes such as DE-BY."`
+	days       int      `help:"How many days ahead to show holidays for." optional:"true" default:"90"`
+	localNames bool     `help:"Whether to show the holidays' names in the country's language rather than in English." values:"true, false" optional:"true" default:"false"`
+	source     string   `help:"Where the holidays come from. The embedded dataset covers DE, FR, GB, and US, and is also used when Nager.Date can't be reached." values:"nager, embedded" optional:"true" default:"nager"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		countries:  wtf.ToStrs(ymlConfig.UList("countries")),
+		days:       ymlConfig.UInt("days", 90),
+		localNames: ymlConfig.UBool("localNames", false),
+		source:     ymlConfig.UString("source", sourceNager),
+	}
+
+	settings.colors.weekend = ymlConfig.UString("colors.weekend", "gray")
+	settings.colors.workday = ymlConfig.UString("colors.workday", "yellow")
+
+	return &settings
+}
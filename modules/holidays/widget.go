@@ -0,0 +1,149 @@
+package holidays
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/i18n"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type Widget struct {
+	wtf.TextWidget
+
+	holidays []Holiday
+	settings *Settings
+}
+
+func NewWidget(app *tview.Application, settings *Settings) *Widget {
+	widget := Widget{
+		TextWidget: wtf.NewTextWidget(app, settings.common, false),
+
+		settings: settings,
+	}
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) Refresh() {
+	if len(widget.settings.countries) == 0 {
+		widget.Redraw(widget.CommonSettings().Title, " No countries configured", true)
+		return
+	}
+
+	today := midnight(wtf.Now())
+	until := today.AddDate(0, 0, widget.settings.days)
+
+	upcoming := []Holiday{}
+
+	for _, code := range widget.settings.countries {
+		for year := today.Year(); year <= until.Year(); year++ {
+			holidays, err := widget.holidaysFor(code, year)
+			if err != nil {
+				widget.RedrawError(widget.CommonSettings().Title, err)
+				return
+			}
+
+			for _, holiday := range holidays {
+				if !holiday.Date.Before(today) && holiday.Date.Before(until) {
+					upcoming = append(upcoming, holiday)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].Date.Before(upcoming[j].Date)
+	})
+
+	widget.holidays = upcoming
+	widget.display()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom(holidays []Holiday) string {
+	if len(holidays) == 0 {
+		return fmt.Sprintf(" No holidays in the next %d days", widget.settings.days)
+	}
+
+	layout := widget.settings.common.DateTime.DateLayout(wtf.FriendlyDateFormat)
+	today := midnight(wtf.Now())
+
+	str := ""
+	for _, holiday := range holidays {
+		color := widget.settings.colors.weekend
+		if holiday.OnWorkday() {
+			color = widget.settings.colors.workday
+		}
+
+		country := ""
+		if len(widget.settings.countries) > 1 {
+			country = fmt.Sprintf(" [gray]%s[white]", holiday.Country)
+		}
+
+		str += fmt.Sprintf(
+			" [%s]%s[white] %s%s [gray]%s[white]\n",
+			color,
+			i18n.FormatDate(holiday.Date, layout),
+			holiday.DisplayName(widget.settings.localNames),
+			country,
+			daysUntil(holiday.Date, today),
+		)
+	}
+
+	return str
+}
+
+// daysUntil describes how many days away the holiday is, such as "in 12 days"
+func daysUntil(date, today time.Time) string {
+	if !date.After(today) {
+		return i18n.T("today")
+	}
+
+	// Compared in UTC, so that a daylight saving change doesn't make a day 23 hours long
+	inUTC := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return i18n.RelativeTime(inUTC(date), inUTC(today))
+}
+
+func (widget *Widget) display() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(widget.holidays), false)
+}
+
+// holidaysFor returns the year's holidays for the country or region from the
+// configured source. When Nager.Date can't be reached, the embedded dataset is used
+// for the countries it covers
+func (widget *Widget) holidaysFor(code string, year int) ([]Holiday, error) {
+	if widget.settings.source == sourceEmbedded {
+		holidays, ok := embeddedHolidays(code, year)
+		if !ok {
+			return nil, fmt.Errorf("the embedded dataset has no holidays for %s", code)
+		}
+
+		return holidays, nil
+	}
+
+	holidays, err := nagerHolidays(code, year)
+	if err == nil {
+		return holidays, nil
+	}
+
+	if embedded, ok := embeddedHolidays(code, year); ok {
+		logger.For(widget.Name()).Warnf("using the embedded holidays for %s: %v", code, err)
+		return embedded, nil
+	}
+
+	return nil, err
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
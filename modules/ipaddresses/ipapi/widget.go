@@ -57,7 +57,7 @@ func (widget *Widget) Refresh() {
 
 //this method reads the config and calls ipinfo for ip information
 func (widget *Widget) ipinfo() {
-	client := &http.Client{}
+	client := wtf.HTTPClient()
 	req, err := http.NewRequest("GET", "http://ip-api.com/json", nil)
 	if err != nil {
 		widget.result = err.Error()
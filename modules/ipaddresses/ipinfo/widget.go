@@ -49,7 +49,7 @@ func (widget *Widget) Refresh() {
 
 //this method reads the config and calls ipinfo for ip information
 func (widget *Widget) ipinfo() {
-	client := &http.Client{}
+	client := wtf.HTTPClient()
 	req, err := http.NewRequest("GET", "https://ipinfo.io/", nil)
 	if err != nil {
 		widget.result = err.Error()
@@ -2,13 +2,15 @@ package jenkins
 
 import (
 	"bytes"
-	"crypto/tls"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func (widget *Widget) Create(jenkinsURL string, username string, apiKey string) (*View, error) {
@@ -27,13 +29,7 @@ func (widget *Widget) Create(jenkinsURL string, username string, apiKey string)
 	req, _ := http.NewRequest("GET", jenkinsAPIURL.String(), nil)
 	req.SetBasicAuth(username, apiKey)
 
-	httpClient := &http.Client{Transport: &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: !widget.settings.verifyServerCertificate,
-		},
-		Proxy: http.ProxyFromEnvironment,
-	},
-	}
+	httpClient := wtf.HTTPClientVerifying(widget.settings.verifyServerCertificate)
 	resp, err := httpClient.Do(req)
 
 	if err != nil {
@@ -55,7 +51,8 @@ func ensureLastSlash(url string) string {
 func parseJson(obj interface{}, text io.Reader) {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		logger.For("jenkins").Errorf("reading the response: %v", err)
+		return
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -64,7 +61,8 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			logger.For("jenkins").Errorf("parsing the response: %v", err)
+			return
 		}
 	}
 }
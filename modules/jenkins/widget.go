@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -48,7 +49,7 @@ func (widget *Widget) Refresh() {
 	widget.view = view
 
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 
@@ -105,6 +106,6 @@ func (widget *Widget) openJob() {
 	sel := widget.GetSelected()
 	if sel >= 0 && widget.view != nil && sel < len(widget.view.Jobs) {
 		job := &widget.view.Jobs[sel]
-		wtf.OpenFile(job.Url)
+		utils.OpenURL(job.Url)
 	}
 }
@@ -2,14 +2,17 @@ package jira
 
 import (
 	"bytes"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func (widget *Widget) IssuesFor(username string, projects []string, jql string) (*SearchResult, error) {
@@ -28,9 +31,24 @@ func (widget *Widget) IssuesFor(username string, projects []string, jql string)
 		query = append(query, jql)
 	}
 
+	return widget.search(strings.Join(query, " AND "), 0)
+}
+
+func buildJql(key string, value string) string {
+	return fmt.Sprintf("%s = \"%s\"", key, value)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// search runs the JQL query. The result holds at most max issues, or as many as Jira
+// returns by default if max is 0, and the total number of issues the query matches
+func (widget *Widget) search(jql string, max int) (*SearchResult, error) {
 	v := url.Values{}
 
-	v.Set("jql", strings.Join(query, " AND "))
+	v.Set("jql", jql)
+	if max > 0 {
+		v.Set("maxResults", strconv.Itoa(max))
+	}
 
 	url := fmt.Sprintf("/rest/api/2/search?%s", v.Encode())
 
@@ -38,6 +56,7 @@ func (widget *Widget) IssuesFor(username string, projects []string, jql string)
 	if err != nil {
 		return &SearchResult{}, err
 	}
+	defer resp.Body.Close()
 
 	searchResult := &SearchResult{}
 	parseJson(searchResult, resp.Body)
@@ -45,12 +64,8 @@ func (widget *Widget) IssuesFor(username string, projects []string, jql string)
 	return searchResult, nil
 }
 
-func buildJql(key string, value string) string {
-	return fmt.Sprintf("%s = \"%s\"", key, value)
-}
-
-/* -------------------- Unexported Functions -------------------- */
-
+// jiraRequest makes a request to the Jira API, signed in with basic auth or, for a
+// personal access token, as its bearer
 func (widget *Widget) jiraRequest(path string) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", widget.settings.domain, path)
 
@@ -58,22 +73,27 @@ func (widget *Widget) jiraRequest(path string) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.SetBasicAuth(widget.settings.email, widget.settings.apiKey)
-
-	httpClient := &http.Client{Transport: &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: !widget.settings.verifyServerCertificate,
-		},
-		Proxy: http.ProxyFromEnvironment,
-	},
+
+	switch widget.settings.authType {
+	case authBearer:
+		req.Header.Set("Authorization", "Bearer "+widget.settings.apiKey)
+	default:
+		login := widget.settings.email
+		if login == "" {
+			login = widget.settings.username
+		}
+
+		req.SetBasicAuth(login, widget.settings.apiKey)
 	}
+
+	httpClient := wtf.HTTPClientVerifying(widget.settings.verifyServerCertificate)
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, fmt.Errorf(resp.Status)
+		return nil, wtf.NewHTTPError(resp)
 	}
 
 	return resp, nil
@@ -82,7 +102,8 @@ func (widget *Widget) jiraRequest(path string) (*http.Response, error) {
 func parseJson(obj interface{}, text io.Reader) {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		logger.For("jira").Errorf("reading the response: %v", err)
+		return
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -91,7 +112,8 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			logger.For("jira").Errorf("parsing the response: %v", err)
+			return
 		}
 	}
 }
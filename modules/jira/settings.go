@@ -9,6 +9,13 @@ import (
 
 const defaultTitle = "Jira"
 
+const (
+	authBasic  = "basic"
+	authBearer = "bearer"
+)
+
+const defaultQueryMax = 5
+
 type colors struct {
 	rows struct {
 		even string
@@ -21,20 +28,30 @@ type Settings struct {
 	common *cfg.Common
 
 	apiKey                  string   `help:"Your Jira API key."`
+	authType                string   `help:"How to sign in to Jira: basic, with email (Jira Cloud) or username (Jira Server) and apiKey, or bearer, with apiKey as a Jira Server or Data Center personal access token." values:"basic, bearer" optional:"true" default:"basic"`
 	domain                  string   `help:"Your Jira corporate domain."`
 	email                   string   `help:"The email address associated with your Jira account."`
 	jql                     string   `help:"Custom JQL to be appended to the search query." values:"See Search Jira like a boss with JQL for details." optional:"true"`
 	projects                []string `help:"An array of projects to get data from"`
+	queries                 []query  `help:"Named JQL queries, each shown as a section with its count and first rows: title, jql, and max, how many rows to show (5 by default). With queries and no username, the assigned issues aren't shown." optional:"true"`
 	username                string   `help:"Your Jira username."`
 	verifyServerCertificate bool     `help:"Determines whether or not the server’s certificate chain and host name are verified." values:"true or false" optional:"true"`
 }
 
+// query is a named JQL search, shown as its own section
+type query struct {
+	jql   string
+	max   int
+	title string
+}
+
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
 
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
 
 		apiKey:                  ymlConfig.UString("apiKey", os.Getenv("WTF_JIRA_API_KEY")),
+		authType:                ymlConfig.UString("authType", authBasic),
 		domain:                  ymlConfig.UString("domain"),
 		email:                   ymlConfig.UString("email"),
 		jql:                     ymlConfig.UString("jql"),
@@ -46,6 +63,7 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings.colors.rows.odd = ymlConfig.UString("colors.odd", "white")
 
 	settings.projects = settings.arrayifyProjects(ymlConfig, globalConfig)
+	settings.queries = parseQueries(ymlConfig)
 
 	return &settings
 }
@@ -73,3 +91,32 @@ func (settings *Settings) arrayifyProjects(ymlConfig *config.Config, globalConfi
 
 	return projects
 }
+
+// parseQueries reads the named JQL queries, skipping those without a title or JQL
+// Example:
+//
+//	queries:
+//	  - title: "Blocked"
+//	    jql: "status = Blocked AND project = WTF"
+//	  - title: "Unassigned bugs"
+//	    jql: "type = Bug AND assignee IS EMPTY ORDER BY created"
+//	    max: 10
+func parseQueries(ymlConfig *config.Config) []query {
+	queries := []query{}
+
+	for _, value := range ymlConfig.UList("queries") {
+		queryConfig := &config.Config{Root: value}
+
+		q := query{
+			jql:   queryConfig.UString("jql"),
+			max:   queryConfig.UInt("max", defaultQueryMax),
+			title: queryConfig.UString("title"),
+		}
+
+		if q.title != "" && q.jql != "" {
+			queries = append(queries, q)
+		}
+	}
+
+	return queries
+}
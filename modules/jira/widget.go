@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -11,10 +12,18 @@ type Widget struct {
 	wtf.KeyboardWidget
 	wtf.ScrollableWidget
 
-	result   *SearchResult
+	assigned map[string]bool
+	issues   []Issue
+	sections []*section
 	settings *Settings
 }
 
+// section is a titled list of the issues one search found
+type section struct {
+	result *SearchResult
+	title  string
+}
+
 func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
 		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
@@ -24,6 +33,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 	}
 
 	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
 	widget.initializeKeyboardControls()
 	widget.View.SetInputCapture(widget.InputCapture)
 
@@ -34,64 +44,152 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 
 /* -------------------- Exported Functions -------------------- */
 
+// Refresh searches for the issues assigned to the user, unless only the named queries
+// are wanted, and then runs each of the named queries
 func (widget *Widget) Refresh() {
-	searchResult, err := widget.IssuesFor(
-		widget.settings.username,
-		widget.settings.projects,
-		widget.settings.jql,
-	)
+	sections := []*section{}
 
-	if err != nil {
-		widget.result = nil
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
-		return
+	if widget.settings.username != "" || len(widget.settings.queries) == 0 {
+		searchResult, err := widget.IssuesFor(
+			widget.settings.username,
+			widget.settings.projects,
+			widget.settings.jql,
+		)
+
+		if err != nil {
+			widget.setSections(nil)
+			widget.RedrawError(widget.CommonSettings().Title, err)
+			return
+		}
+		widget.notifyAssigned(searchResult)
+
+		sections = append(sections, &section{result: searchResult, title: "Assigned Issues"})
+	}
+
+	for _, query := range widget.settings.queries {
+		searchResult, err := widget.search(query.jql, query.max)
+		if err != nil {
+			widget.setSections(nil)
+			widget.RedrawError(widget.CommonSettings().Title, fmt.Errorf("%s: %v", query.title, err))
+			return
+		}
+
+		sections = append(sections, &section{result: searchResult, title: query.title})
+	}
+
+	issues := 0
+	for _, section := range sections {
+		issues += section.result.Total
 	}
-	widget.result = searchResult
-	widget.SetItemCount(len(searchResult.Issues))
+	widget.PublishData("issues", issues)
+
+	widget.setSections(sections)
 	widget.Render()
 }
 
 func (widget *Widget) Render() {
-	if widget.result == nil {
+	if widget.sections == nil {
 		return
 	}
 
 	str := fmt.Sprintf("%s- [green]%s[white]", widget.CommonSettings().Title, widget.settings.projects)
 
-	widget.Redraw(str, widget.contentFrom(widget.result), false)
+	widget.Redraw(str, widget.contentFrom(widget.sections), false)
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
+// notifyAssigned sends a notification for each issue that has been assigned since the
+// last refresh
+func (widget *Widget) notifyAssigned(searchResult *SearchResult) {
+	assigned := make(map[string]bool)
+
+	for _, issue := range searchResult.Issues {
+		assigned[issue.Key] = true
+
+		if widget.assigned != nil && !widget.assigned[issue.Key] {
+			widget.Notify("New issue assigned", fmt.Sprintf("%s: %s", issue.Key, issue.IssueFields.Summary))
+		}
+	}
+
+	widget.assigned = assigned
+}
+
+// setSections replaces the sections, and the list of their issues that rows are
+// selected from
+func (widget *Widget) setSections(sections []*section) {
+	issues := []Issue{}
+	for _, section := range sections {
+		issues = append(issues, section.result.Issues...)
+	}
+
+	widget.sections = sections
+	widget.issues = issues
+	widget.SetItemCount(len(issues))
+}
+
 func (widget *Widget) openItem() {
 	sel := widget.GetSelected()
-	if sel >= 0 && widget.result != nil && sel < len(widget.result.Issues) {
-		issue := &widget.result.Issues[sel]
-		wtf.OpenFile(widget.settings.domain + "/browse/" + issue.Key)
+	if sel >= 0 && sel < len(widget.issues) {
+		issue := &widget.issues[sel]
+		utils.OpenURL(widget.settings.domain + "/browse/" + issue.Key)
 	}
 }
 
-func (widget *Widget) contentFrom(searchResult *SearchResult) string {
-	str := " [red]Assigned Issues[white]\n"
-
-	for idx, issue := range searchResult.Issues {
-		row := fmt.Sprintf(
-			`[%s] [%s]%-6s[white] [green]%-10s[white] [yellow][%s][white] [%s]%s`,
-			widget.RowColor(idx),
-			widget.issueTypeColor(&issue),
-			issue.IssueFields.IssueType.Name,
-			issue.Key,
-			issue.IssueFields.IssueStatus.IName,
-			widget.RowColor(idx),
-			issue.IssueFields.Summary,
-		)
+// rowFor describes the issue at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	issue := widget.issues[idx]
 
-		str += wtf.HighlightableHelper(widget.View, row, idx, len(issue.IssueFields.Summary))
+	return wtf.Row{
+		ID:   issue.Key,
+		Text: issue.IssueFields.Summary,
+		URL:  widget.settings.domain + "/browse/" + issue.Key,
+	}
+}
+
+// contentFrom lists each section's issues under its title and count. The rows are
+// numbered across the sections, so that any of them can be selected
+func (widget *Widget) contentFrom(sections []*section) string {
+	str := ""
+	idx := 0
+
+	for i, section := range sections {
+		if i > 0 {
+			str += "\n"
+		}
+
+		str += fmt.Sprintf(" [red]%s[white] [gray](%d)[white]\n", section.title, section.result.Total)
+
+		if len(section.result.Issues) == 0 {
+			str += " [grey]none[white]\n"
+			continue
+		}
+
+		for _, issue := range section.result.Issues {
+			str += widget.issueRow(idx, issue)
+			idx++
+		}
 	}
 
 	return str
 }
 
+// issueRow writes the issue, as the list's row at the given index
+func (widget *Widget) issueRow(idx int, issue Issue) string {
+	row := fmt.Sprintf(
+		`[%s] [%s]%-6s[white] [green]%-10s[white] [yellow][%s][white] [%s]%s`,
+		widget.RowColor(idx),
+		widget.issueTypeColor(&issue),
+		issue.IssueFields.IssueType.Name,
+		issue.Key,
+		issue.IssueFields.IssueStatus.IName,
+		widget.RowColor(idx),
+		issue.IssueFields.Summary,
+	)
+
+	return wtf.HighlightableHelper(widget.View, row, idx, len(issue.IssueFields.Summary))
+}
+
 func (widget *Widget) issueTypeColor(issue *Issue) string {
 	switch issue.IssueFields.IssueType.Name {
 	case "Bug":
@@ -3,17 +3,30 @@ package logger
 import (
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	log "github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 const defaultTitle = "Logger"
 
 type Settings struct {
 	common *cfg.Common
+
+	level   log.Level `help:"The least serious level of message to show." values:"debug, info, warn, error" optional:"true"`
+	modules []string  `help:"Only show messages from these modules. Shows messages from every module if empty." optional:"true"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+	level, err := log.ParseLevel(ymlConfig.UString("level", "debug"))
+	if err != nil {
+		level = log.LevelDebug
+	}
+
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		level:   level,
+		modules: wtf.ToStrs(ymlConfig.UList("modules")),
 	}
 
 	return &settings
@@ -10,7 +10,7 @@ import (
 	"github.com/wtfutil/wtf/wtf"
 )
 
-const maxBufferSize int64 = 1024
+const maxBufferSize int64 = 16384
 
 type Widget struct {
 	wtf.TextWidget
@@ -25,7 +25,6 @@ func NewWidget(app *tview.Application, settings *Settings) *Widget {
 		TextWidget: wtf.NewTextWidget(app, settings.common, true),
 
 		app:      app,
-		filePath: log.LogFilePath(),
 		settings: settings,
 	}
 
@@ -38,6 +37,9 @@ func (widget *Widget) Refresh() {
 		return
 	}
 
+	// The log file can be moved by a config change, so it is looked up each time
+	widget.filePath = log.LogFilePath()
+
 	logLines := widget.tailFile()
 
 	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(logLines), false)
@@ -48,22 +50,48 @@ func (widget *Widget) Refresh() {
 func (widget *Widget) contentFrom(logLines []string) string {
 	str := ""
 
-	for _, line := range logLines {
-		chunks := strings.Split(line, " ")
+	for _, text := range logLines {
+		line, ok := log.ParseLine(text)
+		if !ok {
+			continue
+		}
 
-		if len(chunks) >= 4 {
-			str += fmt.Sprintf(
-				"[green]%s[white] [yellow]%s[white] %s\n",
-				chunks[0],
-				chunks[1],
-				strings.Join(chunks[3:], " "),
-			)
+		if !widget.shows(line) {
+			continue
 		}
+
+		str += fmt.Sprintf(
+			"[green]%s[white] [%s]%-5s[white] [yellow]%s[white] %s\n",
+			line.Time.Format("15:04:05"),
+			levelColor(line.Level),
+			line.Level,
+			line.Module,
+			tview.Escape(line.Message),
+		)
 	}
 
 	return str
 }
 
+// shows returns true if the line is serious enough, and from a module, to be shown
+func (widget *Widget) shows(line log.Line) bool {
+	if line.Level < widget.settings.level {
+		return false
+	}
+
+	if len(widget.settings.modules) == 0 {
+		return true
+	}
+
+	for _, module := range widget.settings.modules {
+		if module == line.Module {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (widget *Widget) tailFile() []string {
 	file, err := os.Open(widget.filePath)
 	if err != nil {
@@ -100,3 +128,16 @@ func (widget *Widget) tailFile() []string {
 
 	return logLines
 }
+
+func levelColor(level log.Level) string {
+	switch level {
+	case log.LevelDebug:
+		return "grey"
+	case log.LevelWarn:
+		return "orange"
+	case log.LevelError:
+		return "red"
+	default:
+		return "white"
+	}
+}
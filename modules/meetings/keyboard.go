@@ -0,0 +1,17 @@
+package meetings
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next meeting")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous meeting")
+	widget.SetKeyboardChar("J", widget.joinNext, "Join the next meeting")
+	widget.SetKeyboardChar("o", widget.joinSelected, "Join the selected meeting, or the next one")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next meeting")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous meeting")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.joinSelected, "Join the selected meeting, or the next one")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
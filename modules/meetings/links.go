@@ -0,0 +1,41 @@
+package meetings
+
+import (
+	"regexp"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// conferenceLink matches a video conference service's meeting link and names the
+// service
+type conferenceLink struct {
+	regexp  *regexp.Regexp
+	service string
+}
+
+var conferenceLinks = []conferenceLink{
+	{regexp.MustCompile(`https://[\w.-]*zoom\.us/(j|my|w|s)/[^\s"'<>]+`), "Zoom"},
+	{regexp.MustCompile(`https://meet\.google\.com/[a-z0-9-]+`), "Meet"},
+	{regexp.MustCompile(`https://teams\.microsoft\.com/l/meetup-join/[^\s"'<>]+`), "Teams"},
+	{regexp.MustCompile(`https://[\w.-]+\.webex\.com/[^\s"'<>]+`), "Webex"},
+	{regexp.MustCompile(`https://whereby\.com/[^\s"'<>]+`), "Whereby"},
+	{regexp.MustCompile(`https://[\w.-]*jit\.si/[^\s"'<>]+`), "Jitsi"},
+}
+
+// meetingLink finds the event's video conference link: the one the calendar knows of, or
+// the first in its location or description. It returns the link and the service it's for
+func meetingLink(event wtf.CalendarEvent) (string, string) {
+	for _, text := range []string{event.URL, event.Location, event.Description} {
+		for _, link := range conferenceLinks {
+			if found := link.regexp.FindString(text); found != "" {
+				return found, link.service
+			}
+		}
+	}
+
+	if event.URL != "" {
+		return event.URL, "Video"
+	}
+
+	return "", ""
+}
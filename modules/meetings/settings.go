@@ -0,0 +1,27 @@
+package meetings
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Meetings"
+
+type Settings struct {
+	common *cfg.Common
+
+	calendar string `help:"The name of the calendar widget to take the events from. Without it, the events of every calendar widget are used." optional:"true"`
+	count    int    `help:"How many upcoming meetings to show." optional:"true" default:"5"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		calendar: ymlConfig.UString("calendar", ""),
+		count:    ymlConfig.UInt("count", 5),
+	}
+
+	return &settings
+}
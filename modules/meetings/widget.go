@@ -0,0 +1,182 @@
+package meetings
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// meeting is an upcoming calendar event with a video conference link
+type meeting struct {
+	event   wtf.CalendarEvent
+	link    string
+	service string
+}
+
+// A Widget shows the upcoming meetings from the calendar widgets, with a countdown to
+// the next one, and joins them with a key press
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	meetings []meeting
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	// The meetings come from the calendar widgets, so update them whenever the calendars do
+	prefix := ""
+	if settings.calendar != "" {
+		prefix = settings.calendar + "."
+	}
+
+	widget.AddSubscription(wtf.Data.Subscribe(prefix, func(key string, value interface{}) {
+		if _, ok := value.([]wtf.CalendarEvent); ok && widget.Enabled() {
+			widget.Refresh()
+		}
+	}))
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh picks the meetings out of the events that the calendar widgets have published
+func (widget *Widget) Refresh() {
+	now := time.Now()
+	meetings := []meeting{}
+
+	for _, event := range wtf.Data.CalendarEvents(widget.settings.calendar) {
+		if event.AllDay || !event.End.After(now) {
+			continue
+		}
+
+		link, service := meetingLink(event)
+		if link == "" {
+			continue
+		}
+
+		meetings = append(meetings, meeting{event: event, link: link, service: service})
+		if len(meetings) == widget.settings.count {
+			break
+		}
+	}
+
+	widget.meetings = meetings
+	widget.SetItemCount(len(meetings))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	title := widget.CommonSettings().Title
+	if len(widget.meetings) > 0 {
+		title = fmt.Sprintf("%s - %s", title, countdown(widget.meetings[0], time.Now()))
+	}
+
+	widget.Redraw(title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the meetings so that the countdowns stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.meetings) == 0 {
+		return " [gray]No upcoming meetings[white]"
+	}
+
+	now := time.Now()
+
+	str := ""
+	for idx, m := range widget.meetings {
+		timeColor := "gray"
+		if idx == 0 {
+			timeColor = "yellow"
+		}
+		if !m.event.Start.After(now) {
+			timeColor = "green"
+		}
+
+		row := fmt.Sprintf(
+			"[%s] [%s]%s[%s] %s [gray]%s[white]",
+			widget.RowColor(idx),
+			timeColor,
+			countdown(m, now),
+			widget.RowColor(idx),
+			tview.Escape(m.event.Title),
+			m.service,
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(m.event.Title))
+	}
+
+	return str
+}
+
+func (widget *Widget) join(m meeting) {
+	utils.OpenURL(m.link)
+}
+
+// joinNext joins the meeting that's on now or, if none is, the next one
+func (widget *Widget) joinNext() {
+	if len(widget.meetings) > 0 {
+		widget.join(widget.meetings[0])
+	}
+}
+
+func (widget *Widget) joinSelected() {
+	sel := widget.GetSelected()
+	if sel < 0 || sel >= len(widget.meetings) {
+		widget.joinNext()
+		return
+	}
+
+	widget.join(widget.meetings[sel])
+}
+
+// rowFor describes the meeting at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	m := widget.meetings[idx]
+
+	return wtf.Row{
+		ID:   m.event.Start.Format(time.RFC3339),
+		Text: m.event.Title,
+		URL:  m.link,
+	}
+}
+
+// countdown describes when the meeting starts, such as "in 12m", or "now" once it has
+func countdown(m meeting, now time.Time) string {
+	if !m.event.Start.After(now) {
+		return "now"
+	}
+
+	return wtf.RelativeTime(m.event.Start)
+}
@@ -0,0 +1,152 @@
+package msgraph
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const graphURL = "https://graph.microsoft.com/v1.0"
+
+// graphTimeLayout is how Graph writes the times of events, which are in the time zone
+// asked for in the Prefer header
+const graphTimeLayout = "2006-01-02T15:04:05.9999999"
+
+// Presence is the user's availability in Teams, such as "Available" or "Busy", and
+// what they're doing, such as "InACall"
+type Presence struct {
+	Activity     string `json:"activity"`
+	Availability string `json:"availability"`
+}
+
+type graphTime struct {
+	DateTime string `json:"dateTime"`
+}
+
+type graphEvents struct {
+	Value []struct {
+		Body struct {
+			Content string `json:"content"`
+		} `json:"body"`
+		End         graphTime `json:"end"`
+		IsAllDay    bool      `json:"isAllDay"`
+		IsCancelled bool      `json:"isCancelled"`
+		Location    struct {
+			DisplayName string `json:"displayName"`
+		} `json:"location"`
+		OnlineMeeting *struct {
+			JoinURL string `json:"joinUrl"`
+		} `json:"onlineMeeting"`
+		Start   graphTime `json:"start"`
+		Subject string    `json:"subject"`
+	} `json:"value"`
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// fetchEvents returns the user's calendar events over the next day, soonest first
+func (widget *Widget) fetchEvents() ([]wtf.CalendarEvent, error) {
+	now := time.Now().UTC()
+
+	params := url.Values{}
+	params.Set("startDateTime", now.Format(time.RFC3339))
+	params.Set("endDateTime", now.Add(24*time.Hour).Format(time.RFC3339))
+	params.Set("$orderby", "start/dateTime")
+	params.Set("$select", "subject,start,end,isAllDay,isCancelled,location,onlineMeeting,body")
+	params.Set("$top", "20")
+
+	result := graphEvents{}
+	if err := widget.get("/me/calendarView?"+params.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	events := []wtf.CalendarEvent{}
+	for _, item := range result.Value {
+		if item.IsCancelled {
+			continue
+		}
+
+		event := wtf.CalendarEvent{
+			AllDay:      item.IsAllDay,
+			Description: item.Body.Content,
+			End:         parseGraphTime(item.End),
+			Location:    item.Location.DisplayName,
+			Start:       parseGraphTime(item.Start),
+			Title:       item.Subject,
+		}
+
+		if item.OnlineMeeting != nil {
+			event.URL = item.OnlineMeeting.JoinURL
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// fetchPresence returns the user's presence in Teams
+func (widget *Widget) fetchPresence() (Presence, error) {
+	presence := Presence{}
+	err := widget.get("/me/presence", &presence)
+
+	return presence, err
+}
+
+// fetchUnreadCount returns how many messages in the user's inbox are unread
+func (widget *Widget) fetchUnreadCount() (int, error) {
+	inbox := struct {
+		UnreadItemCount int `json:"unreadItemCount"`
+	}{}
+
+	err := widget.get("/me/mailFolders/inbox?$select=unreadItemCount", &inbox)
+
+	return inbox.UnreadItemCount, err
+}
+
+func (widget *Widget) get(path string, result interface{}) error {
+	req, err := http.NewRequest("GET", graphURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Prefer", `outlook.timezone="UTC"`)
+
+	resp, err := widget.session.Client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// parseGraphTime reads one of Graph's event times, which are in UTC
+func parseGraphTime(t graphTime) time.Time {
+	parsed, err := time.ParseInLocation(graphTimeLayout, t.DateTime, time.UTC)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed.Local()
+}
+
+// presenceColor is the color Teams shows the availability in
+func presenceColor(availability string) string {
+	switch availability {
+	case "Available", "AvailableIdle":
+		return "green"
+	case "Busy", "BusyIdle", "DoNotDisturb":
+		return "red"
+	case "Away", "BeRightBack":
+		return "yellow"
+	default:
+		return "gray"
+	}
+}
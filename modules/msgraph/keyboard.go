@@ -0,0 +1,8 @@
+package msgraph
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("/", widget.ShowHelp, "Show/hide this help prompt")
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("o", widget.openMail, "Open Outlook mail in browser")
+	widget.SetKeyboardChar("c", widget.openCalendar, "Open Outlook calendar in browser")
+}
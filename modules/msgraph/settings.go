@@ -0,0 +1,32 @@
+package msgraph
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/oauth"
+)
+
+const defaultTitle = "Microsoft 365"
+
+type Settings struct {
+	common *cfg.Common
+
+	callbackPort string `help:"The port to listen for the sign-in redirect on, for the callback flow." optional:"true" default:"8080"`
+	clientID     string `help:"The application (client) ID of your Azure app registration, which needs the Mail.Read, Calendars.Read, and Presence.Read delegated permissions." values:"Falls back to WTF_MSGRAPH_CLIENT_ID."`
+	flow         string `help:"How to sign in: on Microsoft's device-code page, or through a redirect to wtf." values:"device or callback" optional:"true" default:"device"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		callbackPort: ymlConfig.UString("callbackPort", "8080"),
+		clientID:     ymlConfig.UString("clientID", os.Getenv("WTF_MSGRAPH_CLIENT_ID")),
+		flow:         ymlConfig.UString("flow", oauth.FlowDevice),
+	}
+
+	return &settings
+}
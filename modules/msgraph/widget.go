@@ -0,0 +1,198 @@
+package msgraph
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/oauth"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const calendarURL = "https://outlook.office.com/calendar/"
+const mailURL = "https://outlook.office.com/mail/"
+
+// scopes are what the widget asks to be allowed to do with the user's account
+var scopes = []string{"offline_access", "User.Read", "Mail.Read", "Calendars.Read", "Presence.Read"}
+
+// wordBoundaryRegExp matches where Graph's camel-cased activities break into words
+var wordBoundaryRegExp = regexp.MustCompile(`([a-z])([A-Z])`)
+
+// A Widget shows the user's Teams presence, their unread Outlook mail, and their next
+// meeting, from Microsoft Graph
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.TextWidget
+
+	events   []wtf.CalendarEvent
+	mu       sync.Mutex
+	presence *Presence
+	session  *oauth.Session
+	settings *Settings
+	unread   int
+}
+
+// NewWidget creates a new instance of a widget. The user signs in once, and the sign-in
+// is kept under the widget's name
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
+		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetQuotaHost("graph.microsoft.com")
+
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	widget.session = oauth.NewSession(widget.Name(), oauth.DefaultTokenStore(), widget.Refresh)
+	go widget.session.SignIn(widget.oauthConfig)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the user's unread mail count, upcoming events, and presence
+func (widget *Widget) Refresh() {
+	if widget.session.Client() == nil {
+		widget.Redraw(widget.CommonSettings().Title, widget.session.PromptText(), true)
+		return
+	}
+
+	unread, err := widget.fetchUnreadCount()
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	events, err := widget.fetchEvents()
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	// Presence is only available for work and school accounts
+	var presence *Presence
+	if fetched, err := widget.fetchPresence(); err == nil {
+		presence = &fetched
+	} else {
+		logger.For(widget.Name()).Warnf("fetching the presence: %v", err)
+	}
+
+	widget.mu.Lock()
+	widget.events = events
+	widget.presence = presence
+	widget.unread = unread
+	widget.mu.Unlock()
+
+	widget.PublishData("unread", unread)
+	widget.PublishCalendarEvents(events)
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), true)
+}
+
+// RenderRelativeTimes redraws the widget so that the time until the next meeting stays
+// current
+func (widget *Widget) RenderRelativeTimes() {
+	if widget.session.Client() != nil {
+		widget.Render()
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	str := ""
+
+	if widget.presence != nil {
+		str += fmt.Sprintf(
+			" [%s]●[white] %s",
+			presenceColor(widget.presence.Availability),
+			spaced(widget.presence.Availability),
+		)
+
+		if activity := widget.presence.Activity; activity != "" && activity != widget.presence.Availability {
+			str += fmt.Sprintf(" [gray]%s[white]", spaced(activity))
+		}
+
+		str += "\n"
+	}
+
+	mailColor := "white"
+	if widget.unread > 0 {
+		mailColor = "yellow"
+	}
+	str += fmt.Sprintf(" [green]Mail[white]  [%s]%d unread[white]\n", mailColor, widget.unread)
+
+	str += fmt.Sprintf(" [green]Next[white]  %s\n", nextMeeting(widget.events, time.Now()))
+
+	return str
+}
+
+func (widget *Widget) openCalendar() {
+	utils.OpenURL(calendarURL)
+}
+
+func (widget *Widget) openMail() {
+	utils.OpenURL(mailURL)
+}
+
+// oauthConfig returns the config to sign in to Microsoft with
+func (widget *Widget) oauthConfig() (*oauth.Config, error) {
+	provider, _ := oauth.LookupProvider("microsoft")
+
+	config := &oauth.Config{
+		CallbackPort: widget.settings.callbackPort,
+		ClientID:     widget.settings.clientID,
+		Flow:         widget.settings.flow,
+		Provider:     provider,
+		Scopes:       scopes,
+	}
+
+	return config, nil
+}
+
+// nextMeeting describes the next timed event that hasn't ended, such as "Standup in 12m"
+func nextMeeting(events []wtf.CalendarEvent, now time.Time) string {
+	for _, event := range events {
+		if event.AllDay || !event.End.After(now) {
+			continue
+		}
+
+		when := wtf.RelativeTime(event.Start)
+		if !event.Start.After(now) {
+			when = "now"
+		}
+
+		return fmt.Sprintf("%s [gray]%s[white]", tview.Escape(event.Title), when)
+	}
+
+	return "[gray]none[white]"
+}
+
+// spaced breaks Graph's camel-cased words apart, such as "DoNotDisturb" into "Do Not
+// Disturb"
+func spaced(word string) string {
+	return wordBoundaryRegExp.ReplaceAllString(word, "$1 $2")
+}
@@ -14,16 +14,16 @@ func (widget *Widget) initializeKeyboardControls() {
 }
 
 func (widget *Widget) center() {
-	offset = 0
+	widget.offset = 0
 	widget.Refresh()
 }
 
 func (widget *Widget) next() {
-	offset++
+	widget.offset++
 	widget.Refresh()
 }
 
 func (widget *Widget) prev() {
-	offset--
+	widget.offset--
 	widget.Refresh()
 }
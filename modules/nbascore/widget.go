@@ -18,12 +18,11 @@ type Widget struct {
 	wtf.TextWidget
 
 	language string
+	offset   int
 	result   string
 	settings *Settings
 }
 
-var offset = 0
-
 // NewWidget creates a new instance of a widget
 func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
 	widget := Widget{
@@ -52,9 +51,9 @@ func (widget *Widget) HelpText() string {
 }
 
 func (widget *Widget) nbascore() string {
-	cur := time.Now().AddDate(0, 0, offset) // Go back/forward offset days
-	curString := cur.Format("20060102")     // Need 20060102 format to feed to api
-	client := &http.Client{}
+	cur := time.Now().AddDate(0, 0, widget.offset) // Go back/forward offset days
+	curString := cur.Format("20060102")            // Need 20060102 format to feed to api
+	client := wtf.HTTPClient()
 	req, err := http.NewRequest("GET", "http://data.nba.net/10s/prod/v1/"+curString+"/scoreboard.json", nil)
 	if err != nil {
 		return err.Error()
@@ -0,0 +1,18 @@
+package notifications
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("j", widget.Next, "Select next notification")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous notification")
+	widget.SetKeyboardChar(" ", widget.toggleRead, "Mark the selected notification read/unread")
+	widget.SetKeyboardChar("a", widget.markAllRead, "Mark all notifications read")
+	widget.SetKeyboardChar("d", widget.deleteSelected, "Delete the selected notification")
+	widget.SetKeyboardChar("c", widget.clear, "Delete all notifications")
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next notification")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous notification")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.toggleRead, "Mark the selected notification read/unread")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
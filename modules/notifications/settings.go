@@ -0,0 +1,26 @@
+package notifications
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Notifications"
+
+type Settings struct {
+	common *cfg.Common
+
+	maxNotifications int  `help:"The number of notifications to keep. The oldest are dropped first." optional:"true" default:"100"`
+	showModule       bool `help:"Whether or not to show the name of the module that sent each notification." values:"true, false" optional:"true" default:"true"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		maxNotifications: ymlConfig.UInt("maxNotifications", 100),
+		showModule:       ymlConfig.UBool("showModule", true),
+	}
+
+	return &settings
+}
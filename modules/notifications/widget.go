@@ -0,0 +1,206 @@
+package notifications
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// entry is a notification in the timeline, and whether it has been read
+type entry struct {
+	wtf.Notification
+
+	read bool
+}
+
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	entries  []*entry
+	mu       sync.Mutex
+	settings *Settings
+}
+
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := &Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		entries:  []*entry{},
+		settings: settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	widget.AddSubscription(wtf.Notifications.Subscribe(widget.add))
+	widget.AddSubscription(wtf.Shared.Subscribe(widget.syncRead))
+
+	return widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Refresh redraws the timeline. Notifications arrive as they are sent, so there is
+// nothing to fetch
+func (widget *Widget) Refresh() {
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.mu.Lock()
+	title := widget.title()
+	content := widget.contentFrom(widget.entries)
+	widget.mu.Unlock()
+
+	widget.Redraw(title, content, false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// add puts a newly sent notification at the top of the timeline, keeping the same
+// notification selected
+func (widget *Widget) add(notification wtf.Notification) {
+	widget.mu.Lock()
+	read, _ := wtf.Shared.IsRead(notification.ID())
+	widget.entries = append([]*entry{{Notification: notification, read: read}}, widget.entries...)
+	if len(widget.entries) > widget.settings.maxNotifications {
+		widget.entries = widget.entries[:widget.settings.maxNotifications]
+	}
+	widget.SetItemCount(len(widget.entries))
+
+	if widget.Selected >= 0 && widget.Selected < len(widget.entries)-1 {
+		widget.Selected++
+	}
+	widget.mu.Unlock()
+
+	widget.Render()
+}
+
+func (widget *Widget) clear() {
+	widget.Confirm("Delete all notifications?", func() {
+		widget.mu.Lock()
+		widget.entries = []*entry{}
+		widget.SetItemCount(0)
+		widget.mu.Unlock()
+
+		widget.Unselect()
+	})
+}
+
+func (widget *Widget) contentFrom(entries []*entry) string {
+	if len(entries) == 0 {
+		return " [grey]No notifications[-]"
+	}
+
+	var str string
+
+	for idx, entry := range entries {
+		marker := " "
+		if !entry.read {
+			marker = fmt.Sprintf("[%s]●[%s]", widget.CommonSettings().Colors.Warn, widget.RowColor(idx))
+		}
+
+		source := ""
+		if widget.settings.showModule {
+			source = fmt.Sprintf("[lightblue]%s[%s] ", entry.Module, widget.RowColor(idx))
+		}
+
+		row := fmt.Sprintf(
+			"[%s]%s [grey]%s[%s] %s%s: %s",
+			widget.RowColor(idx),
+			marker,
+			entry.Time.Format("Jan 2 15:04"),
+			widget.RowColor(idx),
+			source,
+			entry.Title,
+			entry.Message,
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, tview.TaggedStringWidth(row))
+	}
+
+	return str
+}
+
+func (widget *Widget) deleteSelected() {
+	widget.mu.Lock()
+	sel := widget.GetSelected()
+	if sel < 0 || sel >= len(widget.entries) {
+		widget.mu.Unlock()
+		return
+	}
+
+	widget.entries = append(widget.entries[:sel], widget.entries[sel+1:]...)
+	widget.SetItemCount(len(widget.entries))
+
+	if widget.Selected >= len(widget.entries) {
+		widget.Selected = len(widget.entries) - 1
+	}
+	widget.mu.Unlock()
+
+	widget.Render()
+}
+
+func (widget *Widget) markAllRead() {
+	widget.mu.Lock()
+	for _, entry := range widget.entries {
+		if !entry.read {
+			entry.read = true
+			wtf.Shared.SetRead(entry.ID(), true, time.Now())
+		}
+	}
+	widget.mu.Unlock()
+
+	widget.Render()
+}
+
+// syncRead brings the notifications' read state in line with what other instances of
+// wtf have marked read
+func (widget *Widget) syncRead() {
+	widget.mu.Lock()
+	for _, entry := range widget.entries {
+		if read, marked := wtf.Shared.IsRead(entry.ID()); marked {
+			entry.read = read
+		}
+	}
+	widget.mu.Unlock()
+
+	widget.Render()
+}
+
+// title returns the widget's title with the number of unread notifications
+func (widget *Widget) title() string {
+	unread := 0
+	for _, entry := range widget.entries {
+		if !entry.read {
+			unread++
+		}
+	}
+
+	if unread == 0 {
+		return widget.CommonSettings().Title
+	}
+
+	return fmt.Sprintf("%s (%d unread)", widget.CommonSettings().Title, unread)
+}
+
+func (widget *Widget) toggleRead() {
+	widget.mu.Lock()
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.entries) {
+		widget.entries[sel].read = !widget.entries[sel].read
+		wtf.Shared.SetRead(widget.entries[sel].ID(), widget.entries[sel].read, time.Now())
+	}
+	widget.mu.Unlock()
+
+	widget.Render()
+}
@@ -56,6 +56,10 @@ func (widget *Widget) Refresh() {
 	} else {
 		widget.View.SetWrap(false)
 		content = widget.contentFrom(onCalls, incidents)
+
+		if widget.settings.showIncidents {
+			widget.PublishData("incidents", len(incidents))
+		}
 	}
 
 	widget.Redraw(widget.CommonSettings().Title, content, wrap)
@@ -0,0 +1,158 @@
+package phabricator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Revision is a Differential revision
+type Revision struct {
+	Author   string
+	ID       int
+	Modified time.Time
+	Title    string
+	URI      string
+}
+
+// conduitResponse is the envelope every Conduit method answers in
+type conduitResponse struct {
+	ErrorCode string          `json:"error_code"`
+	ErrorInfo string          `json:"error_info"`
+	Result    json.RawMessage `json:"result"`
+}
+
+type revisionSearchResult struct {
+	Data []struct {
+		ID     int `json:"id"`
+		Fields struct {
+			AuthorPHID   string `json:"authorPHID"`
+			DateModified int64  `json:"dateModified"`
+			Title        string `json:"title"`
+			URI          string `json:"uri"`
+		} `json:"fields"`
+	} `json:"data"`
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// call runs a Conduit method with the params, which are sent form-encoded, and decodes
+// its result
+func (widget *Widget) call(method string, params url.Values, result interface{}) error {
+	params.Set("api.token", widget.settings.apiToken)
+
+	endpoint := fmt.Sprintf("%s/api/%s", widget.settings.domain, method)
+
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return wtf.NewHTTPError(resp)
+	}
+
+	response := conduitResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return err
+	}
+
+	if response.ErrorCode != "" {
+		return fmt.Errorf("%s: %s", response.ErrorCode, response.ErrorInfo)
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
+// searchRevisions returns the revisions needing review that match the constraint, such as
+// those a user has been asked to review, most recently changed first
+func (widget *Widget) searchRevisions(constraint, phid string) ([]Revision, error) {
+	params := url.Values{}
+	params.Set(fmt.Sprintf("constraints[%s][0]", constraint), phid)
+	params.Set("constraints[statuses][0]", "needs-review")
+	params.Set("order", "updated")
+
+	result := revisionSearchResult{}
+	if err := widget.call("differential.revision.search", params, &result); err != nil {
+		return nil, err
+	}
+
+	revisions := []Revision{}
+	for _, data := range result.Data {
+		if data.Fields.URI == "" {
+			data.Fields.URI = fmt.Sprintf("%s/D%d", widget.settings.domain, data.ID)
+		}
+
+		revisions = append(revisions, Revision{
+			Author:   data.Fields.AuthorPHID,
+			ID:       data.ID,
+			Modified: time.Unix(data.Fields.DateModified, 0),
+			Title:    data.Fields.Title,
+			URI:      data.Fields.URI,
+		})
+	}
+
+	return revisions, nil
+}
+
+// usernames looks up the usernames of the users with the PHIDs
+func (widget *Widget) usernames(phids []string) (map[string]string, error) {
+	names := make(map[string]string)
+	if len(phids) == 0 {
+		return names, nil
+	}
+
+	params := url.Values{}
+	for idx, phid := range phids {
+		params.Set(fmt.Sprintf("constraints[phids][%d]", idx), phid)
+	}
+
+	result := struct {
+		Data []struct {
+			PHID   string `json:"phid"`
+			Fields struct {
+				Username string `json:"username"`
+			} `json:"fields"`
+		} `json:"data"`
+	}{}
+
+	if err := widget.call("user.search", params, &result); err != nil {
+		return names, err
+	}
+
+	for _, user := range result.Data {
+		names[user.PHID] = user.Fields.Username
+	}
+
+	return names, nil
+}
+
+// whoami returns the PHID of the user the API token belongs to
+func (widget *Widget) whoami() (string, error) {
+	user := struct {
+		PHID string `json:"phid"`
+	}{}
+
+	if err := widget.call("user.whoami", url.Values{}, &user); err != nil {
+		return "", err
+	}
+
+	if user.PHID == "" {
+		return "", errors.New("the API token doesn't belong to a user")
+	}
+
+	return user.PHID, nil
+}
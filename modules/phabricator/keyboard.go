@@ -0,0 +1,17 @@
+package phabricator
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next revision")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous revision")
+	widget.SetKeyboardChar("o", widget.openRevision, "Open revision in browser")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next revision")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous revision")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openRevision, "Open revision in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,30 @@
+package phabricator
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Phabricator"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiToken string `help:"Your Conduit API token, from Settings → Conduit API Tokens." values:"Falls back to WTF_PHABRICATOR_API_TOKEN."`
+	domain   string `help:"The address of your Phabricator or Phorge install." values:"Example: https://phabricator.example.com"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiToken: ymlConfig.UString("apiToken", os.Getenv("WTF_PHABRICATOR_API_TOKEN")),
+		domain:   strings.TrimSuffix(ymlConfig.UString("domain", ""), "/"),
+	}
+
+	return &settings
+}
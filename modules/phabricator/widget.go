@@ -0,0 +1,180 @@
+package phabricator
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget lists the Differential revisions waiting on the user's review, and the user's
+// own revisions waiting on review by others
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	authors   map[string]string
+	incoming  []Revision
+	outgoing  []Revision
+	phid      string
+	revisions []Revision
+	settings  *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		authors:  make(map[string]string),
+		settings: settings,
+	}
+
+	if parsed, err := url.Parse(settings.domain); err == nil {
+		widget.SetQuotaHost(parsed.Hostname())
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the revisions waiting on the user and those waiting on others
+func (widget *Widget) Refresh() {
+	if widget.phid == "" {
+		phid, err := widget.whoami()
+		if err != nil {
+			widget.RedrawError(widget.CommonSettings().Title, err)
+			return
+		}
+		widget.phid = phid
+	}
+
+	incoming, err := widget.searchRevisions("reviewerPHIDs", widget.phid)
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	outgoing, err := widget.searchRevisions("authorPHIDs", widget.phid)
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.lookUpAuthors(incoming)
+
+	widget.incoming = incoming
+	widget.outgoing = outgoing
+	widget.revisions = append(append([]Revision{}, incoming...), outgoing...)
+	widget.SetItemCount(len(widget.revisions))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	title := fmt.Sprintf("%s (%d)", widget.CommonSettings().Title, len(widget.incoming))
+
+	widget.Redraw(title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the revisions so that their update times stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	str := " [red]Waiting on You[white]\n"
+	str += widget.revisionRows(widget.incoming, 0, true)
+
+	str += "\n [red]Waiting on Others[white]\n"
+	str += widget.revisionRows(widget.outgoing, len(widget.incoming), false)
+
+	return str
+}
+
+// lookUpAuthors finds the usernames of the revisions' authors that aren't known yet
+func (widget *Widget) lookUpAuthors(revisions []Revision) {
+	phids := []string{}
+	for _, revision := range revisions {
+		if _, ok := widget.authors[revision.Author]; !ok {
+			phids = append(phids, revision.Author)
+		}
+	}
+
+	names, err := widget.usernames(phids)
+	if err != nil {
+		logger.For(widget.Name()).Warnf("looking up the authors: %v", err)
+	}
+
+	for phid, name := range names {
+		widget.authors[phid] = name
+	}
+}
+
+func (widget *Widget) openRevision() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.revisions) {
+		utils.OpenURL(widget.revisions[sel].URI)
+	}
+}
+
+// revisionRows writes the revisions as the list's rows, numbered from the offset
+func (widget *Widget) revisionRows(revisions []Revision, offset int, showAuthor bool) string {
+	if len(revisions) == 0 {
+		return " [grey]none[white]\n"
+	}
+
+	str := ""
+	for i, revision := range revisions {
+		idx := offset + i
+
+		author := ""
+		if name, ok := widget.authors[revision.Author]; ok && showAuthor {
+			author = fmt.Sprintf(" [gray]%s[white]", name)
+		}
+
+		row := fmt.Sprintf(
+			"[%s] [green]D%d[%s] %s%s [gray]%s[white]",
+			widget.RowColor(idx),
+			revision.ID,
+			widget.RowColor(idx),
+			tview.Escape(revision.Title),
+			author,
+			wtf.RelativeTime(revision.Modified),
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(revision.Title))
+	}
+
+	return str
+}
+
+// rowFor describes the revision at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	revision := widget.revisions[idx]
+
+	return wtf.Row{
+		ID:   fmt.Sprintf("D%d", revision.ID),
+		Text: revision.Title,
+		URL:  revision.URI,
+	}
+}
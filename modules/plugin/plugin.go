@@ -0,0 +1,141 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/script"
+)
+
+// The events a plugin is run for
+const (
+	eventKey     = "key"
+	eventRefresh = "refresh"
+)
+
+// Request is what the plugin is sent as JSON on standard input each time it is run:
+//
+//	{"event": "refresh", "config": {...}, "data": {...}, "width": 40, "height": 10}
+//	{"event": "key", "key": "d", "row": {"id": "42", "text": "...", "url": "..."}, ...}
+type Request struct {
+	Config map[string]interface{} `json:"config"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Event  string                 `json:"event"`
+	Height int                    `json:"height"`
+	Key    string                 `json:"key,omitempty"`
+	Row    *Row                   `json:"row,omitempty"`
+	Width  int                    `json:"width"`
+}
+
+// Response is what the plugin writes as JSON on standard output:
+//
+//	{
+//	  "title": "Deploys",
+//	  "rows": [{"text": "api v1.2.3", "color": "green", "id": "42", "url": "https://..."}],
+//	  "keys": [{"key": "d", "help": "Deploy the selected build"}]
+//	}
+//
+// A plugin that fails writes {"error": "..."} or exits with a non-zero status
+type Response struct {
+	Error string `json:"error"`
+	Keys  []Key  `json:"keys"`
+	Rows  []Row  `json:"rows"`
+	Title string `json:"title"`
+}
+
+// Row is a line the plugin shows. The ID and URL are passed back to the plugin when one
+// of its keys is pressed, and can be opened and copied like any other list's rows
+type Row struct {
+	Color string `json:"color,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Text  string `json:"text"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Key is a key the plugin handles. Pressing it runs the plugin again with the key and
+// the selected row
+type Key struct {
+	Help string `json:"help"`
+	Key  string `json:"key"`
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// run starts the plugin, sends it the request, and reads its response, stopping it if
+// it takes longer than the timeout
+func run(cmd string, args []string, timeout time.Duration, request Request) (*Response, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, cmd, args...)
+	command.Env = append(os.Environ(), "WTF_PLUGIN_EVENT="+request.Event)
+	command.Stdin = bytes.NewReader(input)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	if err := command.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s did not answer within %s", cmd, timeout)
+		}
+
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %v\n\n%s", cmd, err, msg)
+		}
+
+		return nil, fmt.Errorf("%s: %v", cmd, err)
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("%s did not write valid JSON: %v", cmd, err)
+	}
+
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+
+	return response, nil
+}
+
+// runScript runs the script's function for the request's event, refresh or key, inside
+// wtf, stopping it if it takes longer than the timeout. The value the function returns
+// is read as the plugin's response would be
+func runScript(loaded *script.Script, timeout time.Duration, request Request) (*Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := loaded.Call(ctx, request.Event, request)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(output, response); err != nil {
+		return nil, fmt.Errorf("%s did not return a valid response: %v", loaded.Name(), err)
+	}
+
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+
+	return response, nil
+}
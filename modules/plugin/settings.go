@@ -0,0 +1,36 @@
+package plugin
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Plugin"
+
+type Settings struct {
+	common *cfg.Common
+
+	args    []string               `help:"The arguments to the plugin, with each item as an element in an array."`
+	cmd     string                 `help:"The plugin to run: a binary or script that reads a request as JSON on standard input and writes what to show as JSON on standard output."`
+	config  map[string]interface{} `help:"Settings passed on to the plugin in each request." optional:"true"`
+	data    string                 `help:"Passes the values on the data bus whose keys start with this prefix, such as 'github.', to the plugin in each request, so that it can filter, sort, and format other modules' data." optional:"true"`
+	script  string                 `help:"A Lua script, or a Starlark script if its name ends in .star, to run in place of cmd. It is loaded when the widget is created and run inside wtf, and defines refresh(request), and key(request) if it asks for keys, which return what to show as a table or dict shaped like the plugin's JSON response." optional:"true"`
+	timeout int                    `help:"How many seconds the plugin has to answer before it is stopped. Default is 10." optional:"true"`
+}
+
+func NewSettingsFromYAML(name string, moduleConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, moduleConfig, globalConfig),
+
+		args:    wtf.ToStrs(moduleConfig.UList("args")),
+		cmd:     moduleConfig.UString("cmd"),
+		config:  moduleConfig.UMap("config"),
+		data:    moduleConfig.UString("data", ""),
+		script:  moduleConfig.UString("script", ""),
+		timeout: moduleConfig.UInt("timeout", 10),
+	}
+
+	return &settings
+}
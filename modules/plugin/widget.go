@@ -0,0 +1,198 @@
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/script"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Widget shows what an external program, the plugin, sends it. The plugin is run on
+// every refresh and whenever one of the keys it asks for is pressed, so custom widgets
+// can be written in any language. A Lua or Starlark script can stand in for the program,
+// and is run inside wtf. The script is loaded once, when the widget is created
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	keys      []Key
+	rows      []Row
+	script    *script.Script
+	scriptErr error
+	settings  *Settings
+	title     string
+}
+
+// NewWidget creates and returns an instance of Widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := &Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	if settings.script != "" {
+		widget.script, widget.scriptErr = script.Load(settings.script)
+	}
+
+	return widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// HelpText returns the help text for the widget's own keys followed by the plugin's
+func (widget *Widget) HelpText() string {
+	str := widget.KeyboardWidget.HelpText()
+
+	if len(widget.keys) > 0 {
+		str += "\n\n [green::b]Plugin commands[white]\n\n"
+		for _, key := range widget.keys {
+			str += fmt.Sprintf("  %s\t%s\n", key.Key, key.Help)
+		}
+	}
+
+	return str
+}
+
+// InputCapture handles the widget's own keys and then the keys the plugin asked for
+func (widget *Widget) InputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if widget.KeyboardWidget.InputCapture(event) == nil {
+		return nil
+	}
+
+	if event.Key() != tcell.KeyRune {
+		return event
+	}
+
+	for _, key := range widget.keys {
+		if key.Key == string(event.Rune()) {
+			go widget.send(eventKey, key.Key)
+			return nil
+		}
+	}
+
+	return event
+}
+
+// Refresh runs the plugin and shows what it sends back
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	widget.send(eventRefresh, "")
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	title := widget.CommonSettings().Title
+	if widget.title != "" {
+		title = widget.title
+	}
+
+	widget.Redraw(title, widget.content(), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) content() string {
+	str := ""
+
+	for idx, row := range widget.rows {
+		color := row.Color
+		if color == "" || widget.View.HasFocus() && idx == widget.Selected || widget.IsMarked(idx) || widget.Matches(idx) {
+			color = widget.RowColor(idx)
+		}
+
+		text := tview.TranslateANSI(row.Text)
+		str += wtf.HighlightableHelper(widget.View, fmt.Sprintf("[%s]%s[-]", color, text), idx, wtf.TextWidth(text))
+	}
+
+	return str
+}
+
+func (widget *Widget) openRow() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.rows) && widget.rows[sel].URL != "" {
+		utils.OpenURL(widget.rows[sel].URL)
+	}
+}
+
+// rowFor describes the plugin's row at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	row := widget.rows[idx]
+
+	return wtf.Row{
+		ID:   row.ID,
+		Text: row.Text,
+		URL:  row.URL,
+	}
+}
+
+// send runs the plugin for the event and shows its response, or the error if it failed
+func (widget *Widget) send(event, key string) {
+	_, _, width, height := widget.View.GetInnerRect()
+
+	request := Request{
+		Config: widget.settings.config,
+		Event:  event,
+		Height: height,
+		Key:    key,
+		Width:  width,
+	}
+
+	if widget.settings.data != "" {
+		request.Data = wtf.Data.Values(widget.settings.data)
+	}
+
+	if sel := widget.GetSelected(); event == eventKey && sel >= 0 && sel < len(widget.rows) {
+		row := widget.rows[sel]
+		request.Row = &row
+	}
+
+	timeout := time.Duration(widget.settings.timeout) * time.Second
+
+	var response *Response
+	var err error
+
+	if widget.settings.script != "" {
+		response, err = widget.runScript(timeout, request)
+	} else {
+		response, err = run(widget.settings.cmd, widget.settings.args, timeout, request)
+	}
+
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.SetRefreshError(nil)
+
+	widget.keys = response.Keys
+	widget.rows = response.Rows
+	widget.title = response.Title
+	widget.SetItemCount(len(widget.rows))
+
+	widget.Render()
+}
+
+// runScript runs the widget's script, or returns why it could not be loaded
+func (widget *Widget) runScript(timeout time.Duration, request Request) (*Response, error) {
+	if widget.scriptErr != nil {
+		return nil, widget.scriptErr
+	}
+
+	return runScript(widget.script, timeout, request)
+}
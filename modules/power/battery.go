@@ -21,6 +21,7 @@ type Battery struct {
 
 	Charge    string
 	Remaining string
+	State     string
 }
 
 func NewBattery() *Battery {
@@ -66,6 +67,9 @@ func (battery *Battery) parse(data string) string {
 		return "unknown (3)"
 	}
 
+	battery.Charge = details[0]
+	battery.State = details[1]
+
 	str := ""
 	str = str + fmt.Sprintf(" %10s: %s\n", "Charge", battery.formatCharge(details[0]))
 	str = str + fmt.Sprintf(" %10s: %s\n", "Remaining", battery.formatRemaining(details[2]))
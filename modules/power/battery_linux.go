@@ -20,6 +20,7 @@ type Battery struct {
 
 	Charge    string
 	Remaining string
+	State     string
 }
 
 func NewBattery() *Battery {
@@ -76,6 +77,8 @@ func (battery *Battery) parse(data string) string {
 		str += fmt.Sprintf(" %10s: %s\n", "TimeToFull", table["time to full"])
 	}
 	batteryState = table["state"]
+	battery.Charge = table["percentage"]
+	battery.State = table["state"]
 	return str
 }
 
@@ -9,11 +9,15 @@ const defaultTitle = "Power"
 
 type Settings struct {
 	common *cfg.Common
+
+	lowBattery float64 `help:"The charge, in percent, below which a discharging battery sends a notification." optional:"true" default:"10"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		lowBattery: float64(ymlConfig.UInt("lowBattery", 10)),
 	}
 
 	return &settings
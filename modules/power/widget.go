@@ -2,6 +2,8 @@ package power
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
@@ -12,7 +14,8 @@ type Widget struct {
 
 	Battery *Battery
 
-	settings *Settings
+	lowNotified bool
+	settings    *Settings
 }
 
 func NewWidget(app *tview.Application, settings *Settings) *Widget {
@@ -36,5 +39,33 @@ func (widget *Widget) Refresh() {
 	content += "\n"
 	content += widget.Battery.String()
 
+	widget.PublishData("charge", widget.Battery.Charge)
+	widget.PublishData("state", widget.Battery.State)
+	widget.notifyIfLow()
+
 	widget.Redraw(widget.CommonSettings().Title, content, true)
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// notifyIfLow sends a notification when the battery is discharging and its charge
+// drops below the lowBattery setting. It notifies again only after the battery has been
+// plugged in or charged back above it
+func (widget *Widget) notifyIfLow() {
+	percent, err := strconv.ParseFloat(strings.TrimSuffix(widget.Battery.Charge, "%"), 64)
+	if err != nil {
+		return
+	}
+
+	if percent >= widget.settings.lowBattery || widget.Battery.State != "discharging" {
+		widget.lowNotified = false
+		return
+	}
+
+	if widget.lowNotified {
+		return
+	}
+
+	widget.lowNotified = true
+	widget.Notify("Battery low", fmt.Sprintf("%s remaining", widget.Battery.Charge))
+}
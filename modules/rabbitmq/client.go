@@ -0,0 +1,75 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type Client struct {
+	apiURL                  string
+	password                string
+	username                string
+	verifyServerCertificate bool
+}
+
+func NewClient(settings *Settings) *Client {
+	client := Client{
+		apiURL:                  strings.TrimRight(settings.apiURL, "/"),
+		password:                settings.password,
+		username:                settings.username,
+		verifyServerCertificate: settings.verifyServerCertificate,
+	}
+
+	return &client
+}
+
+// Queues returns the queues in the given virtual host. If vhost is blank, the queues
+// in all virtual hosts are returned
+func (client *Client) Queues(vhost string) ([]Queue, error) {
+	queues := []Queue{}
+
+	path := "/api/queues"
+	if vhost != "" {
+		path += "/" + url.PathEscape(vhost)
+	}
+
+	resp, err := client.rabbitRequest(path)
+	if err != nil {
+		return queues, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&queues)
+
+	return queues, err
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (client *Client) rabbitRequest(path string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", client.apiURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", "application/json")
+	req.SetBasicAuth(client.username, client.password)
+
+	httpClient := wtf.HTTPClientVerifying(client.verifyServerCertificate)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, wtf.NewHTTPError(resp)
+	}
+
+	return resp, nil
+}
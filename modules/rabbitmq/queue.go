@@ -0,0 +1,29 @@
+package rabbitmq
+
+// Queue is a single queue as reported by the RabbitMQ management API
+type Queue struct {
+	Consumers    int          `json:"consumers"`
+	Messages     int          `json:"messages"`
+	MessageStats MessageStats `json:"message_stats"`
+	Name         string       `json:"name"`
+	Vhost        string       `json:"vhost"`
+}
+
+type MessageStats struct {
+	DeliverGetDetails RateDetails `json:"deliver_get_details"`
+	PublishDetails    RateDetails `json:"publish_details"`
+}
+
+type RateDetails struct {
+	Rate float64 `json:"rate"`
+}
+
+// DeliverRate returns the number of messages delivered per second
+func (queue *Queue) DeliverRate() float64 {
+	return queue.MessageStats.DeliverGetDetails.Rate
+}
+
+// PublishRate returns the number of messages published per second
+func (queue *Queue) PublishRate() float64 {
+	return queue.MessageStats.PublishDetails.Rate
+}
@@ -0,0 +1,40 @@
+package rabbitmq
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "RabbitMQ"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiURL                  string   `help:"The URL of your RabbitMQ management API." values:"Example: http://localhost:15672" optional:"true"`
+	depthThreshold          int      `help:"Queues holding more messages than this are highlighted." values:"A positive integer, 0..n." optional:"true"`
+	password                string   `help:"The password for the management API user."`
+	queues                  []string `help:"An array of queue names to display. If empty, all queues are displayed." optional:"true"`
+	username                string   `help:"The management API user."`
+	verifyServerCertificate bool     `help:"Determines whether or not the server’s certificate chain and host name are verified." values:"true or false" optional:"true"`
+	vhost                   string   `help:"Only display queues in this virtual host." optional:"true"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiURL:                  ymlConfig.UString("apiURL", "http://localhost:15672"),
+		depthThreshold:          ymlConfig.UInt("depthThreshold", 1000),
+		password:                ymlConfig.UString("password", os.Getenv("WTF_RABBITMQ_PASSWORD")),
+		queues:                  wtf.ToStrs(ymlConfig.UList("queues")),
+		username:                ymlConfig.UString("username", "guest"),
+		verifyServerCertificate: ymlConfig.UBool("verifyServerCertificate", true),
+		vhost:                   ymlConfig.UString("vhost"),
+	}
+
+	return &settings
+}
@@ -0,0 +1,133 @@
+package rabbitmq
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	client   *Client
+	queues   []Queue
+	settings *Settings
+	err      error
+}
+
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		client:   NewClient(settings),
+		settings: settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	queues, err := widget.client.Queues(widget.settings.vhost)
+
+	widget.err = err
+	widget.queues = widget.filter(queues)
+	widget.SetItemCount(len(widget.queues))
+
+	widget.Render()
+}
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+func (widget *Widget) Render() {
+	title := widget.CommonSettings().Title
+
+	if widget.err != nil {
+		widget.RedrawError(title, widget.err)
+		return
+	}
+
+	title = fmt.Sprintf("%s (%d)", title, len(widget.queues))
+	widget.Redraw(title, widget.contentFrom(widget.queues), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom(queues []Queue) string {
+	if len(queues) == 0 {
+		return " [grey]No queues found[white]\n"
+	}
+
+	table := wtf.NewTable(
+		wtf.TableColumn{Title: "Queue", MinWidth: 10, MaxWidth: 24},
+		wtf.TableColumn{Title: "Msgs", Align: wtf.AlignRight, MinWidth: 8},
+		wtf.TableColumn{Title: "Cons", Align: wtf.AlignRight, MinWidth: 5},
+		wtf.TableColumn{Title: "Pub/s", Align: wtf.AlignRight, MinWidth: 8},
+		wtf.TableColumn{Title: "Dlv/s", Align: wtf.AlignRight, MinWidth: 8},
+	)
+	table.HeaderColor = "red"
+
+	for idx, queue := range queues {
+		table.AddRow(
+			fmt.Sprintf("[%s]%s", widget.depthColor(&queue, idx), tview.Escape(queue.Name)),
+			fmt.Sprintf("%d", queue.Messages),
+			fmt.Sprintf("[%s]%d", widget.RowColor(idx), queue.Consumers),
+			fmt.Sprintf("%.1f", queue.PublishRate()),
+			fmt.Sprintf("%.1f", queue.DeliverRate()),
+		)
+	}
+
+	_, _, width, _ := widget.View.GetInnerRect()
+	lines := table.Lines(width - 1)
+
+	str := " " + lines[0] + "\n"
+
+	for idx, line := range lines[1:] {
+		row := fmt.Sprintf("[%s] %s", widget.RowColor(idx), line)
+		str += wtf.HighlightableHelper(widget.View, row, idx, wtf.TextWidth(line)+1)
+	}
+
+	return str
+}
+
+// depthColor flags queues that have grown beyond the configured depth threshold
+func (widget *Widget) depthColor(queue *Queue, idx int) string {
+	if widget.settings.depthThreshold > 0 && queue.Messages > widget.settings.depthThreshold {
+		return widget.settings.common.Colors.Crit
+	}
+
+	return widget.RowColor(idx)
+}
+
+// filter removes any queues that have not been explicitly configured for display. If
+// no queues are configured, all queues are returned
+func (widget *Widget) filter(queues []Queue) []Queue {
+	if len(widget.settings.queues) == 0 {
+		return queues
+	}
+
+	filtered := []Queue{}
+	for _, queue := range queues {
+		if !wtf.Exclude(widget.settings.queues, queue.Name) {
+			filtered = append(filtered, queue)
+		}
+	}
+
+	return filtered
+}
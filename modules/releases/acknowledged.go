@@ -0,0 +1,80 @@
+package releases
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+	"gopkg.in/yaml.v2"
+)
+
+// Acknowledged persists the version of each source that the user has seen, so that
+// releases stay marked as new across restarts until they're acknowledged. It is keyed
+// by source
+type Acknowledged struct {
+	filePath string
+	Versions map[string]string `yaml:"versions"`
+}
+
+// NewAcknowledged loads the acknowledged versions for the named widget from its cache
+// directory
+func NewAcknowledged(name string) *Acknowledged {
+	acknowledged := Acknowledged{
+		Versions: map[string]string{},
+	}
+
+	cacheDir, err := cfg.CacheDirFor(name)
+	if err != nil {
+		return &acknowledged
+	}
+
+	acknowledged.filePath = filepath.Join(cacheDir, "acknowledged.yml")
+
+	fileData, err := wtf.ReadFileBytes(acknowledged.filePath)
+	if err == nil {
+		yaml.Unmarshal(fileData, &acknowledged)
+	}
+
+	if acknowledged.Versions == nil {
+		acknowledged.Versions = map[string]string{}
+	}
+
+	return &acknowledged
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Acknowledge records that the user has seen the release
+func (acknowledged *Acknowledged) Acknowledge(release Release) {
+	acknowledged.Versions[release.Source] = release.Version
+}
+
+// IsNew returns true if the release isn't the version last acknowledged for its source
+func (acknowledged *Acknowledged) IsNew(release Release) bool {
+	version, ok := acknowledged.Versions[release.Source]
+
+	return ok && version != release.Version
+}
+
+// Watch starts watching the release's source, if it isn't watched already, by
+// acknowledging its current release. Only the releases that come after it are new
+func (acknowledged *Acknowledged) Watch(release Release) {
+	if _, ok := acknowledged.Versions[release.Source]; !ok {
+		acknowledged.Acknowledge(release)
+	}
+}
+
+// Save writes the acknowledged versions to disk
+func (acknowledged *Acknowledged) Save() error {
+	if acknowledged.filePath == "" {
+		return nil
+	}
+
+	data, err := yaml.Marshal(acknowledged)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(acknowledged.filePath, data, 0600)
+}
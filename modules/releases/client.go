@@ -0,0 +1,133 @@
+package releases
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const githubAPIURL = "https://api.github.com"
+const npmRegistryURL = "https://registry.npmjs.org"
+
+// Release is the newest version of a watched repository or package
+type Release struct {
+	Published time.Time
+	Source    string
+	URL       string
+	Version   string
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// latestRelease fetches the newest version of the source, which is a GitHub repository
+// ("github:owner/repo") or an npm package ("npm:name")
+func (widget *Widget) latestRelease(source string) (Release, error) {
+	parts := strings.SplitN(source, ":", 2)
+	if len(parts) != 2 {
+		return Release{}, fmt.Errorf("%q should be github:owner/repo or npm:package", source)
+	}
+
+	switch parts[0] {
+	case "github":
+		return widget.githubRelease(source, parts[1])
+	case "npm", "yarn":
+		return widget.npmRelease(source, parts[1])
+	default:
+		return Release{}, fmt.Errorf("%q should be github:owner/repo or npm:package", source)
+	}
+}
+
+// githubRelease fetches the repository's latest release or, if it doesn't publish
+// releases, its newest tag
+func (widget *Widget) githubRelease(source, repo string) (Release, error) {
+	release := Release{Source: source}
+
+	latest := struct {
+		HTMLURL     string    `json:"html_url"`
+		PublishedAt time.Time `json:"published_at"`
+		TagName     string    `json:"tag_name"`
+	}{}
+
+	err := widget.getJSON(fmt.Sprintf("%s/repos/%s/releases/latest", githubAPIURL, repo), widget.settings.apiKey, &latest)
+	if err == nil {
+		release.Published = latest.PublishedAt
+		release.URL = latest.HTMLURL
+		release.Version = latest.TagName
+
+		return release, nil
+	}
+
+	if moduleErr, ok := err.(*wtf.ModuleError); !ok || moduleErr.Status != http.StatusNotFound {
+		return release, err
+	}
+
+	tags := []struct {
+		Name string `json:"name"`
+	}{}
+
+	if err := widget.getJSON(fmt.Sprintf("%s/repos/%s/tags?per_page=1", githubAPIURL, repo), widget.settings.apiKey, &tags); err != nil {
+		return release, err
+	}
+
+	if len(tags) == 0 {
+		return release, fmt.Errorf("%s has no releases or tags", repo)
+	}
+
+	release.URL = fmt.Sprintf("https://github.com/%s/releases/tag/%s", repo, url.PathEscape(tags[0].Name))
+	release.Version = tags[0].Name
+
+	return release, nil
+}
+
+// npmRelease fetches the version of the package that's tagged latest on the npm
+// registry, which yarn installs from too
+func (widget *Widget) npmRelease(source, pkg string) (Release, error) {
+	release := Release{Source: source}
+
+	doc := struct {
+		DistTags map[string]string    `json:"dist-tags"`
+		Time     map[string]time.Time `json:"time"`
+	}{}
+
+	if err := widget.getJSON(fmt.Sprintf("%s/%s", npmRegistryURL, strings.Replace(pkg, "/", "%2F", 1)), "", &doc); err != nil {
+		return release, err
+	}
+
+	release.Version = doc.DistTags["latest"]
+	if release.Version == "" {
+		return release, fmt.Errorf("%s has no latest version", pkg)
+	}
+
+	release.Published = doc.Time[release.Version]
+	release.URL = fmt.Sprintf("https://www.npmjs.com/package/%s/v/%s", pkg, release.Version)
+
+	return release, nil
+}
+
+func (widget *Widget) getJSON(path, token string, result interface{}) error {
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
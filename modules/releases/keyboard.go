@@ -0,0 +1,19 @@
+package releases
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next release")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous release")
+	widget.SetKeyboardChar("o", widget.openRelease, "Open release in browser")
+	widget.SetKeyboardChar("x", widget.acknowledgeSelected, "Acknowledge the selected release")
+	widget.SetKeyboardChar("X", widget.acknowledgeAll, "Acknowledge every release")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next release")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous release")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openRelease, "Open release in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
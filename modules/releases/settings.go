@@ -0,0 +1,30 @@
+package releases
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Releases"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey  string   `help:"A GitHub personal access token, which raises GitHub's rate limit." optional:"true" values:"Falls back to WTF_GITHUB_TOKEN."`
+	sources []string `help:"The repositories and packages to watch, such as github:wtfutil/wtf or npm:react. GitHub repositories without releases are watched for new tags."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:  ymlConfig.UString("apiKey", os.Getenv("WTF_GITHUB_TOKEN")),
+		sources: wtf.ToStrs(ymlConfig.UList("sources")),
+	}
+
+	return &settings
+}
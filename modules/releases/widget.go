@@ -0,0 +1,183 @@
+package releases
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows the newest version of each watched repository and package, marking
+// the releases that came out since they were last acknowledged
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	acknowledged *Acknowledged
+	releases     []Release
+	settings     *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		acknowledged: NewAcknowledged(settings.common.Name),
+		settings:     settings,
+	}
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the newest version of every source. Sources whose version can't be
+// fetched are left out
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	releases := []Release{}
+	var lastErr error
+
+	for _, source := range widget.settings.sources {
+		release, err := widget.latestRelease(source)
+		if err != nil {
+			logger.For(widget.Name()).Warnf("fetching the latest release of %s: %v", source, err)
+			lastErr = err
+			continue
+		}
+
+		widget.acknowledged.Watch(release)
+		releases = append(releases, release)
+	}
+
+	if len(releases) == 0 && lastErr != nil {
+		widget.RedrawError(widget.CommonSettings().Title, lastErr)
+		return
+	}
+
+	widget.saveAcknowledged()
+
+	widget.releases = releases
+	widget.SetItemCount(len(releases))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	title := widget.CommonSettings().Title
+	if count := widget.newCount(); count > 0 {
+		title = fmt.Sprintf("%s (%d new)", title, count)
+	}
+
+	widget.Redraw(title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the releases so that their publish times stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) acknowledgeAll() {
+	for _, release := range widget.releases {
+		widget.acknowledged.Acknowledge(release)
+	}
+
+	widget.saveAcknowledged()
+	widget.Render()
+}
+
+func (widget *Widget) acknowledgeSelected() {
+	sel := widget.GetSelected()
+	if sel < 0 || sel >= len(widget.releases) {
+		return
+	}
+
+	widget.acknowledged.Acknowledge(widget.releases[sel])
+
+	widget.saveAcknowledged()
+	widget.Render()
+}
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.releases) == 0 {
+		return " [gray]No releases[white]"
+	}
+
+	str := ""
+	for idx, release := range widget.releases {
+		badge := ""
+		if widget.acknowledged.IsNew(release) {
+			badge = " [black:yellow]NEW[-:-]"
+		}
+
+		row := fmt.Sprintf(
+			"[%s] %s [green]%s[%s]%s [gray]%s[white]",
+			widget.RowColor(idx),
+			release.Source,
+			tview.Escape(release.Version),
+			widget.RowColor(idx),
+			badge,
+			wtf.RelativeTime(release.Published),
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(release.Source))
+	}
+
+	return str
+}
+
+func (widget *Widget) newCount() int {
+	count := 0
+	for _, release := range widget.releases {
+		if widget.acknowledged.IsNew(release) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (widget *Widget) openRelease() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.releases) {
+		utils.OpenURL(widget.releases[sel].URL)
+	}
+}
+
+// rowFor describes the release at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	release := widget.releases[idx]
+
+	return wtf.Row{
+		ID:   release.Source,
+		Text: release.Version,
+		URL:  release.URL,
+	}
+}
+
+func (widget *Widget) saveAcknowledged() {
+	if err := widget.acknowledged.Save(); err != nil {
+		logger.For(widget.Name()).Warnf("saving the acknowledged releases: %v", err)
+	}
+}
@@ -3,11 +3,13 @@ package rollbar
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func CurrentActiveItems(accessToken, assignedToName string, activeOnly bool) (*ActiveItems, error) {
@@ -44,14 +46,14 @@ func rollbarItemRequest(accessToken, assignedToName string, activeOnly bool) (*h
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 
-	httpClient := &http.Client{}
+	httpClient := wtf.HTTPClient()
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, fmt.Errorf(resp.Status)
+		return nil, wtf.NewHTTPError(resp)
 	}
 
 	return resp, nil
@@ -60,7 +62,8 @@ func rollbarItemRequest(accessToken, assignedToName string, activeOnly bool) (*h
 func parseJSON(obj interface{}, text io.Reader) {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		logger.For("rollbar").Errorf("reading the response: %v", err)
+		return
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -69,7 +72,8 @@ func parseJSON(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			logger.For("rollbar").Errorf("parsing the response: %v", err)
+			return
 		}
 	}
 }
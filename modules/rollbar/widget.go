@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -48,7 +49,7 @@ func (widget *Widget) Refresh() {
 	)
 
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 	widget.items = &items.Results
@@ -123,7 +124,7 @@ func (widget *Widget) openBuild() {
 	if widget.GetSelected() >= 0 && widget.items != nil && widget.GetSelected() < len(widget.items.Items) {
 		item := &widget.items.Items[widget.GetSelected()]
 
-		wtf.OpenFile(
+		utils.OpenURL(
 			fmt.Sprintf(
 				"https://rollbar.com/%s/%s/%s/%d",
 				widget.settings.projectOwner,
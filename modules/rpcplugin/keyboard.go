@@ -0,0 +1,5 @@
+package rpcplugin
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+}
@@ -0,0 +1,27 @@
+package rpcplugin
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+type Settings struct {
+	common *cfg.Common
+
+	config map[string]interface{} `help:"Settings passed on to the plugin when it starts." optional:"true"`
+	path   string
+}
+
+// NewSettingsFromYAML creates the settings for a module whose type is the plugin at
+// the given path
+func NewSettingsFromYAML(name, path string, moduleConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, name, moduleConfig, globalConfig),
+
+		config: moduleConfig.UMap("config"),
+		path:   path,
+	}
+
+	return &settings
+}
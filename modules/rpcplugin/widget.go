@@ -0,0 +1,173 @@
+package rpcplugin
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/pluginrpc"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Widget shows a plugin from the plugins directory. The plugin is started the first
+// time the widget refreshes and keeps running until the widget is disabled
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.TextWidget
+
+	client   *pluginrpc.Client
+	keys     []pluginrpc.Key
+	mu       sync.Mutex
+	settings *Settings
+}
+
+// NewWidget creates and returns an instance of Widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := &Widget{
+		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
+		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+	widget.View.SetScrollable(true)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Disable stops the plugin along with the widget
+func (widget *Widget) Disable() {
+	widget.TextWidget.Disable()
+
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	widget.stop()
+}
+
+// HelpText returns the help text for the widget's own keys followed by the plugin's
+func (widget *Widget) HelpText() string {
+	str := widget.KeyboardWidget.HelpText()
+
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	if len(widget.keys) > 0 {
+		str += "\n\n [green::b]Plugin commands[white]\n\n"
+		for _, key := range widget.keys {
+			str += fmt.Sprintf("  %s\t%s\n", key.Key, key.Help)
+		}
+	}
+
+	return str
+}
+
+// InputCapture handles the widget's own keys and then the keys the plugin asked for
+func (widget *Widget) InputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if widget.KeyboardWidget.InputCapture(event) == nil {
+		return nil
+	}
+
+	if event.Key() != tcell.KeyRune {
+		return event
+	}
+
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	for _, key := range widget.keys {
+		if key.Key == string(event.Rune()) {
+			go widget.handleKey(key.Key)
+			return nil
+		}
+	}
+
+	return event
+}
+
+// Refresh has the plugin fetch its data and shows what it renders
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	widget.call(func(client *pluginrpc.Client) error {
+		return client.Refresh()
+	})
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// call starts the plugin if it isn't running, makes the call, and has the plugin render
+// the widget. A plugin that has exited is started again on the next call
+func (widget *Widget) call(fn func(client *pluginrpc.Client) error) {
+	if widget.client == nil {
+		client, err := pluginrpc.Start(widget.settings.path)
+		if err != nil {
+			widget.RedrawError(widget.CommonSettings().Title, err)
+			return
+		}
+
+		if err := client.Configure(widget.settings.config); err != nil {
+			client.Close()
+			widget.RedrawError(widget.CommonSettings().Title, err)
+			return
+		}
+
+		widget.client = client
+	}
+
+	err := fn(widget.client)
+
+	var content *pluginrpc.Content
+	if err == nil {
+		_, _, width, height := widget.View.GetInnerRect()
+		content, err = widget.client.Render(width, height)
+	}
+
+	if err != nil {
+		if widget.client.Exited() {
+			widget.stop()
+		}
+
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.SetRefreshError(nil)
+	widget.keys = content.Keys
+
+	title := widget.CommonSettings().Title
+	if content.Title != "" {
+		title = content.Title
+	}
+
+	widget.Redraw(title, content.Text, false)
+}
+
+func (widget *Widget) handleKey(key string) {
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	widget.call(func(client *pluginrpc.Client) error {
+		return client.HandleKey(key)
+	})
+}
+
+// stop stops the plugin if it is running
+func (widget *Widget) stop() {
+	if widget.client != nil {
+		widget.client.Close()
+		widget.client = nil
+	}
+}
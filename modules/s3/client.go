@@ -0,0 +1,116 @@
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// BucketStats holds the totals for a single bucket
+type BucketStats struct {
+	Name    string
+	Objects int64
+	Size    int64
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Size int64 `xml:"Size"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+type Client struct {
+	settings *Settings
+}
+
+func NewClient(settings *Settings) *Client {
+	client := Client{
+		settings: settings,
+	}
+
+	return &client
+}
+
+// BucketStats walks every object in the bucket and returns the total object count and size.
+// S3 has no cheap "how big is this bucket" call, so large buckets take a while
+func (client *Client) BucketStats(bucket string) (*BucketStats, error) {
+	stats := &BucketStats{Name: bucket}
+	token := ""
+
+	for {
+		result, err := client.listObjects(bucket, token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range result.Contents {
+			stats.Objects++
+			stats.Size += object.Size
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+
+		token = result.NextContinuationToken
+	}
+
+	return stats, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (client *Client) bucketURL(bucket string) *url.URL {
+	scheme := "https"
+	if !client.settings.useSSL {
+		scheme = "http"
+	}
+
+	if client.settings.pathStyle {
+		return &url.URL{Scheme: scheme, Host: client.settings.endpoint, Path: "/" + bucket + "/"}
+	}
+
+	return &url.URL{Scheme: scheme, Host: bucket + "." + client.settings.endpoint, Path: "/"}
+}
+
+func (client *Client) listObjects(bucket, continuationToken string) (*listBucketResult, error) {
+	params := url.Values{}
+	params.Add("list-type", "2")
+	params.Add("max-keys", "1000")
+	if continuationToken != "" {
+		params.Add("continuation-token", continuationToken)
+	}
+
+	reqURL := client.bucketURL(bucket)
+	reqURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	signRequest(req, client.settings.accessKeyID, client.settings.secretAccessKey, client.settings.region, time.Now())
+
+	httpClient := wtf.HTTPClient()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s: %s", bucket, resp.Status)
+	}
+
+	result := &listBucketResult{}
+	err = xml.NewDecoder(resp.Body).Decode(result)
+
+	return result, err
+}
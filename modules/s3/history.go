@@ -0,0 +1,89 @@
+package s3
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+	"gopkg.in/yaml.v2"
+)
+
+// snapshot is the last-seen size of a bucket on a given day
+type snapshot struct {
+	Objects int64 `yaml:"objects"`
+	Size    int64 `yaml:"size"`
+}
+
+// History persists daily bucket snapshots to disk so that growth since yesterday can
+// be calculated across restarts. It is keyed by bucket name, then by date
+type History struct {
+	filePath  string
+	Snapshots map[string]map[string]snapshot `yaml:"snapshots"`
+}
+
+// NewHistory loads the history file for the named widget from its cache directory
+func NewHistory(name string) *History {
+	history := History{
+		Snapshots: map[string]map[string]snapshot{},
+	}
+
+	cacheDir, err := cfg.CacheDirFor(name)
+	if err != nil {
+		return &history
+	}
+
+	history.filePath = filepath.Join(cacheDir, "history.yml")
+
+	fileData, err := wtf.ReadFileBytes(history.filePath)
+	if err == nil {
+		yaml.Unmarshal(fileData, &history)
+	}
+
+	if history.Snapshots == nil {
+		history.Snapshots = map[string]map[string]snapshot{}
+	}
+
+	return &history
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Record stores today's stats for the bucket and discards anything older than yesterday
+func (history *History) Record(stats *BucketStats, now time.Time) {
+	today := now.Format(wtf.DateFormat)
+	yesterday := now.AddDate(0, 0, -1).Format(wtf.DateFormat)
+
+	days := map[string]snapshot{
+		today: {Objects: stats.Objects, Size: stats.Size},
+	}
+
+	if prev, ok := history.Snapshots[stats.Name][yesterday]; ok {
+		days[yesterday] = prev
+	}
+
+	history.Snapshots[stats.Name] = days
+}
+
+// Yesterday returns the last snapshot recorded for the bucket yesterday, if there is one
+func (history *History) Yesterday(bucket string, now time.Time) (snapshot, bool) {
+	yesterday := now.AddDate(0, 0, -1).Format(wtf.DateFormat)
+	snap, ok := history.Snapshots[bucket][yesterday]
+
+	return snap, ok
+}
+
+// Save writes the history to disk
+func (history *History) Save() error {
+	if history.filePath == "" {
+		return nil
+	}
+
+	fileData, err := yaml.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(history.filePath, fileData, 0644)
+}
@@ -0,0 +1,40 @@
+package s3
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Buckets"
+
+type Settings struct {
+	common *cfg.Common
+
+	accessKeyID     string   `help:"Your access key ID. For GCS, use an HMAC key created for interoperability access."`
+	buckets         []string `help:"An array of bucket names to report on."`
+	endpoint        string   `help:"The S3-compatible endpoint to connect to." values:"Example: s3.amazonaws.com, storage.googleapis.com, minio.example.com:9000" optional:"true"`
+	pathStyle       bool     `help:"Use path-style bucket addressing instead of virtual-hosted buckets. Usually required for MinIO." values:"true or false" optional:"true"`
+	region          string   `help:"The region the buckets live in." optional:"true"`
+	secretAccessKey string   `help:"Your secret access key."`
+	useSSL          bool     `help:"Whether or not to connect to the endpoint over HTTPS." values:"true or false" optional:"true"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		accessKeyID:     ymlConfig.UString("accessKeyID", os.Getenv("AWS_ACCESS_KEY_ID")),
+		buckets:         wtf.ToStrs(ymlConfig.UList("buckets")),
+		endpoint:        ymlConfig.UString("endpoint", "s3.amazonaws.com"),
+		pathStyle:       ymlConfig.UBool("pathStyle", false),
+		region:          ymlConfig.UString("region", "us-east-1"),
+		secretAccessKey: ymlConfig.UString("secretAccessKey", os.Getenv("AWS_SECRET_ACCESS_KEY")),
+		useSSL:          ymlConfig.UBool("useSSL", true),
+	}
+
+	return &settings
+}
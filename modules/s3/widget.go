@@ -0,0 +1,106 @@
+package s3
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/bytefmt"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+type Widget struct {
+	wtf.TextWidget
+
+	client   *Client
+	history  *History
+	settings *Settings
+}
+
+func NewWidget(app *tview.Application, settings *Settings) *Widget {
+	widget := Widget{
+		TextWidget: wtf.NewTextWidget(app, settings.common, false),
+
+		client:   NewClient(settings),
+		history:  NewHistory(settings.common.Name),
+		settings: settings,
+	}
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	widget.Redraw(widget.CommonSettings().Title, widget.content(), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) content() string {
+	if len(widget.settings.buckets) == 0 {
+		return " [grey]No buckets configured[white]\n"
+	}
+
+	now := wtf.Now()
+	errors := ""
+
+	table := wtf.NewTable(
+		wtf.TableColumn{Title: "Bucket", MinWidth: 10, MaxWidth: 24},
+		wtf.TableColumn{Title: "Objects", Align: wtf.AlignRight, MinWidth: 7},
+		wtf.TableColumn{Title: "Size", Align: wtf.AlignRight, MinWidth: 6},
+		wtf.TableColumn{Title: "Δ Day", Align: wtf.AlignRight, MinWidth: 6},
+	)
+	table.HeaderColor = "red"
+
+	for _, bucket := range widget.settings.buckets {
+		stats, err := widget.client.BucketStats(bucket)
+		if err != nil {
+			widget.SetRefreshError(err)
+			errors += fmt.Sprintf(" [red]%s[white]\n", err.Error())
+			continue
+		}
+
+		table.AddRow(
+			tview.Escape(stats.Name),
+			fmt.Sprintf("%d", stats.Objects),
+			bytefmt.ByteSize(uint64(stats.Size)),
+			widget.deltaString(stats),
+		)
+
+		widget.history.Record(stats, now)
+	}
+
+	widget.history.Save()
+
+	_, _, width, _ := widget.View.GetInnerRect()
+
+	str := ""
+	for _, line := range table.Lines(width - 1) {
+		str += " " + line + "\n"
+	}
+
+	return str + errors
+}
+
+// deltaString shows how much the bucket has grown (or shrunk) since yesterday
+func (widget *Widget) deltaString(stats *BucketStats) string {
+	prev, ok := widget.history.Yesterday(stats.Name, wtf.Now())
+	if !ok {
+		return "[grey]-[white]"
+	}
+
+	delta := stats.Size - prev.Size
+
+	switch {
+	case delta > 0:
+		return "[yellow]+" + bytefmt.ByteSize(uint64(delta)) + "[white]"
+	case delta < 0:
+		return "[green]-" + bytefmt.ByteSize(uint64(-delta)) + "[white]"
+	default:
+		return "0"
+	}
+}
@@ -0,0 +1,8 @@
+package scratchpad
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("/", widget.ShowHelp, "Show/hide this help prompt")
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("a", widget.promptForLine, "Append a line")
+	widget.SetKeyboardChar("e", widget.openInEditor, "Open in the editor")
+}
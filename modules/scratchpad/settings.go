@@ -0,0 +1,33 @@
+package scratchpad
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Scratchpad"
+
+type Settings struct {
+	common *cfg.Common
+
+	editor   string `help:"The command the scratchpad is opened in for editing." values:"Defaults to $EDITOR, or vi." optional:"true"`
+	filePath string `help:"The file, in the config directory, that the scratchpad is kept in." optional:"true" default:"scratchpad.md"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		editor:   ymlConfig.UString("editor", os.Getenv("EDITOR")),
+		filePath: ymlConfig.UString("filename", "scratchpad.md"),
+	}
+
+	if settings.editor == "" {
+		settings.editor = "vi"
+	}
+
+	return &settings
+}
@@ -0,0 +1,158 @@
+package scratchpad
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows a scratchpad file, which lines can be appended to from a prompt and
+// which can be opened in the editor
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.TextWidget
+
+	app      *tview.Application
+	filePath string
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
+		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
+
+		app:      app,
+		filePath: scratchpadPath(settings.filePath),
+		settings: settings,
+	}
+
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+	widget.View.SetScrollable(true)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh rereads the scratchpad, which may have been changed outside wtf
+func (widget *Widget) Refresh() {
+	data, err := ioutil.ReadFile(widget.filePath)
+	if err != nil && !os.IsNotExist(err) {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	content := tview.Escape(string(data))
+	if strings.TrimSpace(content) == "" {
+		content = " [gray]Empty. Press a to add a line, or e to edit[white]"
+	}
+
+	widget.Redraw(widget.CommonSettings().Title, content, true)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// appendLine adds the line to the end of the scratchpad
+func (widget *Widget) appendLine(line string) error {
+	if err := os.MkdirAll(filepath.Dir(widget.filePath), 0700); err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(widget.filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	file, err := os.OpenFile(widget.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Keep the new line from running on from a last line that wasn't ended
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		line = "\n" + line
+	}
+
+	_, err = file.WriteString(line + "\n")
+	return err
+}
+
+// openInEditor suspends the app while the scratchpad is open in the editor, and shows
+// the edited file once the editor exits
+func (widget *Widget) openInEditor() {
+	args := strings.Fields(widget.settings.editor)
+	if len(args) == 0 {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(widget.filePath), 0700); err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.app.Suspend(func() {
+		cmd := exec.Command(args[0], append(args[1:], widget.filePath)...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			logger.For(widget.Name()).Errorf("running %s: %v", widget.settings.editor, err)
+		}
+	})
+
+	widget.Refresh()
+}
+
+// promptForLine opens a prompt that asks for a line to append to the scratchpad
+func (widget *Widget) promptForLine() {
+	prompt := wtf.TextPrompt{Label: "Append:", History: "scratchpad"}
+
+	widget.ShowPrompt(prompt, func(line string) {
+		if line == "" {
+			return
+		}
+
+		if err := widget.appendLine(line); err != nil {
+			widget.RedrawError(widget.CommonSettings().Title, err)
+			return
+		}
+
+		widget.Refresh()
+		widget.app.QueueUpdateDraw(func() {
+			widget.View.ScrollToEnd()
+		})
+	})
+}
+
+// scratchpadPath returns where the scratchpad is: in the config directory, unless the
+// file path is absolute
+func scratchpadPath(filePath string) string {
+	if filepath.IsAbs(filePath) {
+		return filePath
+	}
+
+	configDir, err := cfg.WtfConfigDir()
+	if err != nil {
+		return filePath
+	}
+
+	return filepath.Join(configDir, filePath)
+}
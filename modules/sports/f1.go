@@ -0,0 +1,121 @@
+package sports
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type f1Driver struct {
+	Code       string `json:"code"`
+	FamilyName string `json:"familyName"`
+}
+
+type f1Response struct {
+	MRData struct {
+		RaceTable struct {
+			Races []struct {
+				Date     string `json:"date"`
+				RaceName string `json:"raceName"`
+				Time     string `json:"time"`
+			} `json:"Races"`
+		} `json:"RaceTable"`
+		StandingsTable struct {
+			StandingsLists []struct {
+				DriverStandings []struct {
+					Constructors []struct {
+						Name string `json:"name"`
+					} `json:"Constructors"`
+					Driver   f1Driver `json:"Driver"`
+					Points   string   `json:"points"`
+					Position string   `json:"position"`
+				} `json:"DriverStandings"`
+			} `json:"StandingsLists"`
+		} `json:"StandingsTable"`
+	} `json:"MRData"`
+}
+
+// f1League fetches the rest of this season's races and the drivers' championship from
+// the Ergast-compatible F1 API, which has no live timing
+func (widget *Widget) f1League() (League, error) {
+	league := League{Name: "Formula 1"}
+	now := time.Now()
+
+	races := f1Response{}
+	if err := widget.f1Get("/current.json", &races); err != nil {
+		return league, err
+	}
+
+	for _, race := range races.MRData.RaceTable.Races {
+		start := raceStart(race.Date, race.Time)
+		if start.IsZero() || start.Add(2*time.Hour).Before(now) {
+			continue
+		}
+
+		if len(league.Upcoming) >= widget.settings.fixtures {
+			break
+		}
+
+		league.Upcoming = append(league.Upcoming, Fixture{
+			Live:  start.Before(now),
+			Name:  race.RaceName,
+			Start: start,
+		})
+	}
+
+	standings := f1Response{}
+	if err := widget.f1Get("/current/driverStandings.json", &standings); err != nil {
+		return league, err
+	}
+
+	for _, list := range standings.MRData.StandingsTable.StandingsLists {
+		for _, row := range list.DriverStandings {
+			if len(widget.settings.teams) == 0 && len(league.Standings) >= unfollowedStandings {
+				break
+			}
+
+			constructor := ""
+			if len(row.Constructors) > 0 {
+				constructor = row.Constructors[len(row.Constructors)-1].Name
+			}
+
+			if !widget.follows(row.Driver.Code, row.Driver.FamilyName, constructor) {
+				continue
+			}
+
+			position, _ := strconv.Atoi(row.Position)
+			points, _ := strconv.ParseFloat(row.Points, 64)
+
+			league.Standings = append(league.Standings, Standing{
+				Name:     row.Driver.FamilyName + " (" + constructor + ")",
+				Points:   points,
+				Position: position,
+			})
+		}
+	}
+
+	return league, nil
+}
+
+func (widget *Widget) f1Get(path string, result interface{}) error {
+	req, err := http.NewRequest("GET", widget.settings.f1URL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	return getJSON(req, result)
+}
+
+// raceStart returns when the race starts from its date and, if it's known, its UTC time
+func raceStart(date, clock string) time.Time {
+	if clock == "" {
+		clock = "00:00:00Z"
+	}
+
+	start, err := time.Parse(time.RFC3339, date+"T"+clock)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return start
+}
@@ -0,0 +1,149 @@
+package sports
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const footballDataURL = "https://api.football-data.org/v4/competitions/"
+
+type footballTeam struct {
+	Name      string `json:"name"`
+	ShortName string `json:"shortName"`
+	TLA       string `json:"tla"`
+}
+
+type footballMatches struct {
+	Competition struct {
+		Name string `json:"name"`
+	} `json:"competition"`
+	Matches []struct {
+		AwayTeam footballTeam `json:"awayTeam"`
+		HomeTeam footballTeam `json:"homeTeam"`
+		Minute   interface{}  `json:"minute"`
+		Score    struct {
+			FullTime struct {
+				Away *int `json:"away"`
+				Home *int `json:"home"`
+			} `json:"fullTime"`
+		} `json:"score"`
+		Status  string    `json:"status"`
+		UTCDate time.Time `json:"utcDate"`
+	} `json:"matches"`
+}
+
+type footballStandings struct {
+	Standings []struct {
+		Table []struct {
+			PlayedGames int          `json:"playedGames"`
+			Points      float64      `json:"points"`
+			Position    int          `json:"position"`
+			Team        footballTeam `json:"team"`
+		} `json:"table"`
+		Type string `json:"type"`
+	} `json:"standings"`
+}
+
+// footballLeague fetches the competition's matches over the next week, of which those
+// in play are live, and its table from football-data.org
+func (widget *Widget) footballLeague(code string) (League, error) {
+	league := League{Name: code}
+	now := time.Now().UTC()
+
+	params := url.Values{}
+	params.Set("dateFrom", now.Format("2006-01-02"))
+	params.Set("dateTo", now.AddDate(0, 0, 7).Format("2006-01-02"))
+
+	matches := footballMatches{}
+	if err := widget.footballDataGet(code+"/matches?"+params.Encode(), &matches); err != nil {
+		return league, err
+	}
+
+	if matches.Competition.Name != "" {
+		league.Name = matches.Competition.Name
+	}
+
+	for _, match := range matches.Matches {
+		if !widget.follows(match.HomeTeam.Name, match.HomeTeam.ShortName, match.HomeTeam.TLA, match.AwayTeam.Name, match.AwayTeam.ShortName, match.AwayTeam.TLA) {
+			continue
+		}
+
+		fixture := Fixture{
+			Away:  teamName(match.AwayTeam),
+			Home:  teamName(match.HomeTeam),
+			Start: match.UTCDate,
+		}
+
+		switch match.Status {
+		case "IN_PLAY", "PAUSED":
+			fixture.Live = true
+			if match.Score.FullTime.Home != nil && match.Score.FullTime.Away != nil {
+				fixture.HomeScore = *match.Score.FullTime.Home
+				fixture.AwayScore = *match.Score.FullTime.Away
+			}
+			if match.Status == "PAUSED" {
+				fixture.Minute = "HT"
+			} else if match.Minute != nil {
+				fixture.Minute = fmt.Sprintf("%v'", match.Minute)
+			}
+
+			league.Live = append(league.Live, fixture)
+		case "SCHEDULED", "TIMED":
+			if len(league.Upcoming) < widget.settings.fixtures {
+				league.Upcoming = append(league.Upcoming, fixture)
+			}
+		}
+	}
+
+	standings := footballStandings{}
+	if err := widget.footballDataGet(code+"/standings", &standings); err != nil {
+		return league, err
+	}
+
+	for _, table := range standings.Standings {
+		if table.Type != "TOTAL" {
+			continue
+		}
+
+		for _, row := range table.Table {
+			if len(widget.settings.teams) == 0 && len(league.Standings) >= unfollowedStandings {
+				break
+			}
+
+			if !widget.follows(row.Team.Name, row.Team.ShortName, row.Team.TLA) {
+				continue
+			}
+
+			league.Standings = append(league.Standings, Standing{
+				Name:     teamName(row.Team),
+				Played:   row.PlayedGames,
+				Points:   row.Points,
+				Position: row.Position,
+			})
+		}
+	}
+
+	return league, nil
+}
+
+func (widget *Widget) footballDataGet(path string, result interface{}) error {
+	req, err := http.NewRequest("GET", footballDataURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Auth-Token", widget.settings.apiKey)
+
+	return getJSON(req, result)
+}
+
+// teamName returns the team's short name, which fits better than its full name
+func teamName(team footballTeam) string {
+	if team.ShortName != "" {
+		return team.ShortName
+	}
+
+	return team.Name
+}
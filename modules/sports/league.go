@@ -0,0 +1,77 @@
+package sports
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Fixture is a match or a race. Matches have a home and an away side, and a score once
+// they have kicked off, while races only have a name
+type Fixture struct {
+	Away      string
+	AwayScore int
+	Home      string
+	HomeScore int
+	Live      bool
+	Minute    string
+	Name      string
+	Start     time.Time
+}
+
+// Standing is a team's or a driver's place in a league table
+type Standing struct {
+	Name     string
+	Played   int
+	Points   float64
+	Position int
+}
+
+// League is a competition's live fixtures, its next fixtures, and its table
+type League struct {
+	Live      []Fixture
+	Name      string
+	Standings []Standing
+	Upcoming  []Fixture
+}
+
+// unfollowedStandings is how much of a table is shown when no teams are followed
+const unfollowedStandings = 5
+
+// follows returns true if any of the names is one of the followed teams, or if no teams
+// are followed
+func (widget *Widget) follows(names ...string) bool {
+	if len(widget.settings.teams) == 0 {
+		return true
+	}
+
+	for _, team := range widget.settings.teams {
+		for _, name := range names {
+			if name != "" && strings.EqualFold(team, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getJSON sends the request and decodes the response
+func getJSON(req *http.Request, result interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
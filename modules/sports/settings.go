@@ -0,0 +1,44 @@
+package sports
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "Sports"
+
+const (
+	f1           = "f1"
+	footballData = "footballdata"
+)
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey   string   `help:"Your football-data.org API token." values:"Falls back to WTF_FOOTBALL_DATA_TOKEN." optional:"true"`
+	f1URL    string   `help:"The address of the Ergast-compatible F1 API." optional:"true" default:"https://api.jolpi.ca/ergast/f1"`
+	fixtures int      `help:"How many of the next fixtures to show for each league." optional:"true" default:"3"`
+	leagues  []string `help:"The football-data.org codes of the competitions to show, such as PL or CL." optional:"true" default:"PL"`
+	provider string   `help:"Where the scores come from." values:"footballdata or f1" optional:"true" default:"footballdata"`
+	teams    []string `help:"The teams to follow: football team names or three-letter codes, or F1 constructors or driver codes. Fixtures and standings are narrowed down to them." optional:"true"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:   ymlConfig.UString("apiKey", os.Getenv("WTF_FOOTBALL_DATA_TOKEN")),
+		f1URL:    strings.TrimSuffix(ymlConfig.UString("f1URL", "https://api.jolpi.ca/ergast/f1"), "/"),
+		fixtures: ymlConfig.UInt("fixtures", 3),
+		leagues:  wtf.ToStrs(ymlConfig.UList("leagues", []interface{}{"PL"})),
+		provider: ymlConfig.UString("provider", footballData),
+		teams:    wtf.ToStrs(ymlConfig.UList("teams")),
+	}
+
+	return &settings
+}
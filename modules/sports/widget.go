@@ -0,0 +1,161 @@
+package sports
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// fixtureTimeFormat is how the start of a fixture is shown, in local time
+const fixtureTimeFormat = "Mon Jan 2 15:04"
+
+// A Widget shows the live scores, next fixtures, and standings of the followed teams in
+// each league, from football-data.org or an Ergast-compatible F1 API
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.TextWidget
+
+	leagues  []League
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
+		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	if settings.provider == footballData {
+		widget.SetQuotaHost("api.football-data.org")
+	}
+
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+	widget.View.SetScrollable(true)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches every league. Leagues that can't be fetched are left out
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	leagues := []League{}
+	var lastErr error
+
+	switch widget.settings.provider {
+	case footballData:
+		for _, code := range widget.settings.leagues {
+			league, err := widget.footballLeague(code)
+			if err != nil {
+				logger.For(widget.Name()).Warnf("fetching the league %s: %v", code, err)
+				lastErr = err
+				continue
+			}
+
+			leagues = append(leagues, league)
+		}
+	case f1:
+		league, err := widget.f1League()
+		if err != nil {
+			lastErr = err
+		} else {
+			leagues = append(leagues, league)
+		}
+	default:
+		lastErr = fmt.Errorf("unknown provider %q, which should be footballdata or f1", widget.settings.provider)
+	}
+
+	if len(leagues) == 0 && lastErr != nil {
+		widget.RedrawError(widget.CommonSettings().Title, lastErr)
+		return
+	}
+
+	widget.leagues = leagues
+
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	str := ""
+
+	for idx, league := range widget.leagues {
+		if idx > 0 {
+			str += "\n"
+		}
+
+		str += fmt.Sprintf(" [green]%s[white]\n", tview.Escape(league.Name))
+
+		for _, fixture := range league.Live {
+			str += " " + fixtureRow(fixture) + "\n"
+		}
+
+		for _, fixture := range league.Upcoming {
+			str += " " + fixtureRow(fixture) + "\n"
+		}
+
+		if len(league.Live) == 0 && len(league.Upcoming) == 0 {
+			str += " [gray]No fixtures this week[white]\n"
+		}
+
+		for _, standing := range league.Standings {
+			played := ""
+			if standing.Played > 0 {
+				played = fmt.Sprintf(" [gray](%d played)[white]", standing.Played)
+			}
+
+			str += fmt.Sprintf(
+				" [yellow]%2d.[white] %s %s pts%s\n",
+				standing.Position,
+				tview.Escape(standing.Name),
+				strconv.FormatFloat(standing.Points, 'f', -1, 64),
+				played,
+			)
+		}
+	}
+
+	return str
+}
+
+// fixtureRow describes a fixture: the score and the minute of a live match, or when a
+// match or race starts
+func fixtureRow(fixture Fixture) string {
+	name := fixture.Name
+	if name == "" {
+		name = fmt.Sprintf("%s v %s", fixture.Home, fixture.Away)
+	}
+
+	switch {
+	case fixture.Live && fixture.Name == "":
+		return fmt.Sprintf(
+			"[red]LIVE[white] %s %d - %d %s [gray]%s[white]",
+			tview.Escape(fixture.Home),
+			fixture.HomeScore,
+			fixture.AwayScore,
+			tview.Escape(fixture.Away),
+			fixture.Minute,
+		)
+	case fixture.Live:
+		return fmt.Sprintf("[red]LIVE[white] %s", tview.Escape(name))
+	default:
+		return fmt.Sprintf("[gray]%s[white] %s", fixture.Start.Local().Format(fixtureTimeFormat), tview.Escape(name))
+	}
+}
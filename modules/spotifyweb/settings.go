@@ -12,9 +12,11 @@ const defaultTitle = "Spotify Web"
 type Settings struct {
 	common *cfg.Common
 
-	callbackPort string
-	clientID     string
-	secretKey    string
+	albumArt       bool
+	albumArtHeight int
+	callbackPort   string
+	clientID       string
+	secretKey      string
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
@@ -22,9 +24,11 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
 
-		callbackPort: ymlConfig.UString("callbackPort", "8080"),
-		clientID:     ymlConfig.UString("clientID", os.Getenv("SPOTIFY_ID")),
-		secretKey:    ymlConfig.UString("secretKey", os.Getenv("SPOTIFY_SECRET")),
+		albumArt:       ymlConfig.UBool("albumArt", false),
+		albumArtHeight: ymlConfig.UInt("albumArtHeight", 8),
+		callbackPort:   ymlConfig.UString("callbackPort", "8080"),
+		clientID:       ymlConfig.UString("clientID", os.Getenv("SPOTIFY_ID")),
+		secretKey:      ymlConfig.UString("secretKey", os.Getenv("SPOTIFY_SECRET")),
 	}
 
 	return &settings
@@ -1,12 +1,16 @@
 package spotifyweb
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
+	"image"
+	"sync"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/oauth"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 	"github.com/zmb3/spotify"
 )
@@ -27,48 +31,31 @@ type Widget struct {
 
 	Info
 
+	albumArt    image.Image
+	albumArtURL string
+	auth        spotify.Authenticator
 	client      *spotify.Client
-	clientChan  chan *spotify.Client
+	mu          sync.Mutex
 	playerState *spotify.PlayerState
+	prompt      oauth.Prompt
 	settings    *Settings
 }
 
-var (
-	auth           spotify.Authenticator
-	tempClientChan = make(chan *spotify.Client)
-	state          = "wtfSpotifyWebStateString"
-	authURL        string
-	callbackPort   string
-	redirectURI    string
-)
-
-func authHandler(w http.ResponseWriter, r *http.Request) {
-	logger.Log("[SpotifyWeb] Got an authentication hit!")
-	tok, err := auth.Token(state, r)
-	if err != nil {
-		http.Error(w, "Couldn't get token", http.StatusForbidden)
-		logger.Log(err.Error())
-	}
-	if st := r.FormValue("state"); st != state {
-		http.NotFound(w, r)
-		logger.Log(fmt.Sprintf("State mismatch: %s != %s\n", st, state))
-	}
-	// use the token to get an authenticated client
-	client := auth.NewClient(tok)
-	fmt.Fprintf(w, "Login Completed!")
-	tempClientChan <- &client
+// scopes are what the widget asks to be allowed to do with the user's account
+var scopes = []string{
+	spotify.ScopeUserReadCurrentlyPlaying,
+	spotify.ScopeUserReadPlaybackState,
+	spotify.ScopeUserModifyPlaybackState,
 }
 
-// NewWidget creates a new widget for WTF
+// NewWidget creates a new widget for WTF. Each Spotify Web widget listens for the login
+// redirect on its own callbackPort, and keeps its sign-in under its own name so that
+// the user only signs in once
 func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
-	redirectURI = "http://localhost:" + settings.callbackPort + "/callback"
+	redirectURI := "http://localhost:" + settings.callbackPort + "/callback"
 
-	auth = spotify.NewAuthenticator(redirectURI, spotify.ScopeUserReadCurrentlyPlaying, spotify.ScopeUserReadPlaybackState, spotify.ScopeUserModifyPlaybackState)
+	auth := spotify.NewAuthenticator(redirectURI, scopes...)
 	auth.SetAuthInfo(settings.clientID, settings.secretKey)
-	authURL = auth.AuthURL(state)
-
-	var client *spotify.Client
-	var playerState *spotify.PlayerState
 
 	widget := Widget{
 		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
@@ -76,44 +63,11 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 
 		Info: Info{},
 
-		client:      client,
-		clientChan:  tempClientChan,
-		playerState: playerState,
-		settings:    settings,
-	}
-
-	http.HandleFunc("/callback", authHandler)
-	go http.ListenAndServe(":"+callbackPort, nil)
-
-	go func() {
-		// wait for auth to complete
-		logger.Log("[SpotifyWeb] Waiting for authentication... URL: " + authURL)
-		client = <-tempClientChan
-
-		// use the client to make calls that require authorization
-		_, err := client.CurrentUser()
-		if err != nil {
-			panic(err)
-		}
-
-		playerState, err = client.PlayerState()
-		if err != nil {
-			panic(err)
-		}
-		logger.Log("[SpotifyWeb] Authentication complete.")
-		widget.client = client
-		widget.playerState = playerState
-		widget.Refresh()
-	}()
-
-	// While I wish I could find the reason this doesn't work, I can't.
-	//
-	// Normally, this should open the URL to the browser, however it opens the Explorer window in Windows.
-	// This mostly likely has to do with the fact that the URL includes some very special characters that no terminal likes.
-	// The only solution would be to include quotes in the command, which is why I do here, but it doesn't work.
-	//
-	// If inconvenient, I'll remove this option and save the URL in a file or some other method.
-	wtf.OpenFile(`"` + authURL + `"`)
+		auth:     auth,
+		settings: settings,
+	}
+
+	go widget.signIn()
 
 	widget.settings.common.RefreshInterval = 5
 
@@ -128,9 +82,70 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 	return &widget
 }
 
+// signIn uses the saved Spotify sign-in or, if there isn't one, opens Spotify's login
+// page and waits for the user to sign in
+func (widget *Widget) signIn() {
+	provider, _ := oauth.LookupProvider("spotify")
+
+	config := &oauth.Config{
+		CallbackPort: widget.settings.callbackPort,
+		ClientID:     widget.settings.clientID,
+		ClientSecret: widget.settings.secretKey,
+		Flow:         oauth.FlowCallback,
+		Provider:     provider,
+		Scopes:       scopes,
+	}
+
+	token, err := oauth.Token(context.Background(), widget.Name(), config, oauth.DefaultTokenStore(), widget.showPrompt)
+	if err != nil {
+		logger.For("spotifyweb").Errorf("signing in: %v", err)
+		return
+	}
+
+	// The authenticator's client refreshes the token as it expires
+	client := widget.auth.NewClient(token)
+
+	// use the client to make calls that require authorization
+	_, err = client.CurrentUser()
+	if err != nil {
+		logger.For("spotifyweb").Errorf("getting the current user: %v", err)
+		return
+	}
+
+	playerState, err := client.PlayerState()
+	if err != nil {
+		logger.For("spotifyweb").Errorf("getting the player state: %v", err)
+		return
+	}
+	logger.For("spotifyweb").Infof("authentication complete")
+
+	widget.mu.Lock()
+	widget.client = &client
+	widget.playerState = playerState
+	widget.mu.Unlock()
+
+	widget.Refresh()
+}
+
+// showPrompt opens Spotify's login page, and keeps its address to show until the user
+// has signed in
+func (widget *Widget) showPrompt(prompt oauth.Prompt) {
+	logger.For("spotifyweb").Infof("waiting for authentication at %s", prompt.URL)
+
+	widget.mu.Lock()
+	widget.prompt = prompt
+	widget.mu.Unlock()
+
+	utils.OpenURL(prompt.URL)
+	widget.Refresh()
+}
+
 func (w *Widget) refreshSpotifyInfos() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	if w.client == nil || w.playerState == nil {
-		return errors.New("Authentication failed! Please log in to Spotify by visiting the following page in your browser: " + authURL)
+		return errors.New("Please log in to Spotify. " + w.prompt.String())
 	}
 	var err error
 	w.playerState, err = w.client.PlayerState()
@@ -151,14 +166,43 @@ func (w *Widget) refreshSpotifyInfos() error {
 	} else {
 		w.Info.Status = "Paused"
 	}
+
+	if w.settings.albumArt {
+		w.fetchAlbumArt(w.playerState.CurrentlyPlaying.Item.Album.Images)
+	}
+
 	return nil
 }
 
+// fetchAlbumArt downloads the album's cover when the album changes. Spotify lists the
+// album's images widest first, so the last one is the smallest that will do
+func (w *Widget) fetchAlbumArt(images []spotify.Image) {
+	if len(images) == 0 {
+		w.albumArt = nil
+		w.albumArtURL = ""
+		return
+	}
+
+	url := images[len(images)-1].URL
+	if url == w.albumArtURL {
+		return
+	}
+
+	art, err := wtf.FetchImage(url)
+	if err != nil {
+		logger.For("spotifyweb").Warnf("fetching the album art failed: %v", err)
+		return
+	}
+
+	w.albumArt = art
+	w.albumArtURL = url
+}
+
 // Refresh refreshes the current view of the widget
 func (w *Widget) Refresh() {
 	err := w.refreshSpotifyInfos()
 	if err != nil {
-		w.Redraw(w.CommonSettings().Title, err.Error(), true)
+		w.RedrawError(w.CommonSettings().Title, err)
 	} else {
 		w.Redraw(w.CommonSettings().Title, w.createOutput(), false)
 	}
@@ -169,7 +213,12 @@ func (widget *Widget) HelpText() string {
 }
 
 func (w *Widget) createOutput() string {
-	output := wtf.CenterText(fmt.Sprintf("[green]Now %v [white]\n", w.Info.Status), w.CommonSettings().Width)
+	output := ""
+	if w.albumArt != nil {
+		output += w.albumArtText() + "\n"
+	}
+
+	output += wtf.CenterText(fmt.Sprintf("[green]Now %v [white]\n", w.Info.Status), w.CommonSettings().Width)
 	output += wtf.CenterText(fmt.Sprintf("[green]Title:[white] %v\n", w.Info.Title), w.CommonSettings().Width)
 	output += wtf.CenterText(fmt.Sprintf("[green]Artist:[white] %v\n", w.Info.Artists), w.CommonSettings().Width)
 	output += wtf.CenterText(fmt.Sprintf("[green]Album:[white] %v\n", w.Info.Album), w.CommonSettings().Width)
@@ -180,3 +229,11 @@ func (w *Widget) createOutput() string {
 	}
 	return output
 }
+
+// albumArtText draws the album's cover, as block characters or with the terminal's
+// graphics protocol, as a square albumArtHeight rows tall
+func (w *Widget) albumArtText() string {
+	rows := w.settings.albumArtHeight
+
+	return wtf.Images.Text(w.View, w.albumArt, rows*2, rows)
+}
@@ -0,0 +1,78 @@
+package strava
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const apiURL = "https://www.strava.com/api/v3/"
+
+// Activity is a run, ride, swim, or other workout the user recorded
+type Activity struct {
+	Distance      float64   `json:"distance"`
+	ElevationGain float64   `json:"total_elevation_gain"`
+	ID            int64     `json:"id"`
+	MovingTime    int64     `json:"moving_time"`
+	Name          string    `json:"name"`
+	SportType     string    `json:"sport_type"`
+	StartDate     time.Time `json:"start_date"`
+}
+
+// Totals are how far, how long, and how much climbing the activities add up to, in
+// metres and seconds
+type Totals struct {
+	Count         int
+	Distance      float64
+	ElevationGain float64
+	MovingTime    time.Duration
+}
+
+// fetchActivities returns the user's activities that started after the given time,
+// newest first, up to the given number of them
+func (widget *Widget) fetchActivities(after time.Time, count int) ([]Activity, error) {
+	params := url.Values{}
+	params.Set("per_page", strconv.Itoa(count))
+	if !after.IsZero() {
+		params.Set("after", strconv.FormatInt(after.Unix(), 10))
+	}
+
+	resp, err := widget.session.Client().Get(apiURL + "athlete/activities?" + params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, wtf.NewHTTPError(resp)
+	}
+
+	activities := []Activity{}
+	err = json.NewDecoder(resp.Body).Decode(&activities)
+
+	return activities, err
+}
+
+// total adds up the activities
+func total(activities []Activity) Totals {
+	totals := Totals{Count: len(activities)}
+
+	for _, activity := range activities {
+		totals.Distance += activity.Distance
+		totals.ElevationGain += activity.ElevationGain
+		totals.MovingTime += time.Duration(activity.MovingTime) * time.Second
+	}
+
+	return totals
+}
+
+// startOfWeek returns midnight on the Monday of the week the time is in
+func startOfWeek(now time.Time) time.Time {
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	monday := now.AddDate(0, 0, -daysSinceMonday)
+
+	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, now.Location())
+}
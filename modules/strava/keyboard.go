@@ -0,0 +1,16 @@
+package strava
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next activity")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous activity")
+	widget.SetKeyboardChar("o", widget.openActivity, "Open activity in browser")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next activity")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous activity")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openActivity, "Open activity in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,54 @@
+package strava
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Strava"
+
+const (
+	imperial = "imperial"
+	metric   = "metric"
+)
+
+// goals are how far, how long, and how much climbing the user aims for each week, in
+// kilometres or miles, hours, and metres or feet. Goals of zero aren't shown
+type goals struct {
+	distance  float64
+	elevation float64
+	hours     float64
+}
+
+type Settings struct {
+	common *cfg.Common
+
+	activities   int    `help:"How many of the latest activities to show." optional:"true" default:"5"`
+	callbackPort string `help:"The port to listen for the sign-in redirect on, which your Strava API application's callback domain must allow." optional:"true" default:"8080"`
+	clientID     string `help:"The client ID of your Strava API application." values:"Falls back to WTF_STRAVA_CLIENT_ID."`
+	clientSecret string `help:"The client secret of your Strava API application." values:"Falls back to WTF_STRAVA_CLIENT_SECRET."`
+	goals        goals  `help:"Your weekly goals, as a map of distance, hours, and elevation, in the units of the widget." optional:"true"`
+	units        string `help:"The units distances and elevations are shown in." values:"metric or imperial" optional:"true" default:"metric"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		activities:   ymlConfig.UInt("activities", 5),
+		callbackPort: ymlConfig.UString("callbackPort", "8080"),
+		clientID:     ymlConfig.UString("clientID", os.Getenv("WTF_STRAVA_CLIENT_ID")),
+		clientSecret: ymlConfig.UString("clientSecret", os.Getenv("WTF_STRAVA_CLIENT_SECRET")),
+		goals: goals{
+			distance:  ymlConfig.UFloat64("goals.distance", 0),
+			elevation: ymlConfig.UFloat64("goals.elevation", 0),
+			hours:     ymlConfig.UFloat64("goals.hours", 0),
+		},
+		units: ymlConfig.UString("units", metric),
+	}
+
+	return &settings
+}
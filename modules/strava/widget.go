@@ -0,0 +1,283 @@
+package strava
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/oauth"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const (
+	activityURL = "https://www.strava.com/activities/"
+
+	// goalBarWidth is how many characters wide the goal progress bars are
+	goalBarWidth = 20
+
+	// maxWeekActivities is the most activities fetched to add up the week's totals
+	maxWeekActivities = 200
+)
+
+// scopes are what the widget asks to be allowed to do with the user's account
+var scopes = []string{"read,activity:read_all"}
+
+// A Widget shows the user's Strava totals for this week, with their progress towards
+// their weekly goals, and their latest activities
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	activities []Activity
+	mu         sync.Mutex
+	session    *oauth.Session
+	settings   *Settings
+	week       Totals
+}
+
+// NewWidget creates a new instance of a widget. The user signs in once, and the sign-in
+// is kept under the widget's name
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetQuotaHost("www.strava.com")
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	widget.session = oauth.NewSession(widget.Name(), oauth.DefaultTokenStore(), widget.Refresh)
+	go widget.session.SignIn(widget.oauthConfig)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches this week's activities, to add up, and the latest activities
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	if widget.session.Client() == nil {
+		widget.Redraw(widget.CommonSettings().Title, widget.session.PromptText(), true)
+		return
+	}
+
+	week, err := widget.fetchActivities(startOfWeek(time.Now()), maxWeekActivities)
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	latest, err := widget.fetchActivities(time.Time{}, widget.settings.activities)
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.mu.Lock()
+	widget.activities = latest
+	widget.week = total(week)
+	widget.mu.Unlock()
+
+	widget.SetItemCount(len(latest))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the activities so that their ages stay current
+func (widget *Widget) RenderRelativeTimes() {
+	if widget.session.Client() != nil {
+		widget.Render()
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	week := widget.week
+	distance := widget.distance(week.Distance)
+	elevation := widget.elevation(week.ElevationGain)
+	hours := week.MovingTime.Hours()
+
+	str := fmt.Sprintf(
+		" [green]This week[white]  %d activities, %s, %s, %s climbed\n",
+		week.Count,
+		widget.formatDistance(week.Distance),
+		formatDuration(week.MovingTime),
+		widget.formatElevation(week.ElevationGain),
+	)
+
+	goals := widget.settings.goals
+	if goals.distance > 0 {
+		str += fmt.Sprintf(" Distance   %s %.1f / %g %s\n", progressBar(distance/goals.distance), distance, goals.distance, widget.distanceUnit())
+	}
+	if goals.hours > 0 {
+		str += fmt.Sprintf(" Time       %s %.1f / %g h\n", progressBar(hours/goals.hours), hours, goals.hours)
+	}
+	if goals.elevation > 0 {
+		str += fmt.Sprintf(" Elevation  %s %.0f / %g %s\n", progressBar(elevation/goals.elevation), elevation, goals.elevation, widget.elevationUnit())
+	}
+
+	str += "\n [green]Latest[white]\n"
+	if len(widget.activities) == 0 {
+		return str + " [gray]none[white]\n"
+	}
+
+	for idx, activity := range widget.activities {
+		row := fmt.Sprintf(
+			"[%s] %s [gray]%s, %s, %s, %s[white]",
+			widget.RowColor(idx),
+			tview.Escape(activity.Name),
+			activity.SportType,
+			widget.formatDistance(activity.Distance),
+			formatDuration(time.Duration(activity.MovingTime)*time.Second),
+			wtf.RelativeTime(activity.StartDate),
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(activity.Name))
+	}
+
+	return str
+}
+
+// distance converts metres to kilometres or miles
+func (widget *Widget) distance(metres float64) float64 {
+	if widget.settings.units == imperial {
+		return metres / 1609.344
+	}
+
+	return metres / 1000
+}
+
+func (widget *Widget) distanceUnit() string {
+	if widget.settings.units == imperial {
+		return "mi"
+	}
+
+	return "km"
+}
+
+// elevation converts metres to metres or feet
+func (widget *Widget) elevation(metres float64) float64 {
+	if widget.settings.units == imperial {
+		return metres * 3.28084
+	}
+
+	return metres
+}
+
+func (widget *Widget) elevationUnit() string {
+	if widget.settings.units == imperial {
+		return "ft"
+	}
+
+	return "m"
+}
+
+func (widget *Widget) formatDistance(metres float64) string {
+	return fmt.Sprintf("%.1f %s", widget.distance(metres), widget.distanceUnit())
+}
+
+func (widget *Widget) formatElevation(metres float64) string {
+	return fmt.Sprintf("%.0f %s", widget.elevation(metres), widget.elevationUnit())
+}
+
+func (widget *Widget) openActivity() {
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.activities) {
+		utils.OpenURL(fmt.Sprintf("%s%d", activityURL, widget.activities[sel].ID))
+	}
+}
+
+// rowFor describes the activity at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	widget.mu.Lock()
+	defer widget.mu.Unlock()
+
+	activity := widget.activities[idx]
+
+	return wtf.Row{
+		ID:   fmt.Sprintf("%d", activity.ID),
+		Text: activity.Name,
+		URL:  fmt.Sprintf("%s%d", activityURL, activity.ID),
+	}
+}
+
+// oauthConfig returns the config to sign in to Strava with
+func (widget *Widget) oauthConfig() (*oauth.Config, error) {
+	provider, _ := oauth.LookupProvider("strava")
+
+	config := &oauth.Config{
+		CallbackPort: widget.settings.callbackPort,
+		ClientID:     widget.settings.clientID,
+		ClientSecret: widget.settings.clientSecret,
+		Flow:         oauth.FlowCallback,
+		Provider:     provider,
+		Scopes:       scopes,
+	}
+
+	return config, nil
+}
+
+// formatDuration writes the duration in hours and minutes, such as "4h 12m"
+func formatDuration(duration time.Duration) string {
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes()) % 60
+
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%dh %02dm", hours, minutes)
+}
+
+// progressBar draws how far towards a goal the fraction is, green once it's reached
+func progressBar(fraction float64) string {
+	filled := int(math.Round(math.Min(fraction, 1) * goalBarWidth))
+	if filled < 0 {
+		filled = 0
+	}
+
+	color := "yellow"
+	if fraction >= 1 {
+		color = "green"
+	}
+
+	return fmt.Sprintf(
+		"[%s]%s[gray]%s[white] %3.0f%%",
+		color,
+		strings.Repeat("█", filled),
+		strings.Repeat("░", goalBarWidth-filled),
+		fraction*100,
+	)
+}
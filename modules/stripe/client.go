@@ -0,0 +1,217 @@
+package stripe
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const apiURL = "https://api.stripe.com/v1"
+
+// maxPages is how many pages of 100 objects a list is read to, so that a busy account
+// doesn't make each refresh page through everything
+const maxPages = 10
+
+// Charge is a payment, or an attempt at one
+type Charge struct {
+	Amount         int64  `json:"amount"`
+	Created        int64  `json:"created"`
+	Currency       string `json:"currency"`
+	Description    string `json:"description"`
+	FailureMessage string `json:"failure_message"`
+	ID             string `json:"id"`
+	Paid           bool   `json:"paid"`
+	Status         string `json:"status"`
+	BillingDetails struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	} `json:"billing_details"`
+}
+
+type subscription struct {
+	Items struct {
+		Data []struct {
+			Price struct {
+				Currency  string `json:"currency"`
+				Recurring struct {
+					Interval      string `json:"interval"`
+					IntervalCount int64  `json:"interval_count"`
+				} `json:"recurring"`
+				UnitAmount int64 `json:"unit_amount"`
+			} `json:"price"`
+			Quantity int64 `json:"quantity"`
+		} `json:"data"`
+	} `json:"items"`
+}
+
+type page struct {
+	Data    []json.RawMessage `json:"data"`
+	HasMore bool              `json:"has_more"`
+}
+
+// Amounts are sums of money in each currency, in the currency's smallest unit
+type Amounts map[string]int64
+
+/* -------------------- Unexported Functions -------------------- */
+
+// chargesSince returns the charges made since the time, newest first
+func (widget *Widget) chargesSince(since time.Time) ([]Charge, error) {
+	params := url.Values{}
+	params.Set("created[gte]", strconv.FormatInt(since.Unix(), 10))
+
+	charges := []Charge{}
+	err := widget.listAll("/charges", params, func(raw json.RawMessage) error {
+		charge := Charge{}
+		if err := json.Unmarshal(raw, &charge); err != nil {
+			return err
+		}
+
+		charges = append(charges, charge)
+		return nil
+	})
+
+	return charges, err
+}
+
+// newCustomerCount returns how many customers were created since the time
+func (widget *Widget) newCustomerCount(since time.Time) (int, error) {
+	params := url.Values{}
+	params.Set("created[gte]", strconv.FormatInt(since.Unix(), 10))
+
+	count := 0
+	err := widget.listAll("/customers", params, func(raw json.RawMessage) error {
+		count++
+		return nil
+	})
+
+	return count, err
+}
+
+// recentFailedCharges returns the most recent charges that failed, up to the count
+func (widget *Widget) recentFailedCharges(count int) ([]Charge, error) {
+	params := url.Values{}
+	params.Set("limit", "100")
+
+	result := page{}
+	if err := widget.get("/charges", params, &result); err != nil {
+		return nil, err
+	}
+
+	failed := []Charge{}
+	for _, raw := range result.Data {
+		charge := Charge{}
+		if err := json.Unmarshal(raw, &charge); err != nil {
+			return nil, err
+		}
+
+		if charge.Status == "failed" {
+			failed = append(failed, charge)
+		}
+
+		if len(failed) == count {
+			break
+		}
+	}
+
+	return failed, nil
+}
+
+// monthlyRecurringRevenue adds up what the active subscriptions bring in each month, with
+// yearly, weekly, and daily prices spread over the months. Discounts aren't taken off
+func (widget *Widget) monthlyRecurringRevenue() (Amounts, error) {
+	params := url.Values{}
+	params.Set("status", "active")
+
+	mrr := Amounts{}
+	err := widget.listAll("/subscriptions", params, func(raw json.RawMessage) error {
+		sub := subscription{}
+		if err := json.Unmarshal(raw, &sub); err != nil {
+			return err
+		}
+
+		for _, item := range sub.Items.Data {
+			price := item.Price
+			mrr[price.Currency] += monthlyAmount(price.UnitAmount*item.Quantity, price.Recurring.Interval, price.Recurring.IntervalCount)
+		}
+
+		return nil
+	})
+
+	return mrr, err
+}
+
+func (widget *Widget) get(path string, params url.Values, result interface{}) error {
+	req, err := http.NewRequest("GET", apiURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+widget.settings.apiKey)
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// listAll pages through the list, up to maxPages, calling each with every object in it
+func (widget *Widget) listAll(path string, params url.Values, each func(raw json.RawMessage) error) error {
+	params.Set("limit", "100")
+
+	for i := 0; i < maxPages; i++ {
+		result := page{}
+		if err := widget.get(path, params, &result); err != nil {
+			return err
+		}
+
+		for _, raw := range result.Data {
+			if err := each(raw); err != nil {
+				return err
+			}
+		}
+
+		if !result.HasMore || len(result.Data) == 0 {
+			return nil
+		}
+
+		last := struct {
+			ID string `json:"id"`
+		}{}
+		if err := json.Unmarshal(result.Data[len(result.Data)-1], &last); err != nil {
+			return err
+		}
+
+		params.Set("starting_after", last.ID)
+	}
+
+	return nil
+}
+
+// monthlyAmount converts an amount charged every intervalCount intervals to the amount
+// it comes to each month
+func monthlyAmount(amount int64, interval string, intervalCount int64) int64 {
+	if intervalCount < 1 {
+		intervalCount = 1
+	}
+
+	switch interval {
+	case "day":
+		return amount * 365 / 12 / intervalCount
+	case "week":
+		return amount * 52 / 12 / intervalCount
+	case "year":
+		return amount / 12 / intervalCount
+	default:
+		return amount / intervalCount
+	}
+}
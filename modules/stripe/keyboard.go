@@ -0,0 +1,16 @@
+package stripe
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next failed payment")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous failed payment")
+	widget.SetKeyboardChar("o", widget.openPayment, "Open failed payment in the Stripe dashboard")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next failed payment")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous failed payment")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openPayment, "Open failed payment in the Stripe dashboard")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,29 @@
+package stripe
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Stripe"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey      string `help:"A Stripe secret key, or a restricted key that can read charges, customers, and subscriptions." values:"Falls back to WTF_STRIPE_KEY."`
+	failedCount int    `help:"How many of the most recent failed payments to show." optional:"true" default:"5"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:      ymlConfig.UString("apiKey", os.Getenv("WTF_STRIPE_KEY")),
+		failedCount: ymlConfig.UInt("failedCount", 5),
+	}
+
+	return &settings
+}
@@ -0,0 +1,219 @@
+package stripe
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const dashboardURL = "https://dashboard.stripe.com/payments/"
+
+// zeroDecimalCurrencies are the currencies whose amounts aren't in hundredths
+var zeroDecimalCurrencies = map[string]bool{
+	"bif": true, "clp": true, "djf": true, "gnf": true, "jpy": true, "kmf": true, "krw": true, "mga": true,
+	"pyg": true, "rwf": true, "ugx": true, "vnd": true, "vuv": true, "xaf": true, "xof": true, "xpf": true,
+}
+
+// A Widget shows a Stripe account's gross volume today, its monthly recurring revenue,
+// the customers it gained today, and its most recent failed payments
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	failed       []Charge
+	grossVolume  Amounts
+	mrr          Amounts
+	newCustomers int
+	settings     *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetQuotaHost("api.stripe.com")
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches today's payments and customers, the active subscriptions, and the
+// recent failed payments
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	charges, err := widget.chargesSince(startOfDay)
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	mrr, err := widget.monthlyRecurringRevenue()
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	newCustomers, err := widget.newCustomerCount(startOfDay)
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	failed, err := widget.recentFailedCharges(widget.settings.failedCount)
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.grossVolume = grossVolume(charges)
+	widget.mrr = mrr
+	widget.newCustomers = newCustomers
+	widget.failed = failed
+	widget.SetItemCount(len(failed))
+
+	widget.PublishData("newCustomers", newCustomers)
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the failed payments so that their times stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	str := fmt.Sprintf(" [green]Today[white]      %s\n", formatAmounts(widget.grossVolume))
+	str += fmt.Sprintf(" [green]MRR[white]        %s\n", formatAmounts(widget.mrr))
+	str += fmt.Sprintf(" [green]Customers[white]  %d new today\n", widget.newCustomers)
+
+	str += "\n [red]Failed Payments[white]\n"
+	if len(widget.failed) == 0 {
+		return str + " [gray]none[white]\n"
+	}
+
+	for idx, charge := range widget.failed {
+		who := charge.BillingDetails.Name
+		if who == "" {
+			who = charge.BillingDetails.Email
+		}
+
+		row := fmt.Sprintf(
+			"[%s] %s %s [gray]%s %s[white]",
+			widget.RowColor(idx),
+			formatAmount(charge.Amount, charge.Currency),
+			tview.Escape(who),
+			tview.Escape(charge.FailureMessage),
+			wtf.RelativeTime(time.Unix(charge.Created, 0)),
+		)
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(who))
+	}
+
+	return str
+}
+
+func (widget *Widget) openPayment() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.failed) {
+		utils.OpenURL(dashboardURL + widget.failed[sel].ID)
+	}
+}
+
+// rowFor describes the failed payment at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	charge := widget.failed[idx]
+
+	return wtf.Row{
+		ID:   charge.ID,
+		Text: fmt.Sprintf("%s %s", formatAmount(charge.Amount, charge.Currency), charge.FailureMessage),
+		URL:  dashboardURL + charge.ID,
+	}
+}
+
+// formatAmount writes the amount in the currency's major unit, such as "1,234.50 USD"
+func formatAmount(amount int64, currency string) string {
+	code := strings.ToUpper(currency)
+
+	if zeroDecimalCurrencies[currency] {
+		return fmt.Sprintf("%s %s", humanize.Comma(amount), code)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	return fmt.Sprintf("%s%s.%02d %s", sign, humanize.Comma(amount/100), amount%100, code)
+}
+
+// formatAmounts writes the amount in each currency, largest first
+func formatAmounts(amounts Amounts) string {
+	if len(amounts) == 0 {
+		return "0"
+	}
+
+	currencies := []string{}
+	for currency := range amounts {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		return amounts[currencies[i]] > amounts[currencies[j]]
+	})
+
+	formatted := []string{}
+	for _, currency := range currencies {
+		formatted = append(formatted, formatAmount(amounts[currency], currency))
+	}
+
+	return strings.Join(formatted, ", ")
+}
+
+// grossVolume adds up the charges that succeeded, before refunds and fees
+func grossVolume(charges []Charge) Amounts {
+	volume := Amounts{}
+
+	for _, charge := range charges {
+		if charge.Status == "succeeded" {
+			volume[charge.Currency] += charge.Amount
+		}
+	}
+
+	return volume
+}
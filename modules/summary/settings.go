@@ -0,0 +1,90 @@
+package summary
+
+import (
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const (
+	defaultTitle     = "Summary"
+	defaultFormat    = "%v"
+	defaultSeparator = " · "
+
+	layoutLine = "line"
+	layoutRows = "rows"
+)
+
+// source is one of the values the summary shows: a value on the data bus or a template
+// over several of them
+type source struct {
+	crit     float64
+	format   string
+	label    string
+	template string
+	value    string
+	warn     float64
+	zero     string
+}
+
+type Settings struct {
+	common *cfg.Common
+
+	layout    string   `help:"How the sources are laid out: 'line' puts them all on one line, 'rows' gives each its own row." optional:"true" default:"line"`
+	separator string   `help:"What goes between the sources on a line." optional:"true" default:" · "`
+	sources   []source `help:"The values to show." values:"A list of maps with a label and either a value, the data bus key of a value such as github.reviewRequests, or a template. Optionally, a format such as '%v PRs', warn and crit thresholds, and the text to show when the value is 0."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		layout:    ymlConfig.UString("layout", layoutLine),
+		separator: ymlConfig.UString("separator", defaultSeparator),
+		sources:   parseSources(ymlConfig),
+	}
+
+	return &settings
+}
+
+// parseSources reads the sources, skipping those with neither a value nor a template
+// Example:
+//
+//	sources:
+//	  - label: GH
+//	    value: github.reviewRequests
+//	    format: "%v PRs"
+//	  - label: Pager
+//	    value: pagerduty.incidents
+//	    zero: OK
+//	    crit: 1
+//	  - label: CI
+//	    value: circleci.failed
+//	    format: "%v red"
+//	    zero: OK
+//	    crit: 1
+//	  - label: Out
+//	    template: '{{ data "weather.temperature" | printf "%.0f°" }}'
+func parseSources(ymlConfig *config.Config) []source {
+	sources := []source{}
+
+	for _, value := range ymlConfig.UList("sources") {
+		sourceConfig := &config.Config{Root: value}
+
+		src := source{
+			crit:     sourceConfig.UFloat64("crit", 0),
+			format:   sourceConfig.UString("format", defaultFormat),
+			label:    sourceConfig.UString("label", ""),
+			template: sourceConfig.UString("template", ""),
+			value:    sourceConfig.UString("value", ""),
+			warn:     sourceConfig.UFloat64("warn", 0),
+			zero:     sourceConfig.UString("zero", ""),
+		}
+
+		if src.value != "" || src.template != "" {
+			sources = append(sources, src)
+		}
+	}
+
+	return sources
+}
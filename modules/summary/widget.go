@@ -0,0 +1,148 @@
+package summary
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// missingValue is shown for a source that hasn't published a value yet
+const missingValue = "[gray]–[-]"
+
+// A Widget combines the values other modules publish on the data bus, such as how many
+// pull requests are waiting for review or how many builds are failing, into a compact
+// overview: "GH: 3 PRs · Jira: 5 · Pager: OK · CI: 1 red"
+type Widget struct {
+	wtf.TextWidget
+
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, settings *Settings) *Widget {
+	widget := Widget{
+		TextWidget: wtf.NewTextWidget(app, settings.common, false),
+
+		settings: settings,
+	}
+
+	// The values come from the other widgets, so update the summary whenever they change
+	widget.AddSubscription(wtf.Data.Subscribe("", func(key string, value interface{}) {
+		if widget.shows(key) && widget.Enabled() {
+			widget.Refresh()
+		}
+	}))
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) Refresh() {
+	parts := []string{}
+	for _, src := range widget.settings.sources {
+		parts = append(parts, widget.textFor(src))
+	}
+
+	if widget.settings.layout == layoutRows {
+		widget.Redraw(widget.CommonSettings().Title, strings.Join(parts, "\n"), false)
+		return
+	}
+
+	widget.Redraw(widget.CommonSettings().Title, strings.Join(parts, widget.settings.separator), true)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// colorFor returns the color of a source's count: crit or warn once it reaches their
+// thresholds, ok below them, and none when the source has no thresholds
+func (widget *Widget) colorFor(src source, count float64) string {
+	colors := widget.settings.common.Colors
+
+	switch {
+	case src.crit > 0 && count >= src.crit:
+		return colors.Crit
+	case src.warn > 0 && count >= src.warn:
+		return colors.Warn
+	case src.crit > 0 || src.warn > 0:
+		return colors.OK
+	default:
+		return ""
+	}
+}
+
+// shows returns TRUE if the summary shows the value with the given key. Templates can
+// use any value, so a summary with one shows them all
+func (widget *Widget) shows(key string) bool {
+	for _, src := range widget.settings.sources {
+		if src.template != "" || src.value == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// textFor returns a source's label and value
+func (widget *Widget) textFor(src source) string {
+	text := widget.valueFor(src)
+
+	if src.label == "" {
+		return text
+	}
+
+	return fmt.Sprintf("%s: %s", src.label, text)
+}
+
+// valueFor returns a source's value, formatted and colored
+func (widget *Widget) valueFor(src source) string {
+	if src.template != "" {
+		text, err := wtf.Data.Render(src.template)
+		if err != nil {
+			return fmt.Sprintf("[%s]%s[-]", widget.settings.common.Colors.Crit, err.Error())
+		}
+
+		return text
+	}
+
+	value, ok := wtf.Data.Get(src.value)
+	if !ok {
+		return missingValue
+	}
+
+	count, isCount := countOf(value)
+	if !isCount {
+		return fmt.Sprintf(src.format, value)
+	}
+
+	text := fmt.Sprintf(src.format, count)
+	if count == 0 && src.zero != "" {
+		text = src.zero
+	}
+
+	if color := widget.colorFor(src, count); color != "" {
+		return fmt.Sprintf("[%s]%s[-]", color, text)
+	}
+
+	return text
+}
+
+// countOf returns the value as a number: itself if it is one, or its length if it's a
+// list or a map
+func countOf(value interface{}) (float64, bool) {
+	switch val := reflect.ValueOf(value); val.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint()), true
+	case reflect.Array, reflect.Map, reflect.Slice:
+		return float64(val.Len()), true
+	default:
+		return 0, false
+	}
+}
@@ -14,6 +14,8 @@ type Settings struct {
 	filePaths   []interface{}
 	format      bool
 	formatStyle string
+	markdown    bool
+	template    bool
 	wrapText    bool
 }
 
@@ -26,6 +28,8 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 		filePaths:   ymlConfig.UList("filePaths"),
 		format:      ymlConfig.UBool("format", false),
 		formatStyle: ymlConfig.UString("formatStyle", "vim"),
+		markdown:    ymlConfig.UBool("markdown", true),
+		template:    ymlConfig.UBool("template", false),
 		wrapText:    ymlConfig.UBool("wrapText", true),
 	}
 
@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/chroma/formatters"
@@ -14,6 +14,7 @@ import (
 	"github.com/alecthomas/chroma/styles"
 	"github.com/radovskyb/watcher"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
@@ -50,6 +51,15 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 
 	go widget.watchForFileChanges()
 
+	// Templates show values from the data bus, so redraw them whenever one changes
+	if settings.template {
+		widget.AddSubscription(wtf.Data.Subscribe("", func(key string, value interface{}) {
+			if widget.Enabled() {
+				widget.display()
+			}
+		}))
+	}
+
 	return &widget
 }
 
@@ -73,9 +83,12 @@ func (widget *Widget) display() {
 	_, _, width, _ := widget.View.GetRect()
 	text := widget.settings.common.SigilStr(len(widget.Sources), widget.Idx, width) + "\n"
 
-	if widget.settings.format {
+	switch {
+	case widget.settings.format:
 		text += widget.formattedText()
-	} else {
+	case widget.settings.markdown && widget.isMarkdown():
+		text += wtf.RenderMarkdown(widget.plainText())
+	default:
 		text += widget.plainText()
 	}
 
@@ -86,6 +99,16 @@ func (widget *Widget) fileName() string {
 	return filepath.Base(widget.CurrentSource())
 }
 
+// isMarkdown returns TRUE if the file being shown is a markdown file
+func (widget *Widget) isMarkdown() bool {
+	switch strings.ToLower(filepath.Ext(widget.CurrentSource())) {
+	case ".md", ".markdown", ".mdown", ".mkd":
+		return true
+	default:
+		return false
+	}
+}
+
 func (widget *Widget) formattedText() string {
 	filePath, _ := utils.ExpandHomeDir(widget.CurrentSource())
 
@@ -120,12 +143,20 @@ func (widget *Widget) formattedText() string {
 func (widget *Widget) plainText() string {
 	filePath, _ := utils.ExpandHomeDir(widget.CurrentSource())
 
-	fmt.Println(filePath)
-
 	text, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return err.Error()
 	}
+
+	if widget.settings.template {
+		rendered, err := wtf.Data.Render(string(text))
+		if err != nil {
+			return err.Error()
+		}
+
+		return rendered
+	}
+
 	return string(text)
 }
 
@@ -139,7 +170,7 @@ func (widget *Widget) watchForFileChanges() {
 			case <-watch.Event:
 				widget.display()
 			case err := <-watch.Error:
-				log.Fatalln(err)
+				logger.For("textfile").Errorf("watching for changes: %v", err)
 			case <-watch.Closed:
 				return
 			}
@@ -151,13 +182,13 @@ func (widget *Widget) watchForFileChanges() {
 		fullPath, err := utils.ExpandHomeDir(source)
 		if err == nil {
 			if err := watch.Add(fullPath); err != nil {
-				log.Fatalln(err)
+				logger.For("textfile").Errorf("watching %s: %v", fullPath, err)
 			}
 		}
 	}
 
 	// Start the watching process - it'll check for changes every 100ms.
 	if err := watch.Start(time.Millisecond * 100); err != nil {
-		log.Fatalln(err)
+		logger.For("textfile").Errorf("starting the watcher: %v", err)
 	}
 }
@@ -0,0 +1,146 @@
+package timetracking
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const clockifyURL = "https://api.clockify.me/api/v1/"
+
+// clockifyTracker keeps timers in Clockify, in the user's active workspace
+type clockifyTracker struct {
+	apiKey string
+}
+
+// clockifyEntry is a time entry as Clockify describes it, with its project filled in.
+// A running entry has no end time
+type clockifyEntry struct {
+	Description string `json:"description"`
+	Project     *struct {
+		Name string `json:"name"`
+	} `json:"project"`
+	TimeInterval struct {
+		End   *time.Time `json:"end"`
+		Start time.Time  `json:"start"`
+	} `json:"timeInterval"`
+}
+
+type clockifyProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type clockifyUser struct {
+	ActiveWorkspace string `json:"activeWorkspace"`
+	ID              string `json:"id"`
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Entries returns the entries that started since the time, with their projects' names
+func (clockify *clockifyTracker) Entries(since time.Time) ([]Entry, error) {
+	user, err := clockify.user()
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("hydrated", "true")
+	params.Set("start", since.UTC().Format("2006-01-02T15:04:05Z"))
+
+	clockifyEntries := []clockifyEntry{}
+	path := fmt.Sprintf("workspaces/%s/user/%s/time-entries?%s", user.ActiveWorkspace, user.ID, params.Encode())
+	if err := clockify.request("GET", path, nil, &clockifyEntries); err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for _, clockifyEntry := range clockifyEntries {
+		entry := Entry{Description: clockifyEntry.Description, Start: clockifyEntry.TimeInterval.Start}
+
+		if clockifyEntry.Project != nil {
+			entry.Project = clockifyEntry.Project.Name
+		}
+
+		if clockifyEntry.TimeInterval.End != nil {
+			entry.Stop = *clockifyEntry.TimeInterval.End
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Start starts a timer in the project, or in none if the project is ""
+func (clockify *clockifyTracker) Start(description, project string) error {
+	user, err := clockify.user()
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"description": description,
+		"start":       time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+	}
+
+	if project != "" {
+		projects := []clockifyProject{}
+		path := fmt.Sprintf("workspaces/%s/projects?name=%s", user.ActiveWorkspace, url.QueryEscape(project))
+		if err := clockify.request("GET", path, nil, &projects); err != nil {
+			return err
+		}
+
+		for _, candidate := range projects {
+			if strings.EqualFold(candidate.Name, project) {
+				body["projectId"] = candidate.ID
+			}
+		}
+	}
+
+	return clockify.request("POST", fmt.Sprintf("workspaces/%s/time-entries", user.ActiveWorkspace), body, nil)
+}
+
+// Stop stops the running timer
+func (clockify *clockifyTracker) Stop() error {
+	user, err := clockify.user()
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"end": time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+	}
+
+	path := fmt.Sprintf("workspaces/%s/user/%s/time-entries", user.ActiveWorkspace, user.ID)
+	err = clockify.request("PATCH", path, body, nil)
+	// Clockify answers that there's nothing to stop as if the entry weren't found
+	if moduleErr, ok := err.(*wtf.ModuleError); ok && moduleErr.Status == http.StatusNotFound {
+		return errors.New("no timer is running")
+	}
+
+	return err
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (clockify *clockifyTracker) request(method, path string, body, result interface{}) error {
+	return apiRequest(method, clockifyURL+path, body, result, func(req *http.Request) {
+		req.Header.Set("X-Api-Key", clockify.apiKey)
+	})
+}
+
+func (clockify *clockifyTracker) user() (*clockifyUser, error) {
+	user := clockifyUser{}
+	if err := clockify.request("GET", "user", nil, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,73 @@
+package timetracking
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// noProject is what the time tracked outside any project is listed as
+const noProject = "(no project)"
+
+func (widget *Widget) contentFrom(entries []Entry, now time.Time) string {
+	str := ""
+
+	if widget.offlineErr != nil {
+		str += fmt.Sprintf(" [%s]%s can't be reached, using the manual timer[white]\n\n", widget.settings.common.Colors.Warn, widget.settings.service)
+	}
+
+	if running, ok := runningEntry(entries); ok {
+		str += fmt.Sprintf(
+			" [green]▶ %s[white]  %s [gray]%s[white]\n\n",
+			formatDuration(running.Duration(now)),
+			running.Description,
+			running.Project,
+		)
+	} else {
+		str += " [gray]No timer running[white]\n\n"
+	}
+
+	totals := map[string]time.Duration{}
+	var total time.Duration
+
+	for _, entry := range entries {
+		project := entry.Project
+		if project == "" {
+			project = noProject
+		}
+
+		totals[project] += entry.Duration(now)
+		total += entry.Duration(now)
+	}
+
+	projects := []string{}
+	for project := range totals {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	for _, project := range projects {
+		str += fmt.Sprintf(" %-20s %6s\n", project, formatDuration(totals[project]))
+	}
+
+	str += fmt.Sprintf(" [::b]%-20s %6s[::-]\n", "Today", formatDuration(total))
+
+	return str
+}
+
+// formatDuration writes the duration in hours and minutes, such as "2:05"
+func formatDuration(duration time.Duration) string {
+	minutes := int(duration.Minutes())
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+// runningEntry returns the entry whose timer is running, if there is one
+func runningEntry(entries []Entry) (Entry, bool) {
+	for _, entry := range entries {
+		if entry.Running() {
+			return entry, true
+		}
+	}
+
+	return Entry{}, false
+}
@@ -0,0 +1,7 @@
+package timetracking
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("/", widget.ShowHelp, "Show/hide this help prompt")
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("s", widget.toggleTimer, "Start a timer, or stop the running one")
+}
@@ -0,0 +1,127 @@
+package timetracking
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// manualTracker keeps timers in a file, for tracking time without a service or while
+// the service can't be reached
+type manualTracker struct {
+	path string
+}
+
+// newManualTracker returns a tracker that keeps its entries in the file. A relative
+// path is in the config directory
+func newManualTracker(filePath string) *manualTracker {
+	if !filepath.IsAbs(filePath) {
+		if configDir, err := cfg.WtfConfigDir(); err == nil {
+			filePath = filepath.Join(configDir, filePath)
+		}
+	}
+
+	return &manualTracker{path: filePath}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Entries returns the entries that started since the time
+func (manual *manualTracker) Entries(since time.Time) ([]Entry, error) {
+	all, err := manual.load()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for _, entry := range all {
+		if !entry.Start.Before(since) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// Start stops the running timer, if there is one, and starts a new one
+func (manual *manualTracker) Start(description, project string) error {
+	entries, err := manual.load()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entries = stopAll(entries, now)
+	entries = append(entries, Entry{Description: description, Project: project, Start: now})
+
+	return manual.save(entries)
+}
+
+// Stop stops the running timer
+func (manual *manualTracker) Stop() error {
+	entries, err := manual.load()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Running() {
+			return manual.save(stopAll(entries, time.Now()))
+		}
+	}
+
+	return errors.New("no timer is running")
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (manual *manualTracker) load() ([]Entry, error) {
+	entries := []Entry{}
+
+	data, err := ioutil.ReadFile(manual.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	for idx := range entries {
+		entries[idx].manual = true
+	}
+
+	return entries, nil
+}
+
+func (manual *manualTracker) save(entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(manual.path), 0700); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(manual.path, data, 0600)
+}
+
+// stopAll stops the running entries at the time
+func stopAll(entries []Entry, now time.Time) []Entry {
+	for idx := range entries {
+		if entries[idx].Running() {
+			entries[idx].Stop = now
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,47 @@
+package timetracking
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Time Tracking"
+
+const (
+	serviceClockify = "clockify"
+	serviceManual   = "manual"
+	serviceToggl    = "toggl"
+)
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey      string `help:"Your Toggl API token or Clockify API key." values:"Falls back to WTF_TOGGL_API_TOKEN or WTF_CLOCKIFY_API_KEY." optional:"true"`
+	description string `help:"The description given to the timers started from the widget." optional:"true"`
+	filePath    string `help:"The file, in the config directory, that the manual timer's entries are kept in." optional:"true" default:"timetracking.json"`
+	project     string `help:"The name of the project the timers started from the widget are for." optional:"true"`
+	service     string `help:"Where the timers are kept. Timers started while Toggl or Clockify can't be reached are kept by the manual timer." values:"toggl, clockify, manual" optional:"true" default:"manual"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		description: ymlConfig.UString("description", ""),
+		filePath:    ymlConfig.UString("filename", "timetracking.json"),
+		project:     ymlConfig.UString("project", ""),
+		service:     ymlConfig.UString("service", serviceManual),
+	}
+
+	switch settings.service {
+	case serviceClockify:
+		settings.apiKey = ymlConfig.UString("apiKey", os.Getenv("WTF_CLOCKIFY_API_KEY"))
+	case serviceToggl:
+		settings.apiKey = ymlConfig.UString("apiKey", os.Getenv("WTF_TOGGL_API_TOKEN"))
+	}
+
+	return &settings
+}
@@ -0,0 +1,142 @@
+package timetracking
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const togglURL = "https://api.track.toggl.com/api/v9/"
+
+// togglTracker keeps timers in Toggl Track
+type togglTracker struct {
+	apiToken string
+}
+
+// togglEntry is a time entry as Toggl describes it. A running entry has no stop time
+type togglEntry struct {
+	Description string     `json:"description"`
+	ID          int64      `json:"id"`
+	ProjectID   *int64     `json:"project_id"`
+	Start       time.Time  `json:"start"`
+	Stop        *time.Time `json:"stop"`
+	WorkspaceID int64      `json:"workspace_id"`
+}
+
+type togglProject struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type togglUser struct {
+	DefaultWorkspaceID int64 `json:"default_workspace_id"`
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Entries returns the entries that started since the time, with their projects' names
+func (toggl *togglTracker) Entries(since time.Time) ([]Entry, error) {
+	params := url.Values{}
+	params.Set("start_date", since.Format(time.RFC3339))
+	params.Set("end_date", time.Now().Add(24*time.Hour).Format(time.RFC3339))
+
+	togglEntries := []togglEntry{}
+	if err := toggl.request("GET", "me/time_entries?"+params.Encode(), nil, &togglEntries); err != nil {
+		return nil, err
+	}
+
+	projects, err := toggl.projects()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for _, togglEntry := range togglEntries {
+		entry := Entry{Description: togglEntry.Description, Start: togglEntry.Start}
+
+		if togglEntry.ProjectID != nil {
+			entry.Project = projects[*togglEntry.ProjectID]
+		}
+
+		if togglEntry.Stop != nil {
+			entry.Stop = *togglEntry.Stop
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Start starts a timer in the project, or in none if the project is ""
+func (toggl *togglTracker) Start(description, project string) error {
+	user := togglUser{}
+	if err := toggl.request("GET", "me", nil, &user); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"created_with": "wtf",
+		"description":  description,
+		"duration":     -1,
+		"start":        time.Now().UTC().Format(time.RFC3339),
+		"workspace_id": user.DefaultWorkspaceID,
+	}
+
+	if project != "" {
+		projects, err := toggl.projects()
+		if err != nil {
+			return err
+		}
+
+		for id, name := range projects {
+			if strings.EqualFold(name, project) {
+				body["project_id"] = id
+			}
+		}
+	}
+
+	path := fmt.Sprintf("workspaces/%d/time_entries", user.DefaultWorkspaceID)
+	return toggl.request("POST", path, body, nil)
+}
+
+// Stop stops the running timer
+func (toggl *togglTracker) Stop() error {
+	current := togglEntry{}
+	if err := toggl.request("GET", "me/time_entries/current", nil, &current); err != nil {
+		return err
+	}
+
+	if current.ID == 0 {
+		return errors.New("no timer is running")
+	}
+
+	path := fmt.Sprintf("workspaces/%d/time_entries/%d/stop", current.WorkspaceID, current.ID)
+	return toggl.request("PATCH", path, nil, nil)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// projects returns the names of the user's projects, by their IDs
+func (toggl *togglTracker) projects() (map[int64]string, error) {
+	togglProjects := []togglProject{}
+	if err := toggl.request("GET", "me/projects", nil, &togglProjects); err != nil {
+		return nil, err
+	}
+
+	projects := map[int64]string{}
+	for _, project := range togglProjects {
+		projects[project.ID] = project.Name
+	}
+
+	return projects, nil
+}
+
+func (toggl *togglTracker) request(method, path string, body, result interface{}) error {
+	return apiRequest(method, togglURL+path, body, result, func(req *http.Request) {
+		req.SetBasicAuth(toggl.apiToken, "api_token")
+	})
+}
@@ -0,0 +1,85 @@
+package timetracking
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Entry is a span of time tracked against a project. A running timer has no stop time.
+// Entries kept by the manual timer in place of the service are marked as manual
+type Entry struct {
+	Description string    `json:"description"`
+	Project     string    `json:"project"`
+	Start       time.Time `json:"start"`
+	Stop        time.Time `json:"stop"`
+
+	manual bool
+}
+
+// tracker is a service that keeps timers
+type tracker interface {
+	// Entries returns the entries that started since the time, including the running one
+	Entries(since time.Time) ([]Entry, error)
+	Start(description, project string) error
+	Stop() error
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Duration returns how long the entry lasted, or has been running for
+func (entry *Entry) Duration(now time.Time) time.Duration {
+	if entry.Running() {
+		return now.Sub(entry.Start)
+	}
+
+	return entry.Stop.Sub(entry.Start)
+}
+
+// Running returns true if the entry's timer hasn't been stopped
+func (entry *Entry) Running() bool {
+	return entry.Stop.IsZero()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// apiRequest sends a request with a JSON body, if there is one, and decodes the JSON
+// response into result, if it's given
+func apiRequest(method, url string, body interface{}, result interface{}, setAuth func(*http.Request)) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	setAuth(req)
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
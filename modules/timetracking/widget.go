@@ -0,0 +1,136 @@
+package timetracking
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows the running timer and the time tracked today in each project, from
+// Toggl, Clockify, or its own manual timer
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.TextWidget
+
+	entries    []Entry
+	manual     *manualTracker
+	offlineErr error
+	settings   *Settings
+	tracker    tracker
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
+		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
+
+		manual:   newManualTracker(settings.filePath),
+		settings: settings,
+	}
+
+	switch settings.service {
+	case serviceClockify:
+		widget.tracker = &clockifyTracker{apiKey: settings.apiKey}
+	case serviceToggl:
+		widget.tracker = &togglTracker{apiToken: settings.apiKey}
+	default:
+		widget.tracker = widget.manual
+	}
+
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches today's entries. The manual timer's entries are included, so that
+// time tracked while the service couldn't be reached still counts
+func (widget *Widget) Refresh() {
+	since := startOfDay(wtf.Now())
+
+	entries, err := widget.tracker.Entries(since)
+	widget.offlineErr = nil
+
+	if widget.tracker != widget.manual {
+		manualEntries, manualErr := widget.manual.Entries(since)
+		if manualErr != nil {
+			logger.For(widget.Name()).Warnf("reading the manual timer: %v", manualErr)
+		}
+
+		if err != nil && len(manualEntries) > 0 {
+			widget.offlineErr = err
+			entries, err = []Entry{}, nil
+		}
+
+		entries = append(entries, manualEntries...)
+	}
+
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
+	}
+
+	widget.entries = entries
+	widget.display()
+}
+
+// RenderRelativeTimes redraws the entries so that the running timer keeps counting
+func (widget *Widget) RenderRelativeTimes() {
+	widget.display()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) display() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(widget.entries, time.Now()), false)
+}
+
+// toggleTimer stops the running timer or, if none is running, starts one. A timer the
+// service can't start is started by the manual timer instead
+func (widget *Widget) toggleTimer() {
+	log := logger.For(widget.Name())
+
+	if running, ok := runningEntry(widget.entries); ok {
+		stopper := widget.tracker
+		if running.manual {
+			stopper = widget.manual
+		}
+
+		if err := stopper.Stop(); err != nil {
+			widget.RedrawError(widget.CommonSettings().Title, fmt.Errorf("stopping the timer: %v", err))
+			return
+		}
+
+		widget.Refresh()
+		return
+	}
+
+	err := widget.tracker.Start(widget.settings.description, widget.settings.project)
+	if err != nil && widget.tracker != widget.manual {
+		log.Warnf("starting a manual timer, as %s can't be reached: %v", widget.settings.service, err)
+		err = widget.manual.Start(widget.settings.description, widget.settings.project)
+	}
+
+	if err != nil {
+		widget.RedrawError(widget.CommonSettings().Title, fmt.Errorf("starting the timer: %v", err))
+		return
+	}
+
+	widget.Refresh()
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -27,9 +27,16 @@ func (widget *Widget) initializeKeyboardControls() {
 }
 
 func (widget *Widget) deleteSelected() {
-	widget.list.Delete()
-	widget.persist()
-	widget.display()
+	item := widget.list.SelectedItem()
+	if item == nil {
+		return
+	}
+
+	widget.Confirm(fmt.Sprintf("Delete '%s'?", item.Text), func() {
+		widget.list.Delete()
+		widget.persist()
+		widget.display()
+	})
 }
 
 func (widget *Widget) demoteSelected() {
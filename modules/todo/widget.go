@@ -4,28 +4,22 @@ import (
 	"fmt"
 	"io/ioutil"
 
-	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/checklist"
+	"github.com/wtfutil/wtf/logger"
 	"github.com/wtfutil/wtf/wtf"
 	"gopkg.in/yaml.v2"
 )
 
-const offscreen = -1000
-const modalWidth = 80
-const modalHeight = 7
-
 // A Widget represents a Todo widget
 type Widget struct {
 	wtf.KeyboardWidget
 	wtf.TextWidget
 
-	app      *tview.Application
 	settings *Settings
 	filePath string
 	list     checklist.Checklist
-	pages    *tview.Pages
 }
 
 // NewWidget creates a new instance of a widget
@@ -34,11 +28,9 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 		KeyboardWidget: wtf.NewKeyboardWidget(app, pages, settings.common),
 		TextWidget:     wtf.NewTextWidget(app, settings.common, true),
 
-		app:      app,
 		settings: settings,
 		filePath: settings.filePath,
 		list:     checklist.NewChecklist(settings.common.Sigils.Checkbox.Checked, settings.common.Sigils.Checkbox.Unchecked),
-		pages:    pages,
 	}
 
 	widget.init()
@@ -71,32 +63,25 @@ func (widget *Widget) HelpText() string {
 
 /* -------------------- Unexported Functions -------------------- */
 
-// edit opens a modal dialog that permits editing the text of the currently-selected item
+// editSelected opens a prompt that permits editing the text of the currently-selected item
 func (widget *Widget) editSelected() {
 	if widget.list.SelectedItem() == nil {
 		return
 	}
 
-	form := widget.modalForm("Edit:", widget.list.SelectedItem().Text)
-
-	saveFctn := func() {
-		text := form.GetFormItem(0).(*tview.InputField).GetText()
+	prompt := wtf.TextPrompt{Label: "Edit:", Text: widget.list.SelectedItem().Text, History: "todo"}
 
+	widget.ShowPrompt(prompt, func(text string) {
 		widget.list.Update(text)
 		widget.persist()
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
 		widget.display()
-	}
-
-	widget.addButtons(form, saveFctn)
-	widget.modalFocus(form)
+	})
 }
 
 func (widget *Widget) init() {
 	_, err := cfg.CreateFile(widget.filePath)
 	if err != nil {
-		panic(err)
+		logger.For("todo").Errorf("creating %s: %v", widget.filePath, err)
 	}
 }
 
@@ -113,23 +98,12 @@ func (widget *Widget) load() {
 }
 
 func (widget *Widget) newItem() {
-	form := widget.modalForm("New Todo:", "")
-
-	saveFctn := func() {
-		text := form.GetFormItem(0).(*tview.InputField).GetText()
+	prompt := wtf.TextPrompt{Label: "New Todo:", History: "todo"}
 
+	widget.ShowPrompt(prompt, func(text string) {
 		widget.list.Add(false, text)
 		widget.persist()
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
 		widget.display()
-	}
-
-	widget.addButtons(form, saveFctn)
-	widget.modalFocus(form)
-
-	widget.app.QueueUpdate(func() {
-		widget.app.Draw()
 	})
 }
 
@@ -143,7 +117,7 @@ func (widget *Widget) persist() {
 	err := ioutil.WriteFile(filePath, fileData, 0644)
 
 	if err != nil {
-		panic(err)
+		logger.For("todo").Errorf("saving %s: %v", filePath, err)
 	}
 }
 
@@ -155,59 +129,3 @@ func (widget *Widget) setItemChecks() {
 		item.UncheckedIcon = widget.settings.common.Checkbox.Unchecked
 	}
 }
-
-/* -------------------- Modal Form -------------------- */
-
-func (widget *Widget) addButtons(form *tview.Form, saveFctn func()) {
-	widget.addSaveButton(form, saveFctn)
-	widget.addCancelButton(form)
-}
-
-func (widget *Widget) addCancelButton(form *tview.Form) {
-	cancelFn := func() {
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
-		widget.display()
-	}
-
-	form.AddButton("Cancel", cancelFn)
-	form.SetCancelFunc(cancelFn)
-}
-
-func (widget *Widget) addSaveButton(form *tview.Form, fctn func()) {
-	form.AddButton("Save", fctn)
-}
-
-func (widget *Widget) modalFocus(form *tview.Form) {
-	widget.app.QueueUpdateDraw(func() {
-		frame := widget.modalFrame(form)
-		widget.pages.AddPage("modal", frame, false, true)
-		widget.app.SetFocus(frame)
-	})
-}
-
-func (widget *Widget) modalForm(lbl, text string) *tview.Form {
-	form := tview.NewForm().SetFieldBackgroundColor(wtf.ColorFor(widget.settings.common.Colors.Background))
-	form.SetButtonsAlign(tview.AlignCenter).SetButtonTextColor(wtf.ColorFor(widget.settings.common.Colors.Text))
-
-	form.AddInputField(lbl, text, 60, nil, nil)
-
-	return form
-}
-
-func (widget *Widget) modalFrame(form *tview.Form) *tview.Frame {
-	frame := tview.NewFrame(form).SetBorders(0, 0, 0, 0, 0, 0)
-	frame.SetRect(offscreen, offscreen, modalWidth, modalHeight)
-	frame.SetBorder(true)
-	frame.SetBorders(1, 1, 0, 0, 1, 1)
-
-	drawFunc := func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
-		w, h := screen.Size()
-		frame.SetRect((w/2)-(width/2), (h/2)-(height/2), width, height)
-		return x, y, width, height
-	}
-
-	frame.SetDrawFunc(drawFunc)
-
-	return frame
-}
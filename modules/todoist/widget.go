@@ -1,6 +1,8 @@
 package todoist
 
 import (
+	"fmt"
+
 	"github.com/darkSasori/todoist"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/wtf"
@@ -113,16 +115,24 @@ func (w *Widget) Close() {
 	w.Next()
 }
 
-// Delete deletes the currently-selected task in the currently-selected project
+// Delete deletes the currently-selected task in the currently-selected project, once
+// the deletion has been confirmed
 func (w *Widget) Delete() {
-	w.CurrentProject().deleteSelectedTask()
-
-	if w.CurrentProject().isLast() {
-		w.Prev()
+	task := w.CurrentProject().currentTask()
+	if task == nil {
 		return
 	}
 
-	w.Next()
+	w.Confirm(fmt.Sprintf("Delete '%s'?", task.Content), func() {
+		w.CurrentProject().deleteSelectedTask()
+
+		if w.CurrentProject().isLast() {
+			w.Prev()
+			return
+		}
+
+		w.Next()
+	})
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -2,6 +2,7 @@ package transmission
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/hekmon/transmissionrpc"
 	"github.com/rivo/tview"
@@ -64,7 +65,7 @@ func (widget *Widget) Refresh() {
 	torrents, err := widget.Fetch()
 	if err != nil {
 		widget.SetItemCount(0)
-		widget.ScrollableWidget.Redraw(widget.CommonSettings().Title, err.Error(), false)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 
@@ -128,9 +129,10 @@ func (widget *Widget) deleteSelectedTorrent() {
 		DeleteLocalData: false,
 	}
 
-	widget.client.TorrentRemove(removePayload)
-
-	widget.display()
+	widget.Confirm(fmt.Sprintf("Remove '%s' from Transmission?", *currTorrent.Name), func() {
+		widget.client.TorrentRemove(removePayload)
+		widget.display()
+	})
 }
 
 // pauseUnpauseTorrent either pauses or unpauses the downloading and seeding of the selected torrent
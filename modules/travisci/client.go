@@ -8,6 +8,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
+
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 var TRAVIS_HOSTS = map[bool]string{
@@ -20,7 +24,7 @@ func BuildsFor(apiKey string, pro bool) (*Builds, error) {
 
 	travisAPIURL.Host = "api." + TRAVIS_HOSTS[pro]
 
-	resp, err := travisRequest(apiKey, "builds")
+	resp, err := travisRequest(apiKey, &url.URL{Path: "builds"}, 10)
 	if err != nil {
 		return builds, err
 	}
@@ -30,17 +34,46 @@ func BuildsFor(apiKey string, pro bool) (*Builds, error) {
 	return builds, nil
 }
 
+// LatestBuildsFor returns the latest build of each of the repositories, which are given
+// by their slugs, such as "wtfutil/wtf". Repositories that haven't been built are left out
+func LatestBuildsFor(apiKey string, pro bool, repositories []string) (*Builds, error) {
+	builds := &Builds{}
+
+	travisAPIURL.Host = "api." + TRAVIS_HOSTS[pro]
+
+	for _, slug := range repositories {
+		path := &url.URL{
+			Path:    fmt.Sprintf("repo/%s/builds", slug),
+			RawPath: fmt.Sprintf("repo/%s/builds", url.PathEscape(slug)),
+		}
+
+		resp, err := travisRequest(apiKey, path, 1)
+		if err != nil {
+			return builds, err
+		}
+
+		repoBuilds := &Builds{}
+		parseJson(&repoBuilds, resp.Body)
+		resp.Body.Close()
+
+		builds.Builds = append(builds.Builds, repoBuilds.Builds...)
+	}
+
+	return builds, nil
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 var (
 	travisAPIURL = &url.URL{Scheme: "https", Path: "/"}
 )
 
-func travisRequest(apiKey string, path string) (*http.Response, error) {
+func travisRequest(apiKey string, path *url.URL, limit int) (*http.Response, error) {
 	params := url.Values{}
-	params.Add("limit", "10")
+	params.Add("limit", strconv.Itoa(limit))
+	path.RawQuery = params.Encode()
 
-	requestUrl := travisAPIURL.ResolveReference(&url.URL{Path: path, RawQuery: params.Encode()})
+	requestUrl := travisAPIURL.ResolveReference(path)
 
 	req, err := http.NewRequest("GET", requestUrl.String(), nil)
 	req.Header.Add("Accept", "application/json")
@@ -53,14 +86,14 @@ func travisRequest(apiKey string, path string) (*http.Response, error) {
 		return nil, err
 	}
 
-	httpClient := &http.Client{}
+	httpClient := wtf.HTTPClient()
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, fmt.Errorf(resp.Status)
+		return nil, wtf.NewHTTPError(resp)
 	}
 
 	return resp, nil
@@ -69,7 +102,8 @@ func travisRequest(apiKey string, path string) (*http.Response, error) {
 func parseJson(obj interface{}, text io.Reader) {
 	jsonStream, err := ioutil.ReadAll(text)
 	if err != nil {
-		panic(err)
+		logger.For("travisci").Errorf("reading the response: %v", err)
+		return
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(jsonStream))
@@ -78,7 +112,8 @@ func parseJson(obj interface{}, text io.Reader) {
 		if err := decoder.Decode(obj); err == io.EOF {
 			break
 		} else if err != nil {
-			panic(err)
+			logger.For("travisci").Errorf("parsing the response: %v", err)
+			return
 		}
 	}
 }
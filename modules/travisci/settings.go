@@ -5,6 +5,7 @@ import (
 
 	"github.com/olebedev/config"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 const defaultTitle = "TravisCI"
@@ -12,8 +13,9 @@ const defaultTitle = "TravisCI"
 type Settings struct {
 	common *cfg.Common
 
-	apiKey string
-	pro    bool
+	apiKey       string
+	pro          bool
+	repositories []string `help:"The slugs of repositories, such as wtfutil/wtf, to show the latest build of. Without them the widget shows your most recent builds." optional:"true"`
 }
 
 func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
@@ -21,8 +23,9 @@ func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *co
 	settings := Settings{
 		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
 
-		apiKey: ymlConfig.UString("apiKey", os.Getenv("WTF_TRAVIS_API_TOKEN")),
-		pro:    ymlConfig.UBool("pro", false),
+		apiKey:       ymlConfig.UString("apiKey", os.Getenv("WTF_TRAVIS_API_TOKEN")),
+		pro:          ymlConfig.UBool("pro", false),
+		repositories: wtf.ToStrs(ymlConfig.UList("repositories")),
 	}
 
 	return &settings
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -40,10 +41,17 @@ func (widget *Widget) Refresh() {
 		return
 	}
 
-	builds, err := BuildsFor(widget.settings.apiKey, widget.settings.pro)
+	var builds *Builds
+	var err error
+
+	if len(widget.settings.repositories) > 0 {
+		builds, err = LatestBuildsFor(widget.settings.apiKey, widget.settings.pro, widget.settings.repositories)
+	} else {
+		builds, err = BuildsFor(widget.settings.apiKey, widget.settings.pro)
+	}
 
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 		return
 	}
 	widget.builds = builds
@@ -109,6 +117,6 @@ func (widget *Widget) openBuild() {
 	if sel >= 0 && widget.builds != nil && sel < len(widget.builds.Builds) {
 		build := &widget.builds.Builds[sel]
 		travisHost := TRAVIS_HOSTS[widget.settings.pro]
-		wtf.OpenFile(fmt.Sprintf("https://%s/%s/%s/%d", travisHost, build.Repository.Slug, "builds", build.ID))
+		utils.OpenURL(fmt.Sprintf("https://%s/%s/%s/%d", travisHost, build.Repository.Slug, "builds", build.ID))
 	}
 }
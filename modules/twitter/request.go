@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+
+	"github.com/wtfutil/wtf/wtf"
 )
 
 func Request(bearerToken string, apiURL string) ([]byte, error) {
@@ -15,7 +17,7 @@ func Request(bearerToken string, apiURL string) ([]byte, error) {
 	// Expected authorization format for single-application twitter dev accounts
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", bearerToken))
 
-	client := &http.Client{}
+	client := wtf.HTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
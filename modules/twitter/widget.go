@@ -37,6 +37,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 	widget.SetDisplayFunction(widget.display)
 
 	widget.client = NewClient(settings)
+	widget.SetQuotaHost("api.twitter.com")
 
 	widget.View.SetBorderPadding(1, 1, 1, 1)
 	widget.View.SetWrap(true)
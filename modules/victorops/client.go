@@ -2,11 +2,11 @@ package victorops
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 // Fetch gets the current oncall users
@@ -22,27 +22,27 @@ func Fetch(apiID, apiKey string) ([]OnCallTeam, error) {
 func victorOpsRequest(url string, apiID string, apiKey string) ([]OnCallTeam, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		logger.Log(fmt.Sprintf("Failed to initialize sessions to VictorOps. ERROR: %s", err))
+		logger.For("victorops").Errorf("creating the request: %v", err)
 		return nil, err
 	}
 
 	req.Header.Set("X-VO-Api-Id", apiID)
 	req.Header.Set("X-VO-Api-Key", apiKey)
-	client := &http.Client{}
+	client := wtf.HTTPClient()
 
 	resp, err := client.Do(req)
 	if err != nil {
-		logger.Log(fmt.Sprintf("Failed to make request to VictorOps. ERROR: %s", err))
+		logger.For("victorops").Errorf("requesting %s: %v", url, err)
 		return nil, err
 	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("%s", resp.Status)
+		return nil, wtf.NewHTTPError(resp)
 	}
 	defer resp.Body.Close()
 
 	response := &OnCallResponse{}
 	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
-		logger.Log(fmt.Sprintf("Failed to decode JSON response. ERROR: %s", err))
+		logger.For("victorops").Errorf("decoding the response: %v", err)
 		return nil, err
 	}
 
@@ -36,7 +36,7 @@ func (widget *Widget) Refresh() {
 	teams, err := Fetch(widget.settings.apiID, widget.settings.apiKey)
 
 	if err != nil {
-		widget.Redraw(widget.CommonSettings().Title, err.Error(), true)
+		widget.RedrawError(widget.CommonSettings().Title, err)
 	} else {
 		widget.teams = teams
 		widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(widget.teams), true)
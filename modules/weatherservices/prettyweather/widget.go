@@ -34,7 +34,7 @@ func (widget *Widget) Refresh() {
 
 //this method reads the config and calls wttr.in for pretty weather
 func (widget *Widget) prettyWeather() {
-	client := &http.Client{}
+	client := wtf.HTTPClient()
 
 	city := widget.settings.city
 	unit := widget.settings.unit
@@ -65,6 +65,7 @@ func (widget *Widget) Refresh() {
 		widget.Data = widget.Fetch(wtf.ToInts(widget.settings.cityIDs))
 	}
 
+	widget.publishWeather()
 	widget.display()
 }
 
@@ -98,6 +99,23 @@ func (widget *Widget) currentData() *owm.CurrentWeatherData {
 	return widget.Data[widget.Idx]
 }
 
+// publishWeather puts the first city's weather on the data bus as "city",
+// "temperature", and "conditions"
+func (widget *Widget) publishWeather() {
+	if len(widget.Data) == 0 {
+		return
+	}
+
+	cityData := widget.Data[0]
+
+	widget.PublishData("city", cityData.Name)
+	widget.PublishData("temperature", cityData.Main.Temp)
+
+	if len(cityData.Weather) > 0 {
+		widget.PublishData("conditions", cityData.Weather[0].Description)
+	}
+}
+
 func (widget *Widget) currentWeather(cityCode int) (*owm.CurrentWeatherData, error) {
 	weather, err := owm.NewCurrent(
 		widget.settings.tempUnit,
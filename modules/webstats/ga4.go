@@ -0,0 +1,146 @@
+package webstats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	ga4APIURL = "https://analyticsdata.googleapis.com/v1beta/properties/"
+	ga4Scope  = "https://www.googleapis.com/auth/analytics.readonly"
+)
+
+type ga4Metric struct {
+	Name string `json:"name"`
+}
+
+type ga4Report struct {
+	Rows []struct {
+		DimensionValues []struct {
+			Value string `json:"value"`
+		} `json:"dimensionValues"`
+		MetricValues []struct {
+			Value string `json:"value"`
+		} `json:"metricValues"`
+	} `json:"rows"`
+}
+
+// ga4Stats fetches the property's active users in the last 30 minutes, today's views,
+// and today's most viewed pages from the Google Analytics Data API
+func (widget *Widget) ga4Stats(s site) (Stats, error) {
+	stats := Stats{Site: s}
+
+	client, err := widget.ga4HTTPClient()
+	if err != nil {
+		return stats, err
+	}
+
+	realtime := ga4Report{}
+	err = widget.ga4Post(client, s.id, "runRealtimeReport", map[string]interface{}{
+		"metrics": []ga4Metric{{Name: "activeUsers"}},
+	}, &realtime)
+	if err != nil {
+		return stats, err
+	}
+	stats.Visitors = realtime.firstMetric()
+
+	today := []map[string]string{{"startDate": "today", "endDate": "today"}}
+
+	total := ga4Report{}
+	err = widget.ga4Post(client, s.id, "runReport", map[string]interface{}{
+		"dateRanges": today,
+		"metrics":    []ga4Metric{{Name: "screenPageViews"}},
+	}, &total)
+	if err != nil {
+		return stats, err
+	}
+	stats.Pageviews = total.firstMetric()
+
+	pages := ga4Report{}
+	err = widget.ga4Post(client, s.id, "runReport", map[string]interface{}{
+		"dateRanges": today,
+		"dimensions": []ga4Metric{{Name: "pagePath"}},
+		"metrics":    []ga4Metric{{Name: "screenPageViews"}},
+		"orderBys": []map[string]interface{}{
+			{"metric": map[string]string{"metricName": "screenPageViews"}, "desc": true},
+		},
+		"limit": widget.settings.topPages,
+	}, &pages)
+	if err != nil {
+		return stats, err
+	}
+
+	for _, row := range pages.Rows {
+		if len(row.DimensionValues) == 0 || len(row.MetricValues) == 0 {
+			continue
+		}
+
+		views, _ := strconv.Atoi(row.MetricValues[0].Value)
+		stats.TopPages = append(stats.TopPages, Page{Path: row.DimensionValues[0].Value, Pageviews: views})
+	}
+
+	return stats, nil
+}
+
+// ga4HTTPClient returns a client that signs its requests in as the service account,
+// which it creates from the secret file the first time it's needed
+func (widget *Widget) ga4HTTPClient() (*http.Client, error) {
+	if widget.ga4Client != nil {
+		return widget.ga4Client, nil
+	}
+
+	secretPath, err := utils.ExpandHomeDir(widget.settings.secretFile)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := ioutil.ReadFile(secretPath)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtConfig, err := google.JWTConfigFromJSON(secret, ga4Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, wtf.HTTPClient())
+	widget.ga4Client = jwtConfig.Client(ctx)
+
+	return widget.ga4Client, nil
+}
+
+func (widget *Widget) ga4Post(client *http.Client, propertyID, method string, body interface{}, result interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s%s:%s", ga4APIURL, propertyID, method), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	return getJSON(client, req, result)
+}
+
+// firstMetric returns the first value of the report's first row, which is the total for
+// reports without dimensions
+func (report ga4Report) firstMetric() int {
+	if len(report.Rows) == 0 || len(report.Rows[0].MetricValues) == 0 {
+		return 0
+	}
+
+	value, _ := strconv.Atoi(report.Rows[0].MetricValues[0].Value)
+	return value
+}
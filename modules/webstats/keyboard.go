@@ -0,0 +1,16 @@
+package webstats
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next page")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous page")
+	widget.SetKeyboardChar("o", widget.openPage, "Open page in browser")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next page")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous page")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openPage, "Open page in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,69 @@
+package webstats
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// plausibleStats fetches the site's current visitors, today's pageviews, and today's
+// most viewed pages from the Plausible Stats API
+func (widget *Widget) plausibleStats(s site) (Stats, error) {
+	stats := Stats{Site: s}
+
+	params := url.Values{}
+	params.Set("site_id", s.id)
+
+	if err := widget.plausibleGet("/api/v1/stats/realtime/visitors", params, &stats.Visitors); err != nil {
+		return stats, err
+	}
+
+	params.Set("period", "day")
+	params.Set("metrics", "pageviews")
+
+	aggregate := struct {
+		Results struct {
+			Pageviews struct {
+				Value int `json:"value"`
+			} `json:"pageviews"`
+		} `json:"results"`
+	}{}
+
+	if err := widget.plausibleGet("/api/v1/stats/aggregate", params, &aggregate); err != nil {
+		return stats, err
+	}
+	stats.Pageviews = aggregate.Results.Pageviews.Value
+
+	params.Set("property", "event:page")
+	params.Set("limit", strconv.Itoa(widget.settings.topPages))
+
+	breakdown := struct {
+		Results []struct {
+			Page      string `json:"page"`
+			Pageviews int    `json:"pageviews"`
+		} `json:"results"`
+	}{}
+
+	if err := widget.plausibleGet("/api/v1/stats/breakdown", params, &breakdown); err != nil {
+		return stats, err
+	}
+
+	for _, result := range breakdown.Results {
+		stats.TopPages = append(stats.TopPages, Page{Path: result.Page, Pageviews: result.Pageviews})
+	}
+
+	return stats, nil
+}
+
+func (widget *Widget) plausibleGet(path string, params url.Values, result interface{}) error {
+	req, err := http.NewRequest("GET", widget.settings.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+widget.settings.apiKey)
+
+	return getJSON(wtf.HTTPClient(), req, result)
+}
@@ -0,0 +1,87 @@
+package webstats
+
+import (
+	"os"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "Web Stats"
+
+const (
+	ga4       = "ga4"
+	plausible = "plausible"
+)
+
+// site is a website whose stats are shown: a Plausible site ID, which is its domain, or
+// a GA4 property ID
+type site struct {
+	id   string
+	name string
+	url  string
+}
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey     string `help:"Your Plausible API key." values:"Falls back to WTF_PLAUSIBLE_TOKEN." optional:"true"`
+	baseURL    string `help:"The address of your Plausible server, if it's self-hosted." optional:"true" default:"https://plausible.io"`
+	secretFile string `help:"The JSON key file of the Google service account that can read your GA4 properties." optional:"true"`
+	service    string `help:"Where the stats come from." values:"plausible or ga4" optional:"true" default:"plausible"`
+	sites      []site `help:"The sites to show. Each is a Plausible site's domain or a GA4 property ID, or a map with an id, a name, and the url that its pages are opened at."`
+	topPages   int    `help:"How many of each site's most viewed pages today to show." optional:"true" default:"5"`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+	service := ymlConfig.UString("service", plausible)
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:     ymlConfig.UString("apiKey", os.Getenv("WTF_PLAUSIBLE_TOKEN")),
+		baseURL:    strings.TrimSuffix(ymlConfig.UString("baseURL", "https://plausible.io"), "/"),
+		secretFile: ymlConfig.UString("secretFile", ""),
+		service:    service,
+		sites:      parseSites(ymlConfig, service),
+		topPages:   ymlConfig.UInt("topPages", 5),
+	}
+
+	return &settings
+}
+
+// parseSites reads the sites, each of which is an ID or a map with an id, a name, and a
+// url. Plausible sites are named after and found at their domain unless told otherwise
+func parseSites(ymlConfig *config.Config, service string) []site {
+	sites := []site{}
+
+	for _, value := range ymlConfig.UList("sites") {
+		s := site{}
+
+		if id, ok := value.(string); ok {
+			s.id = id
+		} else {
+			siteConfig := &config.Config{Root: value}
+
+			s.id = siteConfig.UString("id")
+			s.name = siteConfig.UString("name")
+			s.url = strings.TrimSuffix(siteConfig.UString("url"), "/")
+		}
+
+		if s.id == "" {
+			continue
+		}
+
+		if s.name == "" {
+			s.name = s.id
+		}
+		if s.url == "" && service == plausible {
+			s.url = "https://" + s.id
+		}
+
+		sites = append(sites, s)
+	}
+
+	return sites
+}
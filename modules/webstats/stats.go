@@ -0,0 +1,43 @@
+package webstats
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// Page is one of a site's most viewed pages today
+type Page struct {
+	Path      string
+	Pageviews int
+	URL       string
+}
+
+// Stats are a site's visitors right now and its pageviews today
+type Stats struct {
+	Pageviews int
+	Site      site
+	TopPages  []Page
+	Visitors  int
+}
+
+// getJSON sends the request with the given HTTP client and decodes the response
+func getJSON(client *http.Client, req *http.Request, result interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,176 @@
+package webstats
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dustin/go-humanize"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows the current visitors, today's pageviews, and today's most viewed pages
+// of each site, from Plausible or Google Analytics 4
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	ga4Client *http.Client
+	pages     []Page
+	settings  *Settings
+	stats     []Stats
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.SetQuotaHost(quotaHost(settings))
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the stats of every site. Sites whose stats can't be fetched are left
+// out
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	allStats := []Stats{}
+	pages := []Page{}
+	var lastErr error
+
+	for _, s := range widget.settings.sites {
+		stats, err := widget.fetchStats(s)
+		if err != nil {
+			logger.For(widget.Name()).Warnf("fetching the stats of %s: %v", s.name, err)
+			lastErr = err
+			continue
+		}
+
+		for idx := range stats.TopPages {
+			if s.url != "" {
+				stats.TopPages[idx].URL = s.url + stats.TopPages[idx].Path
+			}
+		}
+
+		allStats = append(allStats, stats)
+		pages = append(pages, stats.TopPages...)
+	}
+
+	if len(allStats) == 0 && lastErr != nil {
+		widget.RedrawError(widget.CommonSettings().Title, lastErr)
+		return
+	}
+
+	widget.stats = allStats
+	widget.pages = pages
+	widget.SetItemCount(len(pages))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.stats) == 0 {
+		return " [gray]No sites to show[white]"
+	}
+
+	str := ""
+	idx := 0
+
+	for _, stats := range widget.stats {
+		str += fmt.Sprintf(
+			" [green]%s[white]  %s now, %s pageviews today\n",
+			tview.Escape(stats.Site.name),
+			humanize.Comma(int64(stats.Visitors)),
+			humanize.Comma(int64(stats.Pageviews)),
+		)
+
+		for _, page := range stats.TopPages {
+			row := fmt.Sprintf(
+				"[%s]   %6s  %s",
+				widget.RowColor(idx),
+				humanize.Comma(int64(page.Pageviews)),
+				tview.Escape(page.Path),
+			)
+
+			str += wtf.HighlightableHelper(widget.View, row, idx, len(page.Path)+9)
+			idx++
+		}
+	}
+
+	return str
+}
+
+// fetchStats fetches the site's stats from the service the widget is set up for
+func (widget *Widget) fetchStats(s site) (Stats, error) {
+	switch widget.settings.service {
+	case plausible:
+		return widget.plausibleStats(s)
+	case ga4:
+		return widget.ga4Stats(s)
+	default:
+		return Stats{}, fmt.Errorf("unknown service %q, which should be plausible or ga4", widget.settings.service)
+	}
+}
+
+func (widget *Widget) openPage() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.pages) && widget.pages[sel].URL != "" {
+		utils.OpenURL(widget.pages[sel].URL)
+	}
+}
+
+// rowFor describes the page at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	page := widget.pages[idx]
+
+	return wtf.Row{
+		ID:   page.Path,
+		Text: fmt.Sprintf("%s (%d pageviews today)", page.Path, page.Pageviews),
+		URL:  page.URL,
+	}
+}
+
+// quotaHost returns the API host that the service's requests count against
+func quotaHost(settings *Settings) string {
+	if settings.service == ga4 {
+		return "analyticsdata.googleapis.com"
+	}
+
+	parsed, err := url.Parse(settings.baseURL)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Hostname()
+}
@@ -0,0 +1,17 @@
+package whatsnew
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.BindSearch(&widget.ScrollableWidget)
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next tool")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous tool")
+	widget.SetKeyboardChar("o", widget.openNotes, "Open release notes in browser")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next tool")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous tool")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openNotes, "Open release notes in browser")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
@@ -0,0 +1,73 @@
+package whatsnew
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultTitle = "What's New"
+
+// tool is a tool the user follows and the feed its release notes are published in
+type tool struct {
+	feed string
+	name string
+}
+
+type Settings struct {
+	common *cfg.Common
+
+	tools []tool `help:"The tools to follow. Each is a GitHub repository, such as wtfutil/wtf, or a map with a name and either a repo or the URL of a release notes feed."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		tools: parseTools(ymlConfig),
+	}
+
+	return &settings
+}
+
+// parseTools reads the tools, each of which is a repository, such as "golang/go", or a
+// map with a name and either a repo or a feed. Tools named by their repository alone
+// are shown under the repository's name
+func parseTools(ymlConfig *config.Config) []tool {
+	tools := []tool{}
+
+	for _, value := range ymlConfig.UList("tools") {
+		if repo, ok := value.(string); ok {
+			tools = append(tools, tool{feed: releasesFeed(repo), name: repo})
+			continue
+		}
+
+		toolConfig := &config.Config{Root: value}
+
+		t := tool{
+			feed: toolConfig.UString("feed"),
+			name: toolConfig.UString("name"),
+		}
+
+		if repo := toolConfig.UString("repo"); repo != "" {
+			t.feed = releasesFeed(repo)
+			if t.name == "" {
+				t.name = repo
+			}
+		}
+
+		if t.feed != "" && t.name != "" {
+			tools = append(tools, t)
+		}
+	}
+
+	return tools
+}
+
+// releasesFeed returns the address of the Atom feed of the repository's GitHub releases
+func releasesFeed(repo string) string {
+	return fmt.Sprintf("https://github.com/%s/releases.atom", strings.Trim(repo, "/"))
+}
@@ -0,0 +1,246 @@
+package whatsnew
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+	"golang.org/x/net/html"
+)
+
+// headlineTags are the elements of release notes that a headline is taken from, the
+// first of which in the notes is the headline
+var headlineTags = map[string]bool{"h1": true, "h2": true, "h3": true, "h4": true, "li": true, "p": true}
+
+// Note is the latest release of a followed tool
+type Note struct {
+	Headline  string
+	Published time.Time
+	Title     string
+	Tool      string
+	URL       string
+}
+
+// A Widget shows the headline of the latest release notes of each followed tool
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	notes    []Note
+	parser   *gofeed.Parser
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		parser:   gofeed.NewParser(),
+		settings: settings,
+	}
+
+	widget.parser.Client = wtf.HTTPClient()
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches the release notes of every tool. Tools whose feed can't be fetched,
+// or that haven't published anything, are left out
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	notes := []Note{}
+	var lastErr error
+
+	for _, t := range widget.settings.tools {
+		note, err := widget.latestNote(t)
+		if err != nil {
+			logger.For(widget.Name()).Warnf("fetching the release notes of %s: %v", t.name, err)
+			lastErr = err
+			continue
+		}
+
+		notes = append(notes, note)
+	}
+
+	if len(notes) == 0 && lastErr != nil {
+		widget.RedrawError(widget.CommonSettings().Title, lastErr)
+		return
+	}
+
+	widget.notes = notes
+	widget.SetItemCount(len(notes))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the notes so that their publish times stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.notes) == 0 {
+		return " [gray]No release notes[white]"
+	}
+
+	str := ""
+	for idx, note := range widget.notes {
+		row := fmt.Sprintf(
+			"[%s] [green]%s[%s] %s",
+			widget.RowColor(idx),
+			tview.Escape(note.Tool),
+			widget.RowColor(idx),
+			tview.Escape(note.Title),
+		)
+
+		if note.Headline != "" && note.Headline != note.Title {
+			row += fmt.Sprintf(": %s", tview.Escape(note.Headline))
+		}
+
+		row += fmt.Sprintf(" [gray]%s[white]", wtf.RelativeTime(note.Published))
+
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(note.Tool))
+	}
+
+	return str
+}
+
+// latestNote fetches the tool's feed and returns its newest entry
+func (widget *Widget) latestNote(t tool) (Note, error) {
+	note := Note{Tool: t.name}
+
+	feed, err := widget.parser.ParseURL(t.feed)
+	if err != nil {
+		return note, err
+	}
+
+	var latest *gofeed.Item
+	for _, item := range feed.Items {
+		if latest == nil || publishedAt(item).After(publishedAt(latest)) {
+			latest = item
+		}
+	}
+
+	if latest == nil {
+		return note, fmt.Errorf("%s has no release notes", t.name)
+	}
+
+	note.Headline = headline(latest)
+	note.Published = publishedAt(latest)
+	note.Title = strings.TrimSpace(latest.Title)
+	note.URL = latest.Link
+
+	return note, nil
+}
+
+func (widget *Widget) openNotes() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.notes) {
+		utils.OpenURL(widget.notes[sel].URL)
+	}
+}
+
+// rowFor describes the note at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	note := widget.notes[idx]
+
+	return wtf.Row{
+		ID:   note.Tool,
+		Text: note.Title,
+		URL:  note.URL,
+	}
+}
+
+// headline returns the first heading, paragraph, or list item of the release notes, or
+// their first line if they aren't HTML
+func headline(item *gofeed.Item) string {
+	content := item.Content
+	if content == "" {
+		content = item.Description
+	}
+
+	all := ""
+	element := ""
+	text := ""
+
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return firstLine(all)
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if element == "" && headlineTags[string(name)] {
+				element = string(name)
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if string(name) == element {
+				if line := firstLine(text); line != "" {
+					return line
+				}
+				element, text = "", ""
+			}
+		case html.TextToken:
+			data := string(tokenizer.Text())
+			all += data
+			if element != "" {
+				text += data
+			}
+		}
+	}
+}
+
+// firstLine returns the first line of the text that isn't blank
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
+
+// publishedAt returns when the item was published or, failing that, last updated
+func publishedAt(item *gofeed.Item) time.Time {
+	if item.PublishedParsed != nil {
+		return *item.PublishedParsed
+	}
+
+	if item.UpdatedParsed != nil {
+		return *item.UpdatedParsed
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,181 @@
+package youtube
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const apiURL = "https://www.googleapis.com/youtube/v3/"
+
+// Channel is a YouTube channel and the latest video uploaded to it
+type Channel struct {
+	ID              string
+	Latest          *Video
+	SubscriberCount int64
+	SubscribersHid  bool
+	Title           string
+	ViewCount       int64
+}
+
+// Video is a video and how many times it has been watched, liked, and commented on
+type Video struct {
+	CommentCount int64
+	ID           string
+	LikeCount    int64
+	Published    time.Time
+	Title        string
+	ViewCount    int64
+}
+
+type channelList struct {
+	Items []struct {
+		ContentDetails struct {
+			RelatedPlaylists struct {
+				Uploads string `json:"uploads"`
+			} `json:"relatedPlaylists"`
+		} `json:"contentDetails"`
+		ID      string `json:"id"`
+		Snippet struct {
+			Title string `json:"title"`
+		} `json:"snippet"`
+		Statistics struct {
+			HiddenSubscriberCount bool  `json:"hiddenSubscriberCount"`
+			SubscriberCount       int64 `json:"subscriberCount,string"`
+			ViewCount             int64 `json:"viewCount,string"`
+		} `json:"statistics"`
+	} `json:"items"`
+}
+
+type videoList struct {
+	Items []struct {
+		ID      string `json:"id"`
+		Snippet struct {
+			PublishedAt time.Time `json:"publishedAt"`
+			Title       string    `json:"title"`
+		} `json:"snippet"`
+		Statistics struct {
+			CommentCount int64 `json:"commentCount,string"`
+			LikeCount    int64 `json:"likeCount,string"`
+			ViewCount    int64 `json:"viewCount,string"`
+		} `json:"statistics"`
+	} `json:"items"`
+}
+
+// fetchChannel fetches the channel's statistics and the latest video in its uploads. The
+// channel is its ID or, starting with an @, its handle
+func (widget *Widget) fetchChannel(channel string) (*Channel, error) {
+	params := url.Values{}
+	params.Set("part", "contentDetails,snippet,statistics")
+	if strings.HasPrefix(channel, "@") {
+		params.Set("forHandle", channel)
+	} else {
+		params.Set("id", channel)
+	}
+
+	channels := channelList{}
+	if err := widget.get("channels", params, &channels); err != nil {
+		return nil, err
+	}
+
+	if len(channels.Items) == 0 {
+		return nil, errors.New("no such channel")
+	}
+
+	item := channels.Items[0]
+	result := &Channel{
+		ID:              item.ID,
+		SubscriberCount: item.Statistics.SubscriberCount,
+		SubscribersHid:  item.Statistics.HiddenSubscriberCount,
+		Title:           item.Snippet.Title,
+		ViewCount:       item.Statistics.ViewCount,
+	}
+
+	latest, err := widget.latestVideo(item.ContentDetails.RelatedPlaylists.Uploads)
+	if err != nil {
+		return nil, err
+	}
+	result.Latest = latest
+
+	return result, nil
+}
+
+// latestVideo fetches the statistics of the newest video in the uploads playlist, which
+// is nil for channels that haven't uploaded anything
+func (widget *Widget) latestVideo(playlistID string) (*Video, error) {
+	if playlistID == "" {
+		return nil, nil
+	}
+
+	params := url.Values{}
+	params.Set("part", "contentDetails")
+	params.Set("playlistId", playlistID)
+	params.Set("maxResults", "1")
+
+	playlist := struct {
+		Items []struct {
+			ContentDetails struct {
+				VideoID string `json:"videoId"`
+			} `json:"contentDetails"`
+		} `json:"items"`
+	}{}
+
+	if err := widget.get("playlistItems", params, &playlist); err != nil {
+		return nil, err
+	}
+
+	if len(playlist.Items) == 0 {
+		return nil, nil
+	}
+
+	params = url.Values{}
+	params.Set("part", "snippet,statistics")
+	params.Set("id", playlist.Items[0].ContentDetails.VideoID)
+
+	videos := videoList{}
+	if err := widget.get("videos", params, &videos); err != nil {
+		return nil, err
+	}
+
+	if len(videos.Items) == 0 {
+		return nil, nil
+	}
+
+	item := videos.Items[0]
+
+	return &Video{
+		CommentCount: item.Statistics.CommentCount,
+		ID:           item.ID,
+		LikeCount:    item.Statistics.LikeCount,
+		Published:    item.Snippet.PublishedAt,
+		Title:        item.Snippet.Title,
+		ViewCount:    item.Statistics.ViewCount,
+	}, nil
+}
+
+func (widget *Widget) get(resource string, params url.Values, result interface{}) error {
+	req, err := http.NewRequest("GET", apiURL+resource+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Goog-Api-Key", widget.settings.apiKey)
+
+	resp, err := wtf.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return wtf.NewHTTPError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
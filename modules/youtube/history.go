@@ -0,0 +1,104 @@
+package youtube
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// sampleInterval is how often a channel's view count is recorded
+	sampleInterval = time.Hour
+
+	// viewsWindow is how far back the views gained are counted from
+	viewsWindow = 48 * time.Hour
+)
+
+// sample is a channel's total view count at a given time
+type sample struct {
+	Time  time.Time `yaml:"time"`
+	Views int64     `yaml:"views"`
+}
+
+// History persists hourly samples of each channel's view count to disk, because the
+// YouTube Data API only gives channels' all-time totals, so that the views gained in
+// the last 48 hours can be worked out across restarts. It is keyed by channel ID
+type History struct {
+	filePath string
+	Samples  map[string][]sample `yaml:"samples"`
+}
+
+// NewHistory loads the history file for the named widget from its cache directory
+func NewHistory(name string) *History {
+	history := History{
+		Samples: map[string][]sample{},
+	}
+
+	cacheDir, err := cfg.CacheDirFor(name)
+	if err != nil {
+		return &history
+	}
+
+	history.filePath = filepath.Join(cacheDir, "history.yml")
+
+	fileData, err := wtf.ReadFileBytes(history.filePath)
+	if err == nil {
+		yaml.Unmarshal(fileData, &history)
+	}
+
+	if history.Samples == nil {
+		history.Samples = map[string][]sample{}
+	}
+
+	return &history
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Record stores the channel's view count, if an hour has passed since the last one was
+// stored, and discards the samples that are no longer needed to look back 48 hours
+func (history *History) Record(channelID string, views int64, now time.Time) {
+	samples := history.Samples[channelID]
+
+	if len(samples) == 0 || now.Sub(samples[len(samples)-1].Time) >= sampleInterval {
+		samples = append(samples, sample{Time: now, Views: views})
+	}
+
+	// Keep the newest sample from before the window, which the views gained are counted from
+	cutoff := now.Add(-viewsWindow)
+	for len(samples) > 1 && !samples[1].Time.After(cutoff) {
+		samples = samples[1:]
+	}
+
+	history.Samples[channelID] = samples
+}
+
+// ViewsGained returns how many views the channel has gained since the newest sample from
+// 48 hours ago or earlier, or since the oldest sample if it's younger than that, and the
+// time of that sample. It returns false if there's nothing to count from
+func (history *History) ViewsGained(channelID string, views int64, now time.Time) (int64, time.Time, bool) {
+	samples := history.Samples[channelID]
+	if len(samples) == 0 || !samples[0].Time.Before(now) {
+		return 0, time.Time{}, false
+	}
+
+	return views - samples[0].Views, samples[0].Time, true
+}
+
+// Save writes the history to disk
+func (history *History) Save() error {
+	if history.filePath == "" {
+		return nil
+	}
+
+	fileData, err := yaml.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(history.filePath, fileData, 0644)
+}
@@ -0,0 +1,17 @@
+package youtube
+
+import "github.com/gdamore/tcell"
+
+func (widget *Widget) initializeKeyboardControls() {
+	widget.SetKeyboardChar("r", widget.Refresh, "Refresh widget")
+	widget.SetKeyboardChar("j", widget.Next, "Select next channel")
+	widget.SetKeyboardChar("k", widget.Prev, "Select previous channel")
+	widget.SetKeyboardChar("o", widget.openLatestVideo, "Open the channel's latest video")
+	widget.SetKeyboardChar("c", widget.openChannel, "Open the channel")
+	widget.BindRowActions(&widget.ScrollableWidget)
+
+	widget.SetKeyboardKey(tcell.KeyDown, widget.Next, "Select next channel")
+	widget.SetKeyboardKey(tcell.KeyUp, widget.Prev, "Select previous channel")
+	widget.SetKeyboardKey(tcell.KeyEnter, widget.openLatestVideo, "Open the channel's latest video")
+	widget.SetKeyboardKey(tcell.KeyEsc, widget.Unselect, "Clear selection")
+}
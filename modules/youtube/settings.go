@@ -0,0 +1,30 @@
+package youtube
+
+import (
+	"os"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+const defaultTitle = "YouTube"
+
+type Settings struct {
+	common *cfg.Common
+
+	apiKey   string   `help:"Your YouTube Data API key." values:"Falls back to WTF_YOUTUBE_API_KEY."`
+	channels []string `help:"The channels to show, each a channel ID, such as UC_x5XG1OV2P6uZZ5FSM9Ttw, or a handle, such as @GoogleDevelopers."`
+}
+
+func NewSettingsFromYAML(name string, ymlConfig *config.Config, globalConfig *config.Config) *Settings {
+
+	settings := Settings{
+		common: cfg.NewCommonSettingsFromModule(name, defaultTitle, ymlConfig, globalConfig),
+
+		apiKey:   ymlConfig.UString("apiKey", os.Getenv("WTF_YOUTUBE_API_KEY")),
+		channels: wtf.ToStrs(ymlConfig.UList("channels")),
+	}
+
+	return &settings
+}
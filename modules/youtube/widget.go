@@ -0,0 +1,182 @@
+package youtube
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+	"github.com/wtfutil/wtf/wtf"
+)
+
+// A Widget shows the subscribers of each YouTube channel, the views it has gained in
+// the last 48 hours, and how its latest video is doing
+type Widget struct {
+	wtf.KeyboardWidget
+	wtf.ScrollableWidget
+
+	channels []*Channel
+	history  *History
+	settings *Settings
+}
+
+// NewWidget creates a new instance of a widget
+func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *Widget {
+	widget := Widget{
+		KeyboardWidget:   wtf.NewKeyboardWidget(app, pages, settings.common),
+		ScrollableWidget: wtf.NewScrollableWidget(app, settings.common, true),
+
+		settings: settings,
+	}
+
+	widget.history = NewHistory(widget.Name())
+
+	widget.SetQuotaHost("www.googleapis.com")
+
+	widget.SetRenderFunction(widget.Render)
+	widget.SetRowFunction(widget.rowFor)
+	widget.initializeKeyboardControls()
+	widget.View.SetInputCapture(widget.InputCapture)
+
+	widget.KeyboardWidget.SetView(widget.View)
+
+	return &widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+func (widget *Widget) HelpText() string {
+	return widget.KeyboardWidget.HelpText()
+}
+
+// Refresh fetches every channel and records its view count. Channels that can't be
+// fetched are left out
+func (widget *Widget) Refresh() {
+	if widget.Disabled() {
+		return
+	}
+
+	channels := []*Channel{}
+	var lastErr error
+	now := time.Now()
+
+	for _, name := range widget.settings.channels {
+		channel, err := widget.fetchChannel(name)
+		if err != nil {
+			logger.For(widget.Name()).Warnf("fetching the channel %s: %v", name, err)
+			lastErr = err
+			continue
+		}
+
+		widget.history.Record(channel.ID, channel.ViewCount, now)
+		channels = append(channels, channel)
+	}
+
+	if len(channels) == 0 && lastErr != nil {
+		widget.RedrawError(widget.CommonSettings().Title, lastErr)
+		return
+	}
+
+	if err := widget.history.Save(); err != nil {
+		logger.For(widget.Name()).Warnf("saving the view history: %v", err)
+	}
+
+	widget.channels = channels
+	widget.SetItemCount(len(channels))
+
+	widget.Render()
+}
+
+// Render sets up the widget data for redrawing to the screen
+func (widget *Widget) Render() {
+	widget.Redraw(widget.CommonSettings().Title, widget.contentFrom(), false)
+}
+
+// RenderRelativeTimes redraws the channels so that their latest videos' ages stay current
+func (widget *Widget) RenderRelativeTimes() {
+	widget.Render()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (widget *Widget) contentFrom() string {
+	if len(widget.channels) == 0 {
+		return " [gray]No channels to show[white]"
+	}
+
+	str := ""
+	now := time.Now()
+
+	for idx, channel := range widget.channels {
+		subscribers := "hidden"
+		if !channel.SubscribersHid {
+			subscribers = humanize.Comma(channel.SubscriberCount)
+		}
+
+		row := fmt.Sprintf(
+			"[%s] %s [gray]%s subscribers[white]",
+			widget.RowColor(idx),
+			tview.Escape(channel.Title),
+			subscribers,
+		)
+		str += wtf.HighlightableHelper(widget.View, row, idx, len(channel.Title))
+
+		gained, since, ok := widget.history.ViewsGained(channel.ID, channel.ViewCount, now)
+		switch {
+		case !ok:
+			str += "   [gray]counting views from now on[white]\n"
+		case now.Sub(since) < viewsWindow:
+			str += fmt.Sprintf("   +%s views, counted from %s\n", humanize.Comma(gained), wtf.RelativeTime(since))
+		default:
+			str += fmt.Sprintf("   +%s views in the last 48h\n", humanize.Comma(gained))
+		}
+
+		if video := channel.Latest; video != nil {
+			str += fmt.Sprintf(
+				"   [yellow]%s[white] [gray]%s[white]\n   %s views, %s likes, %s comments\n",
+				tview.Escape(video.Title),
+				wtf.RelativeTime(video.Published),
+				humanize.Comma(video.ViewCount),
+				humanize.Comma(video.LikeCount),
+				humanize.Comma(video.CommentCount),
+			)
+		}
+	}
+
+	return str
+}
+
+func (widget *Widget) openChannel() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.channels) {
+		utils.OpenURL(channelURL(widget.channels[sel]))
+	}
+}
+
+func (widget *Widget) openLatestVideo() {
+	sel := widget.GetSelected()
+	if sel >= 0 && sel < len(widget.channels) && widget.channels[sel].Latest != nil {
+		utils.OpenURL(videoURL(widget.channels[sel].Latest))
+	}
+}
+
+// rowFor describes the channel at the given index for the actions menu
+func (widget *Widget) rowFor(idx int) wtf.Row {
+	channel := widget.channels[idx]
+
+	return wtf.Row{
+		ID:   channel.ID,
+		Text: channel.Title,
+		URL:  channelURL(channel),
+	}
+}
+
+func channelURL(channel *Channel) string {
+	return "https://www.youtube.com/channel/" + channel.ID
+}
+
+func videoURL(video *Video) string {
+	return "https://www.youtube.com/watch?v=" + video.ID
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
+
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/wtf"
 )
 
 type Resource struct {
@@ -15,41 +17,64 @@ type Resource struct {
 
 func errHandler(err error) {
 	if err != nil {
-		log.Print(err)
+		logger.For("zendesk").Errorf("%v", err)
 	}
 }
 
-func (widget *Widget) api(meth string, path string, params string) (*Resource, error) {
-	trn := &http.Transport{}
-
-	client := &http.Client{
-		Transport: trn,
-	}
+// Client makes requests to the Zendesk API of a subdomain, signed in with an API token.
+// It's shared by the modules that show Zendesk tickets
+type Client struct {
+	APIKey    string
+	Subdomain string
+	Username  string
+}
 
-	baseURL := fmt.Sprintf("https://%v.zendesk.com/api/v2", widget.settings.subdomain)
-	URL := baseURL + "/tickets.json?sort_by=status"
+// Do makes an authenticated request to the path under the API's /api/v2 and returns the
+// body of the response
+func (client *Client) Do(meth string, path string, params string) ([]byte, error) {
+	URL := fmt.Sprintf("https://%v.zendesk.com/api/v2%s", client.Subdomain, path)
 
 	req, err := http.NewRequest(meth, URL, bytes.NewBufferString(params))
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 
-	apiUser := fmt.Sprintf("%v/token", widget.settings.username)
-	req.SetBasicAuth(apiUser, widget.settings.apiKey)
+	apiUser := fmt.Sprintf("%v/token", client.Username)
+	req.SetBasicAuth(apiUser, client.APIKey)
 
-	resp, err := client.Do(req)
+	resp, err := wtf.HTTPClient().Do(req)
 	if err != nil {
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	data, err := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, wtf.NewHTTPError(resp)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// TicketURL returns the address of the ticket in Zendesk's agent interface
+func (client *Client) TicketURL(id int64) string {
+	return fmt.Sprintf("https://%s.zendesk.com/agent/tickets/%d", client.Subdomain, id)
+}
+
+func (widget *Widget) api(meth string, path string, params string) (*Resource, error) {
+	client := Client{
+		APIKey:    widget.settings.apiKey,
+		Subdomain: widget.settings.subdomain,
+		Username:  widget.settings.username,
+	}
+
+	data, err := client.Do(meth, "/tickets.json?sort_by=status", params)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Resource{Response: &resp, Raw: string(data)}, nil
+	return &Resource{Response: data, Raw: string(data)}, nil
 }
@@ -2,9 +2,9 @@ package zendesk
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
 	"github.com/wtfutil/wtf/wtf"
 )
 
@@ -39,13 +39,14 @@ func NewWidget(app *tview.Application, pages *tview.Pages, settings *Settings) *
 
 func (widget *Widget) Refresh() {
 	ticketArray, err := widget.newTickets()
-	ticketArray.Count = len(ticketArray.Tickets)
 	if err != nil {
-		log.Fatal(err)
-	} else {
-		widget.result = ticketArray
+		widget.RedrawError(widget.CommonSettings().Title, err)
+		return
 	}
 
+	ticketArray.Count = len(ticketArray.Tickets)
+	widget.result = ticketArray
+
 	widget.Render()
 }
 
@@ -98,6 +99,6 @@ func (widget *Widget) openTicket() {
 	if sel >= 0 && widget.result != nil && sel < len(widget.result.Tickets) {
 		issue := &widget.result.Tickets[sel]
 		ticketURL := fmt.Sprintf("https://%s.zendesk.com/agent/tickets/%d", widget.settings.subdomain, issue.Id)
-		wtf.OpenFile(ticketURL)
+		utils.OpenURL(ticketURL)
 	}
 }
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/wtfutil/wtf/flags"
+	"github.com/wtfutil/wtf/generator"
+)
+
+// newModule runs 'wtf new-module NAME', which writes the skeleton of a new module into
+// the source tree in the current directory, and exits
+func newModule(flags *flags.Flags) {
+	args := flags.CommandArgs()
+	if len(args) != 1 {
+		fmt.Println("Usage: wtf new-module NAME, from the root of the wtf source tree")
+		os.Exit(1)
+	}
+
+	written, err := generator.NewModule(".", args[0])
+	for _, path := range written {
+		fmt.Printf("  wrote %s\n", path)
+	}
+
+	if err != nil {
+		fmt.Printf("\n\033[0;31mERROR:\033[0m %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nAdded the %s module. Turn it on in your config with:\n\n  %s:\n    enabled: true\n    position: { top: 0, left: 0, height: 1, width: 1 }\n", args[0], args[0])
+	os.Exit(0)
+}
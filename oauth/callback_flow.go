@@ -0,0 +1,93 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// callbackResult is what the provider's redirect back to the local server brought
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// callbackFlow starts a server on localhost, prompts the user to open the provider's
+// sign-in page, and exchanges the code the page redirects back with for a token
+func callbackFlow(ctx context.Context, config *Config, prompt func(Prompt)) (*oauth2.Token, error) {
+	if config.CallbackPort == "" {
+		return nil, fmt.Errorf("the %s flow needs a callback port", FlowCallback)
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "localhost:"+config.CallbackPort)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan callbackResult, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.FormValue("state") != state:
+			http.Error(rw, "This sign-in was not started by wtf", http.StatusForbidden)
+			return
+		case req.FormValue("error") != "":
+			http.Error(rw, "Signing in failed: "+req.FormValue("error"), http.StatusForbidden)
+			deliver(results, callbackResult{err: errors.New(req.FormValue("error"))})
+			return
+		}
+
+		fmt.Fprintln(rw, "Signed in. You can close this window and go back to wtf.")
+		deliver(results, callbackResult{code: req.FormValue("code")})
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	oauthConfig := config.oauth2Config()
+	prompt(Prompt{URL: oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline)})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-results:
+		if result.err != nil {
+			return nil, fmt.Errorf("signing in to %s: %v", config.Provider.Name, result.err)
+		}
+
+		return oauthConfig.Exchange(ctx, result.code)
+	}
+}
+
+// deliver hands the result over unless one already has been, as the browser can hit
+// the callback more than once
+func deliver(results chan callbackResult, result callbackResult) {
+	select {
+	case results <- result:
+	default:
+	}
+}
+
+// randomState returns a value for the sign-in request to carry through the provider's
+// pages and back, so that redirects from sign-ins wtf didn't start are refused
+func randomState() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
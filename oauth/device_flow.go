@@ -0,0 +1,145 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// deviceGrantType is the grant type a device code is exchanged for a token with
+const deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// deviceCode is the provider's answer to a request to sign in a device
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	// Google calls it this instead
+	VerificationURL string `json:"verification_url"`
+}
+
+// tokenResponse is the provider's answer when polled for the token
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	Error        string `json:"error"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+}
+
+// deviceFlow asks the provider for a code, prompts the user to enter it on the
+// provider's device page, and polls the provider until the user has signed in
+func deviceFlow(ctx context.Context, config *Config, prompt func(Prompt)) (*oauth2.Token, error) {
+	if config.Provider.DeviceAuthURL == "" {
+		return nil, fmt.Errorf("%s does not support the %s flow", config.Provider.Name, FlowDevice)
+	}
+
+	client := httpClientFrom(ctx)
+
+	code := deviceCode{}
+	err := postForm(client, config.Provider.DeviceAuthURL, url.Values{
+		"client_id": {config.ClientID},
+		"scope":     {strings.Join(config.Scopes, " ")},
+	}, &code)
+	if err != nil {
+		return nil, err
+	}
+
+	if code.VerificationURI == "" {
+		code.VerificationURI = code.VerificationURL
+	}
+
+	prompt(Prompt{URL: code.VerificationURI, UserCode: code.UserCode})
+
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		params := url.Values{
+			"client_id":   {config.ClientID},
+			"device_code": {code.DeviceCode},
+			"grant_type":  {deviceGrantType},
+		}
+		if config.ClientSecret != "" {
+			params.Set("client_secret", config.ClientSecret)
+		}
+
+		response := tokenResponse{}
+		if err := postForm(client, config.Provider.Endpoint.TokenURL, params, &response); err != nil && response.Error == "" {
+			return nil, err
+		}
+
+		switch response.Error {
+		case "":
+			return response.token(), nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, fmt.Errorf("signing in to %s: %s", config.Provider.Name, response.Error)
+		}
+	}
+
+	return nil, errors.New("the sign-in code expired before it was entered")
+}
+
+func (response tokenResponse) token() *oauth2.Token {
+	token := &oauth2.Token{
+		AccessToken:  response.AccessToken,
+		RefreshToken: response.RefreshToken,
+		TokenType:    response.TokenType,
+	}
+
+	if response.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+
+	return token
+}
+
+func httpClientFrom(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		return client
+	}
+
+	return http.DefaultClient
+}
+
+// postForm posts the form to the endpoint and decodes the JSON response into result.
+// The response is decoded even when the request fails, as providers explain their
+// failures in the body
+func postForm(client *http.Client, endpoint string, params url.Values, result interface{}) error {
+	resp, err := client.PostForm(endpoint, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decodeErr := json.NewDecoder(resp.Body).Decode(result)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+
+	return decodeErr
+}
@@ -0,0 +1,153 @@
+// Package oauth signs modules in to services that use OAuth2, such as Google, Microsoft,
+// Spotify, and Twitch. The user is signed in once, either on a device-code page, which
+// works over SSH, or through a redirect to a local callback, and the tokens are kept in
+// a TokenStore. Access tokens are refreshed as they expire, and the refreshed tokens are
+// saved, so that the module doesn't ask the user to sign in again:
+//
+//	client, err := oauth.Client(ctx, "my_calendar", config, oauth.DefaultTokenStore(), prompt)
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/wtfutil/wtf/wtf"
+	"golang.org/x/oauth2"
+)
+
+// The ways the user can be signed in
+const (
+	// FlowCallback opens the provider's sign-in page in the browser, which redirects back
+	// to a server wtf runs on localhost
+	FlowCallback = "callback"
+	// FlowDevice shows a code that the user enters on the provider's device page, on any
+	// device. It suits wtf running on a remote machine
+	FlowDevice = "device"
+)
+
+// Config describes the app that signs in, the provider it signs in to, and how
+type Config struct {
+	CallbackPort string
+	ClientID     string
+	ClientSecret string
+	Flow         string
+	Provider     Provider
+	Scopes       []string
+}
+
+// Prompt is what the user has to do to sign in: visit the URL and, for the device
+// flow, enter the code there
+type Prompt struct {
+	URL      string
+	UserCode string
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Client returns an HTTP client whose requests carry an access token for the account,
+// signing the user in first if the store has no token for it
+func Client(ctx context.Context, account string, config *Config, store TokenStore, prompt func(Prompt)) (*http.Client, error) {
+	ctx = withHTTPClient(ctx)
+
+	token, err := Token(ctx, account, config, store, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth2.NewClient(ctx, TokenSource(ctx, account, config, store, token)), nil
+}
+
+// Token returns the account's saved token or, if there isn't one, signs the user in
+// with the config's flow and saves the token that comes back
+func Token(ctx context.Context, account string, config *Config, store TokenStore, prompt func(Prompt)) (*oauth2.Token, error) {
+	if token, err := store.Load(account); err == nil && token != nil {
+		return token, nil
+	}
+
+	ctx = withHTTPClient(ctx)
+
+	var token *oauth2.Token
+	var err error
+
+	switch config.Flow {
+	case FlowDevice:
+		token, err = deviceFlow(ctx, config, prompt)
+	case FlowCallback, "":
+		token, err = callbackFlow(ctx, config, prompt)
+	default:
+		err = fmt.Errorf("'%s' is not a sign-in flow: use %s or %s", config.Flow, FlowCallback, FlowDevice)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return token, store.Save(account, token)
+}
+
+// TokenSource returns a source of access tokens for the account, starting from the
+// given token. Each time the access token is refreshed, the new token is saved
+func TokenSource(ctx context.Context, account string, config *Config, store TokenStore, token *oauth2.Token) oauth2.TokenSource {
+	ctx = withHTTPClient(ctx)
+
+	return &savingTokenSource{
+		account: account,
+		last:    token,
+		source:  config.oauth2Config().TokenSource(ctx, token),
+		store:   store,
+	}
+}
+
+// String returns the instructions for signing in
+func (prompt Prompt) String() string {
+	if prompt.UserCode == "" {
+		return fmt.Sprintf("Sign in at %s", prompt.URL)
+	}
+
+	return fmt.Sprintf("Sign in at %s with the code %s", prompt.URL, prompt.UserCode)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (config *Config) oauth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		Endpoint:     config.Provider.Endpoint,
+		RedirectURL:  "http://localhost:" + config.CallbackPort + "/callback",
+		Scopes:       config.Scopes,
+	}
+}
+
+// withHTTPClient makes the oauth2 package talk to providers through the shared HTTP
+// client, so that the proxy and certificate settings apply to signing in too
+func withHTTPClient(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, oauth2.HTTPClient, wtf.HTTPClient())
+}
+
+// savingTokenSource saves each new token its source hands out
+type savingTokenSource struct {
+	account string
+	last    *oauth2.Token
+	source  oauth2.TokenSource
+	store   TokenStore
+}
+
+func (source *savingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := source.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if source.last == nil || token.AccessToken != source.last.AccessToken {
+		source.last = token
+		source.store.Save(source.account, token)
+	}
+
+	return token, nil
+}
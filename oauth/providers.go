@@ -0,0 +1,83 @@
+package oauth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// Provider is a service users sign in to. Providers without a DeviceAuthURL only
+// support the callback flow
+type Provider struct {
+	DeviceAuthURL string
+	Endpoint      oauth2.Endpoint
+	Name          string
+}
+
+var providers = map[string]Provider{
+	"google": {
+		DeviceAuthURL: "https://oauth2.googleapis.com/device/code",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  "https://accounts.google.com/o/oauth2/auth",
+			TokenURL: "https://oauth2.googleapis.com/token",
+		},
+		Name: "google",
+	},
+	"microsoft": {
+		DeviceAuthURL: "https://login.microsoftonline.com/common/oauth2/v2.0/devicecode",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  "https://login.microsoftonline.com/common/oauth2/v2.0/authorize",
+			TokenURL: "https://login.microsoftonline.com/common/oauth2/v2.0/token",
+		},
+		Name: "microsoft",
+	},
+	"spotify": {
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  "https://accounts.spotify.com/authorize",
+			TokenURL: "https://accounts.spotify.com/api/token",
+		},
+		Name: "spotify",
+	},
+	"strava": {
+		Endpoint: oauth2.Endpoint{
+			AuthStyle: oauth2.AuthStyleInParams,
+			AuthURL:   "https://www.strava.com/oauth/authorize",
+			TokenURL:  "https://www.strava.com/oauth/token",
+		},
+		Name: "strava",
+	},
+	"twitch": {
+		DeviceAuthURL: "https://id.twitch.tv/oauth2/device",
+		Endpoint: oauth2.Endpoint{
+			AuthStyle: oauth2.AuthStyleInParams,
+			AuthURL:   "https://id.twitch.tv/oauth2/authorize",
+			TokenURL:  "https://id.twitch.tv/oauth2/token",
+		},
+		Name: "twitch",
+	},
+}
+
+// LookupProvider returns the provider with the given name, such as "google"
+func LookupProvider(name string) (Provider, error) {
+	provider, ok := providers[strings.ToLower(name)]
+	if !ok {
+		return Provider{}, fmt.Errorf("'%s' is not an OAuth provider: use one of %s", name, strings.Join(providerNames(), ", "))
+	}
+
+	return provider, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func providerNames() []string {
+	names := []string{}
+	for name := range providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,112 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// Session signs a widget in to its account in the background. Until the user has signed
+// in it holds what they have to do to sign in, for the widget to show, and then it holds
+// the client the widget talks to its API with:
+//
+//	widget.session = oauth.NewSession(widget.Name(), oauth.DefaultTokenStore(), widget.Refresh)
+//	go widget.session.SignIn(widget.oauthConfig)
+//
+//	client := widget.session.Client()
+//	if client == nil {
+//		widget.Redraw(title, widget.session.PromptText(), true)
+//		return
+//	}
+type Session struct {
+	account  string
+	client   *http.Client
+	err      error
+	mu       sync.Mutex
+	onChange func()
+	prompt   Prompt
+	store    TokenStore
+}
+
+// NewSession creates and returns an instance of Session for the account, whose tokens
+// are kept in the store. onChange is called whenever the session's state changes, so
+// that the widget can redraw
+func NewSession(account string, store TokenStore, onChange func()) *Session {
+	return &Session{
+		account:  account,
+		onChange: onChange,
+		store:    store,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Client returns the client that carries the account's access token, or nil if the user
+// hasn't signed in yet
+func (session *Session) Client() *http.Client {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	return session.client
+}
+
+// PromptText returns what to show in place of the widget's content until the user has
+// signed in: that signing in has started, what the user has to do to sign in, or why
+// signing in failed
+func (session *Session) PromptText() string {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	switch {
+	case session.err != nil:
+		return fmt.Sprintf(" [red]Signing in failed:[white] %v", session.err)
+	case session.prompt.URL != "":
+		return fmt.Sprintf(" [yellow]%s[white]", session.prompt)
+	default:
+		return " [gray]Signing in...[white]"
+	}
+}
+
+// SignIn uses the account's saved sign-in or, if there isn't one, asks the user to sign
+// in with the config that configFunc returns and waits until they have. It's meant to
+// run in its own goroutine. A failure is logged and kept for PromptText to show
+func (session *Session) SignIn(configFunc func() (*Config, error)) {
+	client, err := session.signIn(configFunc)
+
+	session.mu.Lock()
+	session.client = client
+	session.err = err
+	session.mu.Unlock()
+
+	if err != nil {
+		logger.For(session.account).Errorf("signing in: %v", err)
+	}
+
+	session.onChange()
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (session *Session) signIn(configFunc func() (*Config, error)) (*http.Client, error) {
+	config, err := configFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	return Client(context.Background(), session.account, config, session.store, session.showPrompt)
+}
+
+// showPrompt keeps what the user has to do to sign in, to show until they have, and
+// opens the sign-in page
+func (session *Session) showPrompt(prompt Prompt) {
+	session.mu.Lock()
+	session.prompt = prompt
+	session.mu.Unlock()
+
+	utils.OpenURL(prompt.URL)
+	session.onChange()
+}
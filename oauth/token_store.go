@@ -0,0 +1,85 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/wtfutil/wtf/cfg"
+	"golang.org/x/oauth2"
+)
+
+// accountNameRegExp matches the characters an account name can't use in a file name
+var accountNameRegExp = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// TokenStore keeps each account's tokens between runs. A store backed by the operating
+// system's keychain can be used in place of the file store by implementing it
+type TokenStore interface {
+	Load(account string) (*oauth2.Token, error)
+	Save(account string, token *oauth2.Token) error
+}
+
+// FileTokenStore keeps each account's tokens in its own file, readable only by the
+// user, in the directory
+type FileTokenStore struct {
+	Dir string
+}
+
+// DefaultTokenStore returns the store tokens are kept in unless a module says
+// otherwise: files in the tokens directory of the config directory
+func DefaultTokenStore() TokenStore {
+	configDir, err := cfg.WtfConfigDir()
+	if err != nil {
+		return &FileTokenStore{}
+	}
+
+	return &FileTokenStore{Dir: filepath.Join(configDir, "tokens")}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Load returns the account's saved token
+func (store *FileTokenStore) Load(account string) (*oauth2.Token, error) {
+	if store.Dir == "" {
+		return nil, errors.New("there is nowhere to keep tokens")
+	}
+
+	data, err := ioutil.ReadFile(store.path(account))
+	if err != nil {
+		return nil, err
+	}
+
+	token := oauth2.Token{}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// Save writes the account's token, replacing the one saved before
+func (store *FileTokenStore) Save(account string, token *oauth2.Token) error {
+	if store.Dir == "" {
+		return errors.New("there is nowhere to keep tokens")
+	}
+
+	if err := os.MkdirAll(store.Dir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(store.path(account), data, 0600)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (store *FileTokenStore) path(account string) string {
+	return filepath.Join(store.Dir, accountNameRegExp.ReplaceAllString(account, "_")+".json")
+}
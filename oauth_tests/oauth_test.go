@@ -0,0 +1,105 @@
+package oauth_tests
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/oauth"
+	"golang.org/x/oauth2"
+)
+
+func TestCallbackFlow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-oauth")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	provider := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		Equal(t, "the-code", req.FormValue("code"))
+
+		rw.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(rw, `{"access_token": "access", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 3600}`)
+	}))
+	defer provider.Close()
+
+	config := &Config{
+		CallbackPort: freePort(t),
+		ClientID:     "wtf",
+		Flow:         FlowCallback,
+		Provider:     Provider{Name: "test", Endpoint: oauth2.Endpoint{AuthURL: provider.URL + "/auth", TokenURL: provider.URL + "/token"}},
+	}
+
+	// Plays the part of the user signing in on the provider's page
+	signIn := func(prompt Prompt) {
+		authURL, _ := url.Parse(prompt.URL)
+		state := authURL.Query().Get("state")
+
+		go http.Get(fmt.Sprintf("http://localhost:%s/callback?code=the-code&state=%s", config.CallbackPort, state))
+	}
+
+	store := &FileTokenStore{Dir: dir}
+
+	token, err := Token(context.Background(), "spotifyweb", config, store, signIn)
+	Nil(t, err)
+	Equal(t, "access", token.AccessToken)
+	Equal(t, "refresh", token.RefreshToken)
+
+	// The next time, the saved token is used without signing in
+	saved, err := Token(context.Background(), "spotifyweb", config, store, func(Prompt) { t.Error("signed in again") })
+	Nil(t, err)
+	Equal(t, "refresh", saved.RefreshToken)
+}
+
+func TestLookupProvider(t *testing.T) {
+	provider, err := LookupProvider("Google")
+	Nil(t, err)
+	Equal(t, "google", provider.Name)
+	NotEqual(t, "", provider.DeviceAuthURL)
+
+	_, err = LookupProvider("myspace")
+	NotNil(t, err)
+}
+
+func TestPrompt(t *testing.T) {
+	Equal(t, "Sign in at https://example.com/device with the code ABCD-1234", Prompt{URL: "https://example.com/device", UserCode: "ABCD-1234"}.String())
+	Equal(t, "Sign in at https://example.com/auth", Prompt{URL: "https://example.com/auth"}.String())
+}
+
+func TestSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-oauth")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := &FileTokenStore{Dir: dir}
+	Nil(t, store.Save("saved", &oauth2.Token{AccessToken: "access", TokenType: "Bearer"}))
+
+	changes := 0
+	session := NewSession("saved", store, func() { changes++ })
+	Nil(t, session.Client())
+	Contains(t, session.PromptText(), "Signing in...")
+
+	session.SignIn(func() (*Config, error) { return &Config{Provider: Provider{Name: "test"}}, nil })
+	NotNil(t, session.Client())
+	Equal(t, 1, changes)
+
+	failed := NewSession("failed", store, func() {})
+	failed.SignIn(func() (*Config, error) { return nil, fmt.Errorf("no secret file") })
+	Nil(t, failed.Client())
+	Contains(t, failed.PromptText(), "no secret file")
+}
+
+func freePort(t *testing.T) string {
+	listener, err := net.Listen("tcp", "localhost:0")
+	Nil(t, err)
+	defer listener.Close()
+
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+}
@@ -0,0 +1,209 @@
+package pluginrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/pluginrpc/proto"
+	"github.com/wtfutil/wtf/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// callTimeout is how long a plugin has to answer a call before the call fails
+const callTimeout = 30 * time.Second
+
+// Client calls a plugin's widget methods
+type Client struct {
+	plugin *plugin.Client
+	widget proto.WidgetClient
+}
+
+// Start runs the plugin at the given path and returns a Client that calls it. What the
+// plugin writes to standard error goes to wtf's log, under the plugin's name
+func Start(path string) (*Client, error) {
+	pluginClient := plugin.NewClient(&plugin.ClientConfig{
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Cmd:              exec.Command(path),
+		HandshakeConfig:  Handshake,
+		Logger:           newLogger(filepath.Base(path)),
+		Plugins:          pluginSet(nil),
+	})
+
+	conn, err := pluginClient.Client()
+	if err != nil {
+		pluginClient.Kill()
+		return nil, err
+	}
+
+	raw, err := conn.Dispense(PluginName)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, err
+	}
+
+	client := raw.(*Client)
+	client.plugin = pluginClient
+
+	return client, nil
+}
+
+// Path returns the path to the plugin for the module type, or an empty string if there
+// is no such plugin in the plugins directory
+func Path(moduleType string, globalConfig *config.Config) string {
+	dir := globalConfig.UString("wtf.plugins.dir", "")
+	if dir == "" {
+		configDir, err := cfg.WtfConfigDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(configDir, "plugins")
+	}
+
+	dir, err := utils.ExpandHomeDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	path := filepath.Join(dir, moduleType)
+
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+		return ""
+	}
+
+	return path
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Close stops the plugin
+func (client *Client) Close() error {
+	if client.plugin != nil {
+		client.plugin.Kill()
+	}
+
+	return nil
+}
+
+// Configure passes the module's settings on to the plugin
+func (client *Client) Configure(settings map[string]interface{}) error {
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	return client.call("Configure", func(ctx context.Context) error {
+		_, err := client.widget.Configure(ctx, &proto.ConfigureRequest{SettingsJson: string(encoded)})
+		return err
+	})
+}
+
+// Exited returns true if the plugin has stopped running, and has to be started again
+func (client *Client) Exited() bool {
+	return client.plugin != nil && client.plugin.Exited()
+}
+
+// HandleKey tells the plugin that one of its keys was pressed
+func (client *Client) HandleKey(key string) error {
+	return client.call("HandleKey", func(ctx context.Context) error {
+		_, err := client.widget.HandleKey(ctx, &proto.KeyRequest{Key: key})
+		return err
+	})
+}
+
+// Refresh has the plugin fetch its data
+func (client *Client) Refresh() error {
+	return client.call("Refresh", func(ctx context.Context) error {
+		_, err := client.widget.Refresh(ctx, &proto.Empty{})
+		return err
+	})
+}
+
+// Render has the plugin lay out its content in the given number of columns and rows
+func (client *Client) Render(width, height int) (*Content, error) {
+	var reply *proto.Content
+
+	err := client.call("Render", func(ctx context.Context) error {
+		var err error
+		reply, err = client.widget.Render(ctx, &proto.RenderRequest{Height: int32(height), Width: int32(width)})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	content := &Content{Text: reply.Text, Title: reply.Title}
+	for _, key := range reply.Keys {
+		content.Keys = append(content.Keys, Key{Help: key.Help, Key: key.Key})
+	}
+
+	return content, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// call makes the call to the plugin, giving up if it does not answer in time. The
+// errors the plugin returns come back as they were written, without gRPC's wrapping
+func (client *Client) call(method string, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	err := fn(ctx)
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.DeadlineExceeded:
+		return fmt.Errorf("the plugin did not answer %s within %s", method, callTimeout)
+	case codes.Unknown:
+		return errors.New(st.Message())
+	}
+
+	return err
+}
+
+// newLogger returns the logger that go-plugin writes what happens to the plugin, and
+// what the plugin writes to standard error, to. It writes to wtf's log
+func newLogger(name string) hclog.Logger {
+	log := logger.For(name)
+
+	level := hclog.Info
+	if log.Enabled(logger.LevelDebug) {
+		level = hclog.Debug
+	}
+
+	return hclog.New(&hclog.LoggerOptions{
+		Level:  level,
+		Name:   name,
+		Output: logWriter{log: log},
+	})
+}
+
+// logWriter writes the lines it is given to wtf's log
+type logWriter struct {
+	log *logger.Logger
+}
+
+func (writer logWriter) Write(p []byte) (int, error) {
+	writer.log.Infof("%s", strings.TrimSpace(string(p)))
+	return len(p), nil
+}
@@ -0,0 +1,140 @@
+// Package pluginrpc lets widgets be written as separate Go programs, out of this tree,
+// that wtf starts with hashicorp/go-plugin and then talks to over gRPC for as long as
+// the widget is shown. The widget is configured, refreshed, rendered, and sent key
+// presses through the Widget service in proto/widget.proto. A plugin needs only to
+// implement Widget and call Serve:
+//
+//	func main() {
+//		pluginrpc.Serve(&deploys{})
+//	}
+//
+// and be put, named after its module type, in the plugins directory, which is
+// ~/.config/wtf/plugins unless the "wtf.plugins.dir" setting says otherwise
+package pluginrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/wtfutil/wtf/pluginrpc/proto"
+	"google.golang.org/grpc"
+)
+
+// ProtocolVersion is the version of the calls made to plugins. Plugins built for
+// another version refuse to start
+const ProtocolVersion = 2
+
+// PluginName is the name the widget is served under
+const PluginName = "widget"
+
+// Handshake is what wtf and a plugin check before they talk. A plugin that is run by
+// hand, rather than by wtf, exits with an error
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  ProtocolVersion,
+	MagicCookieKey:   "WTF_PLUGIN",
+	MagicCookieValue: "6f3a2c1e9b8d4e7f",
+}
+
+// Widget is what a plugin implements. Configure is called once, with the module's
+// settings, before anything else. Refresh fetches the widget's data, and Render lays
+// it out in the given number of columns and rows. HandleKey is called when one of the
+// keys that Render asked for is pressed while the widget has focus
+type Widget interface {
+	Configure(settings map[string]interface{}) error
+	HandleKey(key string) error
+	Refresh() error
+	Render(width, height int) (*Content, error)
+}
+
+// Content is what the widget shows: its title, its text, which may contain color tags,
+// and the keys it handles
+type Content struct {
+	Keys  []Key
+	Text  string
+	Title string
+}
+
+// Key is a key a plugin handles, and what it does, for the widget's help
+type Key struct {
+	Help string
+	Key  string
+}
+
+// WidgetPlugin is the go-plugin plugin that serves Impl over gRPC in the plugin, and
+// that gives wtf a Client to call it with
+type WidgetPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl Widget
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Serve answers wtf's calls to the widget until wtf stops the plugin
+func Serve(widget Widget) {
+	plugin.Serve(&plugin.ServeConfig{
+		GRPCServer:      plugin.DefaultGRPCServer,
+		HandshakeConfig: Handshake,
+		Plugins:         pluginSet(widget),
+	})
+}
+
+// GRPCClient returns a Client that calls the widget over the connection
+func (p *WidgetPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &Client{widget: proto.NewWidgetClient(conn)}, nil
+}
+
+// GRPCServer registers the widget's service with the server
+func (p *WidgetPlugin) GRPCServer(broker *plugin.GRPCBroker, server *grpc.Server) error {
+	proto.RegisterWidgetServer(server, &grpcServer{widget: p.Impl})
+	return nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func pluginSet(widget Widget) map[string]plugin.Plugin {
+	return map[string]plugin.Plugin{
+		PluginName: &WidgetPlugin{Impl: widget},
+	}
+}
+
+// grpcServer answers the Widget service's calls with a Widget's methods
+type grpcServer struct {
+	widget Widget
+}
+
+func (server *grpcServer) Configure(ctx context.Context, req *proto.ConfigureRequest) (*proto.Empty, error) {
+	settings := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(req.SettingsJson), &settings); err != nil {
+		return nil, err
+	}
+
+	return &proto.Empty{}, server.widget.Configure(settings)
+}
+
+func (server *grpcServer) HandleKey(ctx context.Context, req *proto.KeyRequest) (*proto.Empty, error) {
+	return &proto.Empty{}, server.widget.HandleKey(req.Key)
+}
+
+func (server *grpcServer) Refresh(ctx context.Context, req *proto.Empty) (*proto.Empty, error) {
+	return &proto.Empty{}, server.widget.Refresh()
+}
+
+func (server *grpcServer) Render(ctx context.Context, req *proto.RenderRequest) (*proto.Content, error) {
+	content, err := server.widget.Render(int(req.Width), int(req.Height))
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, errors.New("the plugin rendered nothing")
+	}
+
+	reply := &proto.Content{Text: content.Text, Title: content.Title}
+	for _, key := range content.Keys {
+		reply.Keys = append(reply.Keys, &proto.Key{Help: key.Help, Key: key.Key})
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,5 @@
+// Package proto holds the gRPC service, generated from widget.proto, that wtf calls
+// plugins' widgets through
+package proto
+
+//go:generate protoc -I ./ ./widget.proto --go_out=plugins=grpc:.
@@ -0,0 +1,72 @@
+package pluginrpc_tests
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	plugin "github.com/hashicorp/go-plugin"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/pluginrpc"
+)
+
+type counter struct {
+	count int
+	step  int
+}
+
+func (widget *counter) Configure(settings map[string]interface{}) error {
+	widget.step = int(settings["step"].(float64))
+	return nil
+}
+
+func (widget *counter) HandleKey(key string) error {
+	if key != "+" {
+		return errors.New("unknown key")
+	}
+
+	widget.count += widget.step
+	return nil
+}
+
+func (widget *counter) Refresh() error {
+	widget.count = 0
+	return nil
+}
+
+func (widget *counter) Render(width, height int) (*Content, error) {
+	return &Content{
+		Keys:  []Key{{Help: "Count up", Key: "+"}},
+		Text:  fmt.Sprintf("%d in %dx%d", widget.count, width, height),
+		Title: "Counter",
+	}, nil
+}
+
+func TestPluginCalls(t *testing.T) {
+	conn, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		PluginName: &WidgetPlugin{Impl: &counter{}},
+	})
+	defer server.Stop()
+	defer conn.Close()
+
+	raw, err := conn.Dispense(PluginName)
+	Nil(t, err)
+
+	client := raw.(*Client)
+	defer client.Close()
+
+	Nil(t, client.Configure(map[string]interface{}{"step": 5}))
+	Nil(t, client.Refresh())
+	Nil(t, client.HandleKey("+"))
+	Nil(t, client.HandleKey("+"))
+
+	content, err := client.Render(40, 10)
+	Nil(t, err)
+	Equal(t, "Counter", content.Title)
+	Equal(t, "10 in 40x10", content.Text)
+	Equal(t, []Key{{Help: "Count up", Key: "+"}}, content.Keys)
+
+	err = client.HandleKey("x")
+	NotNil(t, err)
+	Equal(t, "unknown key", err.Error())
+}
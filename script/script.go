@@ -0,0 +1,117 @@
+// Package script runs Lua and Starlark scripts in-process, for widgets and for transforms
+// that filter, sort, and format the data modules fetch. A script whose file ends in .star
+// is Starlark, and any other script is Lua. A script is compiled once, when it's loaded,
+// and each call runs it afresh, so calls never share globals and can run at the same
+// time:
+//
+//	script, err := script.Load("~/.config/wtf/scripts/deploys.star")
+//	result, err := script.Call(ctx, "refresh", request)
+package script
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/wtfutil/wtf/utils"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+	"go.starlark.net/starlark"
+)
+
+// Script is a compiled Lua or Starlark script
+type Script struct {
+	name    string
+	program *starlark.Program
+	proto   *lua.FunctionProto
+}
+
+// Load reads and compiles the script at the path, as Starlark if its name ends in .star
+// and as Lua otherwise
+func Load(path string) (*Script, error) {
+	path, err := utils.ExpandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	name := filepath.Base(path)
+
+	if filepath.Ext(name) == ".star" {
+		return loadStarlark(file, name)
+	}
+
+	chunk, err := parse.Parse(file, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	proto, err := lua.Compile(chunk, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	return &Script{name: name, proto: proto}, nil
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Call runs the script and then calls the global function it defines with the given
+// name, passing it the arguments as Lua or Starlark values, and returns what the
+// function returns. The call fails if the context is done before it finishes
+func (script *Script) Call(ctx context.Context, function string, args ...interface{}) (interface{}, error) {
+	if script.program != nil {
+		return script.callStarlark(ctx, function, args)
+	}
+
+	state := lua.NewState()
+	defer state.Close()
+
+	state.SetContext(ctx)
+
+	state.Push(state.NewFunctionFromProto(script.proto))
+	if err := state.PCall(0, lua.MultRet, nil); err != nil {
+		return nil, script.failed(ctx, err)
+	}
+
+	fn, ok := state.GetGlobal(function).(*lua.LFunction)
+	if !ok {
+		return nil, fmt.Errorf("%s does not define a %s function", script.name, function)
+	}
+
+	params := make([]lua.LValue, len(args))
+	for idx, arg := range args {
+		params[idx] = toLua(state, arg)
+	}
+
+	if err := state.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, params...); err != nil {
+		return nil, script.failed(ctx, err)
+	}
+
+	result := state.Get(-1)
+	state.Pop(1)
+
+	return fromLua(result), nil
+}
+
+// Name returns the name of the script's file
+func (script *Script) Name() string {
+	return script.name
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// failed returns the error to report for a script that stopped with the given error
+func (script *Script) failed(ctx context.Context, err error) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s did not finish in time", script.name)
+	}
+
+	return err
+}
@@ -0,0 +1,193 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/wtfutil/wtf/logger"
+	"go.starlark.net/resolve"
+	"go.starlark.net/starlark"
+)
+
+// Scripts work with the numbers in JSON responses, so Starlark's floats are turned on,
+// along with lambdas, nested functions, and sets. While loops and recursion stay off
+func init() {
+	resolve.AllowFloat = true
+	resolve.AllowLambda = true
+	resolve.AllowNestedDef = true
+	resolve.AllowSet = true
+}
+
+// loadStarlark compiles the Starlark script in the file
+func loadStarlark(file *os.File, name string) (*Script, error) {
+	_, program, err := starlark.SourceProgram(name, file, func(string) bool { return false })
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	return &Script{name: name, program: program}, nil
+}
+
+// callStarlark runs the Starlark script and calls the function it defines with the given
+// name. Starlark has no while loops or recursion, so a script always finishes; one that
+// hasn't by the time the context is done is left to finish on its own and its result is
+// thrown away
+func (script *Script) callStarlark(ctx context.Context, function string, args []interface{}) (interface{}, error) {
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+
+	done := make(chan outcome, 1)
+
+	go func() {
+		result, err := script.runStarlark(function, args)
+		done <- outcome{result: result, err: err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+		return nil, script.failed(ctx, ctx.Err())
+	}
+}
+
+func (script *Script) runStarlark(function string, args []interface{}) (interface{}, error) {
+	thread := &starlark.Thread{
+		Name: script.name,
+		Print: func(_ *starlark.Thread, msg string) {
+			logger.For(script.name).Infof("%s", msg)
+		},
+	}
+
+	globals, err := script.program.Init(thread, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := globals[function].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s does not define a %s function", script.name, function)
+	}
+
+	params := make(starlark.Tuple, len(args))
+	for idx, arg := range args {
+		params[idx] = toStarlark(arg)
+	}
+
+	result, err := starlark.Call(thread, fn, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromStarlark(result), nil
+}
+
+// toStarlark converts a Go value to a Starlark value. Lists become lists, and maps become
+// dicts. Values of other types, such as structs, are converted as their JSON encoding
+// would be
+func toStarlark(value interface{}) starlark.Value {
+	switch val := value.(type) {
+	case nil:
+		return starlark.None
+	case bool:
+		return starlark.Bool(val)
+	case string:
+		return starlark.String(val)
+	case float64:
+		return starlark.Float(val)
+	case float32:
+		return starlark.Float(val)
+	case int:
+		return starlark.MakeInt(val)
+	case int64:
+		return starlark.MakeInt64(val)
+	case int32:
+		return starlark.MakeInt64(int64(val))
+	case uint:
+		return starlark.MakeUint(val)
+	case uint64:
+		return starlark.MakeUint64(val)
+	case json.Number:
+		if number, err := val.Int64(); err == nil {
+			return starlark.MakeInt64(number)
+		}
+		number, _ := val.Float64()
+		return starlark.Float(number)
+	case []interface{}:
+		items := make([]starlark.Value, len(val))
+		for idx, item := range val {
+			items[idx] = toStarlark(item)
+		}
+		return starlark.NewList(items)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		dict := starlark.NewDict(len(val))
+		for _, key := range keys {
+			_ = dict.SetKey(starlark.String(key), toStarlark(val[key]))
+		}
+		return dict
+	}
+
+	var generic interface{}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return starlark.None
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	if decoder.Decode(&generic) != nil {
+		return starlark.None
+	}
+
+	return toStarlark(generic)
+}
+
+// fromStarlark converts a Starlark value to a Go value. Lists and tuples become lists,
+// and dicts become maps keyed by their keys as strings
+func fromStarlark(value starlark.Value) interface{} {
+	switch val := value.(type) {
+	case starlark.Bool:
+		return bool(val)
+	case starlark.String:
+		return string(val)
+	case starlark.Int:
+		if number, ok := val.Int64(); ok {
+			return number
+		}
+		number, _ := starlark.AsFloat(val)
+		return number
+	case starlark.Float:
+		return float64(val)
+	case starlark.Indexable:
+		list := make([]interface{}, 0, val.Len())
+		for idx := 0; idx < val.Len(); idx++ {
+			list = append(list, fromStarlark(val.Index(idx)))
+		}
+		return list
+	case *starlark.Dict:
+		mapping := make(map[string]interface{}, val.Len())
+		for _, item := range val.Items() {
+			key, ok := starlark.AsString(item[0])
+			if !ok {
+				key = item[0].String()
+			}
+			mapping[key] = fromStarlark(item[1])
+		}
+		return mapping
+	}
+
+	return nil
+}
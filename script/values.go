@@ -0,0 +1,102 @@
+package script
+
+import (
+	"encoding/json"
+	"math"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// toLua converts a Go value to a Lua value. Lists become tables indexed from 1, and maps
+// become tables keyed by their keys. Values of other types, such as structs, are
+// converted as their JSON encoding would be
+func toLua(state *lua.LState, value interface{}) lua.LValue {
+	switch val := value.(type) {
+	case nil:
+		return lua.LNil
+	case bool:
+		return lua.LBool(val)
+	case string:
+		return lua.LString(val)
+	case float64:
+		return lua.LNumber(val)
+	case float32:
+		return lua.LNumber(val)
+	case int:
+		return lua.LNumber(val)
+	case int64:
+		return lua.LNumber(val)
+	case int32:
+		return lua.LNumber(val)
+	case uint:
+		return lua.LNumber(val)
+	case uint64:
+		return lua.LNumber(val)
+	case json.Number:
+		number, _ := val.Float64()
+		return lua.LNumber(number)
+	case []interface{}:
+		table := state.NewTable()
+		for _, item := range val {
+			table.Append(toLua(state, item))
+		}
+		return table
+	case map[string]interface{}:
+		table := state.NewTable()
+		for key, item := range val {
+			table.RawSetString(key, toLua(state, item))
+		}
+		return table
+	}
+
+	var generic interface{}
+
+	encoded, err := json.Marshal(value)
+	if err != nil || json.Unmarshal(encoded, &generic) != nil {
+		return lua.LNil
+	}
+
+	return toLua(state, generic)
+}
+
+// fromLua converts a Lua value to a Go value. A table with only the keys 1 to n is a
+// list, and any other table is a map. An empty table is taken to be an empty list, as
+// it usually is a list that a script has filtered everything out of
+func fromLua(value lua.LValue) interface{} {
+	switch val := value.(type) {
+	case lua.LBool:
+		return bool(val)
+	case lua.LString:
+		return string(val)
+	case lua.LNumber:
+		number := float64(val)
+		if number == math.Trunc(number) && math.Abs(number) < 1<<53 {
+			return int64(number)
+		}
+		return number
+	case *lua.LTable:
+		return fromTable(val)
+	}
+
+	return nil
+}
+
+func fromTable(table *lua.LTable) interface{} {
+	count := 0
+	table.ForEach(func(lua.LValue, lua.LValue) { count++ })
+
+	if count == table.MaxN() {
+		list := make([]interface{}, 0, count)
+		for idx := 1; idx <= count; idx++ {
+			list = append(list, fromLua(table.RawGetInt(idx)))
+		}
+		return list
+	}
+
+	mapping := make(map[string]interface{}, count)
+	table.ForEach(func(key, item lua.LValue) {
+		mapping[key.String()] = fromLua(item)
+	})
+
+	return mapping
+}
@@ -0,0 +1,130 @@
+package script_tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/script"
+)
+
+// writeScript writes the source to the named file in the directory and returns its path
+func writeScript(t *testing.T, dir, name, source string) string {
+	path := filepath.Join(dir, name)
+	Nil(t, ioutil.WriteFile(path, []byte(source), 0600))
+
+	return path
+}
+
+func TestScriptCall(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-script")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	loaded, err := Load(writeScript(t, dir, "test.lua", `
+function refresh(request)
+  local rows = {}
+  for _, name in ipairs(request.names) do
+    if name ~= "skip" then
+      table.insert(rows, { text = string.upper(name) })
+    end
+  end
+  return { title = request.title, rows = rows, count = #rows, empty = {} }
+end
+`))
+	Nil(t, err)
+	Equal(t, "test.lua", loaded.Name())
+
+	result, err := loaded.Call(context.Background(), "refresh", map[string]interface{}{
+		"names": []string{"api", "skip", "web"},
+		"title": "Deploys",
+	})
+	Nil(t, err)
+
+	Equal(t, map[string]interface{}{
+		"count": int64(2),
+		"empty": []interface{}{},
+		"rows": []interface{}{
+			map[string]interface{}{"text": "API"},
+			map[string]interface{}{"text": "WEB"},
+		},
+		"title": "Deploys",
+	}, result)
+
+	_, err = loaded.Call(context.Background(), "missing")
+	NotNil(t, err)
+}
+
+func TestScriptErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-script")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = Load(writeScript(t, dir, "test.lua", `function refresh( return end`))
+	NotNil(t, err)
+
+	loaded, err := Load(writeScript(t, dir, "test.lua", `
+function refresh() error("no deploys") end
+function spin() while true do end end
+`))
+	Nil(t, err)
+
+	_, err = loaded.Call(context.Background(), "refresh")
+	Contains(t, err.Error(), "no deploys")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = loaded.Call(ctx, "spin")
+	Equal(t, "test.lua did not finish in time", err.Error())
+}
+
+func TestStarlarkCall(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-script")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	loaded, err := Load(writeScript(t, dir, "test.star", `
+def refresh(request):
+    rows = [{"text": name.upper()} for name in request["names"] if name != "skip"]
+    return {"title": request["title"], "rows": rows, "count": len(rows), "ratio": 0.5, "done": True}
+`))
+	Nil(t, err)
+	Equal(t, "test.star", loaded.Name())
+
+	result, err := loaded.Call(context.Background(), "refresh", map[string]interface{}{
+		"names": []string{"api", "skip", "web"},
+		"title": "Deploys",
+	})
+	Nil(t, err)
+
+	Equal(t, map[string]interface{}{
+		"count": int64(2),
+		"done":  true,
+		"ratio": 0.5,
+		"rows": []interface{}{
+			map[string]interface{}{"text": "API"},
+			map[string]interface{}{"text": "WEB"},
+		},
+		"title": "Deploys",
+	}, result)
+
+	_, err = loaded.Call(context.Background(), "missing")
+	NotNil(t, err)
+
+	_, err = Load(writeScript(t, dir, "broken.star", `def refresh(:`))
+	NotNil(t, err)
+
+	loaded, err = Load(writeScript(t, dir, "fail.star", `
+def refresh():
+    fail("no deploys")
+`))
+	Nil(t, err)
+
+	_, err = loaded.Call(context.Background(), "refresh")
+	Contains(t, err.Error(), "no deploys")
+}
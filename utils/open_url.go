@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+var (
+	openerCommand   string
+	openerCommandMu sync.Mutex
+)
+
+// SetOpenerCommand sets the command that opens URLs, from the "wtf.openerCommand"
+// setting. The URL replaces a "%s" in the command or, if there isn't one, is added to
+// the end of it:
+//
+//	wtf:
+//	  openerCommand: "firefox --new-tab"
+func SetOpenerCommand(command string) {
+	openerCommandMu.Lock()
+	defer openerCommandMu.Unlock()
+
+	openerCommand = command
+}
+
+// OpenURL opens the URL in the browser. It uses the opener command if one is set,
+// then the browsers listed in $BROWSER, and then the operating system's default
+func OpenURL(url string) error {
+	openerCommandMu.Lock()
+	command := openerCommand
+	openerCommandMu.Unlock()
+
+	args := OpenerArgs(url, command, os.Getenv("BROWSER"), runtime.GOOS, isWSL())
+	if len(args) == 0 {
+		return errors.New("no way to open URLs on " + runtime.GOOS + "; set wtf.openerCommand")
+	}
+
+	return exec.Command(args[0], args[1:]...).Start()
+}
+
+// OpenerArgs returns the command line that opens the URL, given the opener command, the
+// value of $BROWSER, and the operating system
+func OpenerArgs(url, command, browser, goos string, wsl bool) []string {
+	if command != "" {
+		return commandArgs(command, url)
+	}
+
+	// $BROWSER is a colon-separated list of browsers to try, in order
+	for _, candidate := range strings.Split(browser, string(os.PathListSeparator)) {
+		args := commandArgs(candidate, url)
+		if len(args) == 0 {
+			continue
+		}
+
+		if hasCommand(args[0]) {
+			return args
+		}
+	}
+
+	switch {
+	case goos == "darwin":
+		return []string{"open", url}
+	case goos == "windows":
+		return []string{"rundll32", "url.dll,FileProtocolHandler", url}
+	case wsl && hasCommand("wslview"):
+		return []string{"wslview", url}
+	case goos == "linux", strings.HasSuffix(goos, "bsd"):
+		return []string{"xdg-open", url}
+	default:
+		return []string{}
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// commandArgs splits the command into its arguments and puts the URL in place of "%s",
+// or at the end if there is no "%s"
+func commandArgs(command, url string) []string {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return args
+	}
+
+	replaced := false
+	for idx, arg := range args {
+		if strings.Contains(arg, "%s") {
+			args[idx] = strings.Replace(arg, "%s", url, -1)
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		args = append(args, url)
+	}
+
+	return args
+}
+
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// isWSL returns TRUE when running under the Windows Subsystem for Linux, where
+// xdg-open usually has no browser to hand URLs to
+func isWSL() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+
+	version, err := ioutil.ReadFile("/proc/version")
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(version)), "microsoft")
+}
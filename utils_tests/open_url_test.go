@@ -0,0 +1,19 @@
+package utils_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/utils"
+)
+
+func TestOpenerArgs(t *testing.T) {
+	url := "https://wtfutil.com"
+
+	Equal(t, []string{"firefox", "--new-tab", url}, OpenerArgs(url, "firefox --new-tab", "", "linux", false))
+	Equal(t, []string{"browser", "--url=" + url, "--new"}, OpenerArgs(url, "browser --url=%s --new", "", "linux", false))
+	Equal(t, []string{"open", url}, OpenerArgs(url, "", "no-such-browser-wtf", "darwin", false))
+	Equal(t, []string{"xdg-open", url}, OpenerArgs(url, "", "", "linux", false))
+	Equal(t, []string{"rundll32", "url.dll,FileProtocolHandler", url}, OpenerArgs(url, "", "", "windows", false))
+	Equal(t, []string{}, OpenerArgs(url, "", "", "plan9", false))
+}
@@ -0,0 +1,119 @@
+package wtf
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
+)
+
+// Alerts keeps track of which of a widget's alert rules hold, checking them against the
+// values on the data bus, and acts on the rules that start holding: it sends their
+// notifications and runs their commands and webhooks
+type Alerts struct {
+	active        map[*cfg.AlertRule]bool
+	mu            sync.Mutex
+	name          string
+	notifications cfg.NotificationSettings
+	rules         []*cfg.AlertRule
+}
+
+// NewAlerts creates and returns an instance of Alerts for the named widget's rules.
+// Their notifications are sent with the widget's notification settings, whether or not
+// the widget's own notifications are enabled
+func NewAlerts(name string, rules []*cfg.AlertRule, notifications cfg.NotificationSettings) *Alerts {
+	notifications.Enabled = true
+
+	return &Alerts{
+		active:        map[*cfg.AlertRule]bool{},
+		name:          name,
+		notifications: notifications,
+		rules:         rules,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Active returns the rules that hold, in the order they're defined
+func (alerts *Alerts) Active() []*cfg.AlertRule {
+	alerts.mu.Lock()
+	defer alerts.mu.Unlock()
+
+	active := []*cfg.AlertRule{}
+	for _, rule := range alerts.rules {
+		if alerts.active[rule] {
+			active = append(active, rule)
+		}
+	}
+
+	return active
+}
+
+// Evaluate checks every rule and acts on those that have started holding. Returns TRUE
+// if any rule started or stopped holding
+func (alerts *Alerts) Evaluate(now time.Time) bool {
+	started := []*cfg.AlertRule{}
+	changed := false
+
+	alerts.mu.Lock()
+	for _, rule := range alerts.rules {
+		holds := rule.Condition.Holds(alerts.lookup)
+		if holds == alerts.active[rule] {
+			continue
+		}
+
+		alerts.active[rule] = holds
+		changed = true
+
+		if holds {
+			started = append(started, rule)
+		}
+	}
+	alerts.mu.Unlock()
+
+	for _, rule := range started {
+		alerts.fire(rule, now)
+	}
+
+	return changed
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// fire sends the rule's notification and runs its command and webhook
+func (alerts *Alerts) fire(rule *cfg.AlertRule, now time.Time) {
+	notification := Notification{
+		Message: rule.Condition.Expression,
+		Module:  alerts.name,
+		Time:    now,
+		Title:   rule.Name,
+	}
+
+	if rule.Notify != "" {
+		notification.Title = rule.Notify
+		Notifications.Publish(notification, alerts.notifications)
+	}
+
+	if rule.Command == "" && rule.Webhook == "" {
+		return
+	}
+
+	hook := EventHook{Command: rule.Command, Module: alerts.name, Webhook: rule.Webhook}
+
+	go func() {
+		if err := runEventHook(&hook, notification); err != nil {
+			logger.For(alerts.name).Errorf("alert %s: %v", rule.Name, err)
+		}
+	}()
+}
+
+// lookup returns the value of a field in a condition: one the widget publishes or, if it
+// doesn't publish one by that name, one with that key on the data bus
+func (alerts *Alerts) lookup(field string) (interface{}, bool) {
+	if value, ok := Data.Get(alerts.name + "." + field); ok {
+		return value, true
+	}
+
+	return Data.Get(field)
+}
@@ -0,0 +1,215 @@
+package wtf
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+)
+
+const blankPageName = "blank"
+
+// ambientIdleCheckInterval is how often ambient mode checks whether to blank the screen
+const ambientIdleCheckInterval = 5 * time.Second
+
+// ambientFeaturedTypes are the modules featured in ambient mode unless others are named
+// in "wtf.ambient.featured"
+var ambientFeaturedTypes = map[string]bool{
+	"clocks":        true,
+	"prettyweather": true,
+	"weather":       true,
+}
+
+// AmbientMode is a presentation mode for dashboards on wall-mounted displays. It hides
+// the widgets of modules marked `sensitive`, gives most of the screen to the clock and
+// the weather, and blanks the screen once there's been no input for a while. It's set
+// up under "wtf.ambient":
+//
+//	wtf:
+//	  ambient:
+//	    enabled: true
+//	    blankAfter: 30
+//	    featured:
+//	      - clocks_a
+//	      - weather
+//	    showOthers: false
+//
+// blankAfter is in minutes, and 0 never blanks the screen. Any key press or click wakes
+// the screen up again
+type AmbientMode struct {
+	app        *tview.Application
+	blankAfter time.Duration
+	blanked    bool
+	display    *Display
+	featured   []string
+	lastInput  time.Time
+	mu         sync.Mutex
+	on         bool
+	pages      *tview.Pages
+	showOthers bool
+	widgets    []Wtfable
+}
+
+// NewAmbientMode creates and returns an instance of AmbientMode from the "wtf.ambient"
+// settings. The screen is blanked by covering the pages
+func NewAmbientMode(app *tview.Application, pages *tview.Pages, display *Display, widgets []Wtfable, config *config.Config) *AmbientMode {
+	ambient := AmbientMode{
+		app:        app,
+		blankAfter: time.Duration(config.UInt("wtf.ambient.blankAfter", 0)) * time.Minute,
+		display:    display,
+		lastInput:  time.Now(),
+		pages:      pages,
+		showOthers: config.UBool("wtf.ambient.showOthers", true),
+		widgets:    widgets,
+	}
+
+	for _, name := range config.UList("wtf.ambient.featured") {
+		if str, ok := name.(string); ok {
+			ambient.featured = append(ambient.featured, str)
+		}
+	}
+
+	if ambient.blankAfter > 0 {
+		go ambient.watchIdle()
+	}
+
+	return &ambient
+}
+
+// AmbientWidgets splits the enabled widgets that aren't sensitive into those featured in
+// ambient mode, which are the named ones or, if none are named, the clocks and the
+// weather, and the others
+func AmbientWidgets(widgets []Wtfable, featuredNames []string) (featured, others []Wtfable) {
+	isFeatured := func(widget Wtfable) bool {
+		if len(featuredNames) == 0 {
+			return ambientFeaturedTypes[widget.CommonSettings().Module.Type]
+		}
+
+		for _, name := range featuredNames {
+			if widget.Name() == name {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, widget := range widgets {
+		if widget.Disabled() || widget.CommonSettings().Sensitive {
+			continue
+		}
+
+		if isFeatured(widget) {
+			featured = append(featured, widget)
+		} else {
+			others = append(others, widget)
+		}
+	}
+
+	return featured, others
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Active returns TRUE if ambient mode is on
+func (ambient *AmbientMode) Active() bool {
+	ambient.mu.Lock()
+	defer ambient.mu.Unlock()
+
+	return ambient.on
+}
+
+// Toggle turns ambient mode on, showing the featured widgets from every page and the
+// others from the current one, or turns it off and puts the grid back
+func (ambient *AmbientMode) Toggle() {
+	ambient.mu.Lock()
+	ambient.on = !ambient.on
+	on := ambient.on
+	ambient.lastInput = time.Now()
+	ambient.mu.Unlock()
+
+	if !on {
+		ambient.display.HideAmbient()
+		return
+	}
+
+	featured, _ := AmbientWidgets(ambient.widgets, ambient.featured)
+
+	others := []Wtfable{}
+	if ambient.showOthers {
+		_, others = AmbientWidgets(ambient.display.CurrentPage().Widgets, ambient.featured)
+	}
+
+	ambient.display.ShowAmbient(featured, others)
+}
+
+// Touch records a key press or click, and wakes the screen up if it's blank. Returns
+// TRUE if it did, so that the input that woke it goes no further
+func (ambient *AmbientMode) Touch() bool {
+	ambient.mu.Lock()
+	blanked := ambient.blanked
+	ambient.blanked = false
+	ambient.lastInput = time.Now()
+	ambient.mu.Unlock()
+
+	if blanked {
+		ambient.pages.RemovePage(blankPageName)
+	}
+
+	return blanked
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// running returns TRUE while the widgets are running. Once they have been disabled, by a
+// config reload, ambient mode stops watching for idleness
+func (ambient *AmbientMode) running() bool {
+	for _, widget := range ambient.widgets {
+		if widget.Enabled() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// watchIdle blanks the screen once ambient mode has gone without input for blankAfter
+func (ambient *AmbientMode) watchIdle() {
+	tick := time.NewTicker(ambientIdleCheckInterval)
+	defer tick.Stop()
+
+	for range tick.C {
+		if !ambient.running() {
+			return
+		}
+
+		ambient.mu.Lock()
+		blank := ambient.on && !ambient.blanked && time.Since(ambient.lastInput) >= ambient.blankAfter
+		if blank {
+			ambient.blanked = true
+		}
+		ambient.mu.Unlock()
+
+		if !blank {
+			continue
+		}
+
+		ambient.app.QueueUpdateDraw(func() {
+			// Input may have woken the screen up before it was blanked
+			ambient.mu.Lock()
+			blanked := ambient.blanked
+			ambient.mu.Unlock()
+
+			if !blanked {
+				return
+			}
+
+			screen := tview.NewBox()
+			screen.SetBackgroundColor(tcell.ColorBlack)
+
+			ambient.pages.AddPage(blankPageName, screen, true, true)
+		})
+	}
+}
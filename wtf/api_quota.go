@@ -0,0 +1,163 @@
+package wtf
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// quotaLowFraction is how little of its quota an API has left before the widgets that
+// use it are refreshed less often, to make what is left last until the quota resets
+const quotaLowFraction = 0.1
+
+// quotaHeaderPrefixes are the prefixes of the headers APIs report their rate limits
+// in: GitHub's, the IETF draft's that GitLab uses, and Twitter's
+var quotaHeaderPrefixes = []string{"X-RateLimit-", "RateLimit-", "X-Rate-Limit-"}
+
+// APIQuota is how many requests an API allows and how many are left, as it last
+// reported in its response headers
+type APIQuota struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// APIQuotas holds the last quota reported by each API host
+type APIQuotas struct {
+	mu     sync.Mutex
+	quotas map[string]APIQuota
+}
+
+// QuotaTransport is an http.RoundTripper that records the rate limits reported in the
+// responses of the underlying transport
+type QuotaTransport struct {
+	Base   http.RoundTripper
+	Quotas *APIQuotas
+}
+
+// quotaUser is implemented by widgets that say which API host their requests count
+// against
+type quotaUser interface {
+	QuotaHost() string
+}
+
+// Quotas are the API quotas reported to every module's HTTP requests
+var Quotas = &APIQuotas{quotas: make(map[string]APIQuota)}
+
+// QuotaFromHeaders reads the rate limit an API reported in the response's headers. The
+// reset time is either a Unix timestamp or, as the IETF draft has it, a number of
+// seconds from now
+func QuotaFromHeaders(header http.Header, now time.Time) (APIQuota, bool) {
+	for _, prefix := range quotaHeaderPrefixes {
+		limit, err := strconv.Atoi(header.Get(prefix + "Limit"))
+		if err != nil {
+			continue
+		}
+
+		remaining, err := strconv.Atoi(header.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+
+		quota := APIQuota{Limit: limit, Remaining: remaining}
+
+		if reset, err := strconv.ParseInt(header.Get(prefix+"Reset"), 10, 64); err == nil {
+			if reset < 1000000000 {
+				quota.Reset = now.Add(time.Duration(reset) * time.Second)
+			} else {
+				quota.Reset = time.Unix(reset, 0)
+			}
+		}
+
+		return quota, true
+	}
+
+	return APIQuota{}, false
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Low returns true if less than a tenth of the quota is left
+func (quota APIQuota) Low() bool {
+	return quota.Limit > 0 && float64(quota.Remaining) < float64(quota.Limit)*quotaLowFraction
+}
+
+// String returns the quota as it's shown in a widget's title, such as "API: 4312/5000"
+func (quota APIQuota) String() string {
+	return fmt.Sprintf("API: %d/%d", quota.Remaining, quota.Limit)
+}
+
+// Quota returns the host's last reported quota. The quota is forgotten once its reset
+// time has passed
+func (quotas *APIQuotas) Quota(host string, now time.Time) (APIQuota, bool) {
+	quotas.mu.Lock()
+	defer quotas.mu.Unlock()
+
+	quota, ok := quotas.quotas[host]
+	if !ok || (!quota.Reset.IsZero() && now.After(quota.Reset)) {
+		return APIQuota{}, false
+	}
+
+	return quota, true
+}
+
+// Record sets the host's quota
+func (quotas *APIQuotas) Record(host string, quota APIQuota) {
+	quotas.mu.Lock()
+	defer quotas.mu.Unlock()
+
+	if quotas.quotas == nil {
+		quotas.quotas = make(map[string]APIQuota)
+	}
+
+	quotas.quotas[host] = quota
+}
+
+// Stretch returns how long to wait before the next request to the host, given the
+// usual interval. While the host's quota is low, the interval is stretched so that the
+// requests left are spread out until the quota resets
+func (quotas *APIQuotas) Stretch(host string, interval time.Duration, now time.Time) time.Duration {
+	quota, ok := quotas.Quota(host, now)
+	if !ok || !quota.Low() || quota.Reset.IsZero() {
+		return interval
+	}
+
+	requestsLeft := quota.Remaining
+	if requestsLeft < 1 {
+		requestsLeft = 1
+	}
+
+	stretched := quota.Reset.Sub(now) / time.Duration(requestsLeft)
+	if stretched > interval {
+		return stretched
+	}
+
+	return interval
+}
+
+// RoundTrip sends the request and records the quota reported in the response
+func (transport *QuotaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := transport.Base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if quota, ok := QuotaFromHeaders(resp.Header, time.Now()); ok {
+		transport.Quotas.Record(req.URL.Hostname(), quota)
+	}
+
+	return resp, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// quotaHostOf returns the API host the widget's requests count against, if it says
+func quotaHostOf(widget Wtfable) string {
+	if user, ok := widget.(quotaUser); ok {
+		return user.QuotaHost()
+	}
+
+	return ""
+}
@@ -0,0 +1,306 @@
+package wtf
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+)
+
+// apiTimeout is how long a request waits for the app to collect the widgets' content
+const apiTimeout = 2 * time.Second
+
+// APIServer serves the widgets' data and content as JSON over HTTP, so that other
+// programs can show what the dashboard shows, and lets them control the app. It is
+// turned on by giving it an address to listen on, either a host and port or a unix
+// socket, and can require a token:
+//
+//	wtf:
+//	  server:
+//	    address: "127.0.0.1:7777"    # or "unix:/tmp/wtf.sock"
+//	    token: "s3cret"
+//
+// GET /api/widgets lists every widget, GET /api/widgets/{name} returns one, GET
+// /metrics returns the app's metrics for Prometheus to scrape, POST /api/commands runs a
+// command, such as {"args": ["refresh", "github"]}, as the command palette would, and
+// /api/sync returns the state shared with other instances or, on POST, merges theirs in
+type APIServer struct {
+	address  string
+	app      *tview.Application
+	commands func(args []string) error
+	mux      *http.ServeMux
+	server   *http.Server
+	token    string
+	widgets  []Wtfable
+}
+
+// APICommand is the body of a request to run a command
+type APICommand struct {
+	Args []string `json:"args"`
+}
+
+// WidgetState is what the API server returns for a widget: its refresh status, the
+// values it has published to the data bus, and the text it is showing
+type WidgetState struct {
+	Data        map[string]interface{} `json:"data"`
+	Error       string                 `json:"error,omitempty"`
+	LastSuccess time.Time              `json:"lastSuccess"`
+	Name        string                 `json:"name"`
+	Paused      bool                   `json:"paused"`
+	Refreshing  bool                   `json:"refreshing"`
+	Text        string                 `json:"text"`
+	Title       string                 `json:"title"`
+	Type        string                 `json:"type"`
+}
+
+// NewAPIServer creates and returns an instance of APIServer for the widgets, or nil if
+// the server isn't turned on
+func NewAPIServer(app *tview.Application, widgets []Wtfable, config *config.Config) *APIServer {
+	address := config.UString("wtf.server.address", "")
+	if address == "" {
+		return nil
+	}
+
+	server := APIServer{
+		address: address,
+		app:     app,
+		mux:     http.NewServeMux(),
+		token:   config.UString("wtf.server.token", ""),
+		widgets: widgets,
+	}
+
+	server.Handle("/api/commands", server.serveCommands)
+	server.Handle("/api/sync", server.serveSync)
+	server.Handle("/api/widgets", server.serveWidgets)
+	server.Handle("/api/widgets/", server.serveWidgets)
+	server.Handle("/metrics", server.serveMetrics)
+
+	return &server
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Handle registers the handler for requests to the path
+func (server *APIServer) Handle(path string, handler func(http.ResponseWriter, *http.Request)) {
+	server.mux.HandleFunc(path, handler)
+}
+
+// ServeHTTP checks the request's token, if the server requires one, and passes the
+// request on to the handler for its path
+func (server *APIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if server.token != "" && r.Header.Get("Authorization") != "Bearer "+server.token {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "missing or wrong token"})
+		return
+	}
+
+	server.mux.ServeHTTP(w, r)
+}
+
+// SetCommands sets the function that runs the commands sent to the server
+func (server *APIServer) SetCommands(fn func(args []string) error) {
+	server.commands = fn
+}
+
+// SetWidgets sets the widgets the server serves. It must only be called while the
+// server is stopped
+func (server *APIServer) SetWidgets(widgets []Wtfable) {
+	server.widgets = widgets
+}
+
+// Start starts listening for requests in the background. A unix socket left behind
+// by an earlier run is replaced, and a server that was stopped can be started again
+func (server *APIServer) Start() error {
+	network, address := "tcp", server.address
+	if strings.HasPrefix(address, "unix:") {
+		network, address = "unix", strings.TrimPrefix(address, "unix:")
+		_ = os.Remove(address)
+	}
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{Handler: server}
+	server.server = httpServer
+
+	go func() {
+		_ = httpServer.Serve(listener)
+	}()
+
+	return nil
+}
+
+// Stop stops the server listening, as when the config is reloaded
+func (server *APIServer) Stop() {
+	if server.server != nil {
+		_ = server.server.Close()
+		server.server = nil
+	}
+
+	if strings.HasPrefix(server.address, "unix:") {
+		_ = os.Remove(strings.TrimPrefix(server.address, "unix:"))
+	}
+}
+
+// WidgetStates returns the state of each widget. The widgets' content is collected by
+// the app, between draws, so that it is never read while it is being changed
+func (server *APIServer) WidgetStates() []WidgetState {
+	texts := server.collectTexts()
+
+	states := []WidgetState{}
+	for _, widget := range server.widgets {
+		if widget.Disabled() {
+			continue
+		}
+
+		status := RefreshStatuses.Status(widget.Name())
+
+		state := WidgetState{
+			Data:        Data.Values(widget.Name() + "."),
+			LastSuccess: status.LastSuccess,
+			Name:        widget.Name(),
+			Paused:      status.Paused,
+			Refreshing:  status.Refreshing,
+			Text:        texts[widget.Name()],
+			Title:       widget.CommonSettings().Title,
+			Type:        widget.CommonSettings().Module.Type,
+		}
+
+		if status.Err != nil {
+			state.Error = status.Err.Error()
+		}
+
+		states = append(states, state)
+	}
+
+	return states
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// collectTexts returns the text each widget is showing, without its color tags, keyed
+// by the widget's name. If the app is too busy to collect them in time, there are none
+func (server *APIServer) collectTexts() map[string]string {
+	if len(server.widgets) == 0 {
+		return map[string]string{}
+	}
+
+	texts := make(chan map[string]string, 1)
+
+	server.app.QueueUpdate(func() {
+		collected := make(map[string]string, len(server.widgets))
+		for _, widget := range server.widgets {
+			collected[widget.Name()] = strings.TrimSpace(widget.TextView().GetText(true))
+		}
+		texts <- collected
+	})
+
+	select {
+	case collected := <-texts:
+		return collected
+	case <-time.After(apiTimeout):
+		return map[string]string{}
+	}
+}
+
+// serveCommands runs the command in the request on the app's goroutine, as commands
+// change what is onscreen, and returns whether it succeeded
+func (server *APIServer) serveCommands(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "only POST is allowed"})
+		return
+	}
+
+	if server.commands == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "commands are not available"})
+		return
+	}
+
+	command := APICommand{}
+	if err := json.NewDecoder(r.Body).Decode(&command); err != nil || len(command.Args) == 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "expected a command, such as {\"args\": [\"refresh\"]}"})
+		return
+	}
+
+	result := make(chan error, 1)
+	server.app.QueueUpdateDraw(func() {
+		result <- server.commands(command.Args)
+	})
+
+	var err error
+	select {
+	case err = <-result:
+	case <-time.After(apiTimeout):
+		err = errors.New("the app did not run the command in time")
+	}
+
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (server *APIServer) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	WriteMetrics(w, server.widgets)
+}
+
+// serveSync returns the state shared with other instances, after merging in the state
+// of the instance that sent it, if any
+func (server *APIServer) serveSync(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		other := SharedState{}
+		if err := json.NewDecoder(r.Body).Decode(&other); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "expected the state of another instance"})
+			return
+		}
+
+		Shared.Merge(other)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "only GET and POST are allowed"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Shared.State())
+}
+
+func (server *APIServer) serveWidgets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "only GET is allowed"})
+		return
+	}
+
+	states := server.WidgetStates()
+
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/widgets"), "/")
+	if name == "" {
+		writeJSON(w, http.StatusOK, states)
+		return
+	}
+
+	for _, state := range states {
+		if state.Name == name {
+			writeJSON(w, http.StatusOK, state)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "no widget named " + name})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/charts"
 )
 
 //BarGraph lets make graphs
@@ -14,6 +15,8 @@ type BarGraph struct {
 	commonSettings *cfg.Common
 	enabled        bool
 	focusable      bool
+	graphStyle     string
+	hidden         bool
 	key            string
 	maxStars       int
 	name           string
@@ -35,6 +38,7 @@ func NewBarGraph(app *tview.Application, name string, settings *cfg.Common, focu
 	widget := BarGraph{
 		enabled:        settings.Enabled,
 		focusable:      focusable,
+		graphStyle:     settings.Config.UString("graphStyle", "stars"),
 		maxStars:       settings.Config.UInt("graphStars", 20),
 		name:           settings.Title,
 		starChar:       settings.Config.UString("graphIcon", "|"),
@@ -82,6 +86,14 @@ func (widget *BarGraph) FocusChar() string {
 	return ""
 }
 
+func (widget *BarGraph) Hidden() bool {
+	return widget.hidden
+}
+
+func (widget *BarGraph) Hide() {
+	widget.hidden = true
+}
+
 func (widget *BarGraph) Key() string {
 	return widget.key
 }
@@ -104,10 +116,18 @@ func (widget *BarGraph) SetFocusChar(char string) {
 	return
 }
 
+func (widget *BarGraph) Show() {
+	widget.hidden = false
+}
+
 func (widget *BarGraph) TextView() *tview.TextView {
 	return widget.View
 }
 
+func (widget *BarGraph) Visible() bool {
+	return !widget.Hidden()
+}
+
 func (widget *BarGraph) HelpText() string {
 	return "No help available for this widget"
 }
@@ -117,11 +137,49 @@ func (widget *BarGraph) ConfigText() string {
 }
 
 // BuildBars will build a string of * to represent your data of [time][value]
-// time should be passed as a int64
+// time should be passed as a int64. With `graphStyle: blocks` the bars are drawn as
+// solid blocks instead
 func (widget *BarGraph) BuildBars(data []Bar) {
+	if widget.graphStyle == "blocks" {
+		widget.View.SetText(BuildBlocks(data, widget.maxStars))
+		return
+	}
+
 	widget.View.SetText(BuildStars(data, widget.maxStars, widget.starChar))
 }
 
+// BuildBlocks builds the string to display, drawing each bar as solid blocks filled to
+// the nearest eighth of a character
+func BuildBlocks(data []Bar, width int) string {
+	var buffer bytes.Buffer
+
+	longestLabel := 0
+	for _, bar := range data {
+		if len(bar.Label) > longestLabel {
+			longestLabel = len(bar.Label)
+		}
+	}
+
+	for _, bar := range data {
+		label := bar.ValueLabel
+		if len(label) == 0 {
+			label = fmt.Sprint(bar.Percent)
+		}
+
+		buffer.WriteString(
+			fmt.Sprintf(
+				"%-*s [red]%s[white] %s\n",
+				longestLabel,
+				bar.Label,
+				charts.Bar(float64(bar.Percent), width),
+				label,
+			),
+		)
+	}
+
+	return buffer.String()
+}
+
 //BuildStars build the string to display
 func BuildStars(data []Bar, maxStars int, starChar string) string {
 	var buffer bytes.Buffer
@@ -0,0 +1,62 @@
+package wtf
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// calendarEventsName is the name that calendar modules publish their events under
+const calendarEventsName = "events"
+
+// CalendarEvent is an event that a calendar module has published on the data bus, for
+// other modules such as meeting reminders to use
+type CalendarEvent struct {
+	AllDay      bool
+	Description string
+	End         time.Time
+	Location    string
+	Start       time.Time
+	Title       string
+
+	// URL is the event's video conference link, such as a Google Meet link, when the
+	// calendar knows it. Otherwise it may be in the location or description
+	URL string
+}
+
+/* -------------------- TextWidget -------------------- */
+
+// PublishCalendarEvents puts the widget's upcoming calendar events on the data bus, as
+// "<widget name>.events"
+func (widget *TextWidget) PublishCalendarEvents(events []CalendarEvent) {
+	widget.PublishData(calendarEventsName, events)
+}
+
+/* -------------------- DataBus -------------------- */
+
+// CalendarEvents returns the events published by the named calendar widget or, if the
+// name is empty, by every calendar widget, soonest first
+func (bus *DataBus) CalendarEvents(widgetName string) []CalendarEvent {
+	events := []CalendarEvent{}
+
+	prefix := ""
+	if widgetName != "" {
+		prefix = widgetName + "."
+	}
+
+	for key, value := range bus.Values(prefix) {
+		if key != calendarEventsName && !strings.HasSuffix(key, "."+calendarEventsName) {
+			continue
+		}
+
+		if published, ok := value.([]CalendarEvent); ok {
+			events = append(events, published...)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	return events
+}
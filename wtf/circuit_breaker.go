@@ -0,0 +1,173 @@
+package wtf
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/i18n"
+)
+
+const (
+	defaultCircuitCooldown = 300
+	defaultCircuitFailures = 5
+)
+
+// CircuitBreakers keep track of the API hosts whose requests keep failing, as configured
+// under "wtf.http.circuitBreaker":
+//
+//	wtf:
+//	  http:
+//	    circuitBreaker:
+//	      cooldown: 300
+//	      failures: 5
+//
+// After failures requests in a row to a host fail, its circuit opens and requests to it
+// are refused without being sent for cooldown seconds. The first request after that is
+// let through as a trial: if it fails too, the circuit opens again straight away
+type CircuitBreakers struct {
+	circuits map[string]*circuit
+	cooldown time.Duration
+	failures int
+	mu       sync.Mutex
+}
+
+// CircuitTransport is an http.RoundTripper that refuses requests to hosts whose circuit
+// is open, and counts the failures of the requests it passes on to the underlying
+// transport
+type CircuitTransport struct {
+	Base     http.RoundTripper
+	Breakers *CircuitBreakers
+}
+
+// CircuitOpenError is returned for requests refused because their host's circuit is open
+type CircuitOpenError struct {
+	Host    string
+	RetryAt time.Time
+}
+
+// circuit is one host's run of failures and, while it is open, when it closes
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// Circuits are the circuit breakers shared by every module's HTTP requests
+var Circuits = NewCircuitBreakers(defaultCircuitFailures, defaultCircuitCooldown*time.Second)
+
+// NewCircuitBreakers returns circuit breakers that open after failures requests in a
+// row fail, for the cooldown. With no failures, circuits never open
+func NewCircuitBreakers(failures int, cooldown time.Duration) *CircuitBreakers {
+	return &CircuitBreakers{
+		circuits: make(map[string]*circuit),
+		cooldown: cooldown,
+		failures: failures,
+	}
+}
+
+// ConfigureCircuitBreakers sets the number of failures and the cooldown from the config.
+// The circuits already open stay open
+func ConfigureCircuitBreakers(globalConfig *config.Config) {
+	failures := globalConfig.UInt("wtf.http.circuitBreaker.failures", defaultCircuitFailures)
+	cooldown := globalConfig.UInt("wtf.http.circuitBreaker.cooldown", defaultCircuitCooldown)
+
+	Circuits.mu.Lock()
+	Circuits.failures = failures
+	Circuits.cooldown = time.Duration(cooldown) * time.Second
+	Circuits.mu.Unlock()
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Error returns the message shown in place of the widget's content, such as
+// "circuit open, retrying at 14:05"
+func (circuitErr *CircuitOpenError) Error() string {
+	return i18n.Tf("circuit open, retrying at %s", circuitErr.RetryAt.Format("15:04"))
+}
+
+// Failure counts a failed request to the host, opening its circuit once too many have
+// failed in a row
+func (breakers *CircuitBreakers) Failure(host string, now time.Time) {
+	breakers.mu.Lock()
+	defer breakers.mu.Unlock()
+
+	if breakers.failures <= 0 {
+		return
+	}
+
+	hostCircuit, ok := breakers.circuits[host]
+	if !ok {
+		hostCircuit = &circuit{}
+		breakers.circuits[host] = hostCircuit
+	}
+
+	hostCircuit.failures++
+
+	if hostCircuit.failures >= breakers.failures {
+		hostCircuit.openUntil = now.Add(breakers.cooldown)
+	}
+}
+
+// OpenUntil returns when the host's circuit closes, if it is open
+func (breakers *CircuitBreakers) OpenUntil(host string, now time.Time) (time.Time, bool) {
+	breakers.mu.Lock()
+	defer breakers.mu.Unlock()
+
+	hostCircuit, ok := breakers.circuits[host]
+	if !ok || !now.Before(hostCircuit.openUntil) {
+		return time.Time{}, false
+	}
+
+	return hostCircuit.openUntil, true
+}
+
+// Success closes the host's circuit and forgets its failures
+func (breakers *CircuitBreakers) Success(host string) {
+	breakers.mu.Lock()
+	defer breakers.mu.Unlock()
+
+	delete(breakers.circuits, host)
+}
+
+// Wait returns how long to wait before the next request to the host, given the usual
+// interval. While the host's circuit is open, that's until it closes
+func (breakers *CircuitBreakers) Wait(host string, interval time.Duration, now time.Time) time.Duration {
+	openUntil, ok := breakers.OpenUntil(host, now)
+	if !ok {
+		return interval
+	}
+
+	if wait := openUntil.Sub(now); wait > interval {
+		return wait
+	}
+
+	return interval
+}
+
+// RoundTrip refuses the request if its host's circuit is open, and otherwise sends it,
+// counting server errors and failures to get a response
+func (transport *CircuitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	if openUntil, ok := transport.Breakers.OpenUntil(host, time.Now()); ok {
+		return nil, &CircuitOpenError{Host: host, RetryAt: openUntil}
+	}
+
+	resp, err := transport.Base.RoundTrip(req)
+
+	switch {
+	case err != nil:
+		// Requests the module gave up on say nothing about the host
+		if req.Context().Err() != context.Canceled {
+			transport.Breakers.Failure(host, time.Now())
+		}
+	case resp.StatusCode >= 500:
+		transport.Breakers.Failure(host, time.Now())
+	default:
+		transport.Breakers.Success(host)
+	}
+
+	return resp, err
+}
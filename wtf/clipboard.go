@@ -0,0 +1,118 @@
+package wtf
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/olebedev/config"
+)
+
+// osc52MaxLength is the most data many terminals accept in a single OSC 52 sequence
+const osc52MaxLength = 100000
+
+var (
+	clipboardMode = "auto"
+	clipboardMu   sync.Mutex
+)
+
+// ConfigureClipboard sets how text is copied to the clipboard from the "wtf.clipboard"
+// setting: "system" uses the platform's clipboard tool, "osc52" asks the terminal to
+// do it, which works over SSH, and "auto", the default, uses OSC 52 in SSH sessions and
+// wherever there is no clipboard tool, and the clipboard tool everywhere else
+func ConfigureClipboard(globalConfig *config.Config) {
+	clipboardMu.Lock()
+	defer clipboardMu.Unlock()
+
+	clipboardMode = globalConfig.UString("wtf.clipboard", "auto")
+}
+
+// CopyToClipboard puts the text on the clipboard
+func CopyToClipboard(text string) error {
+	clipboardMu.Lock()
+	mode := clipboardMode
+	clipboardMu.Unlock()
+
+	switch mode {
+	case "osc52":
+		return copyWithOSC52(text)
+	case "system":
+		return copyWithTool(text)
+	}
+
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return copyWithOSC52(text)
+	}
+
+	if err := copyWithTool(text); err == nil {
+		return nil
+	}
+
+	return copyWithOSC52(text)
+}
+
+// OSC52Sequence returns the terminal escape sequence that puts the text on the
+// clipboard. Inside tmux the sequence is wrapped so that tmux passes it on to the
+// terminal
+func OSC52Sequence(text string, inTmux bool) string {
+	seq := fmt.Sprintf("\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+
+	if inTmux {
+		seq = "\x1bPtmux;" + strings.Replace(seq, "\x1b", "\x1b\x1b", -1) + "\x1b\\"
+	}
+
+	return seq
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// clipboardTool returns the command that copies its standard input to the clipboard
+func clipboardTool() (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("pbcopy"), nil
+	case "windows":
+		return exec.Command("clip"), nil
+	}
+
+	candidates := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append([][]string{{"wl-copy"}}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err == nil {
+			return exec.Command(candidate[0], candidate[1:]...), nil
+		}
+	}
+
+	return nil, errors.New("no clipboard tool found; install xclip, xsel, or wl-copy")
+}
+
+func copyWithOSC52(text string) error {
+	if len(text) > osc52MaxLength {
+		return errors.New("too much text to copy through the terminal")
+	}
+
+	_, err := os.Stdout.WriteString(OSC52Sequence(text, os.Getenv("TMUX") != ""))
+	return err
+}
+
+func copyWithTool(text string) error {
+	cmd, err := clipboardTool()
+	if err != nil {
+		return err
+	}
+
+	cmd.Stdin = strings.NewReader(text)
+
+	return cmd.Run()
+}
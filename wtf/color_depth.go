@@ -0,0 +1,104 @@
+package wtf
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell"
+)
+
+// The number of colors a terminal can display
+const (
+	ColorDepth8    = 8
+	ColorDepth16   = 16
+	ColorDepth256  = 256
+	ColorDepthTrue = 1 << 24
+)
+
+// colorDepth is the number of colors the terminal can display. Colors that the terminal
+// cannot display are replaced by the nearest color it can
+var colorDepth = ColorDepthTrue
+
+var colorTagRegExp = regexp.MustCompile(`\[[a-zA-Z0-9#:\-]*\]`)
+var hexColorRegExp = regexp.MustCompile(`#[0-9a-fA-F]{6}`)
+
+// DetectColorDepth returns the color depth for the given `wtf.colorDepth` setting.
+// Valid settings are "truecolor", "256", "16", and "8". Anything else, including
+// "auto", inspects $COLORTERM and $TERM to work out what the terminal supports
+func DetectColorDepth(setting string) int {
+	switch strings.ToLower(setting) {
+	case "truecolor", "24bit":
+		return ColorDepthTrue
+	case "256":
+		return ColorDepth256
+	case "16":
+		return ColorDepth16
+	case "8":
+		return ColorDepth8
+	}
+
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	term := strings.ToLower(os.Getenv("TERM"))
+
+	switch {
+	case colorTerm == "truecolor" || colorTerm == "24bit":
+		return ColorDepthTrue
+	case strings.Contains(term, "256color"):
+		return ColorDepth256
+	case strings.Contains(term, "color") || strings.HasPrefix(term, "xterm"):
+		return ColorDepth16
+	default:
+		return ColorDepth8
+	}
+}
+
+// SetColorDepth sets the number of colors the terminal can display. Text drawn after
+// it's called is fitted to the new depth, but tcell only picks up a change to or from
+// true color when the screen is initialized
+func SetColorDepth(depth int) {
+	colorDepth = depth
+
+	// tcell maps true colors onto the terminal's palette itself, but only if it has
+	// been told not to send them to the terminal as-is
+	if depth < ColorDepthTrue {
+		os.Setenv("TCELL_TRUECOLOR", "disable")
+	}
+}
+
+// FitColors replaces the hex colors in the text's color tags with the nearest colors the
+// terminal can display. Terminals with 256 or more colors are handled by tcell, so the
+// text is returned unchanged
+func FitColors(text string) string {
+	if colorDepth >= ColorDepth256 {
+		return text
+	}
+
+	return colorTagRegExp.ReplaceAllStringFunc(text, func(tag string) string {
+		return hexColorRegExp.ReplaceAllStringFunc(tag, func(hex string) string {
+			return hexFor(fitColor(tcell.GetColor(strings.ToLower(hex))))
+		})
+	})
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// fitColor returns the nearest color to the given one that the terminal can display
+func fitColor(color tcell.Color) tcell.Color {
+	if colorDepth >= ColorDepth256 || color == tcell.ColorDefault {
+		return color
+	}
+
+	palette := make([]tcell.Color, colorDepth)
+	for i := range palette {
+		palette[i] = tcell.Color(i)
+	}
+
+	return tcell.FindColor(color, palette)
+}
+
+func hexFor(color tcell.Color) string {
+	r, g, b := color.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
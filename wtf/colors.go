@@ -423,7 +423,11 @@ func ASCIItoTviewColors(text string) string {
 
 func ColorFor(label string) tcell.Color {
 	if _, ok := colors[label]; ok {
-		return colors[label]
+		return fitColor(colors[label])
+	}
+
+	if strings.HasPrefix(label, "#") {
+		return fitColor(tcell.GetColor(label))
 	}
 
 	return tcell.ColorGreen
@@ -0,0 +1,153 @@
+package wtf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+const commandPalettePageName = "commandPalette"
+
+type paletteCommand struct {
+	fn   func(args []string) error
+	help string
+	name string
+}
+
+// CommandPalette is a vim-style command line that opens along the bottom of the screen
+// and runs named commands, such as `:refresh github` or `:quit`
+type CommandPalette struct {
+	app      *tview.Application
+	commands map[string]*paletteCommand
+	focus    tview.Primitive
+	input    *tview.InputField
+	pages    *tview.Pages
+	visible  bool
+}
+
+// NewCommandPalette creates and returns an instance of CommandPalette with no commands
+func NewCommandPalette(app *tview.Application, pages *tview.Pages) *CommandPalette {
+	palette := CommandPalette{
+		app:      app,
+		commands: make(map[string]*paletteCommand),
+		pages:    pages,
+	}
+
+	palette.input = tview.NewInputField()
+	palette.input.SetLabel(":")
+	palette.input.SetFieldBackgroundColor(tcell.ColorBlack)
+	palette.input.SetDoneFunc(palette.done)
+
+	return &palette
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Add registers a command. Aliases are alternative names for the same command
+// Example:
+//
+//	palette.Add("quit", "Quit wtf", quitFunc, "q")
+func (palette *CommandPalette) Add(name, help string, fn func(args []string) error, aliases ...string) {
+	cmd := &paletteCommand{fn: fn, help: help, name: name}
+
+	palette.commands[name] = cmd
+	for _, alias := range aliases {
+		palette.commands[alias] = cmd
+	}
+}
+
+// Exec runs the command named by the first of the args with the rest of them, as when
+// the app is controlled remotely
+func (palette *CommandPalette) Exec(args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	cmd, ok := palette.commands[args[0]]
+	if !ok {
+		return fmt.Errorf("not a command: %s", args[0])
+	}
+
+	return cmd.fn(args[1:])
+}
+
+// HelpText returns the list of commands and what they do
+func (palette *CommandPalette) HelpText() string {
+	names := []string{}
+	for name, cmd := range palette.commands {
+		if name == cmd.name {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	str := " [green::b]Commands[white]\n\n"
+	for _, name := range names {
+		str += fmt.Sprintf("  :%-16s\t%s\n", name, palette.commands[name].help)
+	}
+
+	return str
+}
+
+// Hide closes the palette and returns the focus to wherever it was before
+func (palette *CommandPalette) Hide() {
+	if !palette.visible {
+		return
+	}
+
+	palette.visible = false
+	palette.pages.RemovePage(commandPalettePageName)
+
+	if palette.focus != nil {
+		palette.app.SetFocus(palette.focus)
+	}
+}
+
+// Run parses and runs a single command line
+func (palette *CommandPalette) Run(line string) error {
+	return palette.Exec(strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), ":")))
+}
+
+// Show opens the palette along the bottom of the screen
+func (palette *CommandPalette) Show() {
+	palette.focus = palette.app.GetFocus()
+	palette.visible = true
+
+	palette.input.SetLabel(":")
+	palette.input.SetText("")
+
+	flex := tview.NewFlex()
+	flex.SetDirection(tview.FlexRow)
+	flex.AddItem(nil, 0, 1, false)
+	flex.AddItem(palette.input, 1, 0, true)
+
+	palette.pages.AddPage(commandPalettePageName, flex, true, true)
+	palette.app.SetFocus(palette.input)
+}
+
+// Visible returns true if the palette is onscreen
+func (palette *CommandPalette) Visible() bool {
+	return palette.visible
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// done runs the entered command when Enter is pressed. The palette is closed first so
+// that commands can move the focus. If the command fails, the palette reopens to show
+// the error
+func (palette *CommandPalette) done(key tcell.Key) {
+	line := palette.input.GetText()
+	palette.Hide()
+
+	if key != tcell.KeyEnter {
+		return
+	}
+
+	if err := palette.Run(line); err != nil {
+		palette.Show()
+		palette.input.SetLabel(fmt.Sprintf("[red]%s[white] :", err.Error()))
+	}
+}
@@ -0,0 +1,102 @@
+package wtf
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/i18n"
+)
+
+const confirmDialogPageName = "confirm"
+const confirmDialogHeight = 7
+
+// NewConfirmDialog creates and returns a popup that asks a yes or no question, such as
+// "Delete the selected item?". y confirms, and n, Esc, or Enter says no. doneFunc is
+// called with whether or not the question was confirmed
+func NewConfirmDialog(question string, doneFunc func(confirmed bool)) *tview.Frame {
+	promptsOpen++
+	closed := false
+	done := func(confirmed bool) {
+		if closed {
+			return
+		}
+		closed = true
+		promptsOpen--
+
+		doneFunc(confirmed)
+	}
+
+	textView := tview.NewTextView()
+	textView.SetDynamicColors(true)
+	textView.SetTextAlign(tview.AlignCenter)
+	textView.SetWrap(true)
+	textView.SetText(fmt.Sprintf("%s [::b]y/N[::-]", tview.Escape(question)))
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEsc, event.Key() == tcell.KeyEnter:
+			done(false)
+		case event.Key() == tcell.KeyRune && unicode.ToLower(event.Rune()) == 'y':
+			done(true)
+		case event.Key() == tcell.KeyRune && unicode.ToLower(event.Rune()) == 'n':
+			done(false)
+		}
+
+		return nil
+	})
+
+	frame := tview.NewFrame(textView)
+	frame.SetBorder(true)
+	frame.SetBorders(1, 1, 0, 1, 1, 1)
+	frame.AddText(i18n.T("y to confirm, n or Esc to cancel"), false, tview.AlignCenter, tcell.ColorGray)
+
+	width := tview.TaggedStringWidth(question) + 12
+	if width < modalWidth/2 {
+		width = modalWidth / 2
+	}
+	if width > modalWidth {
+		width = modalWidth
+	}
+
+	frame.SetRect(offscreen, offscreen, width, confirmDialogHeight)
+	frame.SetDrawFunc(func(screen tcell.Screen, x, y, w, h int) (int, int, int, int) {
+		screenWidth, screenHeight := screen.Size()
+		frame.SetRect((screenWidth/2)-(width/2), (screenHeight/2)-(confirmDialogHeight/2), width, confirmDialogHeight)
+		return x, y, w, h
+	})
+
+	return frame
+}
+
+/* -------------------- KeyboardWidget -------------------- */
+
+// Confirm asks the question and, if it's confirmed, calls fn. Modules ask before doing
+// anything that can't be undone, unless `confirmations` is turned off for the module or
+// under "wtf", in which case fn is called straight away
+// Example:
+//
+//	widget.SetKeyboardChar("d", func() { widget.Confirm("Delete the selected item?", widget.deleteSelected) }, "Delete item")
+func (widget *KeyboardWidget) Confirm(question string, fn func()) {
+	if widget.settings != nil && !widget.settings.Confirmations {
+		fn()
+		return
+	}
+
+	doneFunc := func(confirmed bool) {
+		widget.pages.RemovePage(confirmDialogPageName)
+		widget.app.SetFocus(widget.view)
+
+		if confirmed {
+			fn()
+		}
+	}
+
+	dialog := NewConfirmDialog(question, doneFunc)
+
+	widget.app.QueueUpdateDraw(func() {
+		widget.pages.AddPage(confirmDialogPageName, dialog, false, true)
+		widget.app.SetFocus(dialog)
+	})
+}
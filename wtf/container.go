@@ -0,0 +1,17 @@
+package wtf
+
+// FocusChild shows a container's child widget as focused or not. The children of a
+// container never receive focus from the app themselves, so they take it from their
+// container: a focused child has a focused border and highlights its selected row
+func FocusChild(child Wtfable, focused bool) {
+	view := child.TextView()
+
+	if focused {
+		view.Focus(nil)
+		view.SetBorderColor(ColorFor(child.CommonSettings().Colors.BorderFocused))
+		return
+	}
+
+	view.Blur()
+	view.SetBorderColor(ColorFor(child.BorderColor()))
+}
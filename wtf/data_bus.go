@@ -0,0 +1,181 @@
+package wtf
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// DataBus holds the values that modules publish for other modules to use. Each value is
+// keyed by the name of the widget that published it and the name of the value, such as
+// "weather.temperature" or "gcal.nextEvent"
+type DataBus struct {
+	mu          sync.Mutex
+	subscribers []*dataSubscriber
+	values      map[string]interface{}
+}
+
+type dataSubscriber struct {
+	fn     func(key string, value interface{})
+	prefix string
+}
+
+// Data is the app-wide data bus
+var Data = NewDataBus()
+
+// NewDataBus creates and returns an instance of DataBus
+func NewDataBus() *DataBus {
+	return &DataBus{
+		subscribers: []*dataSubscriber{},
+		values:      make(map[string]interface{}),
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Float returns the value as a float64, if it is a number
+func (bus *DataBus) Float(key string) (float64, bool) {
+	value, ok := bus.Get(key)
+	if !ok {
+		return 0, false
+	}
+
+	switch number := reflect.ValueOf(value); number.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return number.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(number.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(number.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+// Get returns the value published under the key, if there is one
+func (bus *DataBus) Get(key string) (interface{}, bool) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	value, ok := bus.values[key]
+	return value, ok
+}
+
+// Publish sets the value of the key and, if it has changed, tells the key's subscribers
+func (bus *DataBus) Publish(key string, value interface{}) {
+	bus.mu.Lock()
+	previous, ok := bus.values[key]
+	if ok && reflect.DeepEqual(previous, value) {
+		bus.mu.Unlock()
+		return
+	}
+
+	bus.values[key] = value
+	subscribers := bus.subscribers
+	bus.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		if strings.HasPrefix(key, subscriber.prefix) {
+			subscriber.fn(key, value)
+		}
+	}
+}
+
+// Render executes the template text with a "data" function that looks up values on
+// the bus. Values that haven't been published render as empty strings:
+//
+//	{{ data "weather.temperature" | printf "%.0f°" }} {{ data "gcal.nextEvent" }}
+func (bus *DataBus) Render(text string) (string, error) {
+	tmpl, err := template.New("data").Funcs(bus.TemplateFuncs()).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// String returns the value formatted as a string, or an empty string if there is none
+func (bus *DataBus) String(key string) string {
+	value, ok := bus.Get(key)
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+// Subscribe registers a function that is called every time a value whose key starts
+// with the prefix changes. An empty prefix subscribes to every value. Returns a function
+// that ends the subscription
+func (bus *DataBus) Subscribe(prefix string, fn func(key string, value interface{})) func() {
+	subscriber := &dataSubscriber{fn: fn, prefix: prefix}
+
+	bus.mu.Lock()
+	bus.subscribers = append(bus.subscribers, subscriber)
+	bus.mu.Unlock()
+
+	return func() {
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+
+		// The list is replaced rather than changed, as it may be being published to
+		subscribers := []*dataSubscriber{}
+		for _, other := range bus.subscribers {
+			if other != subscriber {
+				subscribers = append(subscribers, other)
+			}
+		}
+
+		bus.subscribers = subscribers
+	}
+}
+
+// TemplateFuncs returns the functions that give templates access to the bus
+func (bus *DataBus) TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"data": func(key string) interface{} {
+			value, ok := bus.Get(key)
+			if !ok {
+				return ""
+			}
+
+			return value
+		},
+	}
+}
+
+// Time returns the value as a time.Time, if it is one
+func (bus *DataBus) Time(key string) (time.Time, bool) {
+	value, ok := bus.Get(key)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, ok := value.(time.Time)
+	return t, ok
+}
+
+// Values returns the values whose keys start with the prefix, keyed by the rest of
+// their keys
+func (bus *DataBus) Values(prefix string) map[string]interface{} {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	values := make(map[string]interface{})
+	for key, value := range bus.values {
+		if strings.HasPrefix(key, prefix) {
+			values[strings.TrimPrefix(key, prefix)] = value
+		}
+	}
+
+	return values
+}
@@ -1,8 +1,9 @@
 package wtf
 
 import (
-	"fmt"
 	"time"
+
+	"github.com/wtfutil/wtf/i18n"
 )
 
 // DateFormat defines the format we expect to receive dates from BambooHR in
@@ -23,7 +24,7 @@ func Now() time.Time {
 
 func PrettyDate(dateStr string) string {
 	newTime, _ := time.Parse(DateFormat, dateStr)
-	return fmt.Sprint(newTime.Format("Jan 2, 2006"))
+	return i18n.FormatDate(newTime, "Jan 2, 2006")
 }
 
 func Tomorrow() time.Time {
@@ -1,55 +1,365 @@
 package wtf
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/olebedev/config"
 	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cfg"
 )
 
 type Display struct {
-	Grid   *tview.Grid
-	config *config.Config
+	Pages *tview.Pages
+
+	ambient     []Wtfable
+	currentPage int
+	pages       []*DisplayPage
+	scheduler   *Scheduler
+	theme       cfg.Theme
+	zoomed      Wtfable
+}
+
+const ambientPageName = "ambient"
+const zoomPageName = "zoom"
+
+// refreshAnimationInterval is how often the refresh spinners in the title bars move
+const refreshAnimationInterval = 150 * time.Millisecond
+
+// titleRedrawable is implemented by widgets that can redraw their title bar on demand
+type titleRedrawable interface {
+	RedrawTitle()
 }
 
 func NewDisplay(widgets []Wtfable, config *config.Config) *Display {
 	display := Display{
-		Grid:   tview.NewGrid(),
-		config: config,
+		Pages:     tview.NewPages(),
+		pages:     pagesFromConfig(config),
+		scheduler: NewScheduler(config),
+		theme:     cfg.NewThemeFromConfig(config),
 	}
 
 	display.build(widgets)
-	display.Grid.SetBackgroundColor(ColorFor(config.UString("wtf.colors.background", "black")))
+	display.Pages.SetBackgroundColor(ColorFor(display.theme.Background))
 
 	return &display
 }
 
-/* -------------------- Unexported Functions -------------------- */
+/* -------------------- Exported Functions -------------------- */
+
+// CurrentPage returns the page that is currently onscreen
+func (display *Display) CurrentPage() *DisplayPage {
+	return display.pages[display.currentPage]
+}
+
+// NextPage displays the next page. If the current page is the last page it wraps around
+// to the first page
+func (display *Display) NextPage() {
+	display.ShowPage((display.currentPage + 1) % len(display.pages))
+}
+
+// PageCount returns the number of pages in the display
+func (display *Display) PageCount() int {
+	return len(display.pages)
+}
+
+// PageIndex returns the index of the page with the given name, or -1 if there is no
+// such page
+func (display *Display) PageIndex(name string) int {
+	for idx, page := range display.pages {
+		if page.Name == name {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// PrevPage displays the previous page. If the current page is the first page it wraps
+// around to the last page
+func (display *Display) PrevPage() {
+	display.ShowPage((display.currentPage - 1 + len(display.pages)) % len(display.pages))
+}
+
+// Reposition moves the widget to the location defined by its current position settings
+func (display *Display) Reposition(widget Wtfable) {
+	for _, page := range display.pages {
+		for _, pageWidget := range page.Widgets {
+			if pageWidget == widget {
+				page.Grid.RemoveItem(widget.TextView())
+				page.place(widget)
+				return
+			}
+		}
+	}
+}
+
+// HideAmbient puts the grid of the current page back in place of the ambient layout
+func (display *Display) HideAmbient() {
+	if display.ambient == nil {
+		return
+	}
+
+	display.ambient = nil
+	display.Pages.RemovePage(ambientPageName)
+	display.ShowPage(display.currentPage)
+}
+
+// Resize switches every page to the grid layout that best fits the given terminal
+// width. Returns true if any page's layout changed
+func (display *Display) Resize(width int) bool {
+	changed := false
+
+	for _, page := range display.pages {
+		if page.resize(width) {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// Scheduler returns the scheduler that refreshes the display's widgets
+func (display *Display) Scheduler() *Scheduler {
+	return display.scheduler
+}
+
+// ShowAmbient replaces the grid with a layout for ambient mode: the featured widgets side
+// by side across the top two thirds of the display, above the other widgets. Every
+// widget that isn't in it is hidden so that it stops refreshing
+func (display *Display) ShowAmbient(featured, others []Wtfable) {
+	display.Unzoom()
+
+	for _, page := range display.pages {
+		page.hide()
+	}
+
+	layout := tview.NewFlex()
+	layout.SetDirection(tview.FlexRow)
+
+	if len(featured) > 0 {
+		layout.AddItem(ambientRow(featured), 0, 2, false)
+	}
+	if len(others) > 0 {
+		layout.AddItem(ambientRow(others), 0, 1, false)
+	}
+
+	display.ambient = append(append([]Wtfable{}, featured...), others...)
+	for _, widget := range display.ambient {
+		widget.Show()
+	}
+
+	display.Pages.AddPage(ambientPageName, layout, true, true)
+}
+
+// ShowPage brings the page at the given index onscreen, and hides the widgets on every
+// other page so that they stop refreshing
+func (display *Display) ShowPage(idx int) {
+	if idx < 0 || idx >= len(display.pages) {
+		return
+	}
+
+	display.Unzoom()
+	display.currentPage = idx
+
+	for pageIdx, page := range display.pages {
+		if pageIdx != idx {
+			page.hide()
+		}
+	}
+
+	page := display.CurrentPage()
+	page.show()
+
+	display.Pages.SwitchToPage(page.Name)
+}
+
+// ToggleZoom expands the widget to fill the entire display. If a widget is already
+// zoomed, it is restored to its place in the grid instead
+func (display *Display) ToggleZoom(widget Wtfable) {
+	if display.zoomed != nil {
+		display.Unzoom()
+		return
+	}
 
-func (display *Display) add(widget Wtfable) {
-	if widget.Disabled() {
+	display.Zoom(widget)
+}
+
+// Unzoom restores the zoomed widget to its place in the grid
+func (display *Display) Unzoom() {
+	if display.zoomed == nil {
 		return
 	}
 
-	display.Grid.AddItem(
-		widget.TextView(),
-		widget.CommonSettings().Top,
-		widget.CommonSettings().Left,
-		widget.CommonSettings().Height,
-		widget.CommonSettings().Width,
-		0,
-		0,
-		false,
-	)
+	display.zoomed = nil
+	display.Pages.RemovePage(zoomPageName)
+	display.Pages.SwitchToPage(display.CurrentPage().Name)
 }
 
-func (display *Display) build(widgets []Wtfable) *tview.Grid {
-	display.Grid.SetColumns(ToInts(display.config.UList("wtf.grid.columns"))...)
-	display.Grid.SetRows(ToInts(display.config.UList("wtf.grid.rows"))...)
-	display.Grid.SetBorder(false)
+// WidgetAt returns the onscreen widget at the given screen coordinates, or nil if there
+// is none
+func (display *Display) WidgetAt(x, y int) Wtfable {
+	if display.zoomed != nil {
+		return display.zoomed
+	}
+
+	widgets := display.CurrentPage().Widgets
+	if display.ambient != nil {
+		widgets = display.ambient
+	}
 
 	for _, widget := range widgets {
-		display.add(widget)
-		go Schedule(widget)
+		if widget.Disabled() {
+			continue
+		}
+
+		left, top, width, height := widget.TextView().GetRect()
+		if x >= left && x < left+width && y >= top && y < top+height {
+			return widget
+		}
+	}
+
+	return nil
+}
+
+// Zoom expands the widget to fill the entire display, hiding the rest of the grid. The
+// grid's page is hidden rather than covered, so none of it shows around a widget that
+// doesn't draw every cell
+func (display *Display) Zoom(widget Wtfable) {
+	if widget == nil {
+		return
+	}
+
+	display.zoomed = widget
+	display.Pages.AddPage(zoomPageName, widget.TextView(), true, false)
+	display.Pages.SwitchToPage(zoomPageName)
+}
+
+// Zoomed returns true if a widget is currently expanded to fill the display
+func (display *Display) Zoomed() bool {
+	return display.zoomed != nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (display *Display) build(widgets []Wtfable) {
+	for _, page := range display.pages {
+		page.Grid = tview.NewGrid()
+		page.Grid.SetColumns(page.columns...)
+		page.Grid.SetRows(page.rows...)
+		page.Grid.SetBorder(false)
+		page.Grid.SetBackgroundColor(ColorFor(display.theme.Background))
+	}
+
+	for _, widget := range widgets {
+		display.pageFor(widget).add(widget)
+	}
+
+	for idx, page := range display.pages {
+		display.Pages.AddPage(page.Name, display.pageRoot(page), true, idx == 0)
+
+		// Only the widgets on the first page start off onscreen
+		if idx > 0 {
+			page.hide()
+		}
+	}
+
+	display.scheduler.Start(widgets)
+
+	go display.animateRefreshStatus(widgets)
+	go tickRelativeTimes(widgets)
+}
+
+// ambientRow lays the widgets out side by side, each as wide as the others
+func ambientRow(widgets []Wtfable) *tview.Flex {
+	row := tview.NewFlex()
+
+	for _, widget := range widgets {
+		row.AddItem(widget.TextView(), 0, 1, false)
+	}
+
+	return row
+}
+
+// animateRefreshStatus keeps the refresh status in the title bars of the onscreen
+// widgets up to date, spinning the spinners of widgets that are refreshing. It stops
+// once the widgets have been disabled
+func (display *Display) animateRefreshStatus(widgets []Wtfable) {
+	lastStatuses := make(map[string]RefreshStatus)
+
+	tick := time.NewTicker(refreshAnimationInterval)
+	defer tick.Stop()
+
+	for range tick.C {
+		active := false
+
+		for _, widget := range widgets {
+			if widget.Disabled() {
+				continue
+			}
+			active = true
+
+			redrawable, ok := widget.(titleRedrawable)
+			if !ok || widget.Hidden() {
+				continue
+			}
+
+			status := RefreshStatuses.Status(widget.Name())
+			if status.Refreshing || status != lastStatuses[widget.Name()] {
+				redrawable.RedrawTitle()
+			}
+
+			lastStatuses[widget.Name()] = status
+		}
+
+		if !active {
+			return
+		}
+	}
+}
+
+// pageFor returns the page the widget has been configured to appear on. Widgets that
+// do not specify a page, or specify one that does not exist, go on the first page
+func (display *Display) pageFor(widget Wtfable) *DisplayPage {
+	for _, page := range display.pages {
+		if page.Name == widget.CommonSettings().Page {
+			return page
+		}
+	}
+
+	return display.pages[0]
+}
+
+// pageRoot returns the primitive that represents the page onscreen. When there is more
+// than one page, a tab bar listing all the pages is displayed above the grid
+func (display *Display) pageRoot(page *DisplayPage) tview.Primitive {
+	if len(display.pages) < 2 {
+		return page.Grid
+	}
+
+	page.tabBar = tview.NewTextView()
+	page.tabBar.SetBackgroundColor(ColorFor(display.theme.Background))
+	page.tabBar.SetDynamicColors(true)
+	page.tabBar.SetText(display.tabBarText(page))
+
+	flex := tview.NewFlex()
+	flex.SetDirection(tview.FlexRow)
+	flex.AddItem(page.tabBar, 1, 0, false)
+	flex.AddItem(page.Grid, 0, 1, false)
+
+	return flex
+}
+
+func (display *Display) tabBarText(current *DisplayPage) string {
+	str := ""
+
+	for idx, page := range display.pages {
+		if page == current {
+			str += fmt.Sprintf("[black:%s] %d %s [-:-] ", display.theme.BorderFocused, idx+1, page.Name)
+		} else {
+			str += fmt.Sprintf("[gray] %d %s [-] ", idx+1, page.Name)
+		}
 	}
 
-	return display.Grid
+	return str
 }
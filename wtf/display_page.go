@@ -0,0 +1,267 @@
+package wtf
+
+import (
+	"fmt"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// DefaultPageName is the name given to the only page when no pages are configured
+const DefaultPageName = "default"
+
+// DisplayPage is a single named grid of widgets. Only one page is onscreen at a time
+type DisplayPage struct {
+	Name    string
+	Grid    *tview.Grid
+	Widgets []Wtfable
+
+	breakpoint  *breakpoint
+	breakpoints []*breakpoint
+	columns     []int
+	rows        []int
+	tabBar      *tview.TextView
+}
+
+// breakpoint is an alternative grid layout that is used when the terminal is no wider
+// than maxWidth
+type breakpoint struct {
+	name     string
+	maxWidth int
+	columns  []int
+	rows     []int
+}
+
+// pagesFromConfig reads the page definitions from the `wtf.pages` config list. Each page
+// may define its own grid; if it does not, the top-level `wtf.grid` is used
+func pagesFromConfig(config *config.Config) []*DisplayPage {
+	columns, rows := gridSizeFromConfig(config, "wtf.grid")
+	breakpoints := breakpointsFromConfig(config, "wtf.grid")
+
+	pages := []*DisplayPage{}
+
+	for idx := range config.UList("wtf.pages") {
+		pageConfig, err := config.Get(fmt.Sprintf("wtf.pages.%d", idx))
+		if err != nil {
+			continue
+		}
+
+		page := &DisplayPage{
+			Name:        pageConfig.UString("name", fmt.Sprintf("Page %d", idx+1)),
+			breakpoints: breakpoints,
+			columns:     columns,
+			rows:        rows,
+		}
+
+		pageColumns, pageRows := gridSizeFromConfig(pageConfig, "grid")
+
+		if len(pageColumns) > 0 {
+			page.columns = pageColumns
+		}
+
+		if len(pageRows) > 0 {
+			page.rows = pageRows
+		}
+
+		if pageBreakpoints := breakpointsFromConfig(pageConfig, "grid"); len(pageBreakpoints) > 0 {
+			page.breakpoints = pageBreakpoints
+		}
+
+		pages = append(pages, page)
+	}
+
+	if len(pages) == 0 {
+		pages = append(pages, &DisplayPage{Name: DefaultPageName, breakpoints: breakpoints, columns: columns, rows: rows})
+	}
+
+	return pages
+}
+
+// gridSizeFromConfig reads the column and row definitions of the grid at the given path.
+// If the grid has a template but no explicit columns or rows, the template's columns
+// and rows share the available space equally
+func gridSizeFromConfig(config *config.Config, gridPath string) ([]int, []int) {
+	columns := ToInts(config.UList(gridPath + ".columns"))
+	rows := ToInts(config.UList(gridPath + ".rows"))
+
+	template, err := cfg.GridTemplateAt(config, gridPath+".template")
+	if template == nil || err != nil {
+		return columns, rows
+	}
+
+	if len(columns) == 0 {
+		columns = make([]int, template.Columns)
+	}
+
+	if len(rows) == 0 {
+		rows = make([]int, template.Rows)
+	}
+
+	return columns, rows
+}
+
+// breakpointsFromConfig reads the list of breakpoints defined under the given grid path.
+// Breakpoints that do not define their own columns or rows use the grid's
+func breakpointsFromConfig(config *config.Config, gridPath string) []*breakpoint {
+	breakpoints := []*breakpoint{}
+
+	for idx := range config.UList(gridPath + ".breakpoints") {
+		path := fmt.Sprintf("%s.breakpoints.%d", gridPath, idx)
+
+		bp := &breakpoint{
+			name:     config.UString(path+".name", fmt.Sprintf("breakpoint%d", idx+1)),
+			maxWidth: config.UInt(path + ".maxWidth"),
+			columns:  ToInts(config.UList(path+".columns", config.UList(gridPath+".columns"))),
+			rows:     ToInts(config.UList(path+".rows", config.UList(gridPath+".rows"))),
+		}
+
+		breakpoints = append(breakpoints, bp)
+	}
+
+	return breakpoints
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (page *DisplayPage) add(widget Wtfable) {
+	page.Widgets = append(page.Widgets, widget)
+	page.place(widget)
+}
+
+// breakpointName returns the name of the active breakpoint, or an empty string if the
+// page is using its default grid
+func (page *DisplayPage) breakpointName() string {
+	if page.breakpoint == nil {
+		return ""
+	}
+
+	return page.breakpoint.name
+}
+
+// breakpointFor returns the narrowest breakpoint that the given terminal width fits
+// within, or nil if the width is wider than every breakpoint
+func (page *DisplayPage) breakpointFor(width int) *breakpoint {
+	var match *breakpoint
+
+	for _, bp := range page.breakpoints {
+		if width > bp.maxWidth {
+			continue
+		}
+
+		if match == nil || bp.maxWidth < match.maxWidth {
+			match = bp
+		}
+	}
+
+	return match
+}
+
+// gridColumns returns the column definitions of the active layout
+func (page *DisplayPage) gridColumns() []int {
+	if page.breakpoint != nil {
+		return page.breakpoint.columns
+	}
+
+	return page.columns
+}
+
+// gridRows returns the row definitions of the active layout
+func (page *DisplayPage) gridRows() []int {
+	if page.breakpoint != nil {
+		return page.breakpoint.rows
+	}
+
+	return page.rows
+}
+
+// hide takes all of this page's widgets offscreen
+func (page *DisplayPage) hide() {
+	for _, widget := range page.Widgets {
+		widget.Hide()
+	}
+}
+
+// place puts the widget onto the grid at the location defined by its position settings
+func (page *DisplayPage) place(widget Wtfable) {
+	if widget.Disabled() {
+		return
+	}
+
+	pos := page.position(widget)
+
+	page.Grid.AddItem(
+		widget.TextView(),
+		pos.Top,
+		pos.Left,
+		pos.Height,
+		pos.Width,
+		0,
+		0,
+		false,
+	)
+}
+
+// position returns the widget's position in the active layout. When a breakpoint is
+// active and the widget does not define a position for it, its default position is
+// squeezed to fit within the breakpoint's grid and remembered for that breakpoint
+func (page *DisplayPage) position(widget Wtfable) *cfg.PositionSettings {
+	pos := &widget.CommonSettings().PositionSettings
+
+	if page.breakpoint == nil {
+		return pos
+	}
+
+	if bpPos, ok := pos.Breakpoints[page.breakpoint.name]; ok {
+		return bpPos
+	}
+
+	cols := len(page.breakpoint.columns)
+	rows := len(page.breakpoint.rows)
+
+	bpPos := &cfg.PositionSettings{
+		Width:  clamp(pos.Width, 1, cols),
+		Height: clamp(pos.Height, 1, rows),
+	}
+	bpPos.Left = clamp(pos.Left, 0, cols-bpPos.Width)
+	bpPos.Top = clamp(pos.Top, 0, rows-bpPos.Height)
+
+	pos.Breakpoints[page.breakpoint.name] = bpPos
+
+	return bpPos
+}
+
+// resize switches the page to the layout that best fits the given terminal width,
+// re-flowing its widgets if the layout changes. Returns true if the layout changed
+func (page *DisplayPage) resize(width int) bool {
+	bp := page.breakpointFor(width)
+	if bp == page.breakpoint {
+		return false
+	}
+
+	page.breakpoint = bp
+
+	page.Grid.Clear()
+	page.Grid.SetColumns(page.gridColumns()...)
+	page.Grid.SetRows(page.gridRows()...)
+
+	for _, widget := range page.Widgets {
+		page.place(widget)
+	}
+
+	return true
+}
+
+// show brings all of this page's widgets onscreen. Widgets that were hidden are refreshed
+// immediately, as their data will be stale
+func (page *DisplayPage) show() {
+	for _, widget := range page.Widgets {
+		if widget.Hidden() {
+			widget.Show()
+
+			if widget.Enabled() {
+				go RefreshWidget(widget)
+			}
+		}
+	}
+}
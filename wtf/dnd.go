@@ -0,0 +1,177 @@
+package wtf
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+// alertColorNames are the colors muted during Do Not Disturb along with the theme's
+// warn and crit colors
+var alertColorNames = map[string]bool{
+	"darkorange": true,
+	"darkred":    true,
+	"orange":     true,
+	"orangered":  true,
+	"red":        true,
+	"yellow":     true,
+}
+
+// DND is the app's Do Not Disturb mode
+var DND = NewDoNotDisturb(nil, "gray")
+
+// DoNotDisturb is a focus time during which desktop notifications aren't shown, the
+// warning and critical colors in widgets are muted to a neutral one, and the modules
+// with `pauseDuringDND` turned on stop refreshing. It's turned on and off by hand, and
+// can follow daily windows set under "wtf.dnd":
+//
+//	wtf:
+//	  dnd:
+//	    mutedColor: gray
+//	    schedule:
+//	      - start: "09:00"
+//	        end: "12:00"
+//	      - start: "14:00"
+//	        end: "16:30"
+//
+// Turning it on or off by hand holds until the schedule next starts or ends a window
+type DoNotDisturb struct {
+	mu         sync.Mutex
+	mutedColor string
+	override   *bool
+	scheduled  bool
+	schedule   []*QuietHours
+}
+
+// NewDoNotDisturb creates and returns an instance of DoNotDisturb that follows the
+// schedule and mutes alert colors to the given color
+func NewDoNotDisturb(schedule []*QuietHours, mutedColor string) *DoNotDisturb {
+	return &DoNotDisturb{
+		mutedColor: mutedColor,
+		schedule:   schedule,
+	}
+}
+
+// ConfigureDoNotDisturb sets up the Do Not Disturb schedule and muted color from the
+// "wtf.dnd" settings. Windows that can't be parsed are ignored. Turning it on or off by
+// hand is kept across config reloads
+func ConfigureDoNotDisturb(globalConfig *config.Config) {
+	schedule := []*QuietHours{}
+
+	windows, _ := globalConfig.List("wtf.dnd.schedule")
+	for _, window := range windows {
+		fields, ok := window.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		start, _ := fields["start"].(string)
+		end, _ := fields["end"].(string)
+
+		if hours, err := NewQuietHours(start, end); err == nil {
+			schedule = append(schedule, hours)
+		}
+	}
+
+	DND.mu.Lock()
+	defer DND.mu.Unlock()
+
+	DND.mutedColor = globalConfig.UString("wtf.dnd.mutedColor", "gray")
+	DND.schedule = schedule
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Active returns TRUE if Do Not Disturb is on at the given time
+func (dnd *DoNotDisturb) Active(now time.Time) bool {
+	dnd.mu.Lock()
+	defer dnd.mu.Unlock()
+
+	return dnd.active(now)
+}
+
+// Mute returns the text with the alert colors in its color tags, which are the given
+// colors and the reds, oranges, and yellows, replaced by the muted color while Do Not
+// Disturb is on. Backgrounds in alert colors are dropped
+func (dnd *DoNotDisturb) Mute(text string, now time.Time, alertColors ...string) string {
+	dnd.mu.Lock()
+	active := dnd.active(now)
+	mutedColor := dnd.mutedColor
+	dnd.mu.Unlock()
+
+	if !active {
+		return text
+	}
+
+	isAlert := func(color string) bool {
+		color = strings.ToLower(color)
+		if alertColorNames[color] {
+			return true
+		}
+
+		for _, alert := range alertColors {
+			if alert != "" && color == strings.ToLower(alert) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return colorTagRegExp.ReplaceAllStringFunc(text, func(tag string) string {
+		fields := strings.Split(tag[1:len(tag)-1], ":")
+
+		if isAlert(fields[0]) {
+			fields[0] = mutedColor
+		}
+		if len(fields) > 1 && isAlert(fields[1]) {
+			fields[1] = "-"
+		}
+
+		return "[" + strings.Join(fields, ":") + "]"
+	})
+}
+
+// Toggle turns Do Not Disturb off if it's on at the given time, and on if it's off, and
+// returns whether it's now on
+func (dnd *DoNotDisturb) Toggle(now time.Time) bool {
+	dnd.mu.Lock()
+	defer dnd.mu.Unlock()
+
+	active := !dnd.active(now)
+	dnd.override = &active
+	dnd.scheduled = dnd.inSchedule(now)
+
+	return active
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// active returns TRUE if Do Not Disturb is on at the given time. A manual override is
+// dropped once the schedule starts or ends a window
+func (dnd *DoNotDisturb) active(now time.Time) bool {
+	scheduled := dnd.inSchedule(now)
+
+	if dnd.override != nil && scheduled != dnd.scheduled {
+		dnd.override = nil
+	}
+
+	if dnd.override != nil {
+		return *dnd.override
+	}
+
+	return scheduled
+}
+
+// inSchedule returns TRUE if the time falls within one of the scheduled windows
+func (dnd *DoNotDisturb) inSchedule(now time.Time) bool {
+	for _, window := range dnd.schedule {
+		if window.Contains(now) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,200 @@
+package wtf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/logger"
+)
+
+// EventHook runs a shell command, posts to a webhook, or both whenever a module sends a
+// matching notification
+type EventHook struct {
+	Command string
+	Event   string
+	Module  string
+	Webhook string
+}
+
+// EventHooks are the hooks that relay notifications to commands and webhooks
+type EventHooks struct {
+	hooks []*EventHook
+	mu    sync.Mutex
+	run   func(hook *EventHook, notification Notification) error
+}
+
+// eventPayload is the JSON body posted to webhooks
+type eventPayload struct {
+	Message string    `json:"message"`
+	Module  string    `json:"module"`
+	Time    time.Time `json:"time"`
+	Title   string    `json:"title"`
+}
+
+// Hooks are the app-wide event hooks, run for every notification that is sent
+var Hooks = NewEventHooks(runEventHook)
+
+var subscribeHooks sync.Once
+
+// NewEventHooks creates and returns an instance of EventHooks that runs its matching
+// hooks with the run function
+func NewEventHooks(run func(hook *EventHook, notification Notification) error) *EventHooks {
+	return &EventHooks{
+		hooks: []*EventHook{},
+		run:   run,
+	}
+}
+
+// ConfigureEventHooks replaces the event hooks with those in the config's "wtf.onEvent"
+// list:
+//
+//	wtf:
+//	  onEvent:
+//	    - module: datadog
+//	      event: monitor alerting
+//	      command: "say 'a monitor went down'"
+//	    - event: build failed
+//	      webhook: https://hooks.example.com/wtf
+//
+// A hook matches the notifications whose title contains its event, ignoring case, from
+// its module, or from any module if it has none. Commands get the notification in the
+// WTF_EVENT_MODULE, WTF_EVENT_TITLE, WTF_EVENT_MESSAGE, and WTF_EVENT_TIME environment
+// variables; webhooks get it as a JSON POST
+func ConfigureEventHooks(globalConfig *config.Config) {
+	hooks := []*EventHook{}
+
+	for _, value := range globalConfig.UList("wtf.onEvent") {
+		hookConfig := &config.Config{Root: value}
+
+		hook := EventHook{
+			Command: hookConfig.UString("command", ""),
+			Event:   hookConfig.UString("event", ""),
+			Module:  hookConfig.UString("module", ""),
+			Webhook: hookConfig.UString("webhook", ""),
+		}
+
+		if hook.Command == "" && hook.Webhook == "" {
+			continue
+		}
+
+		hooks = append(hooks, &hook)
+	}
+
+	Hooks.mu.Lock()
+	Hooks.hooks = hooks
+	Hooks.mu.Unlock()
+
+	subscribeHooks.Do(func() {
+		Notifications.Subscribe(Hooks.Fire)
+	})
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Fire runs every hook that matches the notification, in the background
+func (hooks *EventHooks) Fire(notification Notification) {
+	for _, hook := range hooks.Matching(notification) {
+		go func(hook *EventHook) {
+			if err := hooks.run(hook, notification); err != nil {
+				logger.For("onEvent").Errorf("%s: %v", notification.Title, err)
+			}
+		}(hook)
+	}
+}
+
+// Matches returns TRUE if the hook should run for the notification
+func (hook *EventHook) Matches(notification Notification) bool {
+	if hook.Module != "" && hook.Module != notification.Module {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(notification.Title), strings.ToLower(hook.Event))
+}
+
+// Matching returns the hooks that should run for the notification
+func (hooks *EventHooks) Matching(notification Notification) []*EventHook {
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+
+	matching := []*EventHook{}
+	for _, hook := range hooks.hooks {
+		if hook.Matches(notification) {
+			matching = append(matching, hook)
+		}
+	}
+
+	return matching
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// runEventHook runs the hook's command and posts to its webhook
+func runEventHook(hook *EventHook, notification Notification) error {
+	if hook.Command != "" {
+		if err := runHookCommand(hook.Command, notification); err != nil {
+			return err
+		}
+	}
+
+	if hook.Webhook != "" {
+		return postHookWebhook(hook.Webhook, notification)
+	}
+
+	return nil
+}
+
+func runHookCommand(command string, notification Notification) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	cmd.Env = append(
+		os.Environ(),
+		"WTF_EVENT_MODULE="+notification.Module,
+		"WTF_EVENT_TITLE="+notification.Title,
+		"WTF_EVENT_MESSAGE="+notification.Message,
+		"WTF_EVENT_TIME="+notification.Time.Format(time.RFC3339),
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("command '%s' failed: %v: %s", command, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func postHookWebhook(webhook string, notification Notification) error {
+	body, err := json.Marshal(eventPayload{
+		Message: notification.Message,
+		Module:  notification.Module,
+		Time:    notification.Time,
+		Title:   notification.Title,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := HTTPClient().Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return NewHTTPError(resp)
+	}
+
+	return nil
+}
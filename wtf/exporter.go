@@ -0,0 +1,107 @@
+package wtf
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/utils"
+)
+
+const defaultExportInterval = 30
+
+// Exporter writes a summary of the widgets' data to a file, or to standard output, every
+// so often, so that it can be shown outside the dashboard, such as in tmux's status line.
+// The summary is a template that uses the values on the data bus:
+//
+//	wtf:
+//	  exporter:
+//	    interval: 30
+//	    output: ~/.cache/wtf/status
+//	    template: '#[fg=yellow]{{ data "weather.temperature" | printf "%.0f" }}°#[default] PRs {{ data "github.reviewCount" }}'
+//
+// and then, in tmux.conf:
+//
+//	set -g status-right '#(cat ~/.cache/wtf/status)'
+type Exporter struct {
+	interval time.Duration
+	output   string
+	stop     chan struct{}
+	template string
+}
+
+// NewExporter creates and returns an instance of Exporter, or nil if there is no
+// template to export
+func NewExporter(config *config.Config) *Exporter {
+	template := config.UString("wtf.exporter.template", "")
+	if template == "" {
+		return nil
+	}
+
+	output, _ := utils.ExpandHomeDir(config.UString("wtf.exporter.output", ""))
+
+	return &Exporter{
+		interval: time.Duration(config.UInt("wtf.exporter.interval", defaultExportInterval)) * time.Second,
+		output:   output,
+		stop:     make(chan struct{}),
+		template: template,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Export writes the summary once. A file is replaced all at once, so that whatever
+// reads it never sees it half-written
+func (exporter *Exporter) Export() error {
+	text, err := Data.Render(exporter.template)
+	if err != nil {
+		return err
+	}
+
+	text = strings.Replace(strings.TrimSpace(text), "\n", " ", -1)
+
+	if exporter.ToStdout() {
+		fmt.Println(text)
+		return nil
+	}
+
+	temp := filepath.Join(filepath.Dir(exporter.output), "."+filepath.Base(exporter.output)+".tmp")
+	if err := ioutil.WriteFile(temp, []byte(text+"\n"), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(temp, exporter.output)
+}
+
+// Start exports the summary every interval until the exporter is stopped
+func (exporter *Exporter) Start() {
+	go func() {
+		tick := time.NewTicker(exporter.interval)
+		defer tick.Stop()
+
+		for {
+			_ = exporter.Export()
+
+			select {
+			case <-tick.C:
+			case <-exporter.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops exporting the summary
+func (exporter *Exporter) Stop() {
+	close(exporter.stop)
+}
+
+// ToStdout returns TRUE if the summary is written to standard output rather than to a
+// file
+func (exporter *Exporter) ToStdout() bool {
+	return exporter.output == "" || exporter.output == "-"
+}
@@ -69,6 +69,75 @@ func (tracker *FocusTracker) FocusOn(char string) bool {
 	return hasFocusable
 }
 
+// FocusDirection moves the focus to the nearest onscreen widget in the given direction,
+// where dx and dy are -1, 0, or 1. If no widget has focus, the first widget is focused
+func (tracker *FocusTracker) FocusDirection(dx, dy int) {
+	current := tracker.FocusedWidget()
+	if current == nil {
+		tracker.Next()
+		return
+	}
+
+	cx, cy := centerOf(current)
+
+	var nearest Wtfable
+	nearestScore := 0
+
+	for _, widget := range tracker.focusables() {
+		if widget == current {
+			continue
+		}
+
+		x, y := centerOf(widget)
+
+		// How far the widget is along the direction of travel, and how far off to the side
+		along := (x-cx)*dx + (y-cy)*dy
+		aside := (x-cx)*dy + (y-cy)*dx
+		if along <= 0 {
+			continue
+		}
+		if aside < 0 {
+			aside = -aside
+		}
+
+		score := along + 2*aside
+		if nearest == nil || score < nearestScore {
+			nearest = widget
+			nearestScore = score
+		}
+	}
+
+	if nearest != nil {
+		tracker.FocusOnWidget(nearest)
+	}
+}
+
+// FocusOnWidget sets the focus on the given widget. Returns false if the widget cannot
+// take focus
+func (tracker *FocusTracker) FocusOnWidget(widget Wtfable) bool {
+	for idx, focusable := range tracker.focusables() {
+		if focusable == widget {
+			tracker.blur(tracker.Idx)
+			tracker.Idx = idx
+			tracker.focus(tracker.Idx)
+
+			tracker.IsFocused = true
+			return true
+		}
+	}
+
+	return false
+}
+
+// FocusedWidget returns the widget that currently has focus, or nil if no widget does
+func (tracker *FocusTracker) FocusedWidget() Wtfable {
+	if !tracker.IsFocused {
+		return nil
+	}
+
+	return tracker.focusableAt(tracker.Idx)
+}
+
 // Next sets the focus on the next widget in the widget list. If the current widget is
 // the last widget, sets focus on the first widget.
 func (tracker *FocusTracker) Next() {
@@ -106,6 +175,13 @@ func (tracker *FocusTracker) Prev() {
 	tracker.IsFocused = true
 }
 
+// Reset removes focus from the currently-focused widget and forgets which widget that was.
+// Used when the set of onscreen widgets changes
+func (tracker *FocusTracker) Reset() {
+	tracker.None()
+	tracker.Idx = -1
+}
+
 func (tracker *FocusTracker) Refocus() {
 	tracker.focus(tracker.Idx)
 }
@@ -120,7 +196,7 @@ func (tracker *FocusTracker) assignHotKeys() {
 	}
 
 	usedKeys := make(map[string]bool)
-	focusables := tracker.focusables()
+	focusables := tracker.allFocusables()
 	i := 1
 
 	for _, focusable := range focusables {
@@ -177,13 +253,28 @@ func (tracker *FocusTracker) focus(idx int) {
 	}
 
 	view := widget.TextView()
-	view.SetBorderColor(ColorFor(tracker.config.UString("wtf.colors.border.focused", "gray")))
+	view.SetBorderColor(ColorFor(widget.CommonSettings().Colors.BorderFocused))
 	tracker.App.SetFocus(view)
 }
 
+// focusables returns the focusable widgets that are currently onscreen
 func (tracker *FocusTracker) focusables() []Wtfable {
 	focusable := []Wtfable{}
 
+	for _, widget := range tracker.allFocusables() {
+		if widget.Visible() {
+			focusable = append(focusable, widget)
+		}
+	}
+
+	return focusable
+}
+
+// allFocusables returns every focusable widget, including those on pages that are not
+// currently onscreen
+func (tracker *FocusTracker) allFocusables() []Wtfable {
+	focusable := []Wtfable{}
+
 	for _, widget := range tracker.Widgets {
 		if widget.Focusable() {
 			focusable = append(focusable, widget)
@@ -240,3 +331,9 @@ func (tracker *FocusTracker) increment() {
 func (tracker *FocusTracker) useNavShortcuts() bool {
 	return tracker.config.UBool("wtf.navigation.shortcuts", true)
 }
+
+// centerOf returns the screen coordinates of the center of the widget
+func centerOf(widget Wtfable) (int, int) {
+	x, y, width, height := widget.TextView().GetRect()
+	return x + width/2, y + height/2
+}
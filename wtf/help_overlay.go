@@ -0,0 +1,57 @@
+package wtf
+
+import (
+	"github.com/rivo/tview"
+)
+
+const helpOverlayPageName = "helpOverlay"
+
+// HelpOverlay is a modal that lists the app-wide keys, the commands, and the keys of
+// the widget that currently has focus
+type HelpOverlay struct {
+	app     *tview.Application
+	focus   tview.Primitive
+	pages   *tview.Pages
+	visible bool
+}
+
+// NewHelpOverlay creates and returns an instance of HelpOverlay
+func NewHelpOverlay(app *tview.Application, pages *tview.Pages) *HelpOverlay {
+	overlay := HelpOverlay{
+		app:   app,
+		pages: pages,
+	}
+
+	return &overlay
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Hide closes the overlay and returns the focus to wherever it was before
+func (overlay *HelpOverlay) Hide() {
+	if !overlay.visible {
+		return
+	}
+
+	overlay.visible = false
+	overlay.pages.RemovePage(helpOverlayPageName)
+
+	if overlay.focus != nil {
+		overlay.app.SetFocus(overlay.focus)
+	}
+}
+
+// Show opens the overlay, displaying the given help text
+func (overlay *HelpOverlay) Show(text string) {
+	overlay.focus = overlay.app.GetFocus()
+	overlay.visible = true
+
+	modal := NewBillboardModal(text, overlay.Hide)
+	overlay.pages.AddPage(helpOverlayPageName, modal, false, true)
+	overlay.app.SetFocus(modal)
+}
+
+// Visible returns true if the overlay is onscreen
+func (overlay *HelpOverlay) Visible() bool {
+	return overlay.visible
+}
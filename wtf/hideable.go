@@ -0,0 +1,11 @@
+package wtf
+
+// Hideable is the interface that enforces show/hide capabilities on a module. Hidden
+// modules are still enabled, but are not onscreen and so do not refresh their data
+type Hideable interface {
+	Hidden() bool
+	Visible() bool
+
+	Hide()
+	Show()
+}
@@ -0,0 +1,218 @@
+package wtf
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CachingTransport is an http.RoundTripper that keeps a copy of every cacheable GET
+// response on disk. A cached response is reused until it goes stale, and is then
+// revalidated with If-None-Match and If-Modified-Since so that an unchanged resource
+// costs the server a "304 Not Modified" rather than a full response
+type CachingTransport struct {
+	Base http.RoundTripper
+	Dir  string
+}
+
+// ignoredCacheKeyHeaders are the request headers that don't change which response the
+// server sends, and so don't separate one cache entry from another
+var ignoredCacheKeyHeaders = map[string]bool{
+	"Cache-Control":     true,
+	"If-Modified-Since": true,
+	"If-None-Match":     true,
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// RoundTrip answers GET requests from the cache where it can, and passes every other
+// request on to the underlying transport
+func (transport *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return transport.Base.RoundTrip(req)
+	}
+
+	path := transport.pathFor(req)
+
+	cached := transport.load(path, req)
+	if cached != nil {
+		if isFresh(cached, time.Now()) && !hasDirective(req.Header, "no-cache") {
+			return cached, nil
+		}
+
+		req = conditionalRequest(req, cached)
+	}
+
+	resp, err := transport.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+
+		// The 304 carries the resource's new freshness, so remember it for next time
+		for _, name := range []string{"Cache-Control", "Date", "Expires"} {
+			if val := resp.Header.Get(name); val != "" {
+				cached.Header.Set(name, val)
+			}
+		}
+		transport.store(path, cached)
+
+		return cached, nil
+	}
+
+	if resp.StatusCode == http.StatusOK && isCacheable(resp) {
+		transport.store(path, resp)
+	}
+
+	return resp, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// load returns the cached response to the request, or nil if there isn't one
+func (transport *CachingTransport) load(path string, req *http.Request) *http.Response {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil
+	}
+
+	return resp
+}
+
+// pathFor returns the path of the cache file for the request. Every header the request
+// is sent with goes into the key, so that requests for the same URL made with different
+// credentials are cached separately whichever header, be it Authorization, X-Api-Key, or
+// a cookie, the API takes them in. The headers the cache itself adds are left out
+func (transport *CachingTransport) pathFor(req *http.Request) string {
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		if !ignoredCacheKeyHeaders[http.CanonicalHeaderKey(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	hash.Write([]byte(req.URL.String()))
+	for _, name := range names {
+		fmt.Fprintf(hash, "\n%s: %s", http.CanonicalHeaderKey(name), strings.Join(req.Header[name], ", "))
+	}
+
+	return filepath.Join(transport.Dir, hex.EncodeToString(hash.Sum(nil)))
+}
+
+// store writes the response to the cache, leaving it readable by the caller.
+// Failing to cache a response is not an error; it will simply be fetched again
+func (transport *CachingTransport) store(path string, resp *http.Response) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return
+	}
+
+	data, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(transport.Dir, 0700); err != nil {
+		return
+	}
+
+	ioutil.WriteFile(path, data, 0600)
+}
+
+// conditionalRequest returns a copy of the request that asks the server to send the
+// resource only if it differs from the cached response
+func conditionalRequest(req *http.Request, cached *http.Response) *http.Request {
+	conditional := req.WithContext(req.Context())
+	conditional.Header = make(http.Header, len(req.Header))
+	for name, vals := range req.Header {
+		conditional.Header[name] = vals
+	}
+
+	if etag := cached.Header.Get("ETag"); etag != "" && req.Header.Get("If-None-Match") == "" {
+		conditional.Header.Set("If-None-Match", etag)
+	}
+
+	if modified := cached.Header.Get("Last-Modified"); modified != "" && req.Header.Get("If-Modified-Since") == "" {
+		conditional.Header.Set("If-Modified-Since", modified)
+	}
+
+	return conditional
+}
+
+// hasDirective returns TRUE if the Cache-Control header includes the directive
+func hasDirective(header http.Header, directive string) bool {
+	for _, part := range strings.Split(header.Get("Cache-Control"), ",") {
+		if strings.TrimSpace(part) == directive {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCacheable returns TRUE if the response may be stored and can later be reused or
+// revalidated
+func isCacheable(resp *http.Response) bool {
+	if hasDirective(resp.Header, "no-store") {
+		return false
+	}
+
+	return resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != "" || maxAge(resp) > 0
+}
+
+// isFresh returns TRUE if the cached response can still be used without asking the
+// server whether it has changed
+func isFresh(resp *http.Response, now time.Time) bool {
+	if hasDirective(resp.Header, "no-cache") {
+		return false
+	}
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return false
+	}
+
+	return now.Before(date.Add(maxAge(resp)))
+}
+
+// maxAge returns how long after it was sent the response stays fresh, per its
+// Cache-Control header
+func maxAge(resp *http.Response) time.Duration {
+	for _, part := range strings.Split(resp.Header.Get("Cache-Control"), ",") {
+		part = strings.TrimSpace(part)
+
+		if strings.HasPrefix(part, "max-age=") {
+			seconds, err := strconv.Atoi(strings.TrimPrefix(part, "max-age="))
+			if err != nil {
+				return 0
+			}
+
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	return 0
+}
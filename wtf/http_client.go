@@ -0,0 +1,268 @@
+package wtf
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
+)
+
+const defaultHTTPTimeout = 30
+
+var (
+	httpClient         = &http.Client{}
+	httpClientMu       sync.Mutex
+	insecureHTTPClient = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+)
+
+// hostRoutingTransport sends requests to the insecure hosts through a transport that
+// does not verify certificates, and all other requests through one that does
+type hostRoutingTransport struct {
+	insecureHosts []string
+	insecure      http.RoundTripper
+	secure        http.RoundTripper
+}
+
+// ConfigureHTTP sets up the HTTP client shared by the modules from the "wtf.http"
+// settings:
+//
+//	wtf:
+//	  http:
+//	    cache: true
+//	    caFile: ~/certs/corp-ca.pem
+//	    compression: true
+//	    insecureHosts:
+//	      - jenkins.corp.example.com
+//	    noProxy:
+//	      - localhost
+//	      - .corp.example.com
+//	    proxy: http://proxy.corp.example.com:3128
+//	    timeout: 30
+//
+// Responses are cached on disk and revalidated with ETags, requests are rate limited
+// per host, hosts that keep failing are left alone for a while, and compressed
+// responses are requested and decompressed transparently.
+// Requests go through the proxy, if one is set, except to the hosts in noProxy;
+// without one, the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables apply.
+// The certificates in caFile are trusted as well as the system's, and the certificates
+// of the insecureHosts are not verified at all. The responses to the URLs of transforms
+// are run through Lua scripts, as TransformTransport describes.
+//
+// Modules get the client from HTTPClient; http.DefaultTransport is left as it is
+func ConfigureHTTP(globalConfig *config.Config) error {
+	proxy, err := proxyFromConfig(globalConfig)
+	if err != nil {
+		return err
+	}
+
+	rootCAs, err := rootCAsFromConfig(globalConfig)
+	if err != nil {
+		return err
+	}
+
+	transforms, err := TransformsFromConfig(globalConfig)
+	if err != nil {
+		return err
+	}
+
+	ConfigureRateLimits(globalConfig)
+	ConfigureCircuitBreakers(globalConfig)
+
+	timeout := time.Duration(globalConfig.UInt("wtf.http.timeout", defaultHTTPTimeout)) * time.Second
+	compression := globalConfig.UBool("wtf.http.compression", true)
+
+	secure := newHTTPTransport(proxy, &tls.Config{RootCAs: rootCAs}, timeout, compression)
+	insecure := newHTTPTransport(proxy, &tls.Config{InsecureSkipVerify: true}, timeout, compression)
+
+	transport := wrapTransport(globalConfig, transforms, &hostRoutingTransport{
+		insecureHosts: ToStrs(globalConfig.UList("wtf.http.insecureHosts")),
+		insecure:      insecure,
+		secure:        secure,
+	})
+
+	httpClientMu.Lock()
+	httpClient = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+	insecureHTTPClient = &http.Client{
+		Timeout:   timeout,
+		Transport: wrapTransport(globalConfig, transforms, insecure),
+	}
+	httpClientMu.Unlock()
+
+	return nil
+}
+
+// HTTPClient returns the HTTP client that modules should use to talk to their APIs
+func HTTPClient() *http.Client {
+	httpClientMu.Lock()
+	defer httpClientMu.Unlock()
+
+	return httpClient
+}
+
+// HTTPClientVerifying returns the shared HTTP client or, if verifyServerCertificate is
+// false, a variant of it that does not verify the certificates of the servers it talks
+// to. It's for modules that let their certificate verification be turned off
+func HTTPClientVerifying(verifyServerCertificate bool) *http.Client {
+	if verifyServerCertificate {
+		return HTTPClient()
+	}
+
+	httpClientMu.Lock()
+	defer httpClientMu.Unlock()
+
+	return insecureHTTPClient
+}
+
+// RoundTrip sends the request with certificate verification turned off if its host is
+// one of the insecure hosts
+func (transport *hostRoutingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, host := range transport.insecureHosts {
+		if strings.EqualFold(host, req.URL.Hostname()) {
+			return transport.insecure.RoundTrip(req)
+		}
+	}
+
+	return transport.secure.RoundTrip(req)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func newHTTPTransport(proxy func(*http.Request) (*url.URL, error), tlsConfig *tls.Config, timeout time.Duration, compression bool) *http.Transport {
+	return &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		DisableCompression:    !compression,
+		ExpectContinueTimeout: 1 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          100,
+		ResponseHeaderTimeout: timeout,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+	}
+}
+
+// proxyFromConfig returns the function that picks the proxy for each request
+func proxyFromConfig(globalConfig *config.Config) (func(*http.Request) (*url.URL, error), error) {
+	proxySetting := globalConfig.UString("wtf.http.proxy", "")
+	if proxySetting == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(proxySetting)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid wtf.http.proxy '%s'", proxySetting)
+	}
+
+	noProxy := ToStrs(globalConfig.UList("wtf.http.noProxy"))
+
+	proxy := func(req *http.Request) (*url.URL, error) {
+		if bypassesProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+
+		return proxyURL, nil
+	}
+
+	return proxy, nil
+}
+
+// rootCAsFromConfig returns the system's certificate pool with the certificates in
+// caFile added to it, or nil to use the system's pool as it is
+func rootCAsFromConfig(globalConfig *config.Config) (*x509.CertPool, error) {
+	caFile := globalConfig.UString("wtf.http.caFile", "")
+	if caFile == "" {
+		return nil, nil
+	}
+
+	path, err := utils.ExpandHomeDir(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read wtf.http.caFile: %v", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in wtf.http.caFile '%s'", caFile)
+	}
+
+	return pool, nil
+}
+
+// wrapTransport adds rate limiting, the recording of API quotas, circuit breaking, and,
+// if it's turned on, caching to the transport, and runs the responses it returns through
+// the transforms. The cache keeps the responses as the server sent them, in a cache
+// directory that is pruned like the modules' own
+func wrapTransport(globalConfig *config.Config, transforms []ResponseTransform, base http.RoundTripper) http.RoundTripper {
+	transport := http.RoundTripper(&CircuitTransport{
+		Base:     &QuotaTransport{Base: RateLimit(base), Quotas: Quotas},
+		Breakers: Circuits,
+	})
+
+	if globalConfig.UBool("wtf.http.cache", true) {
+		if cacheDir, err := cfg.CacheDirFor("http"); err == nil {
+			transport = &CachingTransport{
+				Base: transport,
+				Dir:  cacheDir,
+			}
+		}
+	}
+
+	if len(transforms) > 0 {
+		transport = &TransformTransport{Base: transport, Transforms: transforms}
+	}
+
+	return transport
+}
+
+// bypassesProxy returns TRUE if the host matches one of the noProxy entries: "*", a
+// host name, a domain (".example.com" or "example.com" also matches its subdomains), an
+// IP address, or a CIDR range
+func bypassesProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+
+		if entry == "*" || entry == host {
+			return true
+		}
+
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		if strings.HasSuffix(host, "."+strings.TrimPrefix(entry, ".")) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,434 @@
+package wtf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	// Register the formats that images fetched by modules come in
+	_ "image/gif"
+	_ "image/jpeg"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+)
+
+// The ways images can be drawn in the terminal
+const (
+	ImageBlocks = "blocks"
+	ImageKitty  = "kitty"
+	ImageSixel  = "sixel"
+)
+
+// The size, in pixels, that a terminal cell is assumed to be when images are drawn with
+// a graphics protocol
+const (
+	imageCellHeight = 20
+	imageCellWidth  = 10
+)
+
+// kittyChunkSize is the most base64 data the kitty graphics protocol takes in one escape
+// sequence
+const kittyChunkSize = 4096
+
+var (
+	imageProtocol = ImageBlocks
+	imageMu       sync.Mutex
+)
+
+// Images draws the images that widgets show with the terminal's graphics protocol
+var Images = NewImageLayer()
+
+// ConfigureImages sets how images are drawn from the "wtf.images" setting: "kitty" and
+// "sixel" use those graphics protocols, "blocks" draws them with colored block
+// characters, which works in any terminal with true color, and "auto", the default,
+// picks the best the terminal supports
+func ConfigureImages(globalConfig *config.Config) {
+	imageMu.Lock()
+	defer imageMu.Unlock()
+
+	imageProtocol = DetectImageProtocol(globalConfig.UString("wtf.images", "auto"), os.Getenv)
+}
+
+// DetectImageProtocol returns the way images are drawn for the given "wtf.images"
+// setting, looking at the environment to see what the terminal supports when the
+// setting is "auto". Graphics protocols do not make it through tmux, so block
+// characters are used there
+func DetectImageProtocol(setting string, getenv func(string) string) string {
+	switch setting {
+	case ImageBlocks, ImageKitty, ImageSixel:
+		return setting
+	}
+
+	if getenv("TMUX") != "" {
+		return ImageBlocks
+	}
+
+	term := getenv("TERM")
+
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty":
+		return ImageKitty
+	case strings.Contains(term, "sixel"), term == "mlterm", strings.HasPrefix(term, "foot"), getenv("TERM_PROGRAM") == "WezTerm":
+		return ImageSixel
+	default:
+		return ImageBlocks
+	}
+}
+
+// FetchImage downloads and decodes a PNG, JPEG, or GIF image
+func FetchImage(url string) (image.Image, error) {
+	resp, err := HTTPClient().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.New(resp.Status)
+	}
+
+	img, _, err := image.Decode(resp.Body)
+
+	return img, err
+}
+
+// RenderImageBlocks draws the image with colored half-block characters, as text with
+// color tags that fits in the given number of columns and rows. Each character shows
+// two pixels, one above the other, so the pixels come out roughly square
+func RenderImageBlocks(img image.Image, cols, rows int) string {
+	width, height := fitImage(img.Bounds(), cols, rows*2)
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	scaled := scaleImage(img, width, height)
+	lines := []string{}
+
+	for y := 0; y < height; y += 2 {
+		var line strings.Builder
+		lastTag := ""
+
+		for x := 0; x < width; x++ {
+			bottom := "-"
+			if y+1 < height {
+				bottom = hexColor(scaled.At(x, y+1))
+			}
+
+			tag := fmt.Sprintf("[%s:%s]", hexColor(scaled.At(x, y)), bottom)
+			if tag != lastTag {
+				line.WriteString(tag)
+				lastTag = tag
+			}
+
+			line.WriteString("▀")
+		}
+
+		line.WriteString("[-:-]")
+		lines = append(lines, line.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// KittySequence returns the escape sequences that draw the image, scaled to fill the
+// given number of columns and rows, with the kitty graphics protocol
+func KittySequence(img image.Image, cols, rows int) string {
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var seq strings.Builder
+	for first := true; first || len(data) > 0; first = false {
+		chunk := data
+		if len(chunk) > kittyChunkSize {
+			chunk = chunk[:kittyChunkSize]
+		}
+		data = data[len(chunk):]
+
+		more := 0
+		if len(data) > 0 {
+			more = 1
+		}
+
+		if first {
+			fmt.Fprintf(&seq, "\x1b_Ga=T,f=100,q=2,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&seq, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+
+	return seq.String()
+}
+
+// SixelSequence returns the escape sequence that draws the image with sixels, in a
+// palette of 216 colors
+func SixelSequence(img image.Image) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	var seq strings.Builder
+	fmt.Fprintf(&seq, "\x1bPq\"1;1;%d;%d", width, height)
+
+	for idx := 0; idx < 216; idx++ {
+		fmt.Fprintf(&seq, "#%d;2;%d;%d;%d", idx, idx/36*20, idx/6%6*20, idx%6*20)
+	}
+
+	colorAt := func(x, y int) int {
+		r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+		return sixelLevel(r)*36 + sixelLevel(g)*6 + sixelLevel(b)
+	}
+
+	for band := 0; band < height; band += 6 {
+		used := map[int]bool{}
+		for y := band; y < band+6 && y < height; y++ {
+			for x := 0; x < width; x++ {
+				used[colorAt(x, y)] = true
+			}
+		}
+
+		colors := []int{}
+		for idx := range used {
+			colors = append(colors, idx)
+		}
+		sort.Ints(colors)
+
+		for _, idx := range colors {
+			chars := make([]byte, width)
+			for x := 0; x < width; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && band+dy < height; dy++ {
+					if colorAt(x, band+dy) == idx {
+						bits |= 1 << uint(dy)
+					}
+				}
+				chars[x] = byte(63 + bits)
+			}
+
+			fmt.Fprintf(&seq, "#%d%s$", idx, sixelRunLength(chars))
+		}
+
+		seq.WriteString("-")
+	}
+
+	seq.WriteString("\x1b\\")
+
+	return seq.String()
+}
+
+/* -------------------- ImageLayer -------------------- */
+
+// imagePlacement is an image that a widget shows, and where it was last drawn
+type imagePlacement struct {
+	cols    int
+	drawnAt string
+	img     image.Image
+	rows    int
+}
+
+// ImageLayer draws images over the space that widgets leave for them in their content,
+// for terminals with a graphics protocol. Images are drawn at the top-left of the
+// widget's content
+type ImageLayer struct {
+	mu         sync.Mutex
+	placements map[*tview.TextView]*imagePlacement
+	removed    bool
+}
+
+// NewImageLayer creates and returns a new instance of ImageLayer
+func NewImageLayer() *ImageLayer {
+	return &ImageLayer{
+		placements: make(map[*tview.TextView]*imagePlacement),
+	}
+}
+
+// Clear stops drawing every widget's image, for when the widgets are replaced
+func (layer *ImageLayer) Clear() {
+	layer.mu.Lock()
+	defer layer.mu.Unlock()
+
+	layer.placements = make(map[*tview.TextView]*imagePlacement)
+	layer.removed = true
+}
+
+// Draw writes the images that have moved or changed since they were last drawn to the
+// terminal. It is the app's after-draw function
+func (layer *ImageLayer) Draw(screen tcell.Screen) {
+	layer.mu.Lock()
+	defer layer.mu.Unlock()
+
+	imageMu.Lock()
+	protocol := imageProtocol
+	imageMu.Unlock()
+
+	changed := layer.removed
+	for view, placement := range layer.placements {
+		x, y, width, height := view.GetInnerRect()
+		if placement.drawnAt != fmt.Sprintf("%d,%d,%d,%d", x, y, width, height) {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return
+	}
+
+	layer.removed = false
+
+	var out strings.Builder
+
+	// Kitty keeps images on the screen until they are deleted, so clear out the old ones
+	// before drawing them where they are now
+	if protocol == ImageKitty {
+		out.WriteString("\x1b_Ga=d,q=2\x1b\\")
+	}
+
+	for view, placement := range layer.placements {
+		x, y, width, height := view.GetInnerRect()
+		placement.drawnAt = fmt.Sprintf("%d,%d,%d,%d", x, y, width, height)
+
+		cols, rows := placement.cols, placement.rows
+		if cols > width {
+			cols = width
+		}
+		if rows > height {
+			rows = height
+		}
+		if cols <= 0 || rows <= 0 {
+			continue
+		}
+
+		fitWidth, fitHeight := fitImage(placement.img.Bounds(), cols*imageCellWidth, rows*imageCellHeight)
+		scaled := scaleImage(placement.img, fitWidth, fitHeight)
+
+		// Save the cursor, move it to the widget, draw, and put the cursor back
+		fmt.Fprintf(&out, "\x1b7\x1b[%d;%dH", y+1, x+1)
+		if protocol == ImageKitty {
+			out.WriteString(KittySequence(scaled, cols, rows))
+		} else {
+			out.WriteString(SixelSequence(scaled))
+		}
+		out.WriteString("\x1b8")
+	}
+
+	os.Stdout.WriteString(out.String())
+}
+
+// Remove stops drawing the view's image
+func (layer *ImageLayer) Remove(view *tview.TextView) {
+	layer.mu.Lock()
+	defer layer.mu.Unlock()
+
+	if _, ok := layer.placements[view]; ok {
+		delete(layer.placements, view)
+		layer.removed = true
+	}
+}
+
+// Text returns what the widget shows in its content in place of the image: the image
+// drawn with block characters or, if the terminal has a graphics protocol, blank lines
+// for the image to be drawn over
+func (layer *ImageLayer) Text(view *tview.TextView, img image.Image, cols, rows int) string {
+	if img == nil || rows < 1 {
+		layer.Remove(view)
+		return ""
+	}
+
+	imageMu.Lock()
+	protocol := imageProtocol
+	imageMu.Unlock()
+
+	if protocol == ImageBlocks {
+		layer.Remove(view)
+		return RenderImageBlocks(img, cols, rows)
+	}
+
+	layer.mu.Lock()
+	defer layer.mu.Unlock()
+
+	if placement, ok := layer.placements[view]; !ok || placement.img != img || placement.cols != cols || placement.rows != rows {
+		layer.placements[view] = &imagePlacement{cols: cols, img: img, rows: rows}
+	}
+
+	return strings.Repeat("\n", rows-1)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// fitImage returns the largest size the image can be scaled to, keeping its shape, that
+// fits in the given width and height
+func fitImage(bounds image.Rectangle, width, height int) (int, int) {
+	if bounds.Dx() == 0 || bounds.Dy() == 0 || width <= 0 || height <= 0 {
+		return 0, 0
+	}
+
+	if bounds.Dx()*height > bounds.Dy()*width {
+		return width, max(1, bounds.Dy()*width/bounds.Dx())
+	}
+
+	return max(1, bounds.Dx()*height/bounds.Dy()), height
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// scaleImage resizes the image to the given size, picking the nearest pixel
+func scaleImage(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			scaled.Set(x, y, img.At(bounds.Min.X+x*bounds.Dx()/width, bounds.Min.Y+y*bounds.Dy()/height))
+		}
+	}
+
+	return scaled
+}
+
+// sixelLevel maps a 16-bit color channel onto one of the palette's six levels
+func sixelLevel(channel uint32) int {
+	return (int(channel>>8)*5 + 127) / 255
+}
+
+// sixelRunLength compresses runs of the same sixel with the repeat introducer
+func sixelRunLength(chars []byte) string {
+	var str strings.Builder
+
+	for idx := 0; idx < len(chars); {
+		run := 1
+		for idx+run < len(chars) && chars[idx+run] == chars[idx] {
+			run++
+		}
+
+		if run > 3 {
+			fmt.Fprintf(&str, "!%d%c", run, chars[idx])
+		} else {
+			str.WriteString(strings.Repeat(string(chars[idx]), run))
+		}
+
+		idx += run
+	}
+
+	return str.String()
+}
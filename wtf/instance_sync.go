@@ -0,0 +1,368 @@
+package wtf
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/logger"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// sharedStateMaxAge is how long notifications and read markers are kept in the shared
+// state
+const sharedStateMaxAge = 7 * 24 * time.Hour
+
+// defaultSyncInterval is how often, in seconds, instances catch up with each other
+const defaultSyncInterval = 30
+
+// SharedState is what the wtf instances that sync with each other have in common: when
+// each notification was last sent, by module and title, and which notifications have
+// been read
+type SharedState struct {
+	Notified map[string]time.Time  `json:"notified"`
+	Read     map[string]ReadMarker `json:"read"`
+}
+
+// ReadMarker records whether something has been read, and when that was decided. The
+// latest decision wins when instances disagree
+type ReadMarker struct {
+	Read bool      `json:"read"`
+	Time time.Time `json:"time"`
+}
+
+// InstanceSync shares notification state and read markers between wtf instances running
+// in different terminals or on different machines, so that a notification sent or read
+// on one isn't sent again on the others. Instances share either a sync file, such as one
+// in a synced folder, or the control API of one of them:
+//
+//	wtf:
+//	  sync:
+//	    file: ~/Dropbox/wtf/sync.json
+//	    interval: 30
+//
+//	wtf:
+//	  sync:
+//	    url: http://desktop.local:7777
+//	    token: s3cret
+//
+// interval is how often, in seconds, instances catch up with each other. Changes are
+// passed on straight away. An instance whose control API is turned on under
+// "wtf.server" keeps the state for the instances that sync with it
+type InstanceSync struct {
+	file        string
+	interval    time.Duration
+	mu          sync.Mutex
+	serving     bool
+	started     bool
+	state       SharedState
+	subscribers []*syncSubscriber
+	token       string
+	url         string
+	wake        chan struct{}
+}
+
+// syncSubscriber is a function subscribed to changes from the other instances
+type syncSubscriber struct {
+	fn func()
+}
+
+// Shared is the app's instance sync
+var Shared = NewInstanceSync("", "", "")
+
+// NewInstanceSync creates and returns an instance of InstanceSync that syncs through the
+// file or, if there is no file, the control API at the url. With neither it syncs nothing
+func NewInstanceSync(file, url, token string) *InstanceSync {
+	return &InstanceSync{
+		file:     file,
+		interval: defaultSyncInterval * time.Second,
+		state:    SharedState{Notified: map[string]time.Time{}, Read: map[string]ReadMarker{}},
+		token:    token,
+		url:      strings.TrimSuffix(url, "/"),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// ConfigureSync sets up the instance sync from the "wtf.sync" settings and, the first
+// time it's turned on, starts syncing in the background
+func ConfigureSync(globalConfig *config.Config) {
+	file, _ := utils.ExpandHomeDir(globalConfig.UString("wtf.sync.file", ""))
+
+	Shared.mu.Lock()
+	Shared.file = file
+	Shared.interval = time.Duration(globalConfig.UInt("wtf.sync.interval", defaultSyncInterval)) * time.Second
+	Shared.token = globalConfig.UString("wtf.sync.token", "")
+	Shared.url = strings.TrimSuffix(globalConfig.UString("wtf.sync.url", ""), "/")
+	Shared.serving = globalConfig.UString("wtf.server.address", "") != ""
+
+	start := !Shared.started && (Shared.file != "" || Shared.url != "")
+	if start {
+		Shared.started = true
+	}
+	Shared.mu.Unlock()
+
+	if start {
+		go Shared.run()
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Enabled returns TRUE if there is a sync file or control API to sync with, or if other
+// instances can sync with this one's control API
+func (shared *InstanceSync) Enabled() bool {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	return shared.file != "" || shared.url != "" || shared.serving
+}
+
+// IsRead returns whether the item was last marked read or unread on any instance, and
+// whether it has been marked at all
+func (shared *InstanceSync) IsRead(key string) (read bool, marked bool) {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	marker, ok := shared.state.Read[key]
+	return marker.Read, ok
+}
+
+// Merge takes in the state of another instance, keeping the latest of each notification
+// and read marker, and tells the subscribers if anything changed. Returns true if it did
+func (shared *InstanceSync) Merge(other SharedState) bool {
+	changed := false
+
+	shared.mu.Lock()
+	for key, sent := range other.Notified {
+		if sent.After(shared.state.Notified[key]) {
+			shared.state.Notified[key] = sent
+			changed = true
+		}
+	}
+
+	for key, marker := range other.Read {
+		if marker.Time.After(shared.state.Read[key].Time) {
+			shared.state.Read[key] = marker
+			changed = true
+		}
+	}
+	subscribers := shared.subscribers
+	shared.mu.Unlock()
+
+	if changed {
+		for _, subscriber := range subscribers {
+			subscriber.fn()
+		}
+	}
+
+	return changed
+}
+
+// Notified returns when the notification with the given key was last sent by any
+// instance, or the zero time if it hasn't been
+func (shared *InstanceSync) Notified(key string) time.Time {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	return shared.state.Notified[key]
+}
+
+// RecordNotified records that this instance sent the notification with the given key
+func (shared *InstanceSync) RecordNotified(key string, sent time.Time) {
+	if !shared.Enabled() {
+		return
+	}
+
+	shared.mu.Lock()
+	if sent.After(shared.state.Notified[key]) {
+		shared.state.Notified[key] = sent
+	}
+	shared.mu.Unlock()
+
+	shared.poke()
+}
+
+// SetRead marks the item read or unread on every instance
+func (shared *InstanceSync) SetRead(key string, read bool, now time.Time) {
+	if !shared.Enabled() {
+		return
+	}
+
+	shared.mu.Lock()
+	shared.state.Read[key] = ReadMarker{Read: read, Time: now}
+	shared.mu.Unlock()
+
+	shared.poke()
+}
+
+// State returns a copy of the shared state, dropping anything older than a week
+func (shared *InstanceSync) State() SharedState {
+	cutoff := time.Now().Add(-sharedStateMaxAge)
+
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	state := SharedState{Notified: map[string]time.Time{}, Read: map[string]ReadMarker{}}
+
+	for key, sent := range shared.state.Notified {
+		if sent.Before(cutoff) {
+			delete(shared.state.Notified, key)
+			continue
+		}
+		state.Notified[key] = sent
+	}
+
+	for key, marker := range shared.state.Read {
+		if marker.Time.Before(cutoff) {
+			delete(shared.state.Read, key)
+			continue
+		}
+		state.Read[key] = marker
+	}
+
+	return state
+}
+
+// Subscribe registers a function that is called when another instance's state changes
+// this one's. Returns a function that ends the subscription
+func (shared *InstanceSync) Subscribe(fn func()) func() {
+	subscriber := &syncSubscriber{fn: fn}
+
+	shared.mu.Lock()
+	shared.subscribers = append(shared.subscribers, subscriber)
+	shared.mu.Unlock()
+
+	return func() {
+		shared.mu.Lock()
+		defer shared.mu.Unlock()
+
+		subscribers := []*syncSubscriber{}
+		for _, other := range shared.subscribers {
+			if other != subscriber {
+				subscribers = append(subscribers, other)
+			}
+		}
+
+		shared.subscribers = subscribers
+	}
+}
+
+// Sync catches up with the other instances through the sync file or control API, and
+// passes this instance's state on to them
+func (shared *InstanceSync) Sync() error {
+	shared.mu.Lock()
+	file, url, token := shared.file, shared.url, shared.token
+	shared.mu.Unlock()
+
+	switch {
+	case file != "":
+		return shared.syncFile(file)
+	case url != "":
+		return shared.syncURL(url, token)
+	default:
+		return nil
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// poke wakes the sync loop up to pass a change on
+func (shared *InstanceSync) poke() {
+	select {
+	case shared.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run syncs every interval, and whenever this instance's state changes
+func (shared *InstanceSync) run() {
+	for {
+		if err := shared.Sync(); err != nil {
+			logger.For("sync").Warnf("syncing with the other instances: %v", err)
+		}
+
+		shared.mu.Lock()
+		interval := shared.interval
+		shared.mu.Unlock()
+
+		select {
+		case <-shared.wake:
+		case <-time.After(interval):
+		}
+	}
+}
+
+// syncFile merges the state in the sync file into this instance's, and writes the result
+// back. The file is replaced in one go so that other instances never read half of it
+func (shared *InstanceSync) syncFile(file string) error {
+	data, err := ioutil.ReadFile(file)
+	switch {
+	case err == nil:
+		other := SharedState{}
+		if err := json.Unmarshal(data, &other); err == nil {
+			shared.Merge(other)
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	data, err = json.Marshal(shared.State())
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	tmpFile := file + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, file)
+}
+
+// syncURL sends this instance's state to the other instance's control API, which merges
+// it into its own and replies with the result
+func (shared *InstanceSync) syncURL(url, token string) error {
+	body, err := json.Marshal(shared.State())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url+"/api/sync", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return NewHTTPError(resp)
+	}
+
+	other := SharedState{}
+	if err := json.NewDecoder(resp.Body).Decode(&other); err != nil {
+		return err
+	}
+
+	shared.Merge(other)
+	return nil
+}
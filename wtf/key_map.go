@@ -0,0 +1,207 @@
+package wtf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/i18n"
+)
+
+// keyBinding is a single key press, such as "Ctrl-R", "Tab", "?", or "Alt-1"
+type keyBinding struct {
+	key  tcell.Key
+	mod  tcell.ModMask
+	name string
+	char rune
+}
+
+// keyAction is an app-wide action and the key that triggers it. An optional action can
+// turn a key press down, by returning false, so that it goes on to the focused widget
+type keyAction struct {
+	binding  keyBinding
+	fn       func() bool
+	help     string
+	name     string
+	optional bool
+}
+
+// KeyMap is the registry of app-wide keyboard actions. Widgets should not respond to
+// any of the keys registered here. Any action can be moved to a different key in the
+// `wtf.keys` config, keyed by the action's name
+// Example:
+//
+//	wtf:
+//	  keys:
+//	    refresh: Ctrl-F
+//	    quit: q
+type KeyMap struct {
+	actions []*keyAction
+	config  *config.Config
+	errors  []error
+	notes   []helpItem
+}
+
+// NewKeyMap creates and returns an empty instance of KeyMap
+func NewKeyMap(config *config.Config) *KeyMap {
+	return &KeyMap{
+		actions: []*keyAction{},
+		config:  config,
+		errors:  []error{},
+		notes:   []helpItem{},
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Add registers a named action and the key that triggers it, unless the config has
+// remapped the action to a different key. Keys that cannot be parsed, or that are
+// already bound to another action, are recorded in Errors
+// Example:
+//
+//	keyMap.Add("refresh", "Ctrl-R", "Refresh all widgets", refreshAll)
+func (keyMap *KeyMap) Add(name, key, help string, fn func()) {
+	keyMap.add(name, key, help, func() bool { fn(); return true }, false)
+}
+
+// AddOptional registers a named action that only takes the key press when it has
+// something to do, and returns false otherwise, so that the key press goes on to the
+// focused widget as though the key weren't bound. Widgets may bind the same key
+// Example:
+//
+//	keyMap.AddOptional("retry", "r", "Retry the failed widgets", retryFailed)
+func (keyMap *KeyMap) AddOptional(name, key, help string, fn func() bool) {
+	keyMap.add(name, key, help, fn, true)
+}
+
+// AddNote adds a line to the help text for keys that are handled outside of the key map
+func (keyMap *KeyMap) AddNote(key, help string) {
+	keyMap.notes = append(keyMap.notes, helpItem{key, help})
+}
+
+// Errors returns the problems found while registering the actions
+func (keyMap *KeyMap) Errors() []error {
+	return keyMap.errors
+}
+
+// Handle runs the action bound to the pressed key, if there is one. Returns true if the
+// key press was handled
+func (keyMap *KeyMap) Handle(event *tcell.EventKey) bool {
+	for _, action := range keyMap.actions {
+		if action.binding.matches(event) {
+			return action.fn()
+		}
+	}
+
+	return false
+}
+
+// HelpText returns the list of app-wide keys and what they do
+func (keyMap *KeyMap) HelpText() string {
+	items := []helpItem{}
+	for _, action := range keyMap.actions {
+		items = append(items, helpItem{action.binding.name, i18n.T(action.help)})
+	}
+	for _, note := range keyMap.notes {
+		items = append(items, helpItem{note.Key, i18n.T(note.Text)})
+	}
+
+	width := 0
+	for _, item := range items {
+		if len(item.Key) > width {
+			width = len(item.Key)
+		}
+	}
+
+	str := fmt.Sprintf(" [green::b]%s[white]\n\n", i18n.T("Global keyboard commands"))
+	for _, item := range items {
+		str += fmt.Sprintf("  %-*s\t%s\n", width, item.Key, item.Text)
+	}
+
+	return str
+}
+
+// IsBound returns true if the named key triggers one of the actions. Keys bound to
+// optional actions don't count, as widgets may bind them too
+func (keyMap *KeyMap) IsBound(key string) bool {
+	binding, err := parseKeyBinding(key)
+	if err != nil {
+		return false
+	}
+
+	action := keyMap.actionFor(binding)
+	return action != nil && !action.optional
+}
+
+// Matches returns true if the key press triggers the named action
+func (keyMap *KeyMap) Matches(name string, event *tcell.EventKey) bool {
+	for _, action := range keyMap.actions {
+		if action.name == name {
+			return action.binding.matches(event)
+		}
+	}
+
+	return false
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// add registers an action, unless the config has remapped it to a different key
+func (keyMap *KeyMap) add(name, key, help string, fn func() bool, optional bool) {
+	key = keyMap.config.UString("wtf.keys."+name, key)
+
+	binding, err := parseKeyBinding(key)
+	if err != nil {
+		keyMap.errors = append(keyMap.errors, fmt.Errorf("wtf.keys.%s: %v", name, err))
+		return
+	}
+
+	if other := keyMap.actionFor(binding); other != nil {
+		keyMap.errors = append(keyMap.errors, fmt.Errorf("'%s' is bound to both %s and %s", binding.name, other.name, name))
+		return
+	}
+
+	keyMap.actions = append(keyMap.actions, &keyAction{binding: binding, fn: fn, help: help, name: name, optional: optional})
+}
+
+func (keyMap *KeyMap) actionFor(binding keyBinding) *keyAction {
+	for _, action := range keyMap.actions {
+		if action.binding.key == binding.key && action.binding.char == binding.char && action.binding.mod == binding.mod {
+			return action
+		}
+	}
+
+	return nil
+}
+
+// parseKeyBinding converts the written name of a key into a keyBinding. Special keys
+// use their tcell names ("Ctrl-R", "Tab", "PgDn"), characters are written as themselves,
+// and characters pressed with the Alt key are prefixed with "Alt-"
+func parseKeyBinding(str string) (keyBinding, error) {
+	if len([]rune(str)) == 1 {
+		return keyBinding{key: tcell.KeyRune, char: []rune(str)[0], name: str}, nil
+	}
+
+	for key, name := range tcell.KeyNames {
+		if strings.EqualFold(name, str) {
+			return keyBinding{key: key, name: name}, nil
+		}
+	}
+
+	if strings.HasPrefix(strings.ToLower(str), "alt-") && len([]rune(str)) == 5 {
+		return keyBinding{key: tcell.KeyRune, char: []rune(str)[4], mod: tcell.ModAlt, name: str}, nil
+	}
+
+	return keyBinding{}, fmt.Errorf("unknown key '%s'", str)
+}
+
+func (binding keyBinding) matches(event *tcell.EventKey) bool {
+	if binding.key != tcell.KeyRune {
+		return event.Key() == binding.key
+	}
+
+	return event.Key() == tcell.KeyRune &&
+		event.Rune() == binding.char &&
+		event.Modifiers()&tcell.ModAlt == binding.mod
+}
@@ -26,11 +26,17 @@ type KeyboardWidget struct {
 	charHelp []helpItem
 	keyHelp  []helpItem
 	maxKey   int
+
+	bound      map[string]string
+	keyErrors  []error
+	remaps     map[string]interface{}
+	remapped   []string
+	searchList *ScrollableWidget
 }
 
 // NewKeyboardWidget creates and returns a new instance of KeyboardWidget
 func NewKeyboardWidget(app *tview.Application, pages *tview.Pages, settings *cfg.Common) KeyboardWidget {
-	return KeyboardWidget{
+	widget := KeyboardWidget{
 		app:      app,
 		pages:    pages,
 		settings: settings,
@@ -38,7 +44,26 @@ func NewKeyboardWidget(app *tview.Application, pages *tview.Pages, settings *cfg
 		keyMap:   make(map[tcell.Key]func()),
 		charHelp: []helpItem{},
 		keyHelp:  []helpItem{},
+
+		bound:     make(map[string]string),
+		keyErrors: []error{},
+		remaps:    map[string]interface{}{},
+		remapped:  []string{},
+	}
+
+	if settings != nil && settings.Config != nil {
+		widget.remaps = settings.Config.UMap("keys")
 	}
+
+	return widget
+}
+
+// BindRowActions binds the keys that mark the list's rows, open its actions menu, and
+// copy the rows to the clipboard
+func (widget *KeyboardWidget) BindRowActions(list *ScrollableWidget) {
+	widget.SetKeyboardChar("m", list.ToggleMark, "Mark/unmark the selected row")
+	widget.SetKeyboardChar("a", func() { widget.ShowActionMenu(list) }, "Show the actions for the selected or marked rows")
+	widget.SetKeyboardChar("y", func() { widget.copyRows(list) }, "Copy the URL of the selected or marked rows")
 }
 
 // SetKeyboardChar sets a character/function combination that responds to key presses
@@ -47,8 +72,7 @@ func NewKeyboardWidget(app *tview.Application, pages *tview.Pages, settings *cfg
 //    widget.SetKeyboardChar("d", widget.deleteSelectedItem)
 //
 func (widget *KeyboardWidget) SetKeyboardChar(char string, fn func(), helpText string) {
-	widget.charMap[char] = fn
-	widget.charHelp = append(widget.charHelp, helpItem{char, helpText})
+	widget.bind(char, fn, helpText)
 }
 
 // SetKeyboardKey sets a tcell.Key/function combination that responds to key presses
@@ -57,11 +81,7 @@ func (widget *KeyboardWidget) SetKeyboardChar(char string, fn func(), helpText s
 //    widget.SetKeyboardKey(tcell.KeyCtrlD, widget.deleteSelectedItem)
 //
 func (widget *KeyboardWidget) SetKeyboardKey(key tcell.Key, fn func(), helpText string) {
-	widget.keyMap[key] = fn
-	widget.keyHelp = append(widget.keyHelp, helpItem{tcell.KeyNames[key], helpText})
-	if len(tcell.KeyNames[key]) > widget.maxKey {
-		widget.maxKey = len(tcell.KeyNames[key])
-	}
+	widget.bind(tcell.KeyNames[key], fn, helpText)
 }
 
 // InputCapture is the function passed to tview's SetInputCapture() function
@@ -85,6 +105,17 @@ func (widget *KeyboardWidget) InputCapture(event *tcell.EventKey) *tcell.EventKe
 	return event
 }
 
+// KeyErrors returns the problems found while binding this widget's keys, such as keys
+// remapped to keys that do not exist or to keys that are already bound
+func (widget *KeyboardWidget) KeyErrors() []error {
+	return widget.keyErrors
+}
+
+// RemappedKeys returns the names of the keys the user has moved this widget's actions to
+func (widget *KeyboardWidget) RemappedKeys() []string {
+	return widget.remapped
+}
+
 // HelpText returns the help text and keyboard command info for this widget
 func (widget *KeyboardWidget) HelpText() string {
 	str := " [green::b]Keyboard commands for " + strings.Title(widget.settings.Module.Type) + "[white]\n\n"
@@ -105,6 +136,46 @@ func (widget *KeyboardWidget) SetView(view *tview.TextView) {
 	widget.view = view
 }
 
+// ShowActionMenu opens a popup listing the actions that can be run on the list's
+// selected or marked rows. The chosen action runs in the background, and its error, if
+// it fails, is shown in a popup
+func (widget *KeyboardWidget) ShowActionMenu(list *ScrollableWidget) {
+	rows := list.SelectedRows()
+	actions := list.RowActions()
+	if len(rows) == 0 || len(actions) == 0 {
+		return
+	}
+
+	doneFunc := func(action *RowAction) {
+		widget.pages.RemovePage(actionMenuPageName)
+		widget.app.SetFocus(widget.view)
+
+		if action == nil {
+			return
+		}
+
+		run := func() {
+			go func() {
+				if err := action.Run(rows); err != nil {
+					widget.app.QueueUpdateDraw(func() { widget.showError(action.Name, err) })
+				}
+			}()
+		}
+
+		if action.Confirm != "" {
+			widget.Confirm(action.Confirm, run)
+			return
+		}
+
+		run()
+	}
+
+	menu := NewActionMenu(actions, rows, doneFunc)
+
+	widget.pages.AddPage(actionMenuPageName, menu, false, true)
+	widget.app.SetFocus(menu)
+}
+
 func (widget *KeyboardWidget) ShowHelp() {
 	closeFunc := func() {
 		widget.pages.RemovePage("help")
@@ -120,3 +191,77 @@ func (widget *KeyboardWidget) ShowHelp() {
 		widget.app.Draw()
 	})
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// copyRows copies the URLs of the list's selected or marked rows to the clipboard or,
+// for rows without URLs, their IDs
+func (widget *KeyboardWidget) copyRows(list *ScrollableWidget) {
+	rows := list.SelectedRows()
+	if len(rows) == 0 {
+		return
+	}
+
+	err := copyRows(rows, func(row Row) string {
+		if row.URL != "" {
+			return row.URL
+		}
+
+		return row.ID
+	})
+
+	if err != nil {
+		widget.showError("Copy", err)
+	}
+}
+
+// showError opens a popup describing an action that failed
+func (widget *KeyboardWidget) showError(name string, err error) {
+	closeFunc := func() {
+		widget.pages.RemovePage(actionMenuPageName)
+		widget.app.SetFocus(widget.view)
+	}
+
+	text := fmt.Sprintf("\n [red::b]%s failed[-::-]\n\n %s", name, tview.Escape(err.Error()))
+	modal := NewBillboardModal(text, closeFunc)
+
+	widget.pages.AddPage(actionMenuPageName, modal, false, true)
+	widget.app.SetFocus(modal)
+}
+
+// bind assigns the function to the named key or, if the module's `keys` config remaps
+// that key, to the key it has been remapped to
+// Example:
+//
+//	keys:
+//	  o: O
+//	  Enter: Ctrl-O
+func (widget *KeyboardWidget) bind(name string, fn func(), helpText string) {
+	if remap, ok := widget.remaps[name]; ok {
+		name = fmt.Sprintf("%v", remap)
+		widget.remapped = append(widget.remapped, name)
+	}
+
+	binding, err := parseKeyBinding(name)
+	if err != nil || binding.mod != tcell.ModNone {
+		widget.keyErrors = append(widget.keyErrors, fmt.Errorf("cannot bind '%s' to %s", name, helpText))
+		return
+	}
+
+	if other, ok := widget.bound[binding.name]; ok {
+		widget.keyErrors = append(widget.keyErrors, fmt.Errorf("'%s' is bound to both '%s' and '%s'", binding.name, other, helpText))
+	}
+	widget.bound[binding.name] = helpText
+
+	if binding.key == tcell.KeyRune {
+		widget.charMap[string(binding.char)] = fn
+		widget.charHelp = append(widget.charHelp, helpItem{binding.name, helpText})
+		return
+	}
+
+	widget.keyMap[binding.key] = fn
+	widget.keyHelp = append(widget.keyHelp, helpItem{binding.name, helpText})
+	if len(binding.name) > widget.maxKey {
+		widget.maxKey = len(binding.name)
+	}
+}
@@ -0,0 +1,288 @@
+package wtf
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/logger"
+)
+
+// LayoutEditor lets the user move and resize widgets on the current page at runtime.
+// While the editor is active it captures all keyboard input:
+//
+//	Tab, Shift-Tab    select the next/previous widget
+//	arrows, h/j/k/l   move the selected widget
+//	Shift-arrows, H/J/K/L   shrink and grow the selected widget
+//	Ctrl-S            write the new layout back to the config file
+//	Esc               leave edit mode
+type LayoutEditor struct {
+	Active bool
+
+	config         *config.Config
+	configFilePath string
+	display        *Display
+	errorFunc      func(err error)
+	moved          map[Wtfable]bool
+	selected       int
+	theme          cfg.Theme
+}
+
+// NewLayoutEditor creates and returns an instance of LayoutEditor
+func NewLayoutEditor(display *Display, config *config.Config, configFilePath string) *LayoutEditor {
+	editor := LayoutEditor{
+		config:         config,
+		configFilePath: configFilePath,
+		display:        display,
+		moved:          map[Wtfable]bool{},
+		theme:          cfg.NewThemeFromConfig(config),
+	}
+
+	return &editor
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// InputCapture handles key presses while the editor is active. It returns nil for every
+// key it consumes, which is all of them, so that widgets don't react while editing
+func (editor *LayoutEditor) InputCapture(event *tcell.EventKey) *tcell.EventKey {
+	shifted := event.Modifiers()&tcell.ModShift != 0
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		editor.Stop()
+	case tcell.KeyTab:
+		editor.selectWidget(editor.selected + 1)
+	case tcell.KeyBacktab:
+		editor.selectWidget(editor.selected - 1)
+	case tcell.KeyCtrlS:
+		editor.save()
+	case tcell.KeyUp:
+		editor.adjust(shifted, 0, -1)
+	case tcell.KeyDown:
+		editor.adjust(shifted, 0, 1)
+	case tcell.KeyLeft:
+		editor.adjust(shifted, -1, 0)
+	case tcell.KeyRight:
+		editor.adjust(shifted, 1, 0)
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'h':
+			editor.adjust(false, -1, 0)
+		case 'j':
+			editor.adjust(false, 0, 1)
+		case 'k':
+			editor.adjust(false, 0, -1)
+		case 'l':
+			editor.adjust(false, 1, 0)
+		case 'H':
+			editor.adjust(true, -1, 0)
+		case 'J':
+			editor.adjust(true, 0, 1)
+		case 'K':
+			editor.adjust(true, 0, -1)
+		case 'L':
+			editor.adjust(true, 1, 0)
+		}
+	}
+
+	return nil
+}
+
+// SetErrorFunc sets the function that shows the errors from saving the layout
+func (editor *LayoutEditor) SetErrorFunc(fn func(err error)) {
+	editor.errorFunc = fn
+}
+
+// Start puts the dashboard into edit mode, selecting the given widget if it is on the
+// current page, or the first widget if it is not
+func (editor *LayoutEditor) Start(focused Wtfable) {
+	editor.Active = true
+
+	editor.selected = 0
+	for idx, widget := range editor.widgets() {
+		if widget == focused {
+			editor.selected = idx
+		}
+	}
+
+	editor.selectWidget(editor.selected)
+}
+
+// Stop takes the dashboard out of edit mode
+func (editor *LayoutEditor) Stop() {
+	editor.Active = false
+
+	for _, widget := range editor.widgets() {
+		editor.decorate(widget, false)
+	}
+}
+
+// Toggle switches edit mode on or off
+func (editor *LayoutEditor) Toggle(focused Wtfable) {
+	if editor.Active {
+		editor.Stop()
+	} else {
+		editor.Start(focused)
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// adjust moves the selected widget by the given number of rows and columns or, if
+// resize is true, grows or shrinks it. The widget is kept within the bounds of the grid
+func (editor *LayoutEditor) adjust(resize bool, cols, rows int) {
+	widget := editor.selectedWidget()
+	if widget == nil {
+		return
+	}
+
+	page := editor.display.CurrentPage()
+	pos := page.position(widget)
+
+	if resize {
+		pos.Width = clamp(pos.Width+cols, 1, len(page.gridColumns())-pos.Left)
+		pos.Height = clamp(pos.Height+rows, 1, len(page.gridRows())-pos.Top)
+	} else {
+		pos.Left = clamp(pos.Left+cols, 0, len(page.gridColumns())-pos.Width)
+		pos.Top = clamp(pos.Top+rows, 0, len(page.gridRows())-pos.Height)
+	}
+
+	editor.moved[widget] = true
+
+	editor.display.Reposition(widget)
+	editor.decorate(widget, true)
+}
+
+func (editor *LayoutEditor) decorate(widget Wtfable, selected bool) {
+	if selected {
+		widget.TextView().SetBorderColor(ColorFor(editor.theme.BorderEditing))
+	} else {
+		widget.TextView().SetBorderColor(ColorFor(widget.BorderColor()))
+	}
+}
+
+// save writes the positions of the current page's widgets that have been moved or
+// resized back to the config file, keeping the file's comments and layout. The widgets
+// left alone keep their settings, so that those placed by a grid template area go on
+// following the template. If a breakpoint is active, the positions are saved for that
+// breakpoint only
+func (editor *LayoutEditor) save() {
+	page := editor.display.CurrentPage()
+
+	writer, err := cfg.NewConfigWriter(editor.configFilePath)
+	if err != nil {
+		editor.showError(err)
+		return
+	}
+
+	saved := []Wtfable{}
+
+	for _, widget := range editor.widgets() {
+		if !editor.moved[widget] {
+			continue
+		}
+		saved = append(saved, widget)
+
+		pos := page.position(widget)
+		path := fmt.Sprintf("wtf.mods.%s.position", widget.CommonSettings().Name)
+
+		if name := page.breakpointName(); name != "" {
+			path += ".breakpoints." + name
+		}
+
+		values := []struct {
+			key   string
+			value int
+		}{
+			{"top", pos.Top},
+			{"left", pos.Left},
+			{"width", pos.Width},
+			{"height", pos.Height},
+		}
+
+		for _, val := range values {
+			editor.config.Set(path+"."+val.key, val.value)
+
+			if err == nil {
+				err = writer.Set(path+"."+val.key, val.value)
+			}
+		}
+	}
+
+	if err == nil {
+		err = writer.Save()
+	}
+
+	if err != nil {
+		editor.showError(err)
+		return
+	}
+
+	for _, widget := range saved {
+		delete(editor.moved, widget)
+	}
+}
+
+// showError shows an error from saving the layout with the error function, or logs it
+// if there isn't one
+func (editor *LayoutEditor) showError(err error) {
+	if editor.errorFunc == nil {
+		logger.For("wtf").Errorf("saving the layout: %v", err)
+		return
+	}
+
+	editor.errorFunc(err)
+}
+
+func (editor *LayoutEditor) selectWidget(idx int) {
+	widgets := editor.widgets()
+	if len(widgets) == 0 {
+		return
+	}
+
+	editor.selected = (idx + len(widgets)) % len(widgets)
+
+	for i, widget := range widgets {
+		if i != editor.selected {
+			editor.decorate(widget, false)
+		}
+	}
+
+	editor.decorate(widgets[editor.selected], true)
+}
+
+func (editor *LayoutEditor) selectedWidget() Wtfable {
+	widgets := editor.widgets()
+	if editor.selected < 0 || editor.selected >= len(widgets) {
+		return nil
+	}
+
+	return widgets[editor.selected]
+}
+
+// widgets returns the enabled widgets on the current page
+func (editor *LayoutEditor) widgets() []Wtfable {
+	widgets := []Wtfable{}
+
+	for _, widget := range editor.display.CurrentPage().Widgets {
+		if widget.Enabled() {
+			widgets = append(widgets, widget)
+		}
+	}
+
+	return widgets
+}
+
+func clamp(val, min, max int) int {
+	if val > max {
+		val = max
+	}
+
+	if val < min {
+		val = min
+	}
+
+	return val
+}
@@ -0,0 +1,123 @@
+package wtf
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+var (
+	mdBlockquoteRegExp = regexp.MustCompile(`^(\s*)>\s?(.*)$`)
+	mdFenceRegExp      = regexp.MustCompile("^\\s*(```|~~~)")
+	mdHeadingRegExp    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdListRegExp       = regexp.MustCompile(`^(\s*)([-*+]|[0-9]+[.)])\s+(.*)$`)
+	mdRuleRegExp       = regexp.MustCompile(`^\s*([-*_])(\s*([-*_]))*\s*$`)
+	mdTaskRegExp       = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+
+	// mdInlineRegExp matches, in order of precedence, code spans, links, bold text, and
+	// emphasised text
+	mdInlineRegExp = regexp.MustCompile("`([^`]+)`|\\[([^\\]]+)\\]\\(([^)\\s]+)\\)|\\*\\*([^*]+)\\*\\*|__([^_]+)__|\\*([^*\\s][^*]*)\\*")
+)
+
+// RenderMarkdown turns markdown into text with color tags, so that headings, bold and
+// emphasised text, lists, block quotes, code, and links are shown formatted instead of
+// as raw markdown syntax
+func RenderMarkdown(text string) string {
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	rendered := make([]string, 0, len(lines))
+
+	inCode := false
+
+	for _, line := range lines {
+		if mdFenceRegExp.MatchString(line) {
+			inCode = !inCode
+			continue
+		}
+
+		if inCode {
+			rendered = append(rendered, fmt.Sprintf("  [yellow]%s[-]", tview.Escape(line)))
+			continue
+		}
+
+		rendered = append(rendered, renderMarkdownLine(line))
+	}
+
+	return strings.Join(rendered, "\n")
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// renderMarkdownInline formats the code spans, links, and bold and emphasised text in
+// a line, and escapes the rest of it so that it isn't mistaken for color tags
+func renderMarkdownInline(text string) string {
+	var str strings.Builder
+	last := 0
+
+	for _, match := range mdInlineRegExp.FindAllStringSubmatchIndex(text, -1) {
+		str.WriteString(tview.Escape(text[last:match[0]]))
+		last = match[1]
+
+		group := func(idx int) string {
+			return tview.Escape(text[match[idx*2]:match[idx*2+1]])
+		}
+
+		switch {
+		case match[2] >= 0:
+			str.WriteString(fmt.Sprintf("[yellow]%s[-]", group(1)))
+		case match[4] >= 0:
+			str.WriteString(fmt.Sprintf("[blue::u]%s[-::-] [gray](%s)[-]", group(2), group(3)))
+		case match[8] >= 0:
+			str.WriteString(fmt.Sprintf("[::b]%s[::-]", group(4)))
+		case match[10] >= 0:
+			str.WriteString(fmt.Sprintf("[::b]%s[::-]", group(5)))
+		case match[12] >= 0:
+			str.WriteString(fmt.Sprintf("[::u]%s[::-]", group(6)))
+		}
+	}
+
+	str.WriteString(tview.Escape(text[last:]))
+
+	return str.String()
+}
+
+// renderMarkdownLine formats a line that is not part of a code block
+func renderMarkdownLine(line string) string {
+	if match := mdHeadingRegExp.FindStringSubmatch(line); match != nil {
+		if len(match[1]) == 1 {
+			return fmt.Sprintf("[green::bu]%s[-::-]", renderMarkdownInline(match[2]))
+		}
+
+		return fmt.Sprintf("[green::b]%s[-::-]", renderMarkdownInline(match[2]))
+	}
+
+	if mdRuleRegExp.MatchString(line) && len(strings.TrimSpace(line)) >= 3 {
+		return "[gray]" + strings.Repeat("─", 40) + "[-]"
+	}
+
+	if match := mdBlockquoteRegExp.FindStringSubmatch(line); match != nil {
+		return fmt.Sprintf("%s[gray]│[-] %s", match[1], renderMarkdownInline(match[2]))
+	}
+
+	if match := mdListRegExp.FindStringSubmatch(line); match != nil {
+		bullet := "•"
+		if !strings.ContainsAny(match[2], "-*+") {
+			bullet = match[2]
+		}
+
+		item := match[3]
+		if task := mdTaskRegExp.FindStringSubmatch(item); task != nil {
+			bullet = "[ ]"
+			if task[1] != " " {
+				bullet = "[x]"
+			}
+			bullet = tview.Escape(bullet)
+			item = task[2]
+		}
+
+		return fmt.Sprintf("%s %s %s", match[1], bullet, renderMarkdownInline(item))
+	}
+
+	return renderMarkdownInline(line)
+}
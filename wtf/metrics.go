@@ -0,0 +1,145 @@
+package wtf
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RefreshMetrics records how long each widget's refreshes take and how many of them
+// fail, for the metrics endpoint
+type RefreshMetrics struct {
+	mu      sync.Mutex
+	widgets map[string]*widgetMetrics
+}
+
+type widgetMetrics struct {
+	errors       int
+	lastDuration time.Duration
+	refreshes    int
+	totalTime    time.Duration
+}
+
+// Metrics are the app-wide refresh metrics
+var Metrics = NewRefreshMetrics()
+
+// NewRefreshMetrics creates and returns an instance of RefreshMetrics
+func NewRefreshMetrics() *RefreshMetrics {
+	return &RefreshMetrics{
+		widgets: make(map[string]*widgetMetrics),
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Observe records a refresh of the named widget that took the given time. A nil error
+// means the refresh succeeded
+func (metrics *RefreshMetrics) Observe(name string, duration time.Duration, err error) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	widget, ok := metrics.widgets[name]
+	if !ok {
+		widget = &widgetMetrics{}
+		metrics.widgets[name] = widget
+	}
+
+	widget.lastDuration = duration
+	widget.refreshes++
+	widget.totalTime += duration
+
+	if err != nil {
+		widget.errors++
+	}
+}
+
+// WriteMetrics writes the metrics of the widgets, the remaining requests allowed by
+// each API host's rate limit, and every number on the data bus, in the Prometheus text
+// format
+func WriteMetrics(w io.Writer, widgets []Wtfable) {
+	labels := make(map[string]string, len(widgets))
+	names := make([]string, 0, len(widgets))
+
+	for _, widget := range widgets {
+		if widget.Disabled() {
+			continue
+		}
+
+		labels[widget.Name()] = fmt.Sprintf(`widget="%s",type="%s"`, metricLabel(widget.Name()), metricLabel(widget.CommonSettings().Module.Type))
+		names = append(names, widget.Name())
+	}
+	sort.Strings(names)
+
+	Metrics.mu.Lock()
+	snapshot := make(map[string]widgetMetrics, len(names))
+	for _, name := range names {
+		if widget, ok := Metrics.widgets[name]; ok {
+			snapshot[name] = *widget
+		}
+	}
+	Metrics.mu.Unlock()
+
+	writeMetricHeader(w, "wtf_refresh_duration_seconds", "summary", "How long the widget's refreshes took")
+	for _, name := range names {
+		fmt.Fprintf(w, "wtf_refresh_duration_seconds_sum{%s} %g\n", labels[name], snapshot[name].totalTime.Seconds())
+		fmt.Fprintf(w, "wtf_refresh_duration_seconds_count{%s} %d\n", labels[name], snapshot[name].refreshes)
+	}
+
+	writeMetricHeader(w, "wtf_refresh_last_duration_seconds", "gauge", "How long the widget's most recent refresh took")
+	for _, name := range names {
+		fmt.Fprintf(w, "wtf_refresh_last_duration_seconds{%s} %g\n", labels[name], snapshot[name].lastDuration.Seconds())
+	}
+
+	writeMetricHeader(w, "wtf_refresh_errors_total", "counter", "How many of the widget's refreshes failed")
+	for _, name := range names {
+		fmt.Fprintf(w, "wtf_refresh_errors_total{%s} %d\n", labels[name], snapshot[name].errors)
+	}
+
+	writeMetricHeader(w, "wtf_refresh_last_success_timestamp_seconds", "gauge", "When the widget last refreshed successfully")
+	for _, name := range names {
+		lastSuccess := RefreshStatuses.Status(name).LastSuccess
+		if !lastSuccess.IsZero() {
+			fmt.Fprintf(w, "wtf_refresh_last_success_timestamp_seconds{%s} %d\n", labels[name], lastSuccess.Unix())
+		}
+	}
+
+	remaining := HostRateLimits.Remaining(time.Now())
+	hosts := make([]string, 0, len(remaining))
+	for host := range remaining {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	writeMetricHeader(w, "wtf_rate_limit_remaining", "gauge", "How many requests the API host's rate limit allows right now")
+	for _, host := range hosts {
+		fmt.Fprintf(w, "wtf_rate_limit_remaining{host=\"%s\"} %g\n", metricLabel(host), remaining[host])
+	}
+
+	values := Data.Values("")
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	writeMetricHeader(w, "wtf_data", "gauge", "The numbers the widgets have published to the data bus")
+	for _, key := range keys {
+		if number, ok := Data.Float(key); ok {
+			fmt.Fprintf(w, "wtf_data{key=\"%s\"} %g\n", metricLabel(key), number)
+		}
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// metricLabel escapes the text for use as a label value
+func metricLabel(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text)
+}
+
+func writeMetricHeader(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s.\n# TYPE %s %s\n", name, help, name, kind)
+}
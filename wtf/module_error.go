@@ -0,0 +1,151 @@
+package wtf
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/wtfutil/wtf/i18n"
+)
+
+// ModuleError is an error from a module's data source, with the details needed to work
+// out what went wrong
+type ModuleError struct {
+	Endpoint string
+	Err      error
+	Hint     string
+	Status   int
+}
+
+// errorRedrawable is implemented by widgets that can show an error in place of their
+// content
+type errorRedrawable interface {
+	RedrawError(title string, err error)
+}
+
+// NewHTTPError returns a ModuleError describing an HTTP response whose status is not
+// a success
+// Example:
+//
+//	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+//		return nil, wtf.NewHTTPError(resp)
+//	}
+func NewHTTPError(resp *http.Response) *ModuleError {
+	moduleErr := ModuleError{
+		Err:    fmt.Errorf("%s", resp.Status),
+		Hint:   hintForStatus(resp.StatusCode),
+		Status: resp.StatusCode,
+	}
+
+	if resp.Request != nil {
+		moduleErr.Endpoint = endpointFor(resp.Request.URL)
+	}
+
+	return &moduleErr
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Error returns the error's message
+func (moduleErr *ModuleError) Error() string {
+	if moduleErr.Endpoint == "" {
+		return moduleErr.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", moduleErr.Endpoint, moduleErr.Err.Error())
+}
+
+// ErrorPanel renders an error as the content of a widget, listing the HTTP status, the
+// endpoint, and a hint at how to fix the problem where they are known
+func ErrorPanel(err error, critColor string) string {
+	moduleErr := moduleErrorFor(err)
+
+	str := fmt.Sprintf("\n [%s::b]%s[-::-]\n\n", critColor, i18n.T("Error"))
+
+	if moduleErr.Status != 0 {
+		str += fmt.Sprintf(" [::b]%-9s[::-] %d %s\n", i18n.T("Status"), moduleErr.Status, http.StatusText(moduleErr.Status))
+	} else {
+		str += fmt.Sprintf(" %s\n", moduleErr.Err.Error())
+	}
+
+	if moduleErr.Endpoint != "" {
+		str += fmt.Sprintf(" [::b]%-9s[::-] %s\n", i18n.T("Endpoint"), moduleErr.Endpoint)
+	}
+
+	if moduleErr.Hint != "" {
+		str += fmt.Sprintf(" [::b]%-9s[::-] %s\n", i18n.T("Hint"), moduleErr.Hint)
+	}
+
+	str += fmt.Sprintf("\n [gray]%s[-]\n", i18n.T("Press r to retry"))
+
+	return str
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// endpointFor returns the URL without its query string or credentials, which may hold
+// API keys
+func endpointFor(reqURL *url.URL) string {
+	if reqURL == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s://%s%s", reqURL.Scheme, reqURL.Host, reqURL.Path)
+}
+
+func hintForStatus(status int) string {
+	switch {
+	case status == http.StatusBadRequest:
+		return "The request was rejected. Check the module's settings"
+	case status == http.StatusUnauthorized:
+		return "Check that the module's API key or credentials are valid"
+	case status == http.StatusForbidden:
+		return "The credentials may not have access to this, or the API's rate limit was reached"
+	case status == http.StatusNotFound:
+		return "Check the module's URL, project, and other settings that identify what to fetch"
+	case status == http.StatusTooManyRequests:
+		return "The API's rate limit was reached. Try again later"
+	case status >= 500:
+		return "The service is having problems. Try again later"
+	default:
+		return ""
+	}
+}
+
+// moduleErrorFor returns the error as a ModuleError, filling in what can be worked out
+// about errors that aren't already one
+func moduleErrorFor(err error) *ModuleError {
+	if moduleErr, ok := err.(*ModuleError); ok {
+		return moduleErr
+	}
+
+	urlErr, ok := err.(*url.Error)
+	if !ok {
+		return &ModuleError{Err: err}
+	}
+
+	moduleErr := ModuleError{
+		Err:  urlErr.Err,
+		Hint: "Could not reach the server. Check your network connection and proxy settings",
+	}
+
+	if reqURL, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		moduleErr.Endpoint = endpointFor(reqURL)
+	}
+
+	if _, ok := urlErr.Err.(*CircuitOpenError); ok {
+		moduleErr.Hint = "The service kept failing, so it is left alone for a while. See wtf.http.circuitBreaker"
+		return &moduleErr
+	}
+
+	if urlErr.Timeout() {
+		moduleErr.Hint = "The request timed out. Check your network connection"
+	}
+
+	if strings.Contains(urlErr.Err.Error(), "certificate") {
+		moduleErr.Hint = "The server's certificate could not be verified. See wtf.http.caFile"
+	}
+
+	return &moduleErr
+}
@@ -0,0 +1,124 @@
+package wtf
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+)
+
+// ModuleType describes a type of module. Each module package registers its type when
+// it is loaded:
+//
+//	func init() {
+//		wtf.RegisterModule(wtf.ModuleType{
+//			Name:     "hackernews",
+//			Settings: Settings{},
+//			Make: func(app *tview.Application, pages *tview.Pages, name string, moduleConfig, globalConfig *config.Config) wtf.Wtfable {
+//				return NewWidget(app, pages, NewSettingsFromYAML(name, moduleConfig, globalConfig))
+//			},
+//		})
+//	}
+//
+// Containers, which show other modules inside themselves, set MakeContainer instead of
+// Make. Settings is the module's settings struct, whose fields' help tags describe the
+// module's settings
+type ModuleType struct {
+	Make          func(app *tview.Application, pages *tview.Pages, name string, moduleConfig, globalConfig *config.Config) Wtfable
+	MakeContainer func(app *tview.Application, pages *tview.Pages, name string, moduleConfig, globalConfig *config.Config, children []Wtfable) Wtfable
+	Name          string
+	Settings      interface{}
+}
+
+// ModuleCapabilities describes what a module's widgets can do
+type ModuleCapabilities struct {
+	Actions   bool
+	Container bool
+	Focusable bool
+	List      bool
+	Network   bool
+}
+
+var (
+	moduleTypes   = make(map[string]ModuleType)
+	moduleTypesMu sync.Mutex
+)
+
+// RegisterModule adds the module type to the registry, replacing any registered under
+// the same name
+func RegisterModule(moduleType ModuleType) {
+	moduleTypesMu.Lock()
+	defer moduleTypesMu.Unlock()
+
+	moduleTypes[moduleType.Name] = moduleType
+}
+
+// LookupModule returns the registered module type with the given name
+func LookupModule(name string) (ModuleType, bool) {
+	moduleTypesMu.Lock()
+	defer moduleTypesMu.Unlock()
+
+	moduleType, ok := moduleTypes[name]
+	return moduleType, ok
+}
+
+// ModuleTypes returns every registered module type, in order of name
+func ModuleTypes() []ModuleType {
+	moduleTypesMu.Lock()
+	defer moduleTypesMu.Unlock()
+
+	types := make([]ModuleType, 0, len(moduleTypes))
+	for _, moduleType := range moduleTypes {
+		types = append(types, moduleType)
+	}
+
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+
+	return types
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Capabilities returns what the widget, made from this module type, can do
+func (moduleType ModuleType) Capabilities(widget Wtfable) ModuleCapabilities {
+	capabilities := ModuleCapabilities{
+		Container: moduleType.MakeContainer != nil,
+		Focusable: widget.Focusable(),
+		Network:   widget.CommonSettings().UsesNetwork(),
+	}
+
+	if list, ok := widget.(interface{ RowActions() []RowAction }); ok {
+		capabilities.Actions = len(list.RowActions()) > 0
+	}
+
+	if _, ok := widget.(interface{ GetSelected() int }); ok {
+		capabilities.List = true
+	}
+
+	return capabilities
+}
+
+// Names returns the names of the capabilities the module has
+func (capabilities ModuleCapabilities) Names() []string {
+	names := []string{}
+
+	flags := []struct {
+		name string
+		on   bool
+	}{
+		{"actions", capabilities.Actions},
+		{"container", capabilities.Container},
+		{"focusable", capabilities.Focusable},
+		{"list", capabilities.List},
+		{"network", capabilities.Network},
+	}
+
+	for _, flag := range flags {
+		if flag.on {
+			names = append(names, flag.name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,109 @@
+package wtf
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+// Clickable is implemented by widgets that respond to mouse clicks on their content.
+// The x and y coordinates are relative to the top-left corner of the widget's content
+// area. Returns true if the click was handled
+type Clickable interface {
+	MouseClick(x, y int) bool
+}
+
+// MouseScreen wraps a tcell.Screen and intercepts the mouse events that tview does not
+// handle itself. Button presses and wheel motion are passed on to the handler on the
+// app's event goroutine; button releases and drags are dropped
+type MouseScreen struct {
+	tcell.Screen
+
+	app     *tview.Application
+	buttons tcell.ButtonMask
+	handler func(event *tcell.EventMouse)
+}
+
+// NewMouseScreen creates, initializes, and returns a new instance of MouseScreen
+func NewMouseScreen(app *tview.Application, handler func(event *tcell.EventMouse)) (*MouseScreen, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := screen.Init(); err != nil {
+		return nil, err
+	}
+
+	screen.EnableMouse()
+
+	mouseScreen := MouseScreen{
+		Screen: screen,
+
+		app:     app,
+		handler: handler,
+	}
+
+	return &mouseScreen, nil
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// PollEvent returns the next event that is not a mouse event
+func (screen *MouseScreen) PollEvent() tcell.Event {
+	for {
+		event := screen.Screen.PollEvent()
+
+		mouseEvent, ok := event.(*tcell.EventMouse)
+		if !ok {
+			return event
+		}
+
+		pressed := mouseEvent.Buttons() &^ screen.buttons
+		screen.buttons = mouseEvent.Buttons()
+
+		if pressed != tcell.ButtonNone {
+			screen.app.QueueUpdateDraw(func() {
+				screen.handler(mouseEvent)
+			})
+		}
+	}
+}
+
+// MouseClick passes a click at the given screen coordinates on to the widget. Clicks
+// on the widget's border are ignored
+func MouseClick(widget Wtfable, x, y int) {
+	clickable, ok := widget.(Clickable)
+	if !ok {
+		return
+	}
+
+	left, top, width, height := widget.TextView().GetInnerRect()
+	if x < left || x >= left+width || y < top || y >= top+height {
+		return
+	}
+
+	clickable.MouseClick(x-left, y-top)
+}
+
+// MouseScroll scrolls the widget's content up or down by one line, or for widgets
+// with selectable rows, moves the selection
+func MouseScroll(widget Wtfable, up bool) {
+	key := tcell.KeyDown
+	if up {
+		key = tcell.KeyUp
+	}
+
+	sendKey(widget.TextView(), key)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// sendKey feeds a synthetic key press to the view as if it had focus
+func sendKey(view *tview.TextView, key tcell.Key) {
+	handler := view.InputHandler()
+	if handler == nil {
+		return
+	}
+
+	handler(tcell.NewEventKey(key, 0, tcell.ModNone), func(p tview.Primitive) {})
+}
@@ -1,7 +1,6 @@
 package wtf
 
 import (
-	"fmt"
 	"github.com/wtfutil/wtf/cfg"
 	"github.com/wtfutil/wtf/logger"
 )
@@ -26,7 +25,7 @@ func NewMultiSourceWidget(moduleConfig *cfg.Common, singular, plural string) Mul
 
 	widget.loadSources()
 
-	logger.Log(fmt.Sprintf("%+v", widget.Sources))
+	logger.For(moduleConfig.Module.Type).Debugf("sources: %+v", widget.Sources)
 
 	return widget
 }
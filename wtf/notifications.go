@@ -0,0 +1,169 @@
+package wtf
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// windowsToastScript shows a toast notification on Windows. The title and message are
+// passed in environment variables so that they never have to be quoted for PowerShell
+const windowsToastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName("text")
+$text.Item(0).AppendChild($template.CreateTextNode($env:WTF_NOTIFICATION_TITLE)) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode($env:WTF_NOTIFICATION_MESSAGE)) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier("WTF").Show($toast)
+`
+
+// Notification is something a module wants the user to know about even when they
+// aren't looking at its widget
+type Notification struct {
+	Message string
+	Module  string
+	Time    time.Time
+	Title   string
+}
+
+// NotificationBus delivers the notifications that modules publish to the desktop and
+// to its subscribers, dropping any that repeat too soon
+type NotificationBus struct {
+	deliver     func(notification Notification) error
+	mu          sync.Mutex
+	sent        map[string]time.Time
+	subscribers []*notificationSubscriber
+}
+
+// notificationSubscriber is a function subscribed to the notification bus
+type notificationSubscriber struct {
+	fn func(notification Notification)
+}
+
+// Notifications is the app-wide notification bus
+var Notifications = NewNotificationBus(DesktopNotify)
+
+// NewNotificationBus creates and returns an instance of NotificationBus that shows
+// notifications on the desktop with the deliver function
+func NewNotificationBus(deliver func(notification Notification) error) *NotificationBus {
+	return &NotificationBus{
+		deliver:     deliver,
+		sent:        make(map[string]time.Time),
+		subscribers: []*notificationSubscriber{},
+	}
+}
+
+// DesktopNotify shows the notification with the operating system's notification
+// service: notify-send on Linux and BSD, Notification Center on macOS, and a toast on
+// Windows
+func DesktopNotify(notification Notification) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", notification.Message, notification.Title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToastScript)
+		cmd.Env = append(
+			os.Environ(),
+			"WTF_NOTIFICATION_TITLE="+notification.Title,
+			"WTF_NOTIFICATION_MESSAGE="+notification.Message,
+		)
+	default:
+		cmd = exec.Command("notify-send", "--app-name=wtf", notification.Title, notification.Message)
+	}
+
+	return cmd.Run()
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// ID identifies the notification across instances, by its module, title, and the time
+// it was sent
+func (notification Notification) ID() string {
+	return fmt.Sprintf("%s/%s@%d", notification.Module, notification.Title, notification.Time.UnixNano())
+}
+
+// Publish sends the notification, subject to the module's notification settings, and
+// returns TRUE if it was sent. Notifications with the same module and title as one sent
+// less than the throttle period ago are dropped, as are all notifications from modules
+// that don't have them enabled. They aren't shown on the desktop during Do Not Disturb,
+// nor if another instance has just sent them
+func (bus *NotificationBus) Publish(notification Notification, settings cfg.NotificationSettings) bool {
+	if !settings.Enabled {
+		return false
+	}
+
+	if notification.Time.IsZero() {
+		notification.Time = time.Now()
+	}
+
+	key := notification.Module + "\x00" + notification.Title
+	throttle := time.Duration(settings.Throttle) * time.Second
+
+	bus.mu.Lock()
+	last, ok := bus.sent[key]
+	if ok && notification.Time.Sub(last) < throttle {
+		bus.mu.Unlock()
+		return false
+	}
+
+	// A notification another instance has just sent is the same notification, and has
+	// already been shown on the desktop
+	sentElsewhere := false
+	if shared := Shared.Notified(key); shared.After(last) && notification.Time.Sub(shared) < throttle {
+		notification.Time = shared
+		sentElsewhere = true
+	}
+
+	bus.sent[key] = notification.Time
+	subscribers := bus.subscribers
+	bus.mu.Unlock()
+
+	if !sentElsewhere {
+		Shared.RecordNotified(key, notification.Time)
+	}
+
+	if settings.Desktop && bus.deliver != nil && !sentElsewhere && !DND.Active(notification.Time) {
+		// Notification services can be slow to respond, and a failure to show one on the
+		// desktop isn't worth interrupting the module for
+		go bus.deliver(notification)
+	}
+
+	for _, subscriber := range subscribers {
+		subscriber.fn(notification)
+	}
+
+	return true
+}
+
+// Subscribe registers a function that is called with every notification that is sent.
+// Returns a function that ends the subscription
+func (bus *NotificationBus) Subscribe(fn func(notification Notification)) func() {
+	subscriber := &notificationSubscriber{fn: fn}
+
+	bus.mu.Lock()
+	bus.subscribers = append(bus.subscribers, subscriber)
+	bus.mu.Unlock()
+
+	return func() {
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+
+		subscribers := []*notificationSubscriber{}
+		for _, other := range bus.subscribers {
+			if other != subscriber {
+				subscribers = append(subscribers, other)
+			}
+		}
+
+		bus.subscribers = subscribers
+	}
+}
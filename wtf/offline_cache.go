@@ -0,0 +1,102 @@
+package wtf
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// snapshotSaveInterval is how often an unchanged snapshot is re-saved to record that
+// its data is still current
+const snapshotSaveInterval = time.Minute
+
+// OfflineCache keeps the last content a widget displayed successfully, on disk, so that
+// the widget can show it again when its data can't be fetched, or when the app starts
+type OfflineCache struct {
+	mu       sync.Mutex
+	path     string
+	saved    time.Time
+	snapshot *Snapshot
+}
+
+// Snapshot is the content of a widget at the time it was last refreshed successfully
+type Snapshot struct {
+	Text    string
+	Title   string
+	Updated time.Time
+	Wrap    bool
+}
+
+// NewOfflineCache creates and returns an instance of OfflineCache for the named
+// widget, loading the widget's last snapshot if there is one
+func NewOfflineCache(name string) *OfflineCache {
+	cache := OfflineCache{}
+
+	cacheDir, err := cfg.WtfCacheDir()
+	if err != nil {
+		return &cache
+	}
+
+	cache.path = filepath.Join(cacheDir, "widgets", name+".json")
+
+	data, err := ioutil.ReadFile(cache.path)
+	if err != nil {
+		return &cache
+	}
+
+	snapshot := Snapshot{}
+	if json.Unmarshal(data, &snapshot) == nil {
+		cache.snapshot = &snapshot
+	}
+
+	return &cache
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Last returns the most recent snapshot, if there is one
+func (cache *OfflineCache) Last() (Snapshot, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.snapshot == nil {
+		return Snapshot{}, false
+	}
+
+	return *cache.snapshot, true
+}
+
+// Save records the widget's current content as its most recent snapshot. Failing to
+// write the snapshot to disk is not an error; the widget just has less to fall back on
+func (cache *OfflineCache) Save(title, text string, wrap bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	previous := cache.snapshot
+
+	cache.snapshot = &Snapshot{Text: text, Title: title, Updated: now, Wrap: wrap}
+
+	unchanged := previous != nil && previous.Text == text && previous.Title == title && previous.Wrap == wrap
+	if cache.path == "" || (unchanged && now.Sub(cache.saved) < snapshotSaveInterval) {
+		return
+	}
+
+	cache.saved = now
+
+	data, err := json.Marshal(cache.snapshot)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cache.path), 0700); err != nil {
+		return
+	}
+
+	ioutil.WriteFile(cache.path, data, 0600)
+}
@@ -0,0 +1,160 @@
+package wtf
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+)
+
+// RateLimits holds the request rate limit for each API host, as configured under
+// "wtf.http.rateLimits":
+//
+//	wtf:
+//	  http:
+//	    rateLimits:
+//	      api.github.com:
+//	        perMinute: 30
+//	        burst: 5
+//
+// Hosts without a limit are not limited
+type RateLimits struct {
+	limiters map[string]*hostLimiter
+	mu       sync.Mutex
+}
+
+// RateLimitedTransport is an http.RoundTripper that waits for the request's host to
+// be under its rate limit before passing the request on to the underlying transport
+type RateLimitedTransport struct {
+	Base   http.RoundTripper
+	Limits *RateLimits
+}
+
+// hostLimiter is a token bucket: requests spend tokens, and tokens refill at a steady
+// rate up to the burst size
+type hostLimiter struct {
+	burst  float64
+	last   time.Time
+	perSec float64
+	tokens float64
+}
+
+// HostRateLimits are the rate limits shared by every module's HTTP requests
+var HostRateLimits = &RateLimits{limiters: make(map[string]*hostLimiter)}
+
+// ConfigureRateLimits replaces the rate limits with those in the config
+func ConfigureRateLimits(globalConfig *config.Config) {
+	limiters := make(map[string]*hostLimiter)
+
+	for host, value := range globalConfig.UMap("wtf.http.rateLimits") {
+		// Host names contain dots, so their settings can't be reached by a dotted path
+		limitConfig := &config.Config{Root: value}
+
+		perMinute := limitConfig.UInt("perMinute", 0)
+		if perMinute <= 0 {
+			continue
+		}
+
+		burst := float64(limitConfig.UInt("burst", 1))
+		if burst < 1 {
+			burst = 1
+		}
+
+		limiters[host] = &hostLimiter{
+			burst:  burst,
+			perSec: float64(perMinute) / 60,
+			tokens: burst,
+		}
+	}
+
+	HostRateLimits.mu.Lock()
+	HostRateLimits.limiters = limiters
+	HostRateLimits.mu.Unlock()
+}
+
+// RateLimit wraps the transport so that its requests are subject to the configured
+// per-host rate limits
+func RateLimit(base http.RoundTripper) http.RoundTripper {
+	return &RateLimitedTransport{
+		Base:   base,
+		Limits: HostRateLimits,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Delay reserves a request to the host and returns how long the caller must wait
+// before making it
+func (limits *RateLimits) Delay(host string, now time.Time) time.Duration {
+	limits.mu.Lock()
+	defer limits.mu.Unlock()
+
+	limiter, ok := limits.limiters[host]
+	if !ok {
+		return 0
+	}
+
+	return limiter.reserve(now)
+}
+
+// Remaining returns how many requests each rate-limited host allows at the given time
+// without waiting
+func (limits *RateLimits) Remaining(now time.Time) map[string]float64 {
+	limits.mu.Lock()
+	defer limits.mu.Unlock()
+
+	remaining := make(map[string]float64, len(limits.limiters))
+	for host, limiter := range limits.limiters {
+		remaining[host] = limiter.available(now)
+	}
+
+	return remaining
+}
+
+// RoundTrip waits until the request's host is under its rate limit and then sends the
+// request
+func (transport *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay := transport.Limits.Delay(req.URL.Hostname(), time.Now())
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	return transport.Base.RoundTrip(req)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// available returns how many tokens the bucket holds at the given time
+func (limiter *hostLimiter) available(now time.Time) float64 {
+	tokens := limiter.tokens
+
+	if !limiter.last.IsZero() {
+		tokens += now.Sub(limiter.last).Seconds() * limiter.perSec
+		if tokens > limiter.burst {
+			tokens = limiter.burst
+		}
+	}
+
+	return tokens
+}
+
+func (limiter *hostLimiter) reserve(now time.Time) time.Duration {
+	limiter.tokens = limiter.available(now)
+	limiter.last = now
+	limiter.tokens--
+
+	if limiter.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-limiter.tokens / limiter.perSec * float64(time.Second))
+}
@@ -0,0 +1,256 @@
+package wtf
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// RefreshStatus is the state of a widget's most recent data refresh
+type RefreshStatus struct {
+	Err         error
+	LastSuccess time.Time
+	Paused      bool
+	Refreshing  bool
+}
+
+// RefreshBus tracks the refresh status of every widget and tells its subscribers
+// whenever a widget starts or finishes refreshing
+//
+// It also tracks the progress of a batch of refreshes asked for all at once, such as
+// when every widget is refreshed by hand
+type RefreshBus struct {
+	batch       map[string]bool
+	batchSize   int
+	mu          sync.Mutex
+	statuses    map[string]RefreshStatus
+	subscribers []*refreshSubscriber
+}
+
+// refreshSubscriber is a function subscribed to the refresh status bus
+type refreshSubscriber struct {
+	fn func(name string, status RefreshStatus)
+}
+
+// RefreshStatuses is the app-wide refresh status bus
+var RefreshStatuses = NewRefreshBus()
+
+// refreshErrorer is implemented by widgets that report whether their last refresh failed
+type refreshErrorer interface {
+	RefreshError() error
+	SetRefreshError(err error)
+}
+
+// alertEvaluator is implemented by widgets that check their alert rules after every
+// refresh
+type alertEvaluator interface {
+	EvaluateAlerts()
+}
+
+// rowDiffer is implemented by widgets that highlight the rows a refresh changed
+type rowDiffer interface {
+	ExpectRowChanges()
+}
+
+// NewRefreshBus creates and returns an instance of RefreshBus
+func NewRefreshBus() *RefreshBus {
+	return &RefreshBus{
+		batch:       make(map[string]bool),
+		statuses:    make(map[string]RefreshStatus),
+		subscribers: []*refreshSubscriber{},
+	}
+}
+
+// RefreshWidget refreshes the widget's data, publishing its progress to RefreshStatuses
+// and how long it took to Metrics. A module that panics while refreshing shows the
+// panic as an error rather than taking the app down
+func RefreshWidget(widget Wtfable) {
+	errorer, reportsErrors := widget.(refreshErrorer)
+	if reportsErrors {
+		errorer.SetRefreshError(nil)
+	}
+
+	if differ, ok := widget.(rowDiffer); ok {
+		differ.ExpectRowChanges()
+	}
+
+	RefreshStatuses.Started(widget.Name())
+	started := time.Now()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err := fmt.Errorf("%v", recovered)
+
+			if redrawable, ok := widget.(errorRedrawable); ok {
+				redrawable.RedrawError(widget.CommonSettings().Title, err)
+			}
+
+			Metrics.Observe(widget.Name(), time.Since(started), err)
+			RefreshStatuses.Finished(widget.Name(), err)
+		}
+	}()
+
+	widget.Refresh()
+
+	var err error
+	if reportsErrors {
+		err = errorer.RefreshError()
+	}
+
+	if evaluator, ok := widget.(alertEvaluator); ok && err == nil {
+		evaluator.EvaluateAlerts()
+	}
+
+	Metrics.Observe(widget.Name(), time.Since(started), err)
+	RefreshStatuses.Finished(widget.Name(), err)
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Finished records that the named widget has finished refreshing. A nil error means the
+// refresh succeeded
+func (bus *RefreshBus) Finished(name string, err error) {
+	bus.mu.Lock()
+	status := bus.statuses[name]
+	status.Refreshing = false
+	status.Err = err
+	if err == nil {
+		status.LastSuccess = time.Now()
+	}
+	bus.statuses[name] = status
+	bus.finishBatch(name)
+	bus.mu.Unlock()
+
+	bus.publish(name, status)
+}
+
+// Progress returns how many of the widgets in the batch being refreshed have finished,
+// and how many there are in all. Both are zero when no batch is being refreshed
+func (bus *RefreshBus) Progress() (int, int) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	return bus.batchSize - len(bus.batch), bus.batchSize
+}
+
+// SetPaused records whether the named widget's automatic refreshes are paused
+func (bus *RefreshBus) SetPaused(name string, paused bool) {
+	bus.mu.Lock()
+	status := bus.statuses[name]
+	status.Paused = paused
+	bus.statuses[name] = status
+	bus.mu.Unlock()
+
+	bus.publish(name, status)
+}
+
+// Skipped records that the named widget's refresh was put off, so that it no longer
+// holds up the batch being refreshed
+func (bus *RefreshBus) Skipped(name string) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	bus.finishBatch(name)
+}
+
+// StartBatch starts tracking the progress of refreshing the named widgets. Widgets added
+// while a batch is being refreshed join it
+func (bus *RefreshBus) StartBatch(names []string) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	for _, name := range names {
+		if !bus.batch[name] {
+			bus.batch[name] = true
+			bus.batchSize++
+		}
+	}
+}
+
+// Started records that the named widget has started refreshing
+func (bus *RefreshBus) Started(name string) {
+	bus.mu.Lock()
+	status := bus.statuses[name]
+	status.Refreshing = true
+	bus.statuses[name] = status
+	bus.mu.Unlock()
+
+	bus.publish(name, status)
+}
+
+// Status returns the refresh status of the named widget
+func (bus *RefreshBus) Status(name string) RefreshStatus {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	return bus.statuses[name]
+}
+
+// Subscribe registers a function that is called every time a widget starts or finishes
+// refreshing. Returns a function that ends the subscription
+func (bus *RefreshBus) Subscribe(fn func(name string, status RefreshStatus)) func() {
+	subscriber := &refreshSubscriber{fn: fn}
+
+	bus.mu.Lock()
+	bus.subscribers = append(bus.subscribers, subscriber)
+	bus.mu.Unlock()
+
+	return func() {
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+
+		subscribers := []*refreshSubscriber{}
+		for _, other := range bus.subscribers {
+			if other != subscriber {
+				subscribers = append(subscribers, other)
+			}
+		}
+
+		bus.subscribers = subscribers
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// refreshIndicator returns the text shown in a widget's title bar for its refresh status:
+// a spinner while refreshing, a marker if the last refresh failed, or the time of the
+// last successful refresh
+func refreshIndicator(status RefreshStatus, critColor string) string {
+	switch {
+	case status.Refreshing:
+		frame := time.Now().UnixNano() / int64(refreshAnimationInterval) % int64(len(spinnerFrames))
+		return fmt.Sprintf("%c ", spinnerFrames[frame])
+	case status.Err != nil:
+		return fmt.Sprintf("[%s]✗[-] ", critColor)
+	case !status.LastSuccess.IsZero():
+		return fmt.Sprintf("[gray]%s[-] ", status.LastSuccess.Format("15:04"))
+	default:
+		return ""
+	}
+}
+
+// finishBatch takes the named widget out of the batch being refreshed. Once every
+// widget in the batch has finished, the batch is over. The caller holds the lock
+func (bus *RefreshBus) finishBatch(name string) {
+	if !bus.batch[name] {
+		return
+	}
+
+	delete(bus.batch, name)
+
+	if len(bus.batch) == 0 {
+		bus.batchSize = 0
+	}
+}
+
+func (bus *RefreshBus) publish(name string, status RefreshStatus) {
+	bus.mu.Lock()
+	subscribers := bus.subscribers
+	bus.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber.fn(name, status)
+	}
+}
@@ -0,0 +1,66 @@
+package wtf
+
+import (
+	"time"
+
+	"github.com/wtfutil/wtf/i18n"
+)
+
+// relativeTimeRenderer is implemented by widgets that show relative times, which go
+// stale as time passes even when nothing else has changed. RenderRelativeTimes redraws
+// the widget from the data it already has, without refreshing it
+type relativeTimeRenderer interface {
+	RenderRelativeTimes()
+}
+
+// RelativeTime describes when the time is relative to now, compactly and in the
+// configured language, such as "in 12m" or "2d ago". The zero time is described as ""
+//
+// Widgets that show relative times should implement RenderRelativeTimes() so that they
+// are redrawn every minute, keeping the times current between refreshes
+func RelativeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return i18n.ShortRelativeTime(t, time.Now())
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// tickRelativeTimes redraws the onscreen widgets that show relative times at the start
+// of every minute. It stops once the widgets have been disabled
+func tickRelativeTimes(widgets []Wtfable) {
+	renderers := []Wtfable{}
+	for _, widget := range widgets {
+		if _, ok := widget.(relativeTimeRenderer); ok {
+			renderers = append(renderers, widget)
+		}
+	}
+
+	if len(renderers) == 0 {
+		return
+	}
+
+	for {
+		now := time.Now()
+		time.Sleep(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+
+		active := false
+
+		for _, widget := range renderers {
+			if widget.Disabled() {
+				continue
+			}
+			active = true
+
+			if !widget.Hidden() {
+				widget.(relativeTimeRenderer).RenderRelativeTimes()
+			}
+		}
+
+		if !active {
+			return
+		}
+	}
+}
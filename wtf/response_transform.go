@@ -0,0 +1,154 @@
+package wtf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/script"
+)
+
+// transformTimeout is how long a transform script has to finish
+const transformTimeout = 10 * time.Second
+
+// TransformTransport is an http.RoundTripper that runs the JSON responses to some URLs
+// through Lua or Starlark scripts, so that what a module fetches from its API can be filtered,
+// sorted, and reformatted before the module sees it. The transforms are set under
+// "wtf.http.transforms", each with the start of the URLs it applies to:
+//
+//	wtf:
+//	  http:
+//	    transforms:
+//	      - url: https://api.github.com/search/issues
+//	        script: ~/.config/wtf/scripts/no_bots.lua
+//
+// The script defines transform(body, url), which is passed the decoded response and
+// returns the response the module gets instead:
+//
+//	function transform(body, url)
+//	  local kept = {}
+//	  for _, issue in ipairs(body.items) do
+//	    if issue.user.type ~= "Bot" then
+//	      table.insert(kept, issue)
+//	    end
+//	  end
+//	  body.items = kept
+//	  return body
+//	end
+//
+// A script whose name ends in .star is Starlark:
+//
+//	def transform(body, url):
+//	    body["items"] = [issue for issue in body["items"] if issue["user"]["type"] != "Bot"]
+//	    return body
+type TransformTransport struct {
+	Base       http.RoundTripper
+	Transforms []ResponseTransform
+}
+
+// ResponseTransform is a script that transforms the responses to the requests whose
+// URL starts with URL
+type ResponseTransform struct {
+	Script *script.Script
+	URL    string
+}
+
+// TransformsFromConfig loads the scripts of the transforms under "wtf.http.transforms"
+func TransformsFromConfig(globalConfig *config.Config) ([]ResponseTransform, error) {
+	transforms := []ResponseTransform{}
+
+	for _, value := range globalConfig.UList("wtf.http.transforms") {
+		transformConfig := &config.Config{Root: value}
+
+		url := transformConfig.UString("url", "")
+		path := transformConfig.UString("script", "")
+		if url == "" || path == "" {
+			return nil, fmt.Errorf("each of wtf.http.transforms needs a url and a script")
+		}
+
+		loaded, err := script.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not load the transform for %s: %v", url, err)
+		}
+
+		transforms = append(transforms, ResponseTransform{Script: loaded, URL: url})
+	}
+
+	return transforms, nil
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// RoundTrip sends the request and, if a transform applies to its URL, returns the
+// response as the transform rewrote it. A response that fails to transform is returned
+// as an error, so that the module shows what went wrong
+func (transport *TransformTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := transport.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	transform := transport.transformFor(req.URL.String())
+	if transform == nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	transformed, err := transform.apply(req, body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", transform.Script.Name(), err)
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(transformed))
+	resp.ContentLength = int64(len(transformed))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(transformed)))
+
+	return resp, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// transformFor returns the first transform that applies to the URL, or nil if none do
+func (transport *TransformTransport) transformFor(url string) *ResponseTransform {
+	for idx := range transport.Transforms {
+		if strings.HasPrefix(url, transport.Transforms[idx].URL) {
+			return &transport.Transforms[idx]
+		}
+	}
+
+	return nil
+}
+
+// apply passes the decoded body to the script's transform function and returns what it
+// returns, encoded as JSON
+func (transform *ResponseTransform) apply(req *http.Request, body []byte) ([]byte, error) {
+	var decoded interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("the response from %s is not JSON: %v", req.URL.Host, err)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), transformTimeout)
+	defer cancel()
+
+	result, err := transform.Script.Call(ctx, "transform", decoded, req.URL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
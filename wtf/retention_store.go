@@ -0,0 +1,207 @@
+package wtf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	bolt "go.etcd.io/bbolt"
+)
+
+// defaultRetentionDays is how long recorded values are kept unless "wtf.retention.days"
+// says otherwise
+const defaultRetentionDays = 30
+
+// retentionFile is the name of the store's database in its directory
+const retentionFile = "retention.db"
+
+// retentionLockTimeout is how long to wait for another wtf that has the database open
+const retentionLockTimeout = time.Second
+
+// Point is one value of a series and the time it was recorded
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// RetentionStore keeps the values that modules record over time, such as prices,
+// latencies, or counts, on disk, so that trends and sparklines carry on across restarts.
+// The series are kept in a bbolt database, one bucket per series, keyed by the time each
+// value was recorded. Values older than the retention period are dropped whenever the
+// series gets a new one
+type RetentionStore struct {
+	dir    string
+	maxAge time.Duration
+	mu     sync.Mutex
+}
+
+// Retention is the store that modules record their values in
+var Retention = NewRetentionStore("", defaultRetentionDays*24*time.Hour)
+
+// NewRetentionStore creates and returns a store that keeps its series in a database in
+// the directory for maxAge. A store without a directory records nothing
+func NewRetentionStore(dir string, maxAge time.Duration) *RetentionStore {
+	return &RetentionStore{
+		dir:    dir,
+		maxAge: maxAge,
+	}
+}
+
+// ConfigureRetention points the store at the cache directory and sets how many days
+// values are kept from the "wtf.retention.days" setting
+func ConfigureRetention(globalConfig *config.Config) {
+	dir := ""
+	if cacheDir, err := cfg.WtfCacheDir(); err == nil {
+		dir = filepath.Join(cacheDir, "retention")
+	}
+
+	days := globalConfig.UInt("wtf.retention.days", defaultRetentionDays)
+
+	Retention.mu.Lock()
+	defer Retention.mu.Unlock()
+
+	Retention.dir = dir
+	Retention.maxAge = time.Duration(days) * 24 * time.Hour
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Points returns the series' values that are within the retention period, oldest first
+func (store *RetentionStore) Points(series string) []Point {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	points := []Point{}
+
+	if store.dir == "" {
+		return points
+	}
+
+	if _, err := os.Stat(store.path()); err != nil {
+		return points
+	}
+
+	db, err := bolt.Open(store.path(), 0600, &bolt.Options{ReadOnly: true, Timeout: retentionLockTimeout})
+	if err != nil {
+		return points
+	}
+	defer db.Close()
+
+	_ = db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(series))
+		if bucket == nil {
+			return nil
+		}
+
+		cursor := bucket.Cursor()
+		for key, value := cursor.Seek(timeKey(store.cutoff())); key != nil; key, value = cursor.Next() {
+			if len(key) != 8 || len(value) != 8 {
+				continue
+			}
+
+			points = append(points, Point{
+				Time:  time.Unix(0, int64(binary.BigEndian.Uint64(key))),
+				Value: math.Float64frombits(binary.BigEndian.Uint64(value)),
+			})
+		}
+
+		return nil
+	})
+
+	return points
+}
+
+// Record adds the value to the series, timestamped now
+func (store *RetentionStore) Record(series string, value float64) error {
+	return store.RecordAt(series, time.Now(), value)
+}
+
+// RecordAt adds the value to the series, timestamped with the given time, and drops the
+// series' values that have outlived the retention period
+func (store *RetentionStore) RecordAt(series string, at time.Time, value float64) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if store.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(store.dir, 0700); err != nil {
+		return err
+	}
+
+	db, err := bolt.Open(store.path(), 0600, &bolt.Options{Timeout: retentionLockTimeout})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(series))
+		if err != nil {
+			return err
+		}
+
+		encoded := make([]byte, 8)
+		binary.BigEndian.PutUint64(encoded, math.Float64bits(value))
+
+		if err := bucket.Put(timeKey(at), encoded); err != nil {
+			return err
+		}
+
+		cutoff := timeKey(store.cutoff())
+
+		// Deleting through the cursor would skip keys, so the old ones are gathered first
+		expired := [][]byte{}
+
+		cursor := bucket.Cursor()
+		for key, _ := cursor.First(); key != nil && bytes.Compare(key, cutoff) < 0; key, _ = cursor.Next() {
+			expired = append(expired, key)
+		}
+
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Values returns the series' values that are within the retention period, oldest
+// first, for drawing as a sparkline or comparing as a trend
+func (store *RetentionStore) Values(series string) []float64 {
+	values := []float64{}
+	for _, point := range store.Points(series) {
+		values = append(values, point.Value)
+	}
+
+	return values
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// cutoff returns the time before which values have outlived the retention period
+func (store *RetentionStore) cutoff() time.Time {
+	return time.Now().Add(-store.maxAge)
+}
+
+func (store *RetentionStore) path() string {
+	return filepath.Join(store.dir, retentionFile)
+}
+
+// timeKey returns the key a value recorded at the time is kept under. Keys sort in the
+// order the values were recorded in
+func timeKey(at time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(at.UnixNano()))
+
+	return key
+}
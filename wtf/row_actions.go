@@ -0,0 +1,212 @@
+package wtf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+)
+
+const actionMenuPageName = "actions"
+
+// Row is an item in a widget's list, such as a pull request, an issue, or a story,
+// described well enough for actions to be run on it
+type Row struct {
+	ID   string
+	Text string
+	URL  string
+}
+
+// RowAction is something that can be done to the selected rows of a list. Actions with
+// a Confirm question ask it before they run
+type RowAction struct {
+	Confirm string
+	Name    string
+	Run     func(rows []Row) error
+}
+
+// openRowAction opens each row's URL in the browser
+var openRowAction = RowAction{
+	Name: "Open",
+	Run: func(rows []Row) error {
+		for _, row := range rows {
+			if row.URL != "" {
+				utils.OpenURL(row.URL)
+			}
+		}
+
+		return nil
+	},
+}
+
+// copyRowActions copy the rows' URLs, IDs, or text to the clipboard, one row per line
+var copyRowActions = []RowAction{
+	{Name: "Copy URL", Run: func(rows []Row) error { return copyRows(rows, func(row Row) string { return row.URL }) }},
+	{Name: "Copy ID", Run: func(rows []Row) error { return copyRows(rows, func(row Row) string { return row.ID }) }},
+	{Name: "Copy text", Run: func(rows []Row) error { return copyRows(rows, func(row Row) string { return row.Text }) }},
+}
+
+// NewActionMenu creates and returns a popup listing the actions that can be run on
+// the rows. doneFunc is called with the action that was chosen, or with nil if the
+// popup was closed without choosing one
+func NewActionMenu(actions []RowAction, rows []Row, doneFunc func(action *RowAction)) *tview.Frame {
+	list := tview.NewList()
+	list.ShowSecondaryText(false)
+
+	for idx, action := range actions {
+		shortcut := rune(0)
+		if idx < 9 {
+			shortcut = rune('1' + idx)
+		}
+
+		chosen := action
+		list.AddItem(action.Name, "", shortcut, func() {
+			doneFunc(&chosen)
+		})
+	}
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			doneFunc(nil)
+			return nil
+		case event.Rune() == 'j':
+			return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+		case event.Rune() == 'k':
+			return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+		default:
+			return event
+		}
+	})
+
+	title := fmt.Sprintf(" %d selected ", len(rows))
+	if len(rows) == 1 {
+		title = fmt.Sprintf(" %s ", rows[0].Text)
+	}
+
+	frame := tview.NewFrame(list)
+	frame.SetBorder(true)
+	frame.SetBorders(1, 1, 0, 0, 1, 1)
+	frame.SetTitle(title)
+
+	width := tview.TaggedStringWidth(title) + 4
+	for _, action := range actions {
+		if w := tview.TaggedStringWidth(action.Name) + 12; w > width {
+			width = w
+		}
+	}
+	if width > modalWidth {
+		width = modalWidth
+	}
+	height := len(actions) + 2
+
+	frame.SetRect(offscreen, offscreen, width, height)
+	frame.SetDrawFunc(func(screen tcell.Screen, x, y, w, h int) (int, int, int, int) {
+		screenWidth, screenHeight := screen.Size()
+		frame.SetRect((screenWidth/2)-(width/2), (screenHeight/2)-(height/2), width, height)
+		return x, y, w, h
+	})
+
+	return frame
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// copyRows copies one field of each row to the clipboard, skipping rows where it's empty
+func copyRows(rows []Row, field func(row Row) string) error {
+	lines := []string{}
+	for _, row := range rows {
+		if val := field(row); val != "" {
+			lines = append(lines, val)
+		}
+	}
+
+	if len(lines) == 0 {
+		return errors.New("there is nothing to copy")
+	}
+
+	return CopyToClipboard(strings.Join(lines, "\n"))
+}
+
+// commandRowActions returns the actions defined in a module's "actions" setting. Each
+// runs a shell command once for every selected row, with the row in the WTF_ROW_ID,
+// WTF_ROW_TEXT, and WTF_ROW_URL environment variables. Actions with `confirm` ask before
+// they run, with the question given or, if it's just true, one naming the action:
+//
+//	actions:
+//	  - name: Check out branch
+//	    command: "cd ~/src/wtf && gh pr checkout $WTF_ROW_ID"
+//	  - name: Close
+//	    command: "gh pr close $WTF_ROW_ID"
+//	    confirm: true
+func commandRowActions(moduleConfig *config.Config) []RowAction {
+	actions := []RowAction{}
+
+	if moduleConfig == nil {
+		return actions
+	}
+
+	for _, value := range moduleConfig.UList("actions") {
+		actionConfig := &config.Config{Root: value}
+
+		name := actionConfig.UString("name", "")
+		command := actionConfig.UString("command", "")
+		if name == "" || command == "" {
+			continue
+		}
+
+		confirm := actionConfig.UString("confirm", "")
+		if ask, err := actionConfig.Bool("confirm"); err == nil {
+			confirm = ""
+			if ask {
+				confirm = name + "?"
+			}
+		}
+
+		actions = append(actions, RowAction{
+			Confirm: confirm,
+			Name:    name,
+			Run: func(rows []Row) error {
+				for _, row := range rows {
+					if err := runRowCommand(command, row); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+		})
+	}
+
+	return actions
+}
+
+func runRowCommand(command string, row Row) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	cmd.Env = append(
+		os.Environ(),
+		"WTF_ROW_ID="+row.ID,
+		"WTF_ROW_TEXT="+row.Text,
+		"WTF_ROW_URL="+row.URL,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("'%s' failed: %v\n\n%s", command, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package wtf
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// relativeTimeRegExp matches the compact relative times and durations rows show, such as
+// "12m" or "2d", which change without the row changing
+var relativeTimeRegExp = regexp.MustCompile(`\b\d+(s|m|h|d|w|mo|y)\b`)
+
+var regionTagRegExp = regexp.MustCompile(`\["[^"\]]*"\]`)
+
+// RowDiff works out which of the rows a widget shows are new or changed since its
+// previous refresh, so that they can be highlighted for a number of refreshes. Rows are
+// compared by their text, without color tags or relative times, so a row that only
+// moved isn't counted as changed
+type RowDiff struct {
+	changed  map[string]int
+	cycles   int
+	mu       sync.Mutex
+	pending  bool
+	previous map[string]bool
+}
+
+// NewRowDiff creates and returns an instance of RowDiff that highlights changed rows for
+// the given number of refreshes
+func NewRowDiff(cycles int) *RowDiff {
+	if cycles < 1 {
+		cycles = 1
+	}
+
+	return &RowDiff{
+		changed: map[string]int{},
+		cycles:  cycles,
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Apply returns the text with its changed rows given the background color. If a refresh
+// has just been started, the text is what it produced, and is compared with the text of
+// the previous refresh first. The first refresh has nothing to compare with
+func (diff *RowDiff) Apply(text, color string) string {
+	lines := strings.Split(text, "\n")
+
+	diff.mu.Lock()
+	defer diff.mu.Unlock()
+
+	if diff.pending {
+		diff.pending = false
+		diff.update(lines)
+	}
+
+	if len(diff.changed) == 0 {
+		return text
+	}
+
+	for idx, line := range lines {
+		if diff.changed[rowKey(line)] > 0 {
+			lines[idx] = "[:" + color + "]" + line + "[:-]"
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Expect tells the diff that a refresh has started, so the next text it's given is
+// compared with the previous refresh's
+func (diff *RowDiff) Expect() {
+	diff.mu.Lock()
+	defer diff.mu.Unlock()
+
+	diff.pending = true
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// update counts down the highlights of the rows that changed in earlier refreshes, and
+// starts them for the rows that aren't in the previous refresh's
+func (diff *RowDiff) update(lines []string) {
+	current := map[string]bool{}
+	for _, line := range lines {
+		if key := rowKey(line); key != "" {
+			current[key] = true
+		}
+	}
+
+	for key, remaining := range diff.changed {
+		if remaining <= 1 {
+			delete(diff.changed, key)
+		} else {
+			diff.changed[key] = remaining - 1
+		}
+	}
+
+	if diff.previous != nil {
+		for key := range current {
+			if !diff.previous[key] {
+				diff.changed[key] = diff.cycles
+			}
+		}
+	}
+
+	diff.previous = current
+}
+
+// rowKey is what a row is compared by: its text without tags, relative times, or padding
+func rowKey(line string) string {
+	key := regionTagRegExp.ReplaceAllString(line, "")
+	key = colorTagRegExp.ReplaceAllString(key, "")
+	key = relativeTimeRegExp.ReplaceAllString(key, "")
+
+	return strings.TrimSpace(key)
+}
@@ -0,0 +1,390 @@
+package wtf
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const (
+	defaultConcurrency   = 8
+	defaultJitter        = 10
+	defaultMaxBackoff    = 3600
+	maxStartupStagger    = 2 * time.Second
+	quietHoursTimeFormat = "15:04"
+)
+
+// QuietHours is a daily window of time during which the modules that use the network
+// stop refreshing. The window may wrap around midnight
+type QuietHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Scheduler refreshes the data of every widget from a single loop, handing the
+// refreshes to a limited number of workers. It staggers the refreshes so they don't all
+// happen at once, backs off exponentially from widgets whose refreshes keep failing,
+// and pauses network modules during quiet hours. Widgets whose refreshInterval is a
+// cron expression are refreshed on that schedule instead
+type Scheduler struct {
+	concurrency int
+	entries     map[string]*scheduleEntry
+	jitter      float64
+	maxBackoff  time.Duration
+	mu          sync.Mutex
+	queue       chan *scheduleEntry
+	quietHours  *QuietHours
+	wake        chan struct{}
+}
+
+type scheduleEntry struct {
+	failures int
+	interval time.Duration
+	next     time.Time
+	running  bool
+	schedule *cfg.CronSchedule
+	widget   Wtfable
+}
+
+// NewQuietHours creates and returns an instance of QuietHours from start and end times
+// in 24-hour "15:04" format
+func NewQuietHours(start, end string) (*QuietHours, error) {
+	startTime, err := time.Parse(quietHoursTimeFormat, start)
+	if err != nil {
+		return nil, err
+	}
+
+	endTime, err := time.Parse(quietHoursTimeFormat, end)
+	if err != nil {
+		return nil, err
+	}
+
+	quietHours := QuietHours{
+		Start: time.Duration(startTime.Hour())*time.Hour + time.Duration(startTime.Minute())*time.Minute,
+		End:   time.Duration(endTime.Hour())*time.Hour + time.Duration(endTime.Minute())*time.Minute,
+	}
+
+	return &quietHours, nil
+}
+
+// NewScheduler creates and returns an instance of Scheduler configured from the
+// "wtf.scheduler" and "wtf.quietHours" settings
+func NewScheduler(config *config.Config) *Scheduler {
+	scheduler := Scheduler{
+		concurrency: config.UInt("wtf.scheduler.concurrency", defaultConcurrency),
+		entries:     make(map[string]*scheduleEntry),
+		jitter:      float64(config.UInt("wtf.scheduler.jitter", defaultJitter)) / 100,
+		maxBackoff:  time.Duration(config.UInt("wtf.scheduler.maxBackoff", defaultMaxBackoff)) * time.Second,
+		wake:        make(chan struct{}, 1),
+	}
+
+	// Quiet hours that can't be parsed are ignored rather than pausing modules at random
+	start := config.UString("wtf.quietHours.start", "")
+	end := config.UString("wtf.quietHours.end", "")
+	if start != "" && end != "" {
+		scheduler.quietHours, _ = NewQuietHours(start, end)
+	}
+
+	return &scheduler
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Contains returns TRUE if the given time falls within the quiet hours
+func (quietHours *QuietHours) Contains(t time.Time) bool {
+	offset := sinceMidnight(t)
+
+	if quietHours.Start <= quietHours.End {
+		return offset >= quietHours.Start && offset < quietHours.End
+	}
+
+	return offset >= quietHours.Start || offset < quietHours.End
+}
+
+// Until returns the time at which the quiet hours containing the given time end
+func (quietHours *QuietHours) Until(t time.Time) time.Time {
+	wait := quietHours.End - sinceMidnight(t)
+	if wait <= 0 {
+		wait += 24 * time.Hour
+	}
+
+	return t.Add(wait)
+}
+
+// Backoff returns how long to wait before refreshing a widget whose refreshes have
+// failed the given number of times in a row, doubling the interval with each failure
+// up to the maximum
+func Backoff(interval time.Duration, failures int, max time.Duration) time.Duration {
+	delay := interval
+
+	for i := 0; i < failures && delay < max; i++ {
+		delay *= 2
+	}
+
+	if delay > max {
+		return max
+	}
+
+	return delay
+}
+
+// Start schedules the widgets' refreshes and starts the workers that carry them out.
+// The first refreshes are spread out over a short period, and the scheduler stops once
+// every widget has been disabled
+func (scheduler *Scheduler) Start(widgets []Wtfable) {
+	now := time.Now()
+
+	scheduler.mu.Lock()
+	for _, widget := range widgets {
+		scheduler.entries[widget.Name()] = &scheduleEntry{
+			interval: time.Duration(widget.RefreshInterval()) * time.Second,
+			next:     now.Add(scheduler.stagger()),
+			schedule: widget.CommonSettings().RefreshSchedule,
+			widget:   widget,
+		}
+	}
+	scheduler.mu.Unlock()
+
+	// A widget is never queued twice, so the queue never has to hold more than one
+	// entry per widget
+	scheduler.queue = make(chan *scheduleEntry, len(widgets))
+
+	workers := scheduler.concurrency
+	if workers <= 0 || workers > len(widgets) {
+		workers = len(widgets)
+	}
+
+	for i := 0; i < workers; i++ {
+		go scheduler.work()
+	}
+
+	go scheduler.loop()
+}
+
+// Refresh refreshes the widgets as soon as there are workers free to do so. The status
+// bar shows the progress of the refreshes until they have all finished
+func (scheduler *Scheduler) Refresh(widgets []Wtfable) {
+	now := time.Now()
+
+	names := []string{}
+
+	scheduler.mu.Lock()
+	for _, widget := range widgets {
+		entry, ok := scheduler.entries[widget.Name()]
+		if !ok {
+			continue
+		}
+
+		// A widget that is already refreshing counts once it finishes
+		if !entry.running {
+			entry.next = now
+		}
+
+		names = append(names, widget.Name())
+	}
+	scheduler.mu.Unlock()
+
+	RefreshStatuses.StartBatch(names)
+
+	scheduler.poke()
+}
+
+// TogglePause pauses the widget's automatic refreshes or, if they are already paused,
+// resumes them and refreshes the widget straight away. Returns true if the widget is
+// now paused
+func (scheduler *Scheduler) TogglePause(widget Wtfable) bool {
+	paused := !RefreshStatuses.Status(widget.Name()).Paused
+	RefreshStatuses.SetPaused(widget.Name(), paused)
+
+	if !paused {
+		scheduler.Refresh([]Wtfable{widget})
+	}
+
+	return paused
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// loop sleeps until the next widget is due, queues every widget that is due to be
+// refreshed, and repeats until there are no widgets left to schedule
+func (scheduler *Scheduler) loop() {
+	for {
+		next, ok := scheduler.dispatch(time.Now())
+		if !ok {
+			close(scheduler.queue)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-timer.C:
+		case <-scheduler.wake:
+			timer.Stop()
+		}
+	}
+}
+
+// dispatch queues the widgets that are due at the given time and returns when the
+// next widget will be due. Returns false once there is nothing left to schedule
+func (scheduler *Scheduler) dispatch(now time.Time) (time.Time, bool) {
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+
+	var next time.Time
+
+	for name, entry := range scheduler.entries {
+		if !entry.widget.Enabled() {
+			delete(scheduler.entries, name)
+			continue
+		}
+
+		if entry.running || entry.next.IsZero() {
+			continue
+		}
+
+		if !entry.next.After(now) {
+			scheduler.dispatchEntry(entry, now)
+		}
+
+		if !entry.running && !entry.next.IsZero() && (next.IsZero() || entry.next.Before(next)) {
+			next = entry.next
+		}
+	}
+
+	if len(scheduler.entries) == 0 {
+		return next, false
+	}
+
+	// Nothing is due; refresh() and Refresh() wake the loop when that changes
+	if next.IsZero() {
+		next = now.Add(24 * time.Hour)
+	}
+
+	return next, true
+}
+
+// dispatchEntry queues the refresh of a widget that is due, unless it is hidden, its
+// refreshes are paused, Do Not Disturb is pausing it, or the quiet hours are in effect,
+// in which case its refresh is put off
+func (scheduler *Scheduler) dispatchEntry(entry *scheduleEntry, now time.Time) {
+	if scheduler.quietHours != nil && scheduler.quietHours.Contains(now) && entry.widget.CommonSettings().UsesNetwork() {
+		quietEnd := scheduler.quietHours.Until(now)
+
+		if entry.schedule != nil {
+			scheduler.scheduleNext(entry, quietEnd)
+		} else {
+			entry.next = quietEnd.Add(scheduler.stagger())
+		}
+
+		RefreshStatuses.Skipped(entry.widget.Name())
+		return
+	}
+
+	// Hidden widgets are refreshed when they're shown again, paused widgets when they're
+	// resumed, and widgets paused during Do Not Disturb once it's over
+	if !entry.widget.Visible() || RefreshStatuses.Status(entry.widget.Name()).Paused || (entry.widget.CommonSettings().PauseDuringDND && DND.Active(now)) {
+		scheduler.scheduleNext(entry, now)
+		RefreshStatuses.Skipped(entry.widget.Name())
+		return
+	}
+
+	entry.running = true
+	scheduler.queue <- entry
+}
+
+// work refreshes the queued widgets one at a time until the queue is closed
+func (scheduler *Scheduler) work() {
+	for entry := range scheduler.queue {
+		scheduler.refresh(entry)
+	}
+}
+
+// refresh refreshes the widget's data and schedules its next refresh, backing off if
+// the refresh failed
+func (scheduler *Scheduler) refresh(entry *scheduleEntry) {
+	RefreshWidget(entry.widget)
+
+	scheduler.mu.Lock()
+	entry.running = false
+
+	if RefreshStatuses.Status(entry.widget.Name()).Err != nil {
+		entry.failures++
+	} else {
+		entry.failures = 0
+	}
+
+	scheduler.scheduleNext(entry, time.Now())
+	scheduler.mu.Unlock()
+
+	scheduler.poke()
+}
+
+// scheduleNext sets when the widget will next be refreshed. Widgets with a cron
+// schedule wait for the next time that matches it; a failed refresh is retried no
+// sooner than that. Widgets without a refresh interval are left unscheduled
+func (scheduler *Scheduler) scheduleNext(entry *scheduleEntry, now time.Time) {
+	switch {
+	case entry.schedule != nil:
+		entry.next = entry.schedule.Next(now)
+		if !entry.next.IsZero() {
+			entry.next = entry.next.Add(scheduler.stagger())
+		}
+	case entry.interval > 0:
+		entry.next = now.Add(scheduler.delay(entry))
+	default:
+		entry.next = time.Time{}
+	}
+}
+
+// delay returns how long to wait before the widget's next refresh, including backoff
+// from failed refreshes, stretching while its API quota is low or until its API host's
+// circuit closes, and a random jitter
+func (scheduler *Scheduler) delay(entry *scheduleEntry) time.Duration {
+	delay := Backoff(entry.interval, entry.failures, scheduler.maxOf(entry.interval))
+	delay = Quotas.Stretch(quotaHostOf(entry.widget), delay, time.Now())
+	delay = Circuits.Wait(quotaHostOf(entry.widget), delay, time.Now())
+
+	spread := time.Duration(float64(delay) * scheduler.jitter)
+	if spread > 0 {
+		delay += time.Duration(rand.Int63n(int64(2*spread))) - spread
+	}
+
+	return delay
+}
+
+// maxOf returns the longest the scheduler will back off from a widget with the given
+// refresh interval. Widgets that refresh less often than that are never delayed further
+func (scheduler *Scheduler) maxOf(interval time.Duration) time.Duration {
+	if interval > scheduler.maxBackoff {
+		return interval
+	}
+
+	return scheduler.maxBackoff
+}
+
+// poke wakes the scheduler's loop so that it recalculates when the next widget is due
+func (scheduler *Scheduler) poke() {
+	select {
+	case scheduler.wake <- struct{}{}:
+	default:
+	}
+}
+
+// stagger returns a random delay used to spread out refreshes that would otherwise
+// happen at the same moment
+func (scheduler *Scheduler) stagger() time.Duration {
+	if scheduler.jitter <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(maxStartupStagger)))
+}
+
+// sinceMidnight returns how much of the given time's day has passed
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
@@ -0,0 +1,170 @@
+package wtf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// ScreenshotPNG is the format of screenshots drawn as images by an ANSI-to-image renderer
+const ScreenshotPNG = "png"
+
+// screenshotTimeFormat is how a screenshot's file name says when it was taken
+const screenshotTimeFormat = "20060102-150405"
+
+// Screenshotter writes what a widget shows, border and title included, to a file named
+// after the widget and the time, such as "github-20191016-153000.txt". It's set up
+// under "wtf.screenshot":
+//
+//	wtf:
+//	  screenshot:
+//	    dir: ~/Pictures/wtf
+//	    format: png
+//	    pngCommand: freeze --output {file}
+//
+// Screenshots are plain text, ANSI-colored text, or PNG images. PNG images are drawn by
+// the pngCommand, which is given the ANSI-colored text on its standard input and writes
+// the image to {file} or, if it has no {file}, to its standard output
+type Screenshotter struct {
+	dir        string
+	format     string
+	pngCommand string
+}
+
+// NewScreenshotter creates and returns an instance of Screenshotter from the
+// "wtf.screenshot" settings. Screenshots go in the config directory's screenshots
+// directory unless told otherwise
+func NewScreenshotter(config *config.Config) *Screenshotter {
+	dir := config.UString("wtf.screenshot.dir", "")
+	if dir == "" {
+		if configDir, err := cfg.WtfConfigDir(); err == nil {
+			dir = filepath.Join(configDir, "screenshots")
+		}
+	}
+	dir, _ = utils.ExpandHomeDir(dir)
+
+	return &Screenshotter{
+		dir:        dir,
+		format:     config.UString("wtf.screenshot.format", SnapshotText),
+		pngCommand: config.UString("wtf.screenshot.pngCommand", ""),
+	}
+}
+
+// ScreenCells returns the cells of the screen in the rectangle, row by row, as a
+// simulation screen holds them
+func ScreenCells(screen tcell.Screen, x, y, width, height int) []tcell.SimCell {
+	cells := make([]tcell.SimCell, 0, width*height)
+
+	for row := y; row < y+height; row++ {
+		for col := x; col < x+width; col++ {
+			mainc, combc, style, _ := screen.GetContent(col, row)
+
+			cells = append(cells, tcell.SimCell{
+				Runes: append([]rune{mainc}, combc...),
+				Style: style,
+			})
+		}
+	}
+
+	return cells
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Save writes the cells, which are width by height, to a new file for the named widget
+// in the given format, or the configured format if it's empty, and returns the file's
+// path
+func (shot *Screenshotter) Save(name string, cells []tcell.SimCell, width, height int, format string, now time.Time) (string, error) {
+	if format == "" {
+		format = shot.format
+	}
+
+	if shot.dir == "" {
+		return "", errors.New("there is no wtf.screenshot.dir to save screenshots in")
+	}
+
+	if err := os.MkdirAll(shot.dir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(shot.dir, fmt.Sprintf("%s-%s.%s", name, now.Format(screenshotTimeFormat), screenshotExtension(format)))
+
+	if format == ScreenshotPNG {
+		return path, shot.savePNG(cells, width, height, path)
+	}
+
+	text, err := RenderSnapshot(cells, width, height, format)
+	if err != nil {
+		return "", err
+	}
+
+	return path, ioutil.WriteFile(path, []byte(text), 0644)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// savePNG renders the cells as ANSI-colored text and has the png command draw them
+func (shot *Screenshotter) savePNG(cells []tcell.SimCell, width, height int, path string) error {
+	args := strings.Fields(shot.pngCommand)
+	if len(args) == 0 {
+		return errors.New("PNG screenshots need an ANSI-to-image renderer in wtf.screenshot.pngCommand")
+	}
+
+	text, err := RenderSnapshot(cells, width, height, SnapshotANSI)
+	if err != nil {
+		return err
+	}
+
+	toFile := false
+	for idx, arg := range args {
+		if strings.Contains(arg, "{file}") {
+			args[idx] = strings.Replace(arg, "{file}", path, -1)
+			toFile = true
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %s", args[0], msg)
+		}
+		return err
+	}
+
+	if toFile {
+		return nil
+	}
+
+	return ioutil.WriteFile(path, stdout.Bytes(), 0644)
+}
+
+// screenshotExtension returns the file extension of screenshots in the format
+func screenshotExtension(format string) string {
+	switch format {
+	case SnapshotANSI:
+		return "ans"
+	case SnapshotHTML:
+		return "html"
+	case ScreenshotPNG:
+		return "png"
+	default:
+		return "txt"
+	}
+}
@@ -3,6 +3,7 @@ package wtf
 import (
 	"strconv"
 
+	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
 )
@@ -13,12 +14,22 @@ type ScrollableWidget struct {
 	Selected       int
 	maxItems       int
 	RenderFunction func()
+
+	actions     []RowAction
+	marked      map[int]bool
+	rowFunction func(idx int) Row
+	rowTexts    map[int]string
+	search      listSearch
 }
 
 func NewScrollableWidget(app *tview.Application, commonSettings *cfg.Common, focusable bool) ScrollableWidget {
 
 	widget := ScrollableWidget{
 		TextWidget: NewTextWidget(app, commonSettings, focusable),
+
+		actions:  commandRowActions(commonSettings.Config),
+		marked:   make(map[int]bool),
+		rowTexts: make(map[int]string),
 	}
 
 	widget.Unselect()
@@ -32,22 +43,145 @@ func (widget *ScrollableWidget) SetRenderFunction(displayFunc func()) {
 	widget.RenderFunction = displayFunc
 }
 
+// SetItemCount sets the number of rows in the list. Changing it unmarks every row, as
+// the rows the marks were on may have moved
 func (widget *ScrollableWidget) SetItemCount(items int) {
+	if items != widget.maxItems {
+		widget.marked = make(map[int]bool)
+	}
+
 	widget.maxItems = items
 }
 
+// SetRowFunction sets the function that describes the row at the given index, for
+// actions to run on. Lists without one have no actions
+func (widget *ScrollableWidget) SetRowFunction(rowFunc func(idx int) Row) {
+	widget.rowFunction = rowFunc
+}
+
+// AddRowAction adds a module-specific action to the list's actions menu
+func (widget *ScrollableWidget) AddRowAction(name string, fn func(rows []Row) error) {
+	widget.actions = append(widget.actions, RowAction{Name: name, Run: fn})
+}
+
 func (widget *ScrollableWidget) GetSelected() int {
 	return widget.Selected
 }
 
+// IsMarked returns TRUE if the row at the given index has been marked
+func (widget *ScrollableWidget) IsMarked(idx int) bool {
+	return widget.marked[idx]
+}
+
 func (widget *ScrollableWidget) RowColor(idx int) string {
 	if widget.View.HasFocus() && (idx == widget.Selected) {
 		return widget.CommonSettings().DefaultFocusedRowColor()
 	}
 
+	if widget.IsMarked(idx) {
+		return widget.CommonSettings().Colors.Checked
+	}
+
+	if widget.Matches(idx) {
+		return widget.CommonSettings().Colors.Warn
+	}
+
 	return widget.CommonSettings().RowColor(idx)
 }
 
+// RowActions returns the actions that can be run on the list's rows: opening them and
+// copying them to the clipboard, then the module's own actions, then those in the
+// module's "actions" setting
+func (widget *ScrollableWidget) RowActions() []RowAction {
+	if widget.rowFunction == nil {
+		return []RowAction{}
+	}
+
+	actions := append([]RowAction{openRowAction}, copyRowActions...)
+
+	return append(actions, widget.actions...)
+}
+
+// SelectedRows returns the marked rows or, if none are marked, the selected row
+func (widget *ScrollableWidget) SelectedRows() []Row {
+	rows := []Row{}
+
+	if widget.rowFunction == nil {
+		return rows
+	}
+
+	for idx := 0; idx < widget.maxItems; idx++ {
+		if widget.marked[idx] {
+			rows = append(rows, widget.rowFunction(idx))
+		}
+	}
+
+	if len(rows) == 0 && widget.Selected >= 0 && widget.Selected < widget.maxItems {
+		rows = append(rows, widget.rowFunction(widget.Selected))
+	}
+
+	return rows
+}
+
+// ToggleMark marks or unmarks the selected row, so that actions run on it along with
+// the other marked rows, and moves the selection down to the next row
+func (widget *ScrollableWidget) ToggleMark() {
+	if widget.Selected < 0 || widget.Selected >= widget.maxItems {
+		return
+	}
+
+	if widget.marked[widget.Selected] {
+		delete(widget.marked, widget.Selected)
+	} else {
+		widget.marked[widget.Selected] = true
+	}
+
+	widget.Next()
+}
+
+// First selects the first row
+func (widget *ScrollableWidget) First() {
+	if widget.maxItems == 0 {
+		return
+	}
+
+	widget.Selected = 0
+	widget.RenderFunction()
+}
+
+// Last selects the last row
+func (widget *ScrollableWidget) Last() {
+	if widget.maxItems == 0 {
+		return
+	}
+
+	widget.Selected = widget.maxItems - 1
+	widget.RenderFunction()
+}
+
+// MouseClick selects the row that was clicked on, going by the region tag on the line
+// that was clicked, so that headers and other lines that aren't rows can't be selected.
+// Clicking on the row that is already selected acts as though the Enter key was pressed,
+// which for most modules opens it
+func (widget *ScrollableWidget) MouseClick(x, y int) bool {
+	offset, _ := widget.View.GetScrollOffset()
+
+	idx, ok := rowAt(widget.View.GetText(false), offset+y)
+	if !ok || idx >= widget.maxItems {
+		return false
+	}
+
+	if idx == widget.Selected {
+		sendKey(widget.View, tcell.KeyEnter)
+		return true
+	}
+
+	widget.Selected = idx
+	widget.RenderFunction()
+
+	return true
+}
+
 func (widget *ScrollableWidget) Next() {
 	widget.Selected++
 	if widget.Selected >= widget.maxItems {
@@ -64,6 +198,13 @@ func (widget *ScrollableWidget) Prev() {
 	widget.RenderFunction()
 }
 
+// SetSelected selects the row at the given index, as when the app's last session is
+// restored. The row does not need to exist yet, as the list's rows may not have been
+// fetched
+func (widget *ScrollableWidget) SetSelected(idx int) {
+	widget.Selected = idx
+}
+
 func (widget *ScrollableWidget) Unselect() {
 	widget.Selected = -1
 	if widget.RenderFunction != nil {
@@ -71,8 +212,12 @@ func (widget *ScrollableWidget) Unselect() {
 	}
 }
 
+// Redraw replaces the widget's title and content and scrolls to the selected row. The
+// search query, if there is one, is shown in the title
 func (widget *ScrollableWidget) Redraw(title, content string, wrap bool) {
-	widget.TextWidget.Redraw(title, content, wrap)
+	widget.rowTexts = rowTextsFrom(content)
+
+	widget.TextWidget.Redraw(title+widget.searchTitle(), content, wrap)
 	widget.app.QueueUpdateDraw(func() {
 		widget.View.Highlight(strconv.Itoa(widget.Selected)).ScrollToHighlight()
 	})
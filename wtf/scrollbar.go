@@ -0,0 +1,102 @@
+package wtf
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+const scrollbarThumb = '┃'
+
+// scrollbar draws a thumb on the right border of a text view whose content is taller
+// than the view, showing how far down the content the view is scrolled
+type scrollbar struct {
+	bordered   bool
+	lineWidths []int
+	mu         sync.Mutex
+	view       *tview.TextView
+	wrap       bool
+}
+
+// newScrollbar creates a scrollbar and attaches it to the view
+func newScrollbar(view *tview.TextView, bordered bool) *scrollbar {
+	bar := scrollbar{
+		bordered: bordered,
+		view:     view,
+	}
+
+	view.SetDrawFunc(bar.draw)
+
+	return &bar
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// draw is the view's draw function. It is called after the view's border has been
+// drawn, and returns the view's inner rectangle unchanged
+func (bar *scrollbar) draw(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+	innerX, innerY, innerWidth, innerHeight := x, y, width, height
+	if bar.bordered {
+		innerX, innerY, innerWidth, innerHeight = x+1, y+1, width-2, height-2
+	}
+
+	if !bar.bordered || innerWidth < 1 || innerHeight < 2 {
+		return innerX, innerY, innerWidth, innerHeight
+	}
+
+	rows := bar.rows(innerWidth)
+	if rows <= innerHeight {
+		return innerX, innerY, innerWidth, innerHeight
+	}
+
+	offset, _ := bar.view.GetScrollOffset()
+	if offset > rows-innerHeight {
+		offset = rows - innerHeight
+	}
+
+	pos := offset * (innerHeight - 1) / (rows - innerHeight)
+
+	_, _, style, _ := screen.GetContent(x+width-1, innerY+pos)
+	screen.SetContent(x+width-1, innerY+pos, scrollbarThumb, nil, style)
+
+	return innerX, innerY, innerWidth, innerHeight
+}
+
+// rows returns the number of rows the content takes up in a view of the given width
+func (bar *scrollbar) rows(width int) int {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+
+	if !bar.wrap {
+		return len(bar.lineWidths)
+	}
+
+	rows := 0
+	for _, lineWidth := range bar.lineWidths {
+		if lineWidth <= width {
+			rows++
+			continue
+		}
+
+		rows += (lineWidth + width - 1) / width
+	}
+
+	return rows
+}
+
+// setContent records the size of the view's new content
+func (bar *scrollbar) setContent(text string, wrap bool) {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	lineWidths := make([]int, len(lines))
+	for idx, line := range lines {
+		lineWidths[idx] = tview.TaggedStringWidth(line)
+	}
+
+	bar.mu.Lock()
+	bar.lineWidths = lineWidths
+	bar.wrap = wrap
+	bar.mu.Unlock()
+}
@@ -0,0 +1,249 @@
+package wtf
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/utils"
+)
+
+// regionRegex finds the region tag that HighlightableHelper puts at the start of each row
+var regionRegex = regexp.MustCompile(`\["([0-9]+)"\]`)
+
+// Searcher is implemented by widgets that search their rows. While a search is being
+// typed the widget sees every key press before the app's global keys do. Returns true
+// if the key press was handled
+type Searcher interface {
+	SearchCapture(event *tcell.EventKey) bool
+}
+
+// SearchCapture passes the key press on to the widget if it is searching its rows
+func SearchCapture(widget Wtfable, event *tcell.EventKey) bool {
+	searcher, ok := widget.(Searcher)
+	if !ok {
+		return false
+	}
+
+	return searcher.SearchCapture(event)
+}
+
+// listSearch is the state of a search through a list's rows
+type listSearch struct {
+	query  string
+	typing bool
+}
+
+/* -------------------- ScrollableWidget -------------------- */
+
+// ClearSearch stops searching and removes the highlight from the matching rows
+func (widget *ScrollableWidget) ClearSearch() {
+	widget.search = listSearch{}
+	widget.RenderFunction()
+}
+
+// Matches returns TRUE if the row at the given index contains the search query,
+// ignoring case
+func (widget *ScrollableWidget) Matches(idx int) bool {
+	if widget.search.query == "" {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(widget.rowText(idx)), strings.ToLower(widget.search.query))
+}
+
+// Search highlights the rows that contain the query and, if the selected row is not
+// one of them, selects the next one that is
+func (widget *ScrollableWidget) Search(query string) {
+	widget.search.query = query
+
+	if !widget.Matches(widget.Selected) {
+		if idx := widget.nextMatch(widget.Selected, 1); idx >= 0 {
+			widget.Selected = idx
+		}
+	}
+
+	widget.RenderFunction()
+}
+
+// SearchNext selects the next row that matches the search query
+func (widget *ScrollableWidget) SearchNext() {
+	if idx := widget.nextMatch(widget.Selected+1, 1); idx >= 0 {
+		widget.Selected = idx
+		widget.RenderFunction()
+	}
+}
+
+// SearchPrev selects the previous row that matches the search query
+func (widget *ScrollableWidget) SearchPrev() {
+	if idx := widget.nextMatch(widget.Selected-1, -1); idx >= 0 {
+		widget.Selected = idx
+		widget.RenderFunction()
+	}
+}
+
+// SearchQuery returns what is being searched for, or an empty string if nothing is
+func (widget *ScrollableWidget) SearchQuery() string {
+	return widget.search.query
+}
+
+// matchCount returns the number of rows that match the search query
+func (widget *ScrollableWidget) matchCount() int {
+	count := 0
+	for idx := 0; idx < widget.maxItems; idx++ {
+		if widget.Matches(idx) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// nextMatch returns the index of the first matching row found by stepping through the
+// rows from the given index, wrapping around at either end, or -1 if none match
+func (widget *ScrollableWidget) nextMatch(from, step int) int {
+	if widget.maxItems == 0 || widget.search.query == "" {
+		return -1
+	}
+
+	for count := 0; count < widget.maxItems; count++ {
+		idx := ((from+count*step)%widget.maxItems + widget.maxItems) % widget.maxItems
+		if widget.Matches(idx) {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// rowText returns the text of the row at the given index: the row's Text if the list
+// has a row function, and otherwise what was last drawn in the row
+func (widget *ScrollableWidget) rowText(idx int) string {
+	if idx < 0 || idx >= widget.maxItems {
+		return ""
+	}
+
+	if widget.rowFunction != nil {
+		return widget.rowFunction(idx).Text
+	}
+
+	return widget.rowTexts[idx]
+}
+
+// searchInput handles a key press while the search query is being typed. Enter stops
+// typing and keeps the matches highlighted, and Esc clears the search
+func (widget *ScrollableWidget) searchInput(event *tcell.EventKey) bool {
+	if !widget.search.typing {
+		if event.Key() == tcell.KeyEsc && widget.search.query != "" {
+			widget.ClearSearch()
+			return true
+		}
+
+		return false
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		widget.ClearSearch()
+	case tcell.KeyEnter:
+		widget.search.typing = false
+		widget.RenderFunction()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		query := []rune(widget.search.query)
+		if len(query) > 0 {
+			query = query[:len(query)-1]
+		}
+		widget.Search(string(query))
+	case tcell.KeyRune:
+		widget.Search(widget.search.query + string(event.Rune()))
+	default:
+		return false
+	}
+
+	return true
+}
+
+// searchTitle returns what is added to the widget's title while it is searching
+func (widget *ScrollableWidget) searchTitle() string {
+	if widget.search.query == "" && !widget.search.typing {
+		return ""
+	}
+
+	cursor := ""
+	if widget.search.typing {
+		cursor = "_"
+	}
+
+	return fmt.Sprintf(" /%s%s (%d)", tview.Escape(widget.search.query), cursor, widget.matchCount())
+}
+
+// startSearch starts typing a new search query
+func (widget *ScrollableWidget) startSearch() {
+	widget.search = listSearch{typing: true}
+	widget.RenderFunction()
+}
+
+/* -------------------- KeyboardWidget -------------------- */
+
+// BindSearch binds the keys that search the list's rows: '/' to type what to search
+// for, and 'n' and 'N' to move to the next and previous matching rows
+func (widget *KeyboardWidget) BindSearch(list *ScrollableWidget) {
+	widget.searchList = list
+
+	widget.SetKeyboardChar("/", list.startSearch, "Search the rows")
+	widget.SetKeyboardChar("n", list.SearchNext, "Select the next matching row")
+	widget.SetKeyboardChar("N", list.SearchPrev, "Select the previous matching row")
+}
+
+// SearchCapture handles the key press if the widget's list is being searched
+func (widget *KeyboardWidget) SearchCapture(event *tcell.EventKey) bool {
+	if widget.searchList == nil {
+		return false
+	}
+
+	return widget.searchList.searchInput(event)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// rowAt returns the index in the region tag of the given line of a list's content. Lines
+// without one, such as headers, aren't rows
+func rowAt(content string, line int) (int, bool) {
+	lines := strings.Split(content, "\n")
+	if line < 0 || line >= len(lines) {
+		return 0, false
+	}
+
+	match := regionRegex.FindStringSubmatch(lines[line])
+	if match == nil {
+		return 0, false
+	}
+
+	idx, err := strconv.Atoi(match[1])
+	return idx, err == nil
+}
+
+// rowTextsFrom returns the text of each row in a list's content, keyed by the index in
+// the row's region tag
+func rowTextsFrom(content string) map[int]string {
+	texts := make(map[int]string)
+
+	for _, line := range strings.Split(content, "\n") {
+		match := regionRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		idx, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+
+		texts[idx] = strings.TrimSpace(utils.StripColorTags(line))
+	}
+
+	return texts
+}
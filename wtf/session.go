@@ -0,0 +1,147 @@
+package wtf
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/wtfutil/wtf/cfg"
+)
+
+// Session is the state of the dashboard when the app last exited: the page that was
+// onscreen, the widget that had the focus, and how far each widget was scrolled. It is
+// restored on the next launch so that the dashboard reopens where it was left
+type Session struct {
+	Focused string                   `json:"focused,omitempty"`
+	Page    string                   `json:"page,omitempty"`
+	Widgets map[string]WidgetSession `json:"widgets,omitempty"`
+}
+
+// WidgetSession is the scroll position and selected row of one widget
+type WidgetSession struct {
+	Column   int `json:"column"`
+	Row      int `json:"row"`
+	Selected int `json:"selected"`
+}
+
+// scrollRestorable is implemented by widgets that can be scrolled back to where they were
+type scrollRestorable interface {
+	RestoreScrollOffset(row, column int)
+	ScrollOffset() (int, int)
+}
+
+// selectable is implemented by list widgets, whose selected row is part of the session
+type selectable interface {
+	GetSelected() int
+	SetSelected(idx int)
+}
+
+// CaptureSession records the state of the display and its widgets
+func CaptureSession(display *Display, focused Wtfable, widgets []Wtfable) Session {
+	session := Session{
+		Page:    display.CurrentPage().Name,
+		Widgets: map[string]WidgetSession{},
+	}
+
+	if focused != nil {
+		session.Focused = focused.Name()
+	}
+
+	for _, widget := range widgets {
+		state := WidgetSession{Selected: -1}
+
+		if scrollable, ok := widget.(scrollRestorable); ok {
+			state.Row, state.Column = scrollable.ScrollOffset()
+		}
+
+		if list, ok := widget.(selectable); ok {
+			state.Selected = list.GetSelected()
+		}
+
+		if state != (WidgetSession{Selected: -1}) {
+			session.Widgets[widget.Name()] = state
+		}
+	}
+
+	return session
+}
+
+// LoadSession reads the session saved when the app last exited. It returns an empty
+// session if there is none
+func LoadSession() Session {
+	session := Session{}
+
+	path, err := sessionFilePath()
+	if err != nil {
+		return session
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return session
+	}
+
+	json.Unmarshal(data, &session)
+
+	return session
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Restore brings the session's page onscreen, scrolls the widgets back to where they
+// were, and returns the widget that had the focus. Widgets that no longer exist are
+// skipped
+func (session Session) Restore(display *Display, widgets []Wtfable) Wtfable {
+	if idx := display.PageIndex(session.Page); idx >= 0 {
+		display.ShowPage(idx)
+	}
+
+	var focused Wtfable
+
+	for _, widget := range widgets {
+		if widget.Name() == session.Focused {
+			focused = widget
+		}
+
+		state, ok := session.Widgets[widget.Name()]
+		if !ok {
+			continue
+		}
+
+		if scrollable, ok := widget.(scrollRestorable); ok {
+			scrollable.RestoreScrollOffset(state.Row, state.Column)
+		}
+
+		if list, ok := widget.(selectable); ok {
+			list.SetSelected(state.Selected)
+		}
+	}
+
+	return focused
+}
+
+// Save writes the session to disk, for the next launch to restore
+func (session Session) Save() error {
+	path, err := sessionFilePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func sessionFilePath() (string, error) {
+	cacheDir, err := cfg.WtfCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "session.json"), nil
+}
@@ -0,0 +1,219 @@
+package wtf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+// The services a widget's content can be shared to
+const (
+	ShareGist     = "gist"
+	SharePastebin = "pastebin"
+)
+
+const defaultGistURL = "https://api.github.com/gists"
+
+// Sharer uploads the text a widget is showing to a secret GitHub Gist or to a pastebin,
+// so that it can be passed on as a link. It's set up under "wtf.share":
+//
+//	wtf:
+//	  share:
+//	    service: pastebin
+//	    url: https://0x0.st
+//	    field: file
+//
+// Gists are created with a GitHub token that can create them, which falls back to
+// WTF_GITHUB_TOKEN, and at GitHub Enterprise's gists endpoint if the url is set. Pastebins
+// are sent the text as the request's body or, if a field is named, as a file in that
+// form field, and reply with the paste's URL, either as plain text or as JSON with a
+// "url" or a "key" to add to the pastebin's address
+type Sharer struct {
+	field   string
+	service string
+	token   string
+	url     string
+}
+
+// NewSharer creates and returns an instance of Sharer from the "wtf.share" settings
+func NewSharer(config *config.Config) *Sharer {
+	service := config.UString("wtf.share.service", ShareGist)
+
+	sharer := Sharer{
+		field:   config.UString("wtf.share.field", ""),
+		service: service,
+		token:   config.UString("wtf.share.token", os.Getenv("WTF_GITHUB_TOKEN")),
+		url:     strings.TrimSuffix(config.UString("wtf.share.url", ""), "/"),
+	}
+
+	if service == ShareGist && sharer.url == "" {
+		sharer.url = defaultGistURL
+	}
+
+	return &sharer
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Share uploads the text, named after the widget it came from, and returns the URL it
+// can be seen at
+func (sharer *Sharer) Share(name, text string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", errors.New("there is nothing to share")
+	}
+
+	switch sharer.service {
+	case ShareGist:
+		return sharer.shareGist(name, text)
+	case SharePastebin:
+		return sharer.sharePastebin(name, text)
+	default:
+		return "", fmt.Errorf("unknown share service %q, expected gist or pastebin", sharer.service)
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// shareGist creates a secret gist holding the text in a file named after the widget
+func (sharer *Sharer) shareGist(name, text string) (string, error) {
+	if sharer.token == "" {
+		return "", errors.New("sharing to a gist needs a GitHub token in wtf.share.token or WTF_GITHUB_TOKEN")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"description": "wtf: " + name,
+		"files":       map[string]interface{}{name + ".txt": map[string]string{"content": text}},
+		"public":      false,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest("POST", sharer.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Authorization", "token "+sharer.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	data, err := sharer.send(req)
+	if err != nil {
+		return "", err
+	}
+
+	gist := struct {
+		HTMLURL string `json:"html_url"`
+	}{}
+
+	if err := json.Unmarshal(data, &gist); err != nil {
+		return "", err
+	}
+
+	if gist.HTMLURL == "" {
+		return "", errors.New("GitHub did not say where the gist is")
+	}
+
+	return gist.HTMLURL, nil
+}
+
+// sharePastebin posts the text to the pastebin and reads the paste's URL from its reply
+func (sharer *Sharer) sharePastebin(name, text string) (string, error) {
+	if sharer.url == "" {
+		return "", errors.New("sharing to a pastebin needs its address in wtf.share.url")
+	}
+
+	var body bytes.Buffer
+	contentType := "text/plain; charset=utf-8"
+
+	if sharer.field == "" {
+		body.WriteString(text)
+	} else {
+		form := multipart.NewWriter(&body)
+
+		part, err := form.CreateFormFile(sharer.field, name+".txt")
+		if err != nil {
+			return "", err
+		}
+
+		if _, err := part.Write([]byte(text)); err != nil {
+			return "", err
+		}
+
+		if err := form.Close(); err != nil {
+			return "", err
+		}
+
+		contentType = form.FormDataContentType()
+	}
+
+	req, err := http.NewRequest("POST", sharer.url, &body)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	data, err := sharer.send(req)
+	if err != nil {
+		return "", err
+	}
+
+	return sharer.pasteURL(data)
+}
+
+// pasteURL reads the paste's URL from the pastebin's reply: a URL on its own, or JSON
+// with either the URL or the paste's key
+func (sharer *Sharer) pasteURL(data []byte) (string, error) {
+	reply := struct {
+		Key string `json:"key"`
+		URL string `json:"url"`
+	}{}
+
+	if err := json.Unmarshal(data, &reply); err == nil {
+		switch {
+		case reply.URL != "":
+			return reply.URL, nil
+		case reply.Key != "":
+			base, err := url.Parse(sharer.url)
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf("%s://%s/%s", base.Scheme, base.Host, reply.Key), nil
+		}
+	}
+
+	text := strings.TrimSpace(string(data))
+	if parsed, err := url.Parse(text); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		return text, nil
+	}
+
+	return "", errors.New("the pastebin did not say where the paste is")
+}
+
+// send makes the request with the shared HTTP client and returns the body of its
+// response
+func (sharer *Sharer) send(req *http.Request) ([]byte, error) {
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, NewHTTPError(resp)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
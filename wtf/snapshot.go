@@ -0,0 +1,202 @@
+package wtf
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+// The formats a dashboard snapshot can be written in
+const (
+	SnapshotANSI = "ansi"
+	SnapshotHTML = "html"
+	SnapshotText = "text"
+)
+
+// RenderSnapshot returns what is drawn on the screen as plain text, as text colored with
+// ANSI escape sequences, or as an HTML page, so that the dashboard can be shown outside
+// of the terminal
+func RenderSnapshot(cells []tcell.SimCell, width, height int, format string) (string, error) {
+	lines := make([]string, 0, height)
+
+	for y := 0; y < height; y++ {
+		row := cells[y*width : (y+1)*width]
+
+		switch format {
+		case SnapshotANSI:
+			lines = append(lines, snapshotANSILine(row))
+		case SnapshotHTML:
+			lines = append(lines, snapshotHTMLLine(row))
+		case SnapshotText, "":
+			lines = append(lines, strings.TrimRight(snapshotTextLine(row), " "))
+		default:
+			return "", fmt.Errorf("unknown snapshot format %q, expected text, ansi, or html", format)
+		}
+	}
+
+	if format == SnapshotHTML {
+		return fmt.Sprintf(
+			"<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>wtf</title></head>\n<body style=\"background:#000;color:#fff\">\n<pre style=\"font-family:monospace;line-height:1.1\">\n%s\n</pre>\n</body>\n</html>\n",
+			strings.Join(lines, "\n"),
+		), nil
+	}
+
+	text := strings.Join(lines, "\n")
+
+	// The grid rarely fills the screen, so plain text leaves off the empty lines below it
+	if format != SnapshotANSI {
+		text = strings.TrimRight(text, "\n")
+	}
+
+	return text + "\n", nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// snapshotCells calls the function with the text and style of each cell in the row. The
+// cell after a wide character is covered by it, so it is skipped
+func snapshotCells(row []tcell.SimCell, fn func(text string, style tcell.Style)) {
+	for x := 0; x < len(row); x++ {
+		cell := row[x]
+
+		if len(cell.Runes) == 0 || cell.Runes[0] == 0 {
+			fn(" ", cell.Style)
+			continue
+		}
+
+		fn(string(cell.Runes), cell.Style)
+
+		if runewidth.RuneWidth(cell.Runes[0]) == 2 {
+			x++
+		}
+	}
+}
+
+// snapshotColor returns the color as an HTML hex color, or an empty string if it is the
+// terminal's default color
+func snapshotColor(color tcell.Color) string {
+	if color == tcell.ColorDefault || color.Hex() < 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("#%06x", color.Hex())
+}
+
+func snapshotANSILine(row []tcell.SimCell) string {
+	var str strings.Builder
+	last := tcell.StyleDefault
+
+	snapshotCells(row, func(text string, style tcell.Style) {
+		if style != last {
+			str.WriteString(snapshotSGR(style))
+			last = style
+		}
+
+		str.WriteString(text)
+	})
+
+	str.WriteString("\x1b[0m")
+
+	return str.String()
+}
+
+func snapshotHTMLLine(row []tcell.SimCell) string {
+	var str strings.Builder
+	var span strings.Builder
+	last := tcell.StyleDefault
+
+	flush := func() {
+		if span.Len() == 0 {
+			return
+		}
+
+		if css := snapshotCSS(last); css != "" {
+			str.WriteString(fmt.Sprintf("<span style=\"%s\">%s</span>", css, html.EscapeString(span.String())))
+		} else {
+			str.WriteString(html.EscapeString(span.String()))
+		}
+
+		span.Reset()
+	}
+
+	snapshotCells(row, func(text string, style tcell.Style) {
+		if style != last {
+			flush()
+			last = style
+		}
+
+		span.WriteString(text)
+	})
+
+	flush()
+
+	return str.String()
+}
+
+func snapshotTextLine(row []tcell.SimCell) string {
+	var str strings.Builder
+
+	snapshotCells(row, func(text string, style tcell.Style) {
+		str.WriteString(text)
+	})
+
+	return str.String()
+}
+
+// snapshotCSS returns the inline CSS that gives text the style
+func snapshotCSS(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	if attrs&tcell.AttrReverse != 0 {
+		fg, bg = bg, fg
+	}
+
+	rules := []string{}
+
+	if color := snapshotColor(fg); color != "" {
+		rules = append(rules, "color:"+color)
+	}
+	if color := snapshotColor(bg); color != "" {
+		rules = append(rules, "background:"+color)
+	}
+	if attrs&tcell.AttrBold != 0 {
+		rules = append(rules, "font-weight:bold")
+	}
+	if attrs&tcell.AttrUnderline != 0 {
+		rules = append(rules, "text-decoration:underline")
+	}
+	if attrs&tcell.AttrDim != 0 {
+		rules = append(rules, "opacity:0.6")
+	}
+
+	return strings.Join(rules, ";")
+}
+
+// snapshotSGR returns the ANSI escape sequence that switches to the style
+func snapshotSGR(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	codes := []string{"0"}
+
+	if attrs&tcell.AttrBold != 0 {
+		codes = append(codes, "1")
+	}
+	if attrs&tcell.AttrDim != 0 {
+		codes = append(codes, "2")
+	}
+	if attrs&tcell.AttrUnderline != 0 {
+		codes = append(codes, "4")
+	}
+	if attrs&tcell.AttrReverse != 0 {
+		codes = append(codes, "7")
+	}
+	if r, g, b := fg.RGB(); fg != tcell.ColorDefault && r >= 0 {
+		codes = append(codes, fmt.Sprintf("38;2;%d;%d;%d", r, g, b))
+	}
+	if r, g, b := bg.RGB(); bg != tcell.ColorDefault && r >= 0 {
+		codes = append(codes, fmt.Sprintf("48;2;%d;%d;%d", r, g, b))
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
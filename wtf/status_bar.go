@@ -0,0 +1,202 @@
+package wtf
+
+import (
+	"bytes"
+	"net/url"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/cfg"
+)
+
+const defaultStatusBarTemplate = ` {{.Clock}}  [::b]{{.Focused}}[::-]{{if .RefreshTotal}}  [gray]refreshed {{.RefreshDone}} of {{.RefreshTotal}}[-]{{else if .Refreshing}}  [gray]refreshing {{.Refreshing}}[-]{{end}}{{if .DoNotDisturb}}  [gray]do not disturb[-]{{end}}{{if .Offline}}  [red]offline[-]{{end}}`
+
+// StatusBar is a single line across the bottom of the dashboard. What it shows is set
+// by a template, which can use the values on the data bus as well as the status fields:
+//
+//	wtf:
+//	  statusBar:
+//	    enabled: true
+//	    clockFormat: "15:04"
+//	    template: ' {{.Clock}} {{.Focused}} {{ data "weather.temperature" }}°'
+type StatusBar struct {
+	View *tview.TextView
+
+	app         *tview.Application
+	clockFormat string
+	focused     func() Wtfable
+	mu          sync.Mutex
+	offline     bool
+	template    *template.Template
+	templateErr error
+	unsubscribe []func()
+	widgets     []Wtfable
+}
+
+// StatusBarFields are the fields a status bar template can use. RefreshDone and
+// RefreshTotal are the progress of the widgets being refreshed by hand
+type StatusBarFields struct {
+	Clock        string
+	DoNotDisturb bool
+	Focused      string
+	Offline      bool
+	RefreshDone  int
+	RefreshTotal int
+	Refreshing   int
+	Time         time.Time
+}
+
+// NewStatusBar creates and returns an instance of StatusBar, or nil if the status bar
+// isn't turned on. The focused function returns the widget that has the focus
+func NewStatusBar(app *tview.Application, widgets []Wtfable, config *config.Config, focused func() Wtfable) *StatusBar {
+	if !config.UBool("wtf.statusBar.enabled", false) {
+		return nil
+	}
+
+	theme := cfg.NewThemeFromConfig(config)
+
+	bar := StatusBar{
+		View: tview.NewTextView(),
+
+		app:         app,
+		clockFormat: config.UString("wtf.statusBar.clockFormat", "15:04"),
+		focused:     focused,
+		widgets:     widgets,
+	}
+
+	bar.template, bar.templateErr = template.New("statusBar").
+		Funcs(Data.TemplateFuncs()).
+		Parse(config.UString("wtf.statusBar.template", defaultStatusBarTemplate))
+
+	bar.View.SetBackgroundColor(ColorFor(theme.Background))
+	bar.View.SetTextColor(ColorFor(theme.Text))
+	bar.View.SetDynamicColors(true)
+	bar.View.SetWrap(false)
+
+	bar.unsubscribe = []func(){
+		RefreshStatuses.Subscribe(bar.trackNetwork),
+		Data.Subscribe("", func(key string, value interface{}) { bar.redraw() }),
+	}
+
+	go bar.tick()
+
+	return &bar
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Fields returns the values the status bar's template shows
+func (bar *StatusBar) Fields(now time.Time) StatusBarFields {
+	fields := StatusBarFields{
+		Clock:        now.Format(bar.clockFormat),
+		DoNotDisturb: DND.Active(now),
+		Time:         now,
+	}
+
+	if widget := bar.focused(); widget != nil {
+		fields.Focused = widget.CommonSettings().Title
+	}
+
+	for _, widget := range bar.widgets {
+		if widget.Enabled() && RefreshStatuses.Status(widget.Name()).Refreshing {
+			fields.Refreshing++
+		}
+	}
+
+	fields.RefreshDone, fields.RefreshTotal = RefreshStatuses.Progress()
+
+	bar.mu.Lock()
+	fields.Offline = bar.offline
+	bar.mu.Unlock()
+
+	return fields
+}
+
+// Text returns the status bar's content
+func (bar *StatusBar) Text(now time.Time) string {
+	if bar.templateErr != nil {
+		return " [red]statusBar.template: " + bar.templateErr.Error() + "[-]"
+	}
+
+	var buf bytes.Buffer
+	if err := bar.template.Execute(&buf, bar.Fields(now)); err != nil {
+		return " [red]statusBar.template: " + err.Error() + "[-]"
+	}
+
+	return buf.String()
+}
+
+// Stop ends the status bar's subscriptions, for when a config reload replaces it
+func (bar *StatusBar) Stop() {
+	for _, unsubscribe := range bar.unsubscribe {
+		unsubscribe()
+	}
+	bar.unsubscribe = nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// active returns TRUE while the status bar's widgets are running. Once they have been
+// disabled, by a config reload, the status bar stops updating
+func (bar *StatusBar) active() bool {
+	for _, widget := range bar.widgets {
+		if widget.Enabled() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (bar *StatusBar) redraw() {
+	if !bar.active() {
+		return
+	}
+
+	text := FitColors(bar.Text(time.Now()))
+
+	bar.app.QueueUpdateDraw(func() {
+		bar.View.SetText(text)
+	})
+}
+
+func (bar *StatusBar) tick() {
+	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
+
+	bar.redraw()
+
+	for range tick.C {
+		if !bar.active() {
+			return
+		}
+
+		bar.redraw()
+	}
+}
+
+// trackNetwork decides whether the app is offline from the most recent refresh of a
+// module that uses the network: it is offline if that refresh couldn't reach its server
+func (bar *StatusBar) trackNetwork(name string, status RefreshStatus) {
+	if status.Refreshing {
+		bar.redraw()
+		return
+	}
+
+	for _, widget := range bar.widgets {
+		if widget.Name() != name || !widget.CommonSettings().UsesNetwork() {
+			continue
+		}
+
+		_, unreachable := status.Err.(*url.Error)
+
+		bar.mu.Lock()
+		bar.offline = unreachable
+		bar.mu.Unlock()
+	}
+
+	bar.redraw()
+}
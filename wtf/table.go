@@ -0,0 +1,249 @@
+package wtf
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/tview"
+)
+
+// ellipsis ends text that has been cut short to fit its column
+const ellipsis = "…"
+
+// ansiRegExp matches the ANSI escape sequences that command output is often colored
+// with, which a text view shows as garbage
+var ansiRegExp = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// Alignment is how text is lined up within a table column
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCenter
+)
+
+// TableColumn describes a column of a table. MinWidth and MaxWidth limit how narrow the
+// column gets when the table is squeezed to fit and how wide it gets for long text;
+// zero means no limit
+type TableColumn struct {
+	Align    Alignment
+	Color    string
+	MaxWidth int
+	MinWidth int
+	Title    string
+}
+
+// Table lays out rows of text in aligned columns, cutting text short with an ellipsis
+// where it does not fit. Cells may contain color tags, which take up no space
+type Table struct {
+	Columns     []TableColumn
+	HeaderColor string
+	Rows        [][]string
+	Separator   string
+}
+
+// NewTable creates and returns a table with the given columns
+func NewTable(columns ...TableColumn) *Table {
+	return &Table{
+		Columns:   columns,
+		Rows:      [][]string{},
+		Separator: " ",
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// AddRow adds a row of cells to the bottom of the table
+func (table *Table) AddRow(cells ...string) {
+	table.Rows = append(table.Rows, cells)
+}
+
+// Lines returns the table's lines, headed by the column titles if any column has one,
+// squeezed to fit in the given width. A width of zero does not limit the table's width
+func (table *Table) Lines(width int) []string {
+	widths := table.columnWidths(width)
+	lines := []string{}
+
+	if table.hasHeader() {
+		titles := make([]string, len(table.Columns))
+		for idx, column := range table.Columns {
+			titles[idx] = column.Title
+		}
+
+		lines = append(lines, fmt.Sprintf("[%s::b]%s[-::-]", table.HeaderColor, table.line(titles, widths, false)))
+	}
+
+	for _, row := range table.Rows {
+		lines = append(lines, table.line(row, widths, true))
+	}
+
+	return lines
+}
+
+// Render returns the table as text, one line per row, squeezed to fit in the given width
+func (table *Table) Render(width int) string {
+	lines := table.Lines(width)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// TextWidth returns the number of screen cells the text takes up. Color tags and ANSI
+// escape sequences take up none, and wide characters such as CJK and emoji take up two
+func TextWidth(text string) int {
+	return tview.TaggedStringWidth(StripANSI(text))
+}
+
+// StripANSI removes the ANSI escape sequences from the text
+func StripANSI(text string) string {
+	return ansiRegExp.ReplaceAllString(text, "")
+}
+
+// Truncate cuts the text short, ending it with an ellipsis, if it is wider than the
+// given width. Color tags in the text are kept
+func Truncate(text string, width int) string {
+	text = StripANSI(text)
+
+	if TextWidth(text) <= width {
+		return text
+	}
+
+	if width <= 0 {
+		return ""
+	}
+
+	var str strings.Builder
+	used := 0
+
+	for len(text) > 0 {
+		if loc := colorTagRegExp.FindStringIndex(text); loc != nil && loc[0] == 0 {
+			str.WriteString(text[:loc[1]])
+			text = text[loc[1]:]
+			continue
+		}
+
+		char, size := utf8.DecodeRuneInString(text)
+		charWidth := runewidth.RuneWidth(char)
+		if used+charWidth > width-1 {
+			break
+		}
+
+		str.WriteRune(char)
+		used += charWidth
+		text = text[size:]
+	}
+
+	return str.String() + ellipsis
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// align pads the text out to the width
+func align(text string, width int, alignment Alignment) string {
+	gap := width - TextWidth(text)
+	if gap <= 0 {
+		return text
+	}
+
+	switch alignment {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + text
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + text + strings.Repeat(" ", gap-gap/2)
+	default:
+		return text + strings.Repeat(" ", gap)
+	}
+}
+
+// columnWidths works out how wide each column is: as wide as its widest cell, within
+// its limits, with the widest columns narrowed one cell at a time until the table fits
+func (table *Table) columnWidths(width int) []int {
+	widths := make([]int, len(table.Columns))
+
+	for idx, column := range table.Columns {
+		widths[idx] = TextWidth(column.Title)
+
+		for _, row := range table.Rows {
+			if idx < len(row) && TextWidth(row[idx]) > widths[idx] {
+				widths[idx] = TextWidth(row[idx])
+			}
+		}
+
+		if column.MaxWidth > 0 && widths[idx] > column.MaxWidth {
+			widths[idx] = column.MaxWidth
+		}
+		if widths[idx] < column.MinWidth {
+			widths[idx] = column.MinWidth
+		}
+	}
+
+	if width <= 0 {
+		return widths
+	}
+
+	for table.totalWidth(widths) > width {
+		widest := -1
+		for idx, column := range table.Columns {
+			if widths[idx] > column.MinWidth && widths[idx] > 1 && (widest < 0 || widths[idx] > widths[widest]) {
+				widest = idx
+			}
+		}
+
+		if widest < 0 {
+			break
+		}
+
+		widths[widest]--
+	}
+
+	return widths
+}
+
+func (table *Table) hasHeader() bool {
+	for _, column := range table.Columns {
+		if column.Title != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// line lays out one row of cells in columns of the given widths
+func (table *Table) line(cells []string, widths []int, colored bool) string {
+	parts := make([]string, len(table.Columns))
+
+	for idx, column := range table.Columns {
+		cell := ""
+		if idx < len(cells) {
+			cell = cells[idx]
+		}
+
+		parts[idx] = align(Truncate(cell, widths[idx]), widths[idx], column.Align)
+
+		if colored && column.Color != "" {
+			parts[idx] = fmt.Sprintf("[%s]%s", column.Color, parts[idx])
+		}
+	}
+
+	return strings.Join(parts, table.Separator)
+}
+
+func (table *Table) totalWidth(widths []int) int {
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+
+	if len(widths) > 1 {
+		total += TextWidth(table.Separator) * (len(widths) - 1)
+	}
+
+	return total
+}
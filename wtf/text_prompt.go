@@ -0,0 +1,251 @@
+package wtf
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/wtfutil/wtf/i18n"
+)
+
+const textPromptPageName = "textPrompt"
+const promptHistorySize = 50
+const multiLinePromptHeight = 16
+const singleLinePromptHeight = 7
+
+// promptHistories are the texts entered into each history's prompts, oldest first
+var promptHistories = map[string][]string{}
+
+// promptsOpen is how many prompts and confirmation dialogs are open, so that key presses
+// go to them rather than to the app's global keys
+var promptsOpen = 0
+
+// TextPrompt describes a popup that asks for text, such as a new todo item or a comment
+// on a pull request
+type TextPrompt struct {
+	// History names the history that the prompt's entries are kept in, which the up and
+	// down keys move through. Prompts with no history don't keep one
+	History string
+
+	// Label is shown in front of the text, such as "New Todo:"
+	Label string
+
+	// MultiLine prompts take several lines of text, for which Enter starts a new line
+	// and Ctrl-D saves the text
+	MultiLine bool
+
+	// Text is the text the prompt opens with
+	Text string
+
+	// Title is shown in the prompt's border
+	Title string
+}
+
+// NewTextPrompt creates and returns a popup that asks for text. Enter saves the text
+// (Ctrl-D in a multi-line prompt) and Esc cancels it. doneFunc is called with the text
+// and true when it's saved, or with false when the prompt is cancelled
+func NewTextPrompt(prompt TextPrompt, doneFunc func(text string, ok bool)) *tview.Frame {
+	var content tview.Primitive
+	var height int
+	var hint string
+
+	promptsOpen++
+	closed := false
+	done := func(text string, ok bool) {
+		if closed {
+			return
+		}
+		closed = true
+		promptsOpen--
+
+		if ok {
+			addToPromptHistory(prompt.History, text)
+		}
+
+		doneFunc(text, ok)
+	}
+
+	if prompt.MultiLine {
+		content = newMultiLineInput(prompt, done)
+		height = multiLinePromptHeight
+		hint = i18n.T("Enter for a new line, Ctrl-D to save, Esc to cancel")
+	} else {
+		content = newSingleLineInput(prompt, done)
+		height = singleLinePromptHeight
+		hint = i18n.T("Enter to save, Esc to cancel")
+	}
+
+	frame := tview.NewFrame(content)
+	frame.SetBorder(true)
+	frame.SetBorders(1, 1, 0, 1, 1, 1)
+	frame.AddText(hint, false, tview.AlignCenter, tcell.ColorGray)
+
+	if prompt.Title != "" {
+		frame.SetTitle(" " + prompt.Title + " ")
+	}
+
+	frame.SetRect(offscreen, offscreen, modalWidth, height)
+	frame.SetDrawFunc(func(screen tcell.Screen, x, y, w, h int) (int, int, int, int) {
+		screenWidth, screenHeight := screen.Size()
+		frame.SetRect((screenWidth/2)-(modalWidth/2), (screenHeight/2)-(height/2), modalWidth, height)
+		return x, y, w, h
+	})
+
+	return frame
+}
+
+// PromptOpen returns true if a text prompt or confirmation dialog is open and taking key
+// presses
+func PromptOpen() bool {
+	return promptsOpen > 0
+}
+
+/* -------------------- KeyboardWidget -------------------- */
+
+// ShowPrompt opens a text prompt and returns the focus to the widget once it closes.
+// submitFunc is called with the text if it's saved, but not if the prompt is cancelled
+// Example:
+//
+//	widget.ShowPrompt(wtf.TextPrompt{Label: "New Todo:", History: "todo"}, widget.addItem)
+func (widget *KeyboardWidget) ShowPrompt(prompt TextPrompt, submitFunc func(text string)) {
+	doneFunc := func(text string, ok bool) {
+		widget.pages.RemovePage(textPromptPageName)
+		widget.app.SetFocus(widget.view)
+
+		if ok {
+			submitFunc(text)
+		}
+	}
+
+	frame := NewTextPrompt(prompt, doneFunc)
+
+	widget.app.QueueUpdateDraw(func() {
+		widget.pages.AddPage(textPromptPageName, frame, false, true)
+		widget.app.SetFocus(frame)
+	})
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// addToPromptHistory adds the text to the end of the history, unless it's empty or the
+// same as the last entry
+func addToPromptHistory(name, text string) {
+	if name == "" || strings.TrimSpace(text) == "" {
+		return
+	}
+
+	history := promptHistories[name]
+	if len(history) > 0 && history[len(history)-1] == text {
+		return
+	}
+
+	history = append(history, text)
+	if len(history) > promptHistorySize {
+		history = history[len(history)-promptHistorySize:]
+	}
+
+	promptHistories[name] = history
+}
+
+// newMultiLineInput creates the input of a multi-line prompt: the lines entered so far,
+// above a field for the line being typed. Backspace in an empty field goes back to the
+// end of the line above
+func newMultiLineInput(prompt TextPrompt, done func(text string, ok bool)) tview.Primitive {
+	lines := strings.Split(prompt.Text, "\n")
+	above := lines[:len(lines)-1]
+
+	previous := tview.NewTextView()
+	previous.SetScrollable(true)
+	previous.SetWrap(true)
+
+	input := tview.NewInputField()
+	input.SetLabel(prompt.Label + " ")
+	input.SetFieldBackgroundColor(tcell.ColorBlack)
+	input.SetText(lines[len(lines)-1])
+
+	render := func() {
+		previous.SetText(tview.Escape(strings.Join(above, "\n")))
+		previous.ScrollToEnd()
+	}
+
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			done("", false)
+		case tcell.KeyCtrlD:
+			done(strings.Join(append(above, input.GetText()), "\n"), true)
+		case tcell.KeyEnter:
+			above = append(above, input.GetText())
+			input.SetText("")
+			render()
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if input.GetText() != "" || len(above) == 0 {
+				return event
+			}
+
+			input.SetText(above[len(above)-1])
+			above = above[:len(above)-1]
+			render()
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	render()
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow)
+	flex.AddItem(previous, 0, 1, false)
+	flex.AddItem(input, 1, 0, true)
+
+	return flex
+}
+
+// newSingleLineInput creates the input of a single-line prompt, in which the up and down
+// keys move through the prompt's history
+func newSingleLineInput(prompt TextPrompt, done func(text string, ok bool)) tview.Primitive {
+	history := promptHistories[prompt.History]
+	position := len(history)
+	typed := prompt.Text
+
+	input := tview.NewInputField()
+	input.SetLabel(prompt.Label + " ")
+	input.SetFieldBackgroundColor(tcell.ColorBlack)
+	input.SetText(prompt.Text)
+
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			done("", false)
+		case tcell.KeyEnter:
+			done(input.GetText(), true)
+		case tcell.KeyUp:
+			if position == len(history) {
+				typed = input.GetText()
+			}
+
+			if position > 0 {
+				position--
+				input.SetText(history[position])
+			}
+		case tcell.KeyDown:
+			if position >= len(history) {
+				return nil
+			}
+
+			position++
+			if position == len(history) {
+				input.SetText(typed)
+			} else {
+				input.SetText(history[position])
+			}
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	return input
+}
@@ -2,21 +2,41 @@ package wtf
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/rivo/tview"
 	"github.com/wtfutil/wtf/cfg"
+	"github.com/wtfutil/wtf/i18n"
 	"github.com/wtfutil/wtf/utils"
 )
 
+// scrollOffset is how many rows and columns a widget's content is scrolled by
+type scrollOffset struct {
+	column int
+	row    int
+}
+
 type TextWidget struct {
+	alerts          *Alerts
 	bordered        bool
 	commonSettings  *cfg.Common
 	enabled         bool
 	focusable       bool
 	focusChar       string
+	hidden          bool
 	name            string
+	offlineCache    *OfflineCache
+	quotaHost       string
+	refreshErr      error
 	refreshing      bool
 	refreshInterval int
+	restoredOffset  *scrollOffset
+	rowDiff         *RowDiff
+	scrollbar       *scrollbar
+	staleSince      time.Time
+	subscriptions   []func()
+	title           string
 	app             *tview.Application
 
 	View *tview.TextView
@@ -38,6 +58,20 @@ func NewTextWidget(app *tview.Application, commonSettings *cfg.Common, focusable
 
 	widget.View = widget.addView()
 	widget.View.SetBorder(widget.bordered)
+	widget.scrollbar = newScrollbar(widget.View, widget.bordered)
+
+	if len(commonSettings.Alerts) > 0 {
+		widget.alerts = NewAlerts(widget.name, commonSettings.Alerts, commonSettings.Notifications)
+	}
+
+	if commonSettings.ChangeHighlights.Enabled {
+		widget.rowDiff = NewRowDiff(commonSettings.ChangeHighlights.Cycles)
+	}
+
+	if commonSettings.Enabled && commonSettings.OfflineCache && commonSettings.UsesNetwork() {
+		widget.offlineCache = NewOfflineCache(widget.name)
+		widget.showSnapshot()
+	}
 
 	return widget
 }
@@ -49,7 +83,15 @@ func (widget *TextWidget) Bordered() bool {
 	return widget.bordered
 }
 
+// BorderColor returns the color of the widget's border when it doesn't have the focus:
+// the color of its first alert that holds, if any do
 func (widget *TextWidget) BorderColor() string {
+	if widget.alerts != nil {
+		if active := widget.alerts.Active(); len(active) > 0 {
+			return active[0].Color
+		}
+	}
+
 	if widget.Focusable() {
 		return widget.commonSettings.Colors.BorderFocusable
 	}
@@ -73,8 +115,20 @@ func (widget *TextWidget) ContextualTitle(defaultStr string) string {
 	return fmt.Sprintf(" %s [darkgray::u]%s[::-][green] ", defaultStr, widget.FocusChar())
 }
 
+// AddSubscription keeps the function that ends one of the widget's subscriptions, such
+// as to the data bus, so that it is called when the widget is disabled. Widgets a config
+// reload replaces are disabled, so their subscriptions don't outlive them
+func (widget *TextWidget) AddSubscription(unsubscribe func()) {
+	widget.subscriptions = append(widget.subscriptions, unsubscribe)
+}
+
 func (widget *TextWidget) Disable() {
 	widget.enabled = false
+
+	for _, unsubscribe := range widget.subscriptions {
+		unsubscribe()
+	}
+	widget.subscriptions = nil
 }
 
 func (widget *TextWidget) Disabled() bool {
@@ -85,6 +139,30 @@ func (widget *TextWidget) Enabled() bool {
 	return widget.enabled
 }
 
+// EvaluateAlerts checks the widget's alert rules against the values it has published,
+// and redraws its border and title if any started or stopped holding
+func (widget *TextWidget) EvaluateAlerts() {
+	if widget.alerts == nil || !widget.alerts.Evaluate(time.Now()) {
+		return
+	}
+
+	widget.app.QueueUpdateDraw(func() {
+		if !widget.View.HasFocus() {
+			widget.View.SetBorderColor(ColorFor(widget.BorderColor()))
+		}
+
+		widget.View.SetTitle(widget.decoratedTitle())
+	})
+}
+
+// ExpectRowChanges tells the widget that a refresh has started, so that the rows it
+// changes are highlighted if change highlights are turned on
+func (widget *TextWidget) ExpectRowChanges() {
+	if widget.rowDiff != nil {
+		widget.rowDiff.Expect()
+	}
+}
+
 func (widget *TextWidget) Focusable() bool {
 	return widget.enabled && widget.focusable
 }
@@ -93,6 +171,14 @@ func (widget *TextWidget) FocusChar() string {
 	return widget.focusChar
 }
 
+func (widget *TextWidget) Hidden() bool {
+	return widget.hidden
+}
+
+func (widget *TextWidget) Hide() {
+	widget.hidden = true
+}
+
 func (widget *TextWidget) HelpText() string {
 	return fmt.Sprintf("\n  There is no help available for widget %s", widget.commonSettings.Module.Type)
 }
@@ -101,6 +187,33 @@ func (widget *TextWidget) Name() string {
 	return widget.name
 }
 
+// Notify publishes a notification from the widget, if its notifications are enabled
+func (widget *TextWidget) Notify(title, message string) {
+	notification := Notification{
+		Message: message,
+		Module:  widget.name,
+		Title:   title,
+	}
+
+	Notifications.Publish(notification, widget.commonSettings.Notifications)
+}
+
+// PublishData puts a value on the data bus for other modules to use, keyed by the
+// widget's name and the value's name
+func (widget *TextWidget) PublishData(name string, value interface{}) {
+	Data.Publish(widget.name+"."+name, value)
+}
+
+// QuotaHost returns the API host whose quota the widget's requests count against
+func (widget *TextWidget) QuotaHost() string {
+	return widget.quotaHost
+}
+
+// RefreshError returns the error that caused the widget's last refresh to fail, if any
+func (widget *TextWidget) RefreshError() error {
+	return widget.refreshErr
+}
+
 // Refreshing returns TRUE if the widget is currently refreshing its data, FALSE if it is not
 func (widget *TextWidget) Refreshing() bool {
 	return widget.refreshing
@@ -111,10 +224,38 @@ func (widget *TextWidget) RefreshInterval() int {
 	return widget.refreshInterval
 }
 
+// SetRefreshError records that the widget's refresh failed. Modules call this from
+// Refresh() so that the failure is shown in the widget's title bar
+func (widget *TextWidget) SetRefreshError(err error) {
+	widget.refreshErr = err
+}
+
+// SetQuotaHost sets the API host the widget's requests count against. The host's
+// remaining quota is shown in the widget's title, and the widget is refreshed less
+// often when the quota runs low or the host keeps failing
+func (widget *TextWidget) SetQuotaHost(host string) {
+	widget.quotaHost = host
+}
+
+// RestoreScrollOffset scrolls the widget back to the given offset once it has content
+// long enough to be scrolled that far, as when the app's last session is restored
+func (widget *TextWidget) RestoreScrollOffset(row, column int) {
+	widget.restoredOffset = &scrollOffset{column: column, row: row}
+}
+
+// ScrollOffset returns how many rows and columns the widget's content is scrolled by
+func (widget *TextWidget) ScrollOffset() (int, int) {
+	return widget.View.GetScrollOffset()
+}
+
 func (widget *TextWidget) SetFocusChar(char string) {
 	widget.focusChar = char
 }
 
+func (widget *TextWidget) Show() {
+	widget.hidden = false
+}
+
 func (widget *TextWidget) String() string {
 	return widget.name
 }
@@ -123,17 +264,130 @@ func (widget *TextWidget) TextView() *tview.TextView {
 	return widget.View
 }
 
+func (widget *TextWidget) Visible() bool {
+	return !widget.Hidden()
+}
+
+// Redraw replaces the widget's title and content, keeping the content scrolled to where
+// it was. If the offline cache is turned on and the widget's last refresh failed, the
+// content from its last successful refresh is shown instead, marked as stale. Rows that
+// are new or changed since the previous refresh are highlighted if that's turned on
 func (widget *TextWidget) Redraw(title, text string, wrap bool) {
+	staleSince := time.Time{}
+
+	if widget.offlineCache != nil {
+		if widget.refreshErr == nil {
+			widget.offlineCache.Save(title, text, wrap)
+		} else if snapshot, ok := widget.offlineCache.Last(); ok {
+			title, text, wrap = snapshot.Title, snapshot.Text, snapshot.Wrap
+			staleSince = snapshot.Updated
+		}
+	}
+
+	// Only the content of a successful refresh is compared with the previous one's
+	if widget.rowDiff != nil && widget.refreshErr == nil {
+		text = widget.rowDiff.Apply(text, widget.commonSettings.ChangeHighlights.Color)
+	}
+
 	widget.app.QueueUpdateDraw(func() {
+		widget.staleSince = staleSince
+		widget.title = title
+
+		row, column := widget.View.GetScrollOffset()
+		if restored := widget.restoredOffset; restored != nil && strings.Count(text, "\n") > restored.row {
+			row, column = restored.row, restored.column
+			widget.restoredOffset = nil
+		}
+
 		widget.View.Clear()
 		widget.View.SetWrap(wrap)
-		widget.View.SetTitle(widget.ContextualTitle(title))
-		widget.View.SetText(text)
+		widget.View.SetTitle(widget.decoratedTitle())
+		widget.View.SetText(FitColors(DND.Mute(text, time.Now(), widget.commonSettings.Colors.Warn, widget.commonSettings.Colors.Crit)))
+		widget.View.ScrollTo(row, column)
+
+		widget.scrollbar.setContent(text, wrap)
+	})
+}
+
+// RedrawError records that the widget's refresh failed and shows the error in place of
+// the widget's content
+func (widget *TextWidget) RedrawError(title string, err error) {
+	widget.SetRefreshError(err)
+	widget.Redraw(title, ErrorPanel(err, widget.commonSettings.Colors.Crit), true)
+}
+
+// RedrawTitle redraws the widget's title bar, updating its refresh status indicator
+func (widget *TextWidget) RedrawTitle() {
+	widget.app.QueueUpdateDraw(func() {
+		widget.View.SetTitle(widget.decoratedTitle())
 	})
 }
 
 /* -------------------- Unexported Functions -------------------- */
 
+// decoratedTitle returns the title to display, followed by the alerts that hold, by a
+// marker if the widget's refreshes are paused, by its refresh status if the refresh
+// indicator is turned on, and by the quota left on its API host or, while it's open,
+// the host's circuit
+func (widget *TextWidget) decoratedTitle() string {
+	title := widget.title
+	if title == "" {
+		title = widget.commonSettings.Title
+	}
+	title = widget.ContextualTitle(title)
+
+	if widget.alerts != nil {
+		for _, rule := range widget.alerts.Active() {
+			title += fmt.Sprintf("[%s]▲ %s[-] ", rule.Color, rule.Name)
+		}
+	}
+
+	status := RefreshStatuses.Status(widget.name)
+
+	if status.Paused {
+		title += fmt.Sprintf("[%s]⏸[-] ", widget.commonSettings.Colors.Warn)
+	}
+
+	if widget.commonSettings.RefreshIndicator {
+		title += refreshIndicator(status, widget.commonSettings.Colors.Crit)
+	}
+
+	if retryAt, ok := Circuits.OpenUntil(widget.quotaHost, time.Now()); ok {
+		title += fmt.Sprintf("[%s]%s[-] ", widget.commonSettings.Colors.Crit, i18n.Tf("circuit open, retrying at %s", retryAt.Format("15:04")))
+	} else if quota, ok := Quotas.Quota(widget.quotaHost, time.Now()); ok {
+		color := "gray"
+		if quota.Low() {
+			color = widget.commonSettings.Colors.Warn
+		}
+
+		title += fmt.Sprintf("[%s]%s[-] ", color, quota)
+	}
+
+	if !widget.staleSince.IsZero() {
+		title += fmt.Sprintf("[%s]%s[-] ", widget.commonSettings.Colors.Warn, i18n.Tf("stale since %s", widget.staleSince.Format("15:04")))
+	}
+
+	return FitColors(title)
+}
+
+// showSnapshot shows the content from the widget's last successful refresh, from a
+// previous run of the app, until the widget refreshes for the first time
+func (widget *TextWidget) showSnapshot() {
+	snapshot, ok := widget.offlineCache.Last()
+	if !ok {
+		return
+	}
+
+	widget.staleSince = snapshot.Updated
+	widget.title = snapshot.Title
+
+	widget.View.SetWrap(snapshot.Wrap)
+	widget.View.SetTitle(widget.decoratedTitle())
+	widget.View.SetText(FitColors(snapshot.Text))
+
+	widget.scrollbar.setContent(snapshot.Text, snapshot.Wrap)
+}
+
 func (widget *TextWidget) addView() *tview.TextView {
 	view := tview.NewTextView()
 
@@ -5,7 +5,6 @@ import (
 	"io/ioutil"
 	"os/exec"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 
@@ -117,18 +116,11 @@ func NamesFromEmails(emails []string) []string {
 	return names
 }
 
-// OpenFile opens the file defined in `path` via the operating system
+// OpenFile opens the file defined in `path` via the operating system. URLs are opened
+// in the browser with utils.OpenURL
 func OpenFile(path string) {
 	if (strings.HasPrefix(path, "http://")) || (strings.HasPrefix(path, "https://")) {
-		switch runtime.GOOS {
-		case "linux":
-			exec.Command("xdg-open", path).Start()
-		case "windows":
-			exec.Command("rundll32", "url.dll,FileProtocolHandler", path).Start()
-		case "darwin":
-			exec.Command("open", path).Start()
-		default:
-		}
+		utils.OpenURL(path)
 	} else {
 		filePath, _ := utils.ExpandHomeDir(path)
 		cmd := exec.Command(OpenFileUtil, filePath)
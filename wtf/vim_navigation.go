@@ -0,0 +1,89 @@
+package wtf
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+)
+
+// listNavigable is implemented by widgets whose rows can be jumped between
+type listNavigable interface {
+	First()
+	Last()
+}
+
+// VimNavigation adds optional vim-style keys for moving around the app:
+//
+//	Ctrl-W h/j/k/l   focus the widget to the left, below, above, or to the right
+//	gg, G            select the first or last row of the focused widget
+//
+// Moving between widgets takes the Ctrl-W prefix, as in vim's window commands, because
+// plain h/j/k/l already move between the rows of many widgets. It is enabled with
+// `wtf.navigation.vim: true`
+type VimNavigation struct {
+	Enabled bool
+
+	pending tcell.Key
+	char    rune
+}
+
+// NewVimNavigation creates and returns an instance of VimNavigation
+func NewVimNavigation(config *config.Config) *VimNavigation {
+	vim := VimNavigation{
+		Enabled: config.UBool("wtf.navigation.vim", false),
+	}
+
+	return &vim
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// InputCapture handles the vim navigation keys. Returns true if the key press was
+// handled and should go no further
+func (vim *VimNavigation) InputCapture(event *tcell.EventKey, tracker *FocusTracker) bool {
+	if !vim.Enabled {
+		return false
+	}
+
+	pending, char := vim.pending, vim.char
+	vim.pending, vim.char = tcell.KeyRune, 0
+
+	if pending == tcell.KeyCtrlW && event.Key() == tcell.KeyRune {
+		switch event.Rune() {
+		case 'h':
+			tracker.FocusDirection(-1, 0)
+		case 'j':
+			tracker.FocusDirection(0, 1)
+		case 'k':
+			tracker.FocusDirection(0, -1)
+		case 'l':
+			tracker.FocusDirection(1, 0)
+		}
+
+		return true
+	}
+
+	if event.Key() == tcell.KeyCtrlW {
+		vim.pending = tcell.KeyCtrlW
+		return true
+	}
+
+	list, ok := tracker.FocusedWidget().(listNavigable)
+	if !ok || event.Key() != tcell.KeyRune {
+		return false
+	}
+
+	switch event.Rune() {
+	case 'g':
+		if char == 'g' {
+			list.First()
+		} else {
+			vim.char = 'g'
+		}
+		return true
+	case 'G':
+		list.Last()
+		return true
+	}
+
+	return false
+}
@@ -3,6 +3,7 @@ package wtf
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/logrusorgru/aurora"
 )
@@ -29,12 +30,7 @@ func ValidateWidgets(widgets []Wtfable) {
 				fmt.Sprintf(
 					"%s in %s configuration",
 					aurora.Red("Errors"),
-					aurora.Yellow(
-						fmt.Sprintf(
-							"%s.position",
-							widget.Name(),
-						),
-					),
+					aurora.Yellow(widget.Name()),
 				),
 			)
 
@@ -49,3 +45,90 @@ func ValidateWidgets(widgets []Wtfable) {
 		os.Exit(1)
 	}
 }
+
+// ValidateKeys looks for problems with the global keys and with each widget's keys,
+// such as two actions bound to the same key. If it finds any it writes them to the
+// console and kills the app gracefully
+func ValidateKeys(keyMap *KeyMap, widgets []Wtfable) {
+	problems := keyProblems(keyMap, widgets)
+	if len(problems) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("%s in %s configuration\n", aurora.Red("Errors"), aurora.Yellow("keys"))
+
+	for _, problem := range problems {
+		if problem.widget == "" {
+			fmt.Printf(" - %s %v\n", aurora.Red("Error:"), problem.err)
+		} else {
+			fmt.Printf(" - %s %s: %v\n", aurora.Red("Error:"), aurora.Yellow(problem.widget), problem.err)
+		}
+	}
+
+	os.Exit(1)
+}
+
+// CheckKeys looks for the same problems with the keys as ValidateKeys, and returns
+// them as one error rather than exiting. It's for the reloaded config, whose problems
+// can't be printed once the dashboard is onscreen
+func CheckKeys(keyMap *KeyMap, widgets []Wtfable) error {
+	problems := keyProblems(keyMap, widgets)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(problems))
+	for idx, problem := range problems {
+		lines[idx] = problem.err.Error()
+		if problem.widget != "" {
+			lines[idx] = problem.widget + ": " + lines[idx]
+		}
+	}
+
+	return fmt.Errorf("errors in the keys configuration:\n - %s", strings.Join(lines, "\n - "))
+}
+
+// keyProblem is a problem with a key, and the widget whose key it is, if it isn't a
+// global key
+type keyProblem struct {
+	err    error
+	widget string
+}
+
+// keyProblems returns the problems with the global keys and the keys of the widgets
+func keyProblems(keyMap *KeyMap, widgets []Wtfable) []keyProblem {
+	problems := []keyProblem{}
+
+	for _, err := range keyMap.Errors() {
+		problems = append(problems, keyProblem{err: err})
+	}
+
+	for _, widget := range widgets {
+		keyed, ok := widget.(keyValidatable)
+		if !ok || widget.Disabled() {
+			continue
+		}
+
+		for _, err := range keyed.KeyErrors() {
+			problems = append(problems, keyProblem{err: err, widget: widget.Name()})
+		}
+
+		for _, key := range keyed.RemappedKeys() {
+			if keyMap.IsBound(key) {
+				problems = append(problems, keyProblem{
+					err:    fmt.Errorf("'%s' is already a global key", key),
+					widget: widget.Name(),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// keyValidatable is implemented by widgets that bind their own keys
+type keyValidatable interface {
+	KeyErrors() []error
+	RemappedKeys() []string
+}
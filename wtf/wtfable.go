@@ -9,6 +9,7 @@ import (
 // Wtfable is the interface that enforces WTF system capabilities on a module
 type Wtfable interface {
 	Enablable
+	Hideable
 	Schedulable
 
 	BorderColor() string
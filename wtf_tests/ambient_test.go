@@ -0,0 +1,38 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func ambientTestWidget(name, yaml string) Wtfable {
+	moduleConfig, _ := config.ParseYaml(yaml + "\nofflineCache: false")
+	globalConfig, _ := config.ParseYaml("wtf:\n  grid:\n    columns: [10]\n    rows: [10]")
+
+	common := cfg.NewCommonSettingsFromModule(name, name, moduleConfig, globalConfig)
+
+	return &listWidget{ScrollableWidget: NewScrollableWidget(tview.NewApplication(), common, false)}
+}
+
+func TestAmbientWidgets(t *testing.T) {
+	clock := ambientTestWidget("clocks", "enabled: true")
+	weather := ambientTestWidget("home", "enabled: true\ntype: weather")
+	gmail := ambientTestWidget("gmail", "enabled: true\nsensitive: true")
+	todo := ambientTestWidget("todo", "enabled: true")
+	off := ambientTestWidget("jira", "enabled: false")
+
+	widgets := []Wtfable{clock, weather, gmail, todo, off}
+
+	featured, others := AmbientWidgets(widgets, nil)
+	Equal(t, []Wtfable{clock, weather}, featured)
+	Equal(t, []Wtfable{todo}, others)
+
+	featured, others = AmbientWidgets(widgets, []string{"todo", "gmail"})
+	Equal(t, []Wtfable{todo}, featured)
+	Equal(t, []Wtfable{clock, weather}, others)
+}
@@ -0,0 +1,63 @@
+package wtf_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestQuotaFromHeaders(t *testing.T) {
+	now := time.Unix(1565000000, 0)
+
+	github := http.Header{}
+	github.Set("X-RateLimit-Limit", "5000")
+	github.Set("X-RateLimit-Remaining", "4312")
+	github.Set("X-RateLimit-Reset", "1565003600")
+
+	quota, ok := QuotaFromHeaders(github, now)
+	True(t, ok)
+	Equal(t, APIQuota{Limit: 5000, Remaining: 4312, Reset: time.Unix(1565003600, 0)}, quota)
+	Equal(t, "API: 4312/5000", quota.String())
+	False(t, quota.Low())
+
+	gitlab := http.Header{}
+	gitlab.Set("RateLimit-Limit", "600")
+	gitlab.Set("RateLimit-Remaining", "12")
+	gitlab.Set("RateLimit-Reset", "60")
+
+	quota, ok = QuotaFromHeaders(gitlab, now)
+	True(t, ok)
+	Equal(t, now.Add(time.Minute), quota.Reset)
+	True(t, quota.Low())
+
+	_, ok = QuotaFromHeaders(http.Header{}, now)
+	False(t, ok)
+}
+
+func TestQuotaStretch(t *testing.T) {
+	now := time.Now()
+	quotas := &APIQuotas{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Rate-Limit-Limit", "900")
+		rw.Header().Set("X-Rate-Limit-Remaining", "10")
+		rw.Header().Set("X-Rate-Limit-Reset", "600")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &QuotaTransport{Base: http.DefaultTransport, Quotas: quotas}}
+	_, err := client.Get(server.URL)
+	Nil(t, err)
+
+	quota, ok := quotas.Quota("127.0.0.1", now)
+	True(t, ok)
+	Equal(t, 10, quota.Remaining)
+
+	// Ten requests left over ten minutes is one a minute
+	InDelta(t, float64(time.Minute), float64(quotas.Stretch("127.0.0.1", 5*time.Second, now)), float64(time.Second))
+	Equal(t, 5*time.Second, quotas.Stretch("api.github.com", 5*time.Second, now))
+}
@@ -0,0 +1,132 @@
+package wtf_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestAPIServer(t *testing.T) {
+	Nil(t, NewAPIServer(tview.NewApplication(), []Wtfable{}, &config.Config{Root: map[string]interface{}{}}))
+
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  server:
+    address: "127.0.0.1:0"
+    token: s3cret
+`)
+	Nil(t, err)
+
+	server := NewAPIServer(tview.NewApplication(), []Wtfable{}, globalConfig)
+
+	request := httptest.NewRequest("GET", "/api/widgets", nil)
+	response := httptest.NewRecorder()
+	server.ServeHTTP(response, request)
+	Equal(t, http.StatusUnauthorized, response.Code)
+
+	request.Header.Set("Authorization", "Bearer s3cret")
+	response = httptest.NewRecorder()
+	server.ServeHTTP(response, request)
+	Equal(t, http.StatusOK, response.Code)
+
+	states := []WidgetState{}
+	Nil(t, json.Unmarshal(response.Body.Bytes(), &states))
+	Equal(t, 0, len(states))
+
+	request = httptest.NewRequest("GET", "/api/widgets/weather", nil)
+	request.Header.Set("Authorization", "Bearer s3cret")
+	response = httptest.NewRecorder()
+	server.ServeHTTP(response, request)
+	Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestAPIServerRestart(t *testing.T) {
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  server:
+    address: "127.0.0.1:0"
+`)
+	Nil(t, err)
+
+	server := NewAPIServer(tview.NewApplication(), []Wtfable{}, globalConfig)
+
+	Nil(t, server.Start())
+	server.Stop()
+	Nil(t, server.Start())
+	server.Stop()
+}
+
+func TestAPIServerMetrics(t *testing.T) {
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  server:
+    address: "127.0.0.1:0"
+`)
+	Nil(t, err)
+
+	Data.Publish("metricstest.temperature", 21.5)
+	Data.Publish("metricstest.summary", "sunny")
+
+	server := NewAPIServer(tview.NewApplication(), []Wtfable{}, globalConfig)
+
+	response := httptest.NewRecorder()
+	server.ServeHTTP(response, httptest.NewRequest("GET", "/metrics", nil))
+
+	Equal(t, http.StatusOK, response.Code)
+	Contains(t, response.Body.String(), "# TYPE wtf_refresh_errors_total counter\n")
+	Contains(t, response.Body.String(), "wtf_data{key=\"metricstest.temperature\"} 21.5\n")
+	NotContains(t, response.Body.String(), "metricstest.summary")
+}
+
+func TestAPIServerCommands(t *testing.T) {
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  server:
+    address: "127.0.0.1:0"
+`)
+	Nil(t, err)
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	Nil(t, screen.Init())
+
+	app := tview.NewApplication()
+	app.SetScreen(screen)
+	app.SetRoot(tview.NewBox(), true)
+	go app.Run()
+	defer app.Stop()
+
+	ran := []string{}
+
+	server := NewAPIServer(app, []Wtfable{}, globalConfig)
+	server.SetCommands(func(args []string) error {
+		if args[0] == "fail" {
+			return errors.New("failed")
+		}
+
+		ran = append(ran, strings.Join(args, " "))
+		return nil
+	})
+
+	response := httptest.NewRecorder()
+	server.ServeHTTP(response, httptest.NewRequest("POST", "/api/commands", strings.NewReader(`{"args": ["page", "2"]}`)))
+	Equal(t, http.StatusOK, response.Code)
+	Equal(t, []string{"page 2"}, ran)
+
+	response = httptest.NewRecorder()
+	server.ServeHTTP(response, httptest.NewRequest("POST", "/api/commands", strings.NewReader(`{"args": ["fail"]}`)))
+	Equal(t, http.StatusBadRequest, response.Code)
+	Contains(t, response.Body.String(), "failed")
+
+	response = httptest.NewRecorder()
+	server.ServeHTTP(response, httptest.NewRequest("GET", "/api/commands", nil))
+	Equal(t, http.StatusMethodNotAllowed, response.Code)
+}
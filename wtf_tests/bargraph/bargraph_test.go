@@ -38,3 +38,12 @@ func TestOutput(t *testing.T) {
 		result,
 	)
 }
+
+func TestBlocksOutput(t *testing.T) {
+	result := BuildBlocks(makeData(), 10)
+
+	Equal(t,
+		"Jun 27, 2018 [red]██        [white] 20\nJul 09, 2018 [red]████████  [white] 80\n",
+		result,
+	)
+}
@@ -0,0 +1,71 @@
+package wtf_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	now := time.Unix(1565000000, 0)
+	breakers := NewCircuitBreakers(3, 5*time.Minute)
+
+	breakers.Failure("api.example.com", now)
+	breakers.Failure("api.example.com", now)
+
+	_, open := breakers.OpenUntil("api.example.com", now)
+	Equal(t, false, open)
+
+	breakers.Failure("api.example.com", now)
+
+	openUntil, open := breakers.OpenUntil("api.example.com", now)
+	Equal(t, true, open)
+	Equal(t, now.Add(5*time.Minute), openUntil)
+	Equal(t, 5*time.Minute, breakers.Wait("api.example.com", time.Minute, now))
+	Equal(t, time.Minute, breakers.Wait("other.example.com", time.Minute, now))
+
+	// Once the cooldown is over, one more failure opens the circuit again
+	later := now.Add(6 * time.Minute)
+	_, open = breakers.OpenUntil("api.example.com", later)
+	Equal(t, false, open)
+
+	breakers.Failure("api.example.com", later)
+	_, open = breakers.OpenUntil("api.example.com", later)
+	Equal(t, true, open)
+
+	breakers.Success("api.example.com")
+	_, open = breakers.OpenUntil("api.example.com", later)
+	Equal(t, false, open)
+}
+
+func TestCircuitTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	breakers := NewCircuitBreakers(2, time.Minute)
+	client := &http.Client{Transport: &CircuitTransport{Base: http.DefaultTransport, Breakers: breakers}}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		NoError(t, err)
+		resp.Body.Close()
+	}
+
+	_, err := client.Get(server.URL)
+	Error(t, err)
+	Equal(t, 2, requests)
+
+	urlErr, ok := err.(*url.Error)
+	Equal(t, true, ok)
+	IsType(t, &CircuitOpenError{}, urlErr.Err)
+	Contains(t, err.Error(), "circuit open, retrying at")
+}
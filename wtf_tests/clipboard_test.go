@@ -0,0 +1,13 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestOSC52Sequence(t *testing.T) {
+	Equal(t, "\x1b]52;c;aGVsbG8=\a", OSC52Sequence("hello", false))
+	Equal(t, "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\a\x1b\\", OSC52Sequence("hello", true))
+}
@@ -0,0 +1,46 @@
+package wtf_tests
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestCommandPaletteRun(t *testing.T) {
+	palette := NewCommandPalette(tview.NewApplication(), tview.NewPages())
+
+	refreshed := []string{}
+	palette.Add("refresh", "Refresh widgets", func(args []string) error {
+		refreshed = append(refreshed, args...)
+		return nil
+	})
+
+	page := 0
+	palette.Add("goto", "Show a page", func(args []string) error {
+		var err error
+		page, err = strconv.Atoi(args[0])
+		return err
+	}, "g")
+
+	Nil(t, palette.Run(":refresh github"))
+	Equal(t, []string{"github"}, refreshed)
+
+	Nil(t, palette.Run("  :refresh  jira   todo "))
+	Equal(t, []string{"github", "jira", "todo"}, refreshed)
+
+	Nil(t, palette.Run(":goto 3"))
+	Equal(t, 3, page)
+
+	Nil(t, palette.Run("g 2"))
+	Equal(t, 2, page)
+
+	NotNil(t, palette.Run(":goto three"))
+	NotNil(t, palette.Run(":nope"))
+	Nil(t, palette.Run(":"))
+
+	Contains(t, palette.HelpText(), ":goto")
+	NotContains(t, palette.HelpText(), ":g ")
+}
@@ -0,0 +1,90 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestConfirmDialog(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []interface{}
+		expected bool
+	}{
+		{name: "y", keys: []interface{}{"y"}, expected: true},
+		{name: "Y", keys: []interface{}{"Y"}, expected: true},
+		{name: "n", keys: []interface{}{"n"}, expected: false},
+		{name: "Enter says no", keys: []interface{}{tcell.KeyEnter}, expected: false},
+		{name: "Esc", keys: []interface{}{tcell.KeyEsc}, expected: false},
+		{name: "other keys are ignored", keys: []interface{}{"q", "y"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			confirmed := false
+
+			dialog := NewConfirmDialog("Delete it?", func(ok bool) {
+				calls++
+				confirmed = ok
+			})
+			True(t, PromptOpen())
+
+			press(focusedIn(dialog), tt.keys...)
+			press(focusedIn(dialog), "y")
+
+			Equal(t, 1, calls)
+			Equal(t, tt.expected, confirmed)
+			False(t, PromptOpen())
+		})
+	}
+}
+
+func TestConfirmWithoutConfirmations(t *testing.T) {
+	moduleConfig, err := config.ParseYaml("confirmations: false")
+	Nil(t, err)
+
+	common := cfg.NewCommonSettingsFromModule("todo", "Todo", moduleConfig, &config.Config{Root: map[string]interface{}{}})
+	widget := NewKeyboardWidget(tview.NewApplication(), tview.NewPages(), common)
+
+	called := false
+	widget.Confirm("Delete it?", func() { called = true })
+
+	True(t, called)
+	False(t, PromptOpen())
+}
+
+func TestCommandRowActionConfirm(t *testing.T) {
+	moduleConfig, err := config.ParseYaml(`
+actions:
+  - name: Close
+    command: "true"
+    confirm: true
+  - name: Delete branch
+    command: "true"
+    confirm: Delete the branch for good?
+  - name: Check out
+    command: "true"
+    confirm: false
+`)
+	Nil(t, err)
+
+	common := cfg.NewCommonSettingsFromModule("github", "GitHub", moduleConfig, &config.Config{Root: map[string]interface{}{}})
+	widget := NewScrollableWidget(tview.NewApplication(), common, true)
+	widget.SetRowFunction(func(idx int) Row { return Row{} })
+
+	confirms := map[string]string{}
+	for _, action := range widget.RowActions() {
+		confirms[action.Name] = action.Confirm
+	}
+
+	Equal(t, "Close?", confirms["Close"])
+	Equal(t, "Delete the branch for good?", confirms["Delete branch"])
+	Equal(t, "", confirms["Check out"])
+}
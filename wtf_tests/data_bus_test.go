@@ -0,0 +1,69 @@
+package wtf_tests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestDataBus(t *testing.T) {
+	bus := NewDataBus()
+
+	changed := []string{}
+	bus.Subscribe("weather.", func(key string, value interface{}) {
+		changed = append(changed, key)
+	})
+
+	bus.Publish("weather.temperature", 21.5)
+	bus.Publish("weather.temperature", 21.5)
+	bus.Publish("weather.city", "Toronto")
+	bus.Publish("power.charge", "80%")
+
+	Equal(t, []string{"weather.temperature", "weather.city"}, changed)
+
+	temp, ok := bus.Float("weather.temperature")
+	Equal(t, true, ok)
+	Equal(t, 21.5, temp)
+
+	_, ok = bus.Float("weather.city")
+	Equal(t, false, ok)
+
+	rendered, err := bus.Render(`{{ data "weather.temperature" | printf "%.0f" }}° in {{ data "weather.city" }}{{ data "gcal.nextEvent" }}`)
+	Nil(t, err)
+	Equal(t, "22° in Toronto", rendered)
+}
+
+func TestDataBusUnsubscribe(t *testing.T) {
+	bus := NewDataBus()
+
+	changed := []string{}
+	unsubscribe := bus.Subscribe("", func(key string, value interface{}) {
+		changed = append(changed, key)
+	})
+
+	bus.Publish("weather.temperature", 21.5)
+	unsubscribe()
+	bus.Publish("weather.city", "Toronto")
+
+	Equal(t, []string{"weather.temperature"}, changed)
+}
+
+func TestCalendarEvents(t *testing.T) {
+	bus := NewDataBus()
+	now := time.Now()
+
+	bus.Publish("work.events", []CalendarEvent{{Title: "Standup", Start: now.Add(time.Hour)}})
+	bus.Publish("home.events", []CalendarEvent{{Title: "Dentist", Start: now.Add(time.Minute)}})
+	bus.Publish("home.nextEvent", "Dentist")
+
+	titles := []string{}
+	for _, event := range bus.CalendarEvents("") {
+		titles = append(titles, event.Title)
+	}
+	Equal(t, []string{"Dentist", "Standup"}, titles)
+
+	Len(t, bus.CalendarEvents("work"), 1)
+	Len(t, bus.CalendarEvents("other"), 0)
+}
@@ -0,0 +1,47 @@
+package wtf_tests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestDoNotDisturb(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2019, 6, 1, hour, min, 0, 0, time.Local)
+	}
+
+	morning, err := NewQuietHours("09:00", "12:00")
+	Nil(t, err)
+
+	dnd := NewDoNotDisturb([]*QuietHours{morning}, "gray")
+
+	Equal(t, true, dnd.Active(at(10, 0)))
+	Equal(t, false, dnd.Active(at(13, 0)))
+
+	// Turning it off by hand lasts until the window ends, and on by hand until one starts
+	Equal(t, false, dnd.Toggle(at(10, 0)))
+	Equal(t, false, dnd.Active(at(11, 0)))
+	Equal(t, false, dnd.Active(at(12, 30)))
+
+	Equal(t, true, dnd.Toggle(at(12, 30)))
+	Equal(t, true, dnd.Active(at(20, 0)))
+	Equal(t, true, dnd.Active(at(9, 30)))
+	Equal(t, false, dnd.Active(at(12, 0)))
+}
+
+func TestDoNotDisturbMute(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2019, 6, 1, hour, 0, 0, 0, time.Local)
+	}
+
+	morning, _ := NewQuietHours("09:00", "12:00")
+	dnd := NewDoNotDisturb([]*QuietHours{morning}, "gray")
+
+	text := "[red]failed[-] [#ff5555::b]down[-::-] [green:red]ok[-:-] [white]fine"
+
+	Equal(t, text, dnd.Mute(text, at(14), "#ff5555"))
+	Equal(t, "[gray]failed[-] [gray::b]down[-::-] [green:-]ok[-:-] [white]fine", dnd.Mute(text, at(10), "#ff5555"))
+}
@@ -0,0 +1,37 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestConfigureEventHooks(t *testing.T) {
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  onEvent:
+    - module: datadog
+      event: monitor alerting
+      command: "true"
+    - event: Build Failed
+      webhook: https://hooks.example.com/wtf
+    - event: ignored without a command or webhook
+`)
+	Nil(t, err)
+
+	ConfigureEventHooks(globalConfig)
+	defer ConfigureEventHooks(&config.Config{})
+
+	alerting := Notification{Module: "datadog", Title: "Monitor alerting"}
+	Equal(t, 1, len(Hooks.Matching(alerting)))
+	Equal(t, "true", Hooks.Matching(alerting)[0].Command)
+
+	alerting.Module = "newrelic"
+	Equal(t, 0, len(Hooks.Matching(alerting)))
+
+	failed := Notification{Module: "circleci", Title: "wtf build failed"}
+	Equal(t, 1, len(Hooks.Matching(failed)))
+	Equal(t, "https://hooks.example.com/wtf", Hooks.Matching(failed)[0].Webhook)
+}
@@ -0,0 +1,43 @@
+package wtf_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestExporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-exporter")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "status")
+
+	cfg, _ := config.ParseYaml(`
+wtf:
+  exporter:
+    output: ` + output + `
+    template: |
+      {{ data "exporter.city" }}
+      {{ data "exporter.count" }} PRs
+`)
+
+	Data.Publish("exporter.city", "Toronto")
+	Data.Publish("exporter.count", 3)
+
+	exporter := NewExporter(cfg)
+	Equal(t, false, exporter.ToStdout())
+	Nil(t, exporter.Export())
+
+	written, err := ioutil.ReadFile(output)
+	Nil(t, err)
+	Equal(t, "Toronto 3 PRs\n", string(written))
+
+	cfg, _ = config.ParseYaml("wtf:\n  exporter:\n    interval: 5\n")
+	Nil(t, NewExporter(cfg))
+}
@@ -0,0 +1,77 @@
+package wtf_tests
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestCachingTransportRevalidates(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "hello")
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "wtf-http-cache")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	client := &http.Client{Transport: &CachingTransport{Base: http.DefaultTransport, Dir: dir}}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		Nil(t, err)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		Equal(t, http.StatusOK, resp.StatusCode)
+		Equal(t, "hello", string(body))
+	}
+
+	Equal(t, 2, hits)
+}
+
+func TestCachingTransportSeparatesCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Date", time.Now().UTC().Format(http.TimeFormat))
+		fmt.Fprint(w, r.Header.Get("X-Api-Key"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "wtf-http-cache")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	client := &http.Client{Transport: &CachingTransport{Base: http.DefaultTransport, Dir: dir}}
+
+	for _, key := range []string{"alice", "bob"} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("X-Api-Key", key)
+
+		resp, err := client.Do(req)
+		Nil(t, err)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		Equal(t, key, string(body))
+	}
+}
@@ -0,0 +1,21 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestConfigureHTTPErrors(t *testing.T) {
+	for _, yaml := range []string{
+		"wtf:\n  http:\n    proxy: ':not a url'\n",
+		"wtf:\n  http:\n    caFile: /does/not/exist.pem\n",
+	} {
+		globalConfig, err := config.ParseYaml(yaml)
+		Nil(t, err)
+
+		NotNil(t, ConfigureHTTP(globalConfig), yaml)
+	}
+}
@@ -0,0 +1,46 @@
+package wtf_tests
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestDetectImageProtocol(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	Equal(t, ImageSixel, DetectImageProtocol("sixel", env(nil)))
+	Equal(t, ImageKitty, DetectImageProtocol("auto", env(map[string]string{"TERM": "xterm-kitty"})))
+	Equal(t, ImageSixel, DetectImageProtocol("auto", env(map[string]string{"TERM": "foot"})))
+	Equal(t, ImageBlocks, DetectImageProtocol("auto", env(map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux"})))
+	Equal(t, ImageBlocks, DetectImageProtocol("auto", env(map[string]string{"TERM": "xterm-256color"})))
+}
+
+func TestRenderImageBlocks(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+	img.Set(1, 0, color.RGBA{255, 0, 0, 255})
+	img.Set(0, 1, color.RGBA{0, 0, 255, 255})
+	img.Set(1, 1, color.RGBA{0, 0, 255, 255})
+
+	Equal(t, "[#ff0000:#0000ff]▀▀[-:-]", RenderImageBlocks(img, 2, 1))
+	Equal(t, "[#ff0000:-]▀[-:-]", RenderImageBlocks(img, 1, 4))
+}
+
+func TestSixelSequence(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.RGBA{255, 255, 255, 255})
+	}
+
+	seq := SixelSequence(img)
+
+	True(t, strings.HasPrefix(seq, "\x1bPq\"1;1;4;1"))
+	True(t, strings.HasSuffix(seq, "#215!4@$-\x1b\\"))
+}
@@ -0,0 +1,49 @@
+package wtf_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestInstanceSyncFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-sync")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "sync.json")
+	now := time.Now().Round(time.Second)
+
+	laptop := NewInstanceSync(file, "", "")
+	desktop := NewInstanceSync(file, "", "")
+
+	changed := 0
+	desktop.Subscribe(func() { changed++ })
+
+	laptop.RecordNotified("circleci\x00wtf build failed", now)
+	laptop.SetRead("circleci/wtf build failed@1", true, now)
+	Nil(t, laptop.Sync())
+	Nil(t, desktop.Sync())
+
+	Equal(t, true, now.Equal(desktop.Notified("circleci\x00wtf build failed")))
+	read, marked := desktop.IsRead("circleci/wtf build failed@1")
+	Equal(t, true, read)
+	Equal(t, true, marked)
+	Equal(t, 1, changed)
+
+	// The latest decision wins
+	desktop.SetRead("circleci/wtf build failed@1", false, now.Add(time.Minute))
+	Nil(t, desktop.Sync())
+	Nil(t, laptop.Sync())
+
+	read, _ = laptop.IsRead("circleci/wtf build failed@1")
+	Equal(t, false, read)
+
+	_, marked = laptop.IsRead("github/review requested@1")
+	Equal(t, false, marked)
+}
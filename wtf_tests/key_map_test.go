@@ -0,0 +1,77 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestKeyMapHandle(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n  keys:\n    refresh: Ctrl-F\n")
+	keyMap := NewKeyMap(globalConfig)
+
+	refreshed := false
+	keyMap.Add("refresh", "Ctrl-R", "Refresh all widgets", func() { refreshed = true })
+
+	Equal(t, false, keyMap.Handle(tcell.NewEventKey(tcell.KeyCtrlR, 0, tcell.ModCtrl)))
+	Equal(t, true, keyMap.Handle(tcell.NewEventKey(tcell.KeyCtrlF, 0, tcell.ModCtrl)))
+	Equal(t, true, refreshed)
+	Equal(t, 0, len(keyMap.Errors()))
+}
+
+func TestKeyMapRunes(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n")
+	keyMap := NewKeyMap(globalConfig)
+	keyMap.Add("help", "?", "Show help", func() {})
+	keyMap.Add("page", "Alt-1", "Show page 1", func() {})
+
+	Equal(t, true, keyMap.Matches("help", tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone)))
+	Equal(t, false, keyMap.Matches("help", tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone)))
+	Equal(t, true, keyMap.Matches("page", tcell.NewEventKey(tcell.KeyRune, '1', tcell.ModAlt)))
+	Equal(t, false, keyMap.Matches("page", tcell.NewEventKey(tcell.KeyRune, '1', tcell.ModNone)))
+}
+
+func TestKeyMapErrors(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n  keys:\n    zoom: Ctrl-R\n    quit: Ctrl-Nope\n")
+	keyMap := NewKeyMap(globalConfig)
+	keyMap.Add("refresh", "Ctrl-R", "Refresh all widgets", func() {})
+	keyMap.Add("zoom", "Ctrl-Z", "Zoom", func() {})
+	keyMap.Add("quit", "Ctrl-C", "Quit", func() {})
+
+	Equal(t, 2, len(keyMap.Errors()))
+	Equal(t, true, keyMap.IsBound("Ctrl-R"))
+	Equal(t, false, keyMap.IsBound("Ctrl-Z"))
+}
+
+func TestKeyMapOptional(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n  keys:\n    retry: R\n")
+	keyMap := NewKeyMap(globalConfig)
+
+	failed := false
+	keyMap.AddOptional("retry", "r", "Retry the failed widgets", func() bool { return failed })
+
+	Equal(t, false, keyMap.Handle(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone)))
+	Equal(t, false, keyMap.Handle(tcell.NewEventKey(tcell.KeyRune, 'R', tcell.ModNone)))
+
+	failed = true
+	Equal(t, true, keyMap.Handle(tcell.NewEventKey(tcell.KeyRune, 'R', tcell.ModNone)))
+	Equal(t, false, keyMap.IsBound("R"))
+	Contains(t, keyMap.HelpText(), "Retry the failed widgets")
+}
+
+func TestCheckKeys(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n  keys:\n    zoom: Ctrl-R\n")
+	keyMap := NewKeyMap(globalConfig)
+	keyMap.Add("refresh", "Ctrl-R", "Refresh all widgets", func() {})
+
+	Nil(t, CheckKeys(NewKeyMap(globalConfig), []Wtfable{}))
+
+	keyMap.Add("zoom", "Ctrl-Z", "Zoom", func() {})
+
+	err := CheckKeys(keyMap, []Wtfable{})
+	NotNil(t, err)
+	Contains(t, err.Error(), "errors in the keys configuration")
+}
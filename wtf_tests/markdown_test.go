@@ -0,0 +1,33 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"heading", "# Title", "[green::bu]Title[-::-]"},
+		{"subheading", "## Notes ##", "[green::b]Notes[-::-]"},
+		{"bold and code", "Run **now** with `make`", "Run [::b]now[::-] with [yellow]make[-]"},
+		{"link", "See [the docs](https://wtfutil.com)", "See [blue::u]the docs[-::-] [gray](https://wtfutil.com)[-]"},
+		{"list", "  - item", "   • item"},
+		{"ordered list", "2. second", " 2. second"},
+		{"task", "- [x] done", " [x[] done"},
+		{"quote", "> wise words", "[gray]│[-] wise words"},
+		{"escaped tags", "keep [red] as text", "keep [red[] as text"},
+		{"code block", "```\nx := [a]\n```", "  [yellow]x := [a[][-]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Equal(t, tt.expected, RenderMarkdown(tt.markdown))
+		})
+	}
+}
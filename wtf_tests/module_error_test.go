@@ -0,0 +1,31 @@
+package wtf_tests
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestNewHTTPError(t *testing.T) {
+	reqURL, _ := url.Parse("https://api.example.com/v1/issues?token=secret")
+	resp := &http.Response{
+		Request:    &http.Request{URL: reqURL},
+		Status:     "401 Unauthorized",
+		StatusCode: http.StatusUnauthorized,
+	}
+
+	err := NewHTTPError(resp)
+
+	Equal(t, "https://api.example.com/v1/issues", err.Endpoint)
+	Equal(t, http.StatusUnauthorized, err.Status)
+	Equal(t, "https://api.example.com/v1/issues: 401 Unauthorized", err.Error())
+	NotEqual(t, "", err.Hint)
+
+	panel := ErrorPanel(err, "red")
+	Contains(t, panel, "401 Unauthorized")
+	Contains(t, panel, err.Hint)
+	NotContains(t, panel, "secret")
+}
@@ -0,0 +1,34 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestModuleRegistry(t *testing.T) {
+	RegisterModule(ModuleType{
+		Make: func(app *tview.Application, pages *tview.Pages, name string, moduleConfig, globalConfig *config.Config) Wtfable {
+			return nil
+		},
+		Name: "registrytest",
+	})
+
+	moduleType, ok := LookupModule("registrytest")
+	Equal(t, true, ok)
+	Equal(t, "registrytest", moduleType.Name)
+
+	_, ok = LookupModule("missing")
+	Equal(t, false, ok)
+
+	names := []string{}
+	for _, moduleType := range ModuleTypes() {
+		names = append(names, moduleType.Name)
+	}
+	Contains(t, names, "registrytest")
+
+	Equal(t, []string{"actions", "list", "network"}, ModuleCapabilities{Actions: true, List: true, Network: true}.Names())
+}
@@ -0,0 +1,42 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestScrollableWidgetMouseClick(t *testing.T) {
+	common := cfg.NewCommonSettingsFromModule("rabbitmq", "RabbitMQ", &config.Config{}, &config.Config{Root: map[string]interface{}{}})
+	widget := NewScrollableWidget(tview.NewApplication(), common, true)
+	widget.SetRenderFunction(func() {})
+	widget.SetItemCount(2)
+
+	content := "[red]Queue  Msgs[white]\n"
+	content += HighlightableHelper(widget.View, "orders  12", 0, 10)
+	content += HighlightableHelper(widget.View, "emails   3", 1, 10)
+	widget.View.SetText(content)
+
+	screen := tcell.NewSimulationScreen("")
+	screen.Init()
+	widget.View.SetRect(0, 0, 40, 10)
+	widget.View.Draw(screen)
+
+	Equal(t, false, widget.MouseClick(0, 0))
+	Equal(t, -1, widget.GetSelected())
+
+	Equal(t, true, widget.MouseClick(0, 1))
+	Equal(t, 0, widget.GetSelected())
+
+	Equal(t, true, widget.MouseClick(4, 2))
+	Equal(t, 1, widget.GetSelected())
+
+	Equal(t, false, widget.MouseClick(0, 3))
+	Equal(t, 1, widget.GetSelected())
+}
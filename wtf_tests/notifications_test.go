@@ -0,0 +1,35 @@
+package wtf_tests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestNotificationBusPublish(t *testing.T) {
+	bus := NewNotificationBus(nil)
+
+	received := []Notification{}
+	bus.Subscribe(func(notification Notification) {
+		received = append(received, notification)
+	})
+
+	now := time.Date(2019, 8, 1, 9, 0, 0, 0, time.UTC)
+	failed := Notification{Module: "circleci", Title: "wtf build failed", Time: now}
+	settings := cfg.NotificationSettings{Enabled: true, Throttle: 300}
+
+	Equal(t, false, bus.Publish(failed, cfg.NotificationSettings{Throttle: 300}))
+	Equal(t, true, bus.Publish(failed, settings))
+
+	failed.Time = now.Add(time.Minute)
+	Equal(t, false, bus.Publish(failed, settings))
+
+	failed.Time = now.Add(5 * time.Minute)
+	Equal(t, true, bus.Publish(failed, settings))
+
+	Equal(t, true, bus.Publish(Notification{Module: "power", Title: "Battery low", Time: now}, settings))
+	Equal(t, 3, len(received))
+}
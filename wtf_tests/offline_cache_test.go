@@ -0,0 +1,30 @@
+package wtf_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestOfflineCachePersists(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-offline-cache")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	os.Setenv("XDG_CACHE_HOME", dir)
+	defer os.Unsetenv("XDG_CACHE_HOME")
+
+	_, ok := NewOfflineCache("github").Last()
+	Equal(t, false, ok)
+
+	NewOfflineCache("github").Save("GitHub", "3 open PRs", true)
+
+	snapshot, ok := NewOfflineCache("github").Last()
+	Equal(t, true, ok)
+	Equal(t, "GitHub", snapshot.Title)
+	Equal(t, "3 open PRs", snapshot.Text)
+	Equal(t, true, snapshot.Wrap)
+}
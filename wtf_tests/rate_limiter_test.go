@@ -0,0 +1,31 @@
+package wtf_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestHostRateLimits(t *testing.T) {
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  http:
+    rateLimits:
+      api.github.com:
+        perMinute: 60
+        burst: 2
+`)
+	Nil(t, err)
+
+	ConfigureRateLimits(globalConfig)
+
+	now := time.Now()
+
+	Equal(t, time.Duration(0), HostRateLimits.Delay("api.github.com", now))
+	Equal(t, time.Duration(0), HostRateLimits.Delay("api.github.com", now))
+	Equal(t, time.Second, HostRateLimits.Delay("api.github.com", now))
+	Equal(t, time.Duration(0), HostRateLimits.Delay("example.com", now))
+}
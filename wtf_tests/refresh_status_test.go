@@ -0,0 +1,31 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestRefreshBusProgress(t *testing.T) {
+	bus := NewRefreshBus()
+
+	done, total := bus.Progress()
+	Equal(t, 0, done)
+	Equal(t, 0, total)
+
+	bus.StartBatch([]string{"clocks", "weather", "jira"})
+	bus.Finished("clocks", nil)
+	bus.Finished("todo", nil)
+
+	done, total = bus.Progress()
+	Equal(t, 1, done)
+	Equal(t, 3, total)
+
+	bus.Skipped("weather")
+	bus.Finished("jira", nil)
+
+	done, total = bus.Progress()
+	Equal(t, 0, done)
+	Equal(t, 0, total)
+}
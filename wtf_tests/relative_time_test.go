@@ -0,0 +1,16 @@
+package wtf_tests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestRelativeTime(t *testing.T) {
+	Equal(t, "", RelativeTime(time.Time{}))
+	Equal(t, "now", RelativeTime(time.Now()))
+	Equal(t, "2d ago", RelativeTime(time.Now().Add(-49*time.Hour)))
+	Equal(t, "in 12m", RelativeTime(time.Now().Add(12*time.Minute+30*time.Second)))
+}
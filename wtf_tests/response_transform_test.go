@@ -0,0 +1,87 @@
+package wtf_tests
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestTransformTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": [{"user": "dependabot", "id": 1}, {"user": "alice", "id": 2}], "total": 2}`)
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "wtf-transform")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "no_bots.lua")
+	Nil(t, ioutil.WriteFile(path, []byte(`
+function transform(body, url)
+  local kept = {}
+  for _, item in ipairs(body.items) do
+    if item.user ~= "dependabot" then
+      table.insert(kept, item)
+    end
+  end
+  body.items = kept
+  body.total = #kept
+  return body
+end
+`), 0600))
+
+	globalConfig, err := config.ParseYaml(fmt.Sprintf(`
+wtf:
+  http:
+    transforms:
+      - url: %s/search
+        script: %s
+`, server.URL, path))
+	Nil(t, err)
+
+	transforms, err := TransformsFromConfig(globalConfig)
+	Nil(t, err)
+
+	client := &http.Client{Transport: &TransformTransport{Base: http.DefaultTransport, Transforms: transforms}}
+
+	resp, err := client.Get(server.URL + "/search?q=is:open")
+	Nil(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	JSONEq(t, `{"items": [{"user": "alice", "id": 2}], "total": 1}`, string(body))
+
+	resp, err = client.Get(server.URL + "/other")
+	Nil(t, err)
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	JSONEq(t, `{"items": [{"user": "dependabot", "id": 1}, {"user": "alice", "id": 2}], "total": 2}`, string(body))
+}
+
+func TestTransformsFromConfig(t *testing.T) {
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  http:
+    transforms:
+      - url: https://api.github.com
+        script: /does/not/exist.lua
+`)
+	Nil(t, err)
+
+	_, err = TransformsFromConfig(globalConfig)
+	NotNil(t, err)
+
+	transforms, err := TransformsFromConfig(&config.Config{Root: map[string]interface{}{}})
+	Nil(t, err)
+	Equal(t, 0, len(transforms))
+}
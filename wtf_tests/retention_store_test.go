@@ -0,0 +1,39 @@
+package wtf_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestRetentionStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-retention")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewRetentionStore(dir, 24*time.Hour)
+	now := time.Now()
+
+	Nil(t, store.RecordAt("stocks/AAPL", now.Add(-48*time.Hour), 150))
+	Nil(t, store.RecordAt("stocks/AAPL", now.Add(-time.Hour), 201.5))
+	Nil(t, store.Record("stocks/AAPL", 204))
+
+	Equal(t, []float64{201.5, 204}, store.Values("stocks/AAPL"))
+	Equal(t, []float64{}, store.Values("stocks/MSFT"))
+
+	// A new store over the same directory sees the values recorded before it
+	reopened := NewRetentionStore(dir, 24*time.Hour)
+	Equal(t, 2, len(reopened.Points("stocks/AAPL")))
+
+	// The value that had outlived the retention period was dropped when the next was recorded
+	longer := NewRetentionStore(dir, 72*time.Hour)
+	Equal(t, []float64{201.5, 204}, longer.Values("stocks/AAPL"))
+
+	unsaved := NewRetentionStore("", 24*time.Hour)
+	Nil(t, unsaved.Record("stocks/AAPL", 1))
+	Equal(t, []float64{}, unsaved.Values("stocks/AAPL"))
+}
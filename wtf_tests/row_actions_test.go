@@ -0,0 +1,51 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestScrollableWidgetSelectedRows(t *testing.T) {
+	moduleConfig, err := config.ParseYaml(`
+actions:
+  - name: Check out
+    command: "true"
+  - name: Missing its command
+`)
+	Nil(t, err)
+
+	common := cfg.NewCommonSettingsFromModule("jira", "Jira", moduleConfig, &config.Config{Root: map[string]interface{}{}})
+	widget := NewScrollableWidget(tview.NewApplication(), common, true)
+	widget.SetRenderFunction(func() {})
+
+	Equal(t, 0, len(widget.RowActions()))
+
+	keys := []string{"WTF-1", "WTF-2", "WTF-3"}
+	widget.SetRowFunction(func(idx int) Row { return Row{ID: keys[idx]} })
+	widget.SetItemCount(len(keys))
+
+	Equal(t, []Row{}, widget.SelectedRows())
+
+	widget.Next()
+	Equal(t, []Row{{ID: "WTF-1"}}, widget.SelectedRows())
+
+	widget.ToggleMark()
+	widget.Next()
+	widget.ToggleMark()
+	Equal(t, []Row{{ID: "WTF-1"}, {ID: "WTF-3"}}, widget.SelectedRows())
+
+	actions := widget.RowActions()
+	Equal(t, 5, len(actions))
+	Equal(t, "Open", actions[0].Name)
+	Equal(t, "Copy URL", actions[1].Name)
+	Equal(t, "Check out", actions[4].Name)
+	Nil(t, actions[4].Run(widget.SelectedRows()))
+
+	widget.SetItemCount(2)
+	Equal(t, false, widget.IsMarked(0))
+}
@@ -0,0 +1,31 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestRowDiff(t *testing.T) {
+	diff := NewRowDiff(1)
+
+	// The first refresh has nothing to compare with
+	diff.Expect()
+	Equal(t, "[green]build 41 passed 3m[-]\n[red]build 42 failed 1m[-]", diff.Apply("[green]build 41 passed 3m[-]\n[red]build 42 failed 1m[-]", "gray"))
+
+	// New and changed rows are highlighted until the next refresh, but moved rows and
+	// relative times are not
+	diff.Expect()
+	Equal(
+		t,
+		"[:gray][yellow]build 43 running[-][:-]\n[red]build 42 failed 2m[-]\n[:gray][green]build 41 deployed 4m[-][:-]",
+		diff.Apply("[yellow]build 43 running[-]\n[red]build 42 failed 2m[-]\n[green]build 41 deployed 4m[-]", "gray"),
+	)
+
+	// Redrawing without a refresh keeps the highlights
+	Equal(t, "[:gray]build 43 running[:-]", diff.Apply("build 43 running", "gray"))
+
+	diff.Expect()
+	Equal(t, "build 43 running", diff.Apply("build 43 running", "gray"))
+}
@@ -0,0 +1,40 @@
+package wtf_tests
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestBackoff(t *testing.T) {
+	Equal(t, time.Minute, Backoff(time.Minute, 0, time.Hour))
+	Equal(t, 4*time.Minute, Backoff(time.Minute, 2, time.Hour))
+	Equal(t, time.Hour, Backoff(time.Minute, 20, time.Hour))
+}
+
+func TestQuietHours(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2019, 6, 1, hour, min, 0, 0, time.Local)
+	}
+
+	overnight, err := NewQuietHours("22:00", "07:30")
+	Nil(t, err)
+
+	Equal(t, true, overnight.Contains(at(23, 0)))
+	Equal(t, true, overnight.Contains(at(3, 0)))
+	Equal(t, false, overnight.Contains(at(7, 30)))
+	Equal(t, false, overnight.Contains(at(12, 0)))
+	Equal(t, at(7, 30), overnight.Until(at(3, 0)))
+	Equal(t, at(7, 30).AddDate(0, 0, 1), overnight.Until(at(23, 0)))
+
+	lunch, err := NewQuietHours("12:00", "13:00")
+	Nil(t, err)
+
+	Equal(t, true, lunch.Contains(at(12, 15)))
+	Equal(t, false, lunch.Contains(at(13, 15)))
+
+	_, err = NewQuietHours("noon", "13:00")
+	NotNil(t, err)
+}
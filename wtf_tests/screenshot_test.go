@@ -0,0 +1,45 @@
+package wtf_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestScreenshotter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-screenshot")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	Nil(t, screen.Init())
+	screen.SetSize(10, 3)
+	for idx, char := range "hi there" {
+		screen.SetContent(1+idx, 1, char, nil, tcell.StyleDefault)
+	}
+	screen.Show()
+
+	cells := ScreenCells(screen, 1, 1, 8, 1)
+	Equal(t, 8, len(cells))
+
+	cfg, _ := config.ParseYaml("wtf:\n  screenshot:\n    dir: " + dir + "\n")
+	now := time.Date(2019, 10, 16, 15, 30, 0, 0, time.UTC)
+
+	path, err := NewScreenshotter(cfg).Save("clocks", cells, 8, 1, "", now)
+	Nil(t, err)
+	Equal(t, filepath.Join(dir, "clocks-20191016-153000.txt"), path)
+
+	written, err := ioutil.ReadFile(path)
+	Nil(t, err)
+	Equal(t, "hi there\n", string(written))
+
+	_, err = NewScreenshotter(cfg).Save("clocks", cells, 8, 1, ScreenshotPNG, now)
+	NotNil(t, err)
+}
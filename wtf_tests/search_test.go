@@ -0,0 +1,46 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestScrollableWidgetSearch(t *testing.T) {
+	common := cfg.NewCommonSettingsFromModule("hackernews", "Hacker News", &config.Config{}, &config.Config{Root: map[string]interface{}{}})
+	widget := NewScrollableWidget(tview.NewApplication(), common, true)
+	widget.SetRenderFunction(func() {})
+
+	titles := []string{"Show HN: wtf", "Go 1.13 released", "Ask HN: terminal dashboards?", "Rust in the kernel"}
+	widget.SetRowFunction(func(idx int) Row { return Row{Text: titles[idx]} })
+	widget.SetItemCount(len(titles))
+
+	widget.Search("hn")
+	Equal(t, 0, widget.GetSelected())
+	Equal(t, true, widget.Matches(2))
+	Equal(t, false, widget.Matches(1))
+
+	widget.SearchNext()
+	Equal(t, 2, widget.GetSelected())
+
+	widget.SearchNext()
+	Equal(t, 0, widget.GetSelected())
+
+	widget.SearchPrev()
+	Equal(t, 2, widget.GetSelected())
+
+	widget.Search("rust")
+	Equal(t, 3, widget.GetSelected())
+
+	keyboard := NewKeyboardWidget(tview.NewApplication(), tview.NewPages(), common)
+	keyboard.BindSearch(&widget)
+
+	Equal(t, true, keyboard.SearchCapture(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)))
+	Equal(t, "", widget.SearchQuery())
+	Equal(t, false, keyboard.SearchCapture(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)))
+}
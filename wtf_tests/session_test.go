@@ -0,0 +1,57 @@
+package wtf_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+type listWidget struct {
+	ScrollableWidget
+}
+
+func (widget *listWidget) Refresh() {}
+
+func TestSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-session")
+	Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	os.Setenv("XDG_CACHE_HOME", dir)
+	defer os.Unsetenv("XDG_CACHE_HOME")
+
+	globalConfig, _ := config.ParseYaml(`
+wtf:
+  grid:
+    columns: [40, 40]
+    rows: [10]
+`)
+	moduleConfig, _ := config.ParseYaml(`
+position: { top: 0, left: 0, height: 1, width: 1 }
+`)
+
+	common := cfg.NewCommonSettingsFromModule("hackernews", "Hacker News", moduleConfig, globalConfig)
+	widget := listWidget{ScrollableWidget: NewScrollableWidget(tview.NewApplication(), common, true)}
+	widget.SetSelected(3)
+
+	display := NewDisplay([]Wtfable{&widget}, globalConfig)
+
+	session := CaptureSession(display, &widget, []Wtfable{&widget})
+	Equal(t, "hackernews", session.Focused)
+	Equal(t, 3, session.Widgets["hackernews"].Selected)
+	Nil(t, session.Save())
+
+	loaded := LoadSession()
+	Equal(t, session, loaded)
+
+	widget.SetSelected(-1)
+	focused := loaded.Restore(display, []Wtfable{&widget})
+	Equal(t, Wtfable(&widget), focused)
+	Equal(t, 3, widget.GetSelected())
+}
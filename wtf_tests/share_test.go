@@ -0,0 +1,74 @@
+package wtf_tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestShareGist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		Equal(t, "token abc", req.Header.Get("Authorization"))
+
+		gist := struct {
+			Files  map[string]map[string]string `json:"files"`
+			Public bool                         `json:"public"`
+		}{}
+		Nil(t, json.NewDecoder(req.Body).Decode(&gist))
+		Equal(t, "3 PRs", gist.Files["github.txt"]["content"])
+		False(t, gist.Public)
+
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte(`{"html_url": "https://gist.github.com/abc123"}`))
+	}))
+	defer server.Close()
+
+	cfg, _ := config.ParseYaml("wtf:\n  share:\n    token: abc\n    url: " + server.URL + "\n")
+
+	link, err := NewSharer(cfg).Share("github", "3 PRs")
+	Nil(t, err)
+	Equal(t, "https://gist.github.com/abc123", link)
+
+	_, err = NewSharer(cfg).Share("github", "  \n")
+	NotNil(t, err)
+}
+
+func TestSharePastebin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/documents":
+			body, _ := ioutil.ReadAll(req.Body)
+			Equal(t, "3 PRs", string(body))
+			rw.Write([]byte(`{"key": "xyz"}`))
+		case "/upload":
+			file, _, err := req.FormFile("file")
+			Nil(t, err)
+			body, _ := ioutil.ReadAll(file)
+			Equal(t, "3 PRs", string(body))
+			rw.Write([]byte("https://paste.example.com/xyz.txt\n"))
+		default:
+			rw.Write([]byte("Thanks!"))
+		}
+	}))
+	defer server.Close()
+
+	cfg, _ := config.ParseYaml("wtf:\n  share:\n    service: pastebin\n    url: " + server.URL + "/documents\n")
+	link, err := NewSharer(cfg).Share("github", "3 PRs")
+	Nil(t, err)
+	Equal(t, server.URL+"/xyz", link)
+
+	cfg, _ = config.ParseYaml("wtf:\n  share:\n    service: pastebin\n    field: file\n    url: " + server.URL + "/upload\n")
+	link, err = NewSharer(cfg).Share("github", "3 PRs")
+	Nil(t, err)
+	Equal(t, "https://paste.example.com/xyz.txt", link)
+
+	cfg, _ = config.ParseYaml("wtf:\n  share:\n    service: pastebin\n    url: " + server.URL + "/other\n")
+	_, err = NewSharer(cfg).Share("github", "3 PRs")
+	NotNil(t, err)
+}
@@ -0,0 +1,34 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestRenderSnapshot(t *testing.T) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	Nil(t, screen.Init())
+	screen.SetSize(6, 3)
+
+	screen.SetContent(0, 0, 'h', nil, tcell.StyleDefault.Bold(true))
+	screen.SetContent(1, 0, 'i', nil, tcell.StyleDefault)
+	screen.SetContent(0, 1, '<', nil, tcell.StyleDefault.Foreground(tcell.ColorRed))
+	screen.Show()
+
+	cells, width, height := screen.GetContents()
+
+	text, err := RenderSnapshot(cells, width, height, SnapshotText)
+	Nil(t, err)
+	Equal(t, "hi\n<\n", text)
+
+	html, err := RenderSnapshot(cells, width, height, SnapshotHTML)
+	Nil(t, err)
+	Contains(t, html, `<span style="font-weight:bold">h</span>i`)
+	Contains(t, html, `<span style="color:#ff0000">&lt;</span>`)
+
+	_, err = RenderSnapshot(cells, width, height, "pdf")
+	NotNil(t, err)
+}
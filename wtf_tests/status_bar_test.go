@@ -0,0 +1,30 @@
+package wtf_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestStatusBarText(t *testing.T) {
+	Nil(t, NewStatusBar(tview.NewApplication(), []Wtfable{}, &config.Config{Root: map[string]interface{}{}}, nil))
+
+	globalConfig, err := config.ParseYaml(`
+wtf:
+  statusBar:
+    enabled: true
+    template: '{{.Clock}} {{if .Focused}}{{.Focused}}{{else}}none{{end}} {{ data "statusbartest.temperature" }}°'
+`)
+	Nil(t, err)
+
+	Data.Publish("statusbartest.temperature", 21)
+
+	bar := NewStatusBar(tview.NewApplication(), []Wtfable{}, globalConfig, func() Wtfable { return nil })
+	now := time.Date(2019, 8, 1, 9, 30, 0, 0, time.UTC)
+
+	Equal(t, "09:30 none 21°", bar.Text(now))
+}
@@ -0,0 +1,44 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+func TestTableLines(t *testing.T) {
+	table := NewTable(
+		TableColumn{Title: "Name", MinWidth: 4},
+		TableColumn{Title: "Count", Align: AlignRight},
+	)
+	table.HeaderColor = "red"
+	table.AddRow("wtfutil", "12")
+	table.AddRow("[green]ok", "3")
+
+	Equal(t, []string{
+		"[red::b]Name    Count[-::-]",
+		"wtfutil    12",
+		"[green]ok          3",
+	}, table.Lines(0))
+
+	Equal(t, []string{
+		"[red::b]Name  Count[-::-]",
+		"wtfu…    12",
+		"[green]ok        3",
+	}, table.Lines(11))
+}
+
+func TestTextWidth(t *testing.T) {
+	Equal(t, 5, TextWidth("[red]hello[white]"))
+	Equal(t, 5, TextWidth("\x1b[31mhello\x1b[0m"))
+	Equal(t, 4, TextWidth("日本"))
+}
+
+func TestTruncate(t *testing.T) {
+	Equal(t, "hello", Truncate("hello", 5))
+	Equal(t, "hel…", Truncate("hello", 4))
+	Equal(t, "[red]hel…", Truncate("[red]hello", 4))
+	Equal(t, "日…", Truncate("日本語", 4))
+	Equal(t, "", Truncate("hello", 0))
+}
@@ -0,0 +1,89 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+// focusedIn returns the primitive that gets the key presses sent to the prompt
+func focusedIn(primitive tview.Primitive) tview.Primitive {
+	for {
+		var next tview.Primitive
+		primitive.Focus(func(p tview.Primitive) { next = p })
+
+		if next == nil || next == primitive {
+			return primitive
+		}
+		primitive = next
+	}
+}
+
+func press(primitive tview.Primitive, keys ...interface{}) {
+	handler := primitive.InputHandler()
+
+	for _, key := range keys {
+		switch k := key.(type) {
+		case string:
+			for _, char := range k {
+				handler(tcell.NewEventKey(tcell.KeyRune, char, tcell.ModNone), func(p tview.Primitive) {})
+			}
+		case tcell.Key:
+			handler(tcell.NewEventKey(k, 0, tcell.ModNone), func(p tview.Primitive) {})
+		}
+	}
+}
+
+func TestTextPrompt(t *testing.T) {
+	text, saved := "", false
+	doneFunc := func(str string, ok bool) { text, saved = str, ok }
+
+	prompt := NewTextPrompt(TextPrompt{Label: "New:", Text: "a"}, doneFunc)
+	True(t, PromptOpen())
+
+	press(focusedIn(prompt), "bc", tcell.KeyEnter)
+	Equal(t, "abc", text)
+	True(t, saved)
+	False(t, PromptOpen())
+
+	prompt = NewTextPrompt(TextPrompt{Label: "New:"}, doneFunc)
+	press(focusedIn(prompt), "ignored", tcell.KeyEsc)
+	False(t, saved)
+	False(t, PromptOpen())
+}
+
+func TestTextPromptHistory(t *testing.T) {
+	text := ""
+	doneFunc := func(str string, ok bool) { text = str }
+
+	for _, entry := range []string{"first", "second"} {
+		prompt := NewTextPrompt(TextPrompt{History: "test"}, doneFunc)
+		press(focusedIn(prompt), entry, tcell.KeyEnter)
+	}
+
+	prompt := NewTextPrompt(TextPrompt{History: "test"}, doneFunc)
+	press(focusedIn(prompt), "typed", tcell.KeyUp, tcell.KeyUp, tcell.KeyUp, tcell.KeyEnter)
+	Equal(t, "first", text)
+
+	prompt = NewTextPrompt(TextPrompt{History: "test"}, doneFunc)
+	press(focusedIn(prompt), "typed", tcell.KeyUp, tcell.KeyDown, tcell.KeyEnter)
+	Equal(t, "typed", text)
+
+	prompt = NewTextPrompt(TextPrompt{History: "other"}, doneFunc)
+	press(focusedIn(prompt), tcell.KeyUp, tcell.KeyEnter)
+	Equal(t, "", text)
+}
+
+func TestMultiLineTextPrompt(t *testing.T) {
+	text, saved := "", false
+	doneFunc := func(str string, ok bool) { text, saved = str, ok }
+
+	prompt := NewTextPrompt(TextPrompt{MultiLine: true, Text: "one\ntw"}, doneFunc)
+	press(focusedIn(prompt), "o", tcell.KeyEnter, "three", tcell.KeyEnter, tcell.KeyBackspace2, "!", tcell.KeyCtrlD)
+
+	Equal(t, "one\ntwo\nthree!", text)
+	True(t, saved)
+}
@@ -0,0 +1,98 @@
+package wtf_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/stretchr/testify/assert"
+	"github.com/wtfutil/wtf/cfg"
+	. "github.com/wtfutil/wtf/wtf"
+)
+
+// makeListWidget returns a list widget named name, drawn at the given place onscreen
+func makeListWidget(app *tview.Application, globalConfig *config.Config, name string, top, left int) *listWidget {
+	moduleConfig, _ := config.ParseYaml(fmt.Sprintf("enabled: true\nposition: { top: %d, left: %d, height: 1, width: 1 }\n", top, left))
+
+	common := cfg.NewCommonSettingsFromModule("hackernews", name, moduleConfig, globalConfig)
+	widget := listWidget{ScrollableWidget: NewScrollableWidget(app, common, true)}
+	widget.SetRenderFunction(func() {})
+	widget.TextView().SetRect(left*40, top*10, 40, 10)
+
+	return &widget
+}
+
+func TestFocusDirection(t *testing.T) {
+	app := tview.NewApplication()
+	globalConfig, _ := config.ParseYaml("wtf:\n  grid:\n    columns: [40, 40]\n    rows: [10, 10]\n")
+
+	topLeft := makeListWidget(app, globalConfig, "top_left", 0, 0)
+	topRight := makeListWidget(app, globalConfig, "top_right", 0, 1)
+	bottomLeft := makeListWidget(app, globalConfig, "bottom_left", 1, 0)
+
+	tracker := NewFocusTracker(app, []Wtfable{topLeft, topRight, bottomLeft}, globalConfig)
+
+	tracker.FocusDirection(1, 0)
+	Equal(t, Wtfable(topLeft), tracker.FocusedWidget())
+
+	tracker.FocusDirection(1, 0)
+	Equal(t, Wtfable(topRight), tracker.FocusedWidget())
+
+	// There's nothing further right, so the focus stays put
+	tracker.FocusDirection(1, 0)
+	Equal(t, Wtfable(topRight), tracker.FocusedWidget())
+
+	tracker.FocusDirection(0, 1)
+	Equal(t, Wtfable(bottomLeft), tracker.FocusedWidget())
+
+	tracker.FocusDirection(0, -1)
+	Equal(t, Wtfable(topLeft), tracker.FocusedWidget())
+}
+
+func TestVimNavigation(t *testing.T) {
+	app := tview.NewApplication()
+	globalConfig, _ := config.ParseYaml("wtf:\n  grid:\n    columns: [40, 40]\n    rows: [10]\n  navigation:\n    vim: true\n")
+
+	left := makeListWidget(app, globalConfig, "left", 0, 0)
+	right := makeListWidget(app, globalConfig, "right", 0, 1)
+	right.SetItemCount(5)
+
+	tracker := NewFocusTracker(app, []Wtfable{left, right}, globalConfig)
+	tracker.FocusOnWidget(left)
+
+	vim := NewVimNavigation(globalConfig)
+	key := func(r rune) bool {
+		return vim.InputCapture(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), &tracker)
+	}
+
+	// A plain l is left to the widget, as widgets use h/j/k/l for their own rows
+	Equal(t, false, key('l'))
+	Equal(t, Wtfable(left), tracker.FocusedWidget())
+
+	Equal(t, true, vim.InputCapture(tcell.NewEventKey(tcell.KeyCtrlW, 0, tcell.ModCtrl), &tracker))
+	Equal(t, true, key('l'))
+	Equal(t, Wtfable(right), tracker.FocusedWidget())
+
+	Equal(t, true, key('G'))
+	Equal(t, 4, right.GetSelected())
+
+	Equal(t, true, key('g'))
+	Equal(t, 4, right.GetSelected())
+	Equal(t, true, key('g'))
+	Equal(t, 0, right.GetSelected())
+
+	right.Last()
+	Equal(t, 4, right.GetSelected())
+	right.First()
+	Equal(t, 0, right.GetSelected())
+}
+
+func TestVimNavigationDisabled(t *testing.T) {
+	globalConfig, _ := config.ParseYaml("wtf:\n")
+	tracker := NewFocusTracker(tview.NewApplication(), []Wtfable{}, globalConfig)
+
+	vim := NewVimNavigation(globalConfig)
+	Equal(t, false, vim.InputCapture(tcell.NewEventKey(tcell.KeyCtrlW, 0, tcell.ModCtrl), &tracker))
+}